@@ -0,0 +1,116 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidKey is returned for a key that is empty, absolute or climbs out of the store
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// Local is a blob store on the local filesystem, each blob is a file under dir named by its key. Keys are
+// slash separated like S3 object keys so that the same keys work against an S3 compatible store.
+type Local struct {
+	dir string
+}
+
+func NewLocal(dir string) Local {
+	return Local{dir: dir}
+}
+
+// Put writes the blob under key, replacing any blob already there. The blob is written to a temporary file first
+// so that a reader never sees half a blob. The filesystem has nowhere to keep the content type, callers keep it
+// alongside the key.
+func (l Local) Put(ctx context.Context, key, _ string, body io.Reader) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmp, contextReader{ctx: ctx, r: body})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), name)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+
+	return err
+}
+
+// Get opens the blob under key, an error satisfying errors.Is(err, fs.ErrNotExist) is returned when there is none
+func (l Local) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return os.Open(name)
+}
+
+// Delete removes the blob under key, deleting a blob that is not there is not an error
+func (l Local) Delete(ctx context.Context, key string) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	if err = os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// path maps a key to its file under the store directory
+func (l Local) path(key string) (string, error) {
+	clean := path.Clean(key)
+
+	if key == "" || path.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") ||
+		strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(l.dir, filepath.FromSlash(clean)), nil
+}
+
+// contextReader stops reading once its context is done
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return c.r.Read(p)
+}
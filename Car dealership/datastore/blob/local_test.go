@@ -0,0 +1,78 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+// TestLocal function to test blobs are written, read back and deleted
+func TestLocal(t *testing.T) {
+	l := NewLocal(t.TempDir())
+	ctx := context.TODO()
+
+	if err := l.Put(ctx, "cars/1/photo.jpg", "image/jpeg", bytes.NewReader([]byte("first"))); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Put(ctx, "cars/1/photo.jpg", "image/jpeg", bytes.NewReader([]byte("second"))); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := l.Get(ctx, "cars/1/photo.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := io.ReadAll(r)
+	_ = r.Close()
+
+	if err != nil || string(body) != "second" {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "replaced", string(body), "second")
+	}
+
+	if err = l.Delete(ctx, "cars/1/photo.jpg"); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "delete", err, nil)
+	}
+
+	if _, err = l.Get(ctx, "cars/1/photo.jpg"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "deleted", err, fs.ErrNotExist)
+	}
+
+	if err = l.Delete(ctx, "cars/1/photo.jpg"); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "delete twice", err, nil)
+	}
+
+	entries, _ := os.ReadDir(l.dir + "/cars/1")
+	if len(entries) != 0 {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 4, "no temporary files", entries, nil)
+	}
+}
+
+// TestKeys function to test keys can not escape the store directory
+func TestKeys(t *testing.T) {
+	l := NewLocal(t.TempDir())
+
+	testCases := []struct {
+		desc string
+		key  string
+		err  error
+	}{
+		{desc: "nested", key: "cars/1/a.png"},
+		{desc: "empty", key: "", err: ErrInvalidKey},
+		{desc: "absolute", key: "/etc/passwd", err: ErrInvalidKey},
+		{desc: "parent", key: "../secret", err: ErrInvalidKey},
+		{desc: "climbs out", key: "cars/../../secret", err: ErrInvalidKey},
+		{desc: "backslash", key: `cars\..\..\secret`, err: ErrInvalidKey},
+	}
+
+	for i, tc := range testCases {
+		if _, err := l.path(tc.key); !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
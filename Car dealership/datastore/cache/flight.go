@@ -0,0 +1,49 @@
+package cache
+
+import "sync"
+
+// flight is a load in progress, callers asking for the same key while it runs wait for it instead of loading again
+type flight struct {
+	wg    sync.WaitGroup
+	value []byte
+	err   error
+}
+
+// group collapses concurrent loads of the same key into one
+type group struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// do runs load for key unless a load of key is already running, in which case it waits for that load and shares
+// its result. shared reports whether the result came from another caller's load.
+func (g *group) do(key string, load func() ([]byte, error)) (value []byte, shared bool, err error) {
+	g.mu.Lock()
+
+	if f, ok := g.flights[key]; ok {
+		g.mu.Unlock()
+		f.wg.Wait()
+
+		return f.value, true, f.err
+	}
+
+	if g.flights == nil {
+		g.flights = map[string]*flight{}
+	}
+
+	f := &flight{}
+	f.wg.Add(1)
+	g.flights[key] = f
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		f.wg.Done()
+	}()
+
+	f.value, f.err = load()
+
+	return f.value, false, f.err
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestLRU function to test the least recently used entry is dropped first and entries expire after their ttl
+func TestLRU(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	c := NewLRU(2)
+	c.now = func() time.Time { return now }
+
+	ctx := context.TODO()
+
+	_ = c.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = c.Set(ctx, "b", []byte("2"), 0)
+
+	// reading a makes b the least recently used
+	if v, ok, _ := c.Get(ctx, "a"); !ok || string(v) != "1" {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "get", string(v), "1")
+	}
+
+	_ = c.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, ok, _ := c.Get(ctx, "b"); ok || c.Len() != 2 {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "least recently used dropped", ok, false)
+	}
+
+	_ = c.Set(ctx, "c", []byte("4"), 2*time.Minute)
+	now = now.Add(time.Minute)
+
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "expired", ok, false)
+	}
+
+	if v, ok, _ := c.Get(ctx, "c"); !ok || string(v) != "4" {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "replaced with new ttl", string(v), "4")
+	}
+
+	_ = c.Delete(ctx, "c")
+	_ = c.Delete(ctx, "missing")
+
+	if c.Len() != 0 {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 4, "deleted", c.Len(), 0)
+	}
+}
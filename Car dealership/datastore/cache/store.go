@@ -0,0 +1,367 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	carPrefix    = "car:"
+	enginePrefix = "engine:"
+
+	// generationKey holds the generation of the cache in the backend, every other key is prefixed with it
+	generationKey = "generation"
+
+	// loadTimeout bounds a load of the store, which runs apart from the request that started it
+	loadTimeout = 30 * time.Second
+)
+
+// Metrics counts the lookups of a cache since it was created. Misses include the lookups which shared a load
+// already in flight, Shared counts those alone.
+type Metrics struct {
+	Hits          uint64 `json:"Hits"`
+	Misses        uint64 `json:"Misses"`
+	Shared        uint64 `json:"Shared"`
+	Invalidations uint64 `json:"Invalidations"`
+	Errors        uint64 `json:"Errors"`
+}
+
+// counters are kept apart from Store so that copies of the store count together, they come first in the struct
+// so that the atomic operations on them are aligned on 32 bit platforms
+type counters struct {
+	hits, misses, shared, invalidations, errors uint64
+
+	// writes counts invalidations, a load which raced a write does not cache what it read before the write
+	writes uint64
+
+	flights group
+}
+
+// Store is a datastore.Car and datastore.Engine which reads cars and engines by id through a cache. The values
+// are cached as JSON so that a backend shared between instances can hold them and every caller gets a copy of
+// its own, the generation Flush moves on is kept in the backend as well. Concurrent misses of the same id are
+// collapsed into one read of the store and not found errors are not cached. Writes through the store drop what
+// they change, the price, status, reservation, order and catalogue stores change cars as well and are wrapped with
+// NewPrices, NewStatus, NewReservations, NewOrders and NewCatalogue to do the same.
+type Store struct {
+	datastore.Car
+	datastore.Engine
+	cache    datastore.Cache
+	ttl      time.Duration
+	counters *counters
+}
+
+func New(car datastore.Car, engine datastore.Engine, cache datastore.Cache, ttl time.Duration) Store {
+	return Store{Car: car, Engine: engine, cache: cache, ttl: ttl, counters: &counters{}}
+}
+
+// GetCarByID reads the car from the cache, or from the store when it is not cached
+func (s Store) GetCarByID(ctx context.Context, id string) (models.Car, error) {
+	var c models.Car
+
+	err := s.read(ctx, carPrefix+id, &c, func(ctx context.Context) (interface{}, error) {
+		return s.Car.GetCarByID(ctx, id)
+	})
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	return c, nil
+}
+
+// UpdateCar updates the car and drops it from the cache
+func (s Store) UpdateCar(ctx context.Context, id string, car models.Car) (models.Car, error) {
+	defer s.EvictCar(ctx, id)
+
+	return s.Car.UpdateCar(ctx, id, car)
+}
+
+// DeleteCar deletes the car and drops it from the cache
+func (s Store) DeleteCar(ctx context.Context, id string) (models.Car, error) {
+	defer s.EvictCar(ctx, id)
+
+	return s.Car.DeleteCar(ctx, id)
+}
+
+// EngineGetByID reads the engine from the cache, or from the store when it is not cached
+func (s Store) EngineGetByID(ctx context.Context, id string) (models.Engine, error) {
+	var e models.Engine
+
+	err := s.read(ctx, enginePrefix+id, &e, func(ctx context.Context) (interface{}, error) {
+		return s.Engine.EngineGetByID(ctx, id)
+	})
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	return e, nil
+}
+
+// EngineGetByIDs reads the engines which are cached from the cache and the rest from the store in one batch. Each
+// engine found is returned once, in the order of the ids.
+func (s Store) EngineGetByIDs(ctx context.Context, ids []string) ([]models.Engine, error) {
+	var (
+		engines = make(map[string]models.Engine, len(ids))
+		seen    = make(map[string]bool, len(ids))
+		missed  []string
+	)
+
+	gen, genErr := s.generation(ctx)
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+
+		seen[id] = true
+
+		if genErr != nil {
+			atomic.AddUint64(&s.counters.misses, 1)
+
+			missed = append(missed, id)
+
+			continue
+		}
+
+		b, ok, err := s.cache.Get(ctx, gen+enginePrefix+id)
+
+		var e models.Engine
+
+		switch {
+		case err != nil:
+			s.failed(err)
+		case ok:
+			if err = json.Unmarshal(b, &e); err == nil {
+				atomic.AddUint64(&s.counters.hits, 1)
+				engines[id] = e
+
+				continue
+			}
+
+			s.failed(err)
+		}
+
+		atomic.AddUint64(&s.counters.misses, 1)
+
+		missed = append(missed, id)
+	}
+
+	if len(missed) > 0 {
+		writes := atomic.LoadUint64(&s.counters.writes)
+
+		loaded, err := s.Engine.EngineGetByIDs(ctx, missed)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range loaded {
+			id := e.EngineID.String()
+
+			b, err := json.Marshal(e)
+			if err != nil {
+				return nil, err
+			}
+
+			if genErr == nil && atomic.LoadUint64(&s.counters.writes) == writes {
+				if err = s.cache.Set(ctx, gen+enginePrefix+id, b, s.ttl); err != nil {
+					s.failed(err)
+				}
+			}
+
+			engines[id] = e
+		}
+	}
+
+	res := make([]models.Engine, 0, len(engines))
+
+	for _, id := range ids {
+		if e, ok := engines[id]; ok {
+			res = append(res, e)
+			delete(engines, id)
+		}
+	}
+
+	return res, nil
+}
+
+// EngineUpdate updates the engine and drops it from the cache
+func (s Store) EngineUpdate(ctx context.Context, id string, engine models.Engine) (models.Engine, error) {
+	defer s.evict(ctx, enginePrefix+id)
+
+	return s.Engine.EngineUpdate(ctx, id, engine)
+}
+
+// EngineDelete deletes the engine and drops it from the cache
+func (s Store) EngineDelete(ctx context.Context, id string) (models.Engine, error) {
+	defer s.evict(ctx, enginePrefix+id)
+
+	return s.Engine.EngineDelete(ctx, id)
+}
+
+// EvictCar drops a car from the cache. Writes drop what they change whether or not they succeed, a write which
+// failed part way may still have changed the car and dropping it costs one read of the store at most.
+func (s Store) EvictCar(ctx context.Context, id string) {
+	s.evict(ctx, carPrefix+id)
+}
+
+// Flush drops every car and engine from the cache, for writes which change cars without knowing which. It moves
+// the generation in the backend on, so the entries of every instance sharing the backend are dropped.
+func (s Store) Flush(ctx context.Context) {
+	atomic.AddUint64(&s.counters.writes, 1)
+	atomic.AddUint64(&s.counters.invalidations, 1)
+
+	s.newGeneration(ctx)
+}
+
+// Metrics returns the counts of cache lookups so far
+func (s Store) Metrics() Metrics {
+	return Metrics{
+		Hits:          atomic.LoadUint64(&s.counters.hits),
+		Misses:        atomic.LoadUint64(&s.counters.misses),
+		Shared:        atomic.LoadUint64(&s.counters.shared),
+		Invalidations: atomic.LoadUint64(&s.counters.invalidations),
+		Errors:        atomic.LoadUint64(&s.counters.errors),
+	}
+}
+
+// read decodes the value under key into v, loading it from the store and caching it on a miss. A cache which
+// fails is counted and read past, the store answers instead. The load is shared by every caller missing the key
+// while it runs, so it runs with the values of ctx but not its cancellation.
+func (s Store) read(ctx context.Context, key string, v interface{},
+	load func(ctx context.Context) (interface{}, error)) error {
+	gen, err := s.generation(ctx)
+	if err != nil {
+		atomic.AddUint64(&s.counters.misses, 1)
+
+		res, err := load(ctx)
+		if err != nil {
+			return err
+		}
+
+		b, err := json.Marshal(res)
+		if err != nil {
+			return err
+		}
+
+		return json.Unmarshal(b, v)
+	}
+
+	key = gen + key
+
+	b, ok, err := s.cache.Get(ctx, key)
+
+	switch {
+	case err != nil:
+		s.failed(err)
+	case ok:
+		if err = json.Unmarshal(b, v); err == nil {
+			atomic.AddUint64(&s.counters.hits, 1)
+			return nil
+		}
+
+		s.failed(err)
+	}
+
+	atomic.AddUint64(&s.counters.misses, 1)
+
+	b, shared, err := s.counters.flights.do(key, func() ([]byte, error) {
+		ctx, cancel := context.WithTimeout(detached{ctx}, loadTimeout)
+		defer cancel()
+
+		writes := atomic.LoadUint64(&s.counters.writes)
+
+		res, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := json.Marshal(res)
+		if err != nil {
+			return nil, err
+		}
+
+		if atomic.LoadUint64(&s.counters.writes) == writes {
+			if err = s.cache.Set(ctx, key, b, s.ttl); err != nil {
+				s.failed(err)
+			}
+		}
+
+		return b, nil
+	})
+
+	if shared {
+		atomic.AddUint64(&s.counters.shared, 1)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+func (s Store) evict(ctx context.Context, key string) {
+	atomic.AddUint64(&s.counters.writes, 1)
+	atomic.AddUint64(&s.counters.invalidations, 1)
+
+	gen, err := s.generation(ctx)
+	if err == nil {
+		err = s.cache.Delete(ctx, gen+key)
+	}
+
+	if err != nil {
+		s.failed(err)
+	}
+}
+
+// generation returns the prefix of the keys of the current generation, read from the backend. A backend which
+// lost the generation starts a new one, as the entries of the lost one may be stale.
+func (s Store) generation(ctx context.Context) (string, error) {
+	b, ok, err := s.cache.Get(ctx, generationKey)
+	if err != nil {
+		s.failed(err)
+		return "", err
+	}
+
+	if !ok {
+		b = s.newGeneration(ctx)
+	}
+
+	return string(b) + ":", nil
+}
+
+// newGeneration writes a new generation to the backend. It is random rather than counted so that instances
+// moving it on at once do not have to agree on the next one.
+func (s Store) newGeneration(ctx context.Context) []byte {
+	b := []byte(uuid.NewString())
+
+	if err := s.cache.Set(ctx, generationKey, b, 0); err != nil {
+		s.failed(err)
+	}
+
+	return b
+}
+
+// detached is a context with the values of a request but without its deadline or cancellation
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detached) Done() <-chan struct{} { return nil }
+
+func (detached) Err() error { return nil }
+
+func (s Store) failed(err error) {
+	atomic.AddUint64(&s.counters.errors, 1)
+	log.Println("cache:", err)
+}
@@ -0,0 +1,348 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetCarByID test function to test cars are read from the store once, then from the cache until they change
+func TestGetCarByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := datastore.NewMockCar(ctrl)
+	s := New(mockCar, datastore.NewMockEngine(ctrl), NewLRU(10), time.Minute)
+
+	trimID := uuid.New()
+	car := models.Car{ID: uuid.New(), Name: "Golf", Brand: "Volkswagen", TrimID: &trimID, ListPrice: 2500000}
+	id, missing := car.ID.String(), uuid.New().String()
+
+	gomock.InOrder(
+		mockCar.EXPECT().GetCarByID(gomock.Any(), id).Return(car, nil),
+		mockCar.EXPECT().UpdateCar(gomock.Any(), id, car).Return(car, nil),
+		mockCar.EXPECT().GetCarByID(gomock.Any(), id).Return(car, nil),
+		mockCar.EXPECT().DeleteCar(gomock.Any(), id).Return(models.Car{}, nil),
+		mockCar.EXPECT().GetCarByID(gomock.Any(), id).Return(models.Car{}, sql.ErrNoRows),
+	)
+	mockCar.EXPECT().GetCarByID(gomock.Any(), missing).Return(models.Car{}, sql.ErrNoRows).Times(2)
+
+	for i := 0; i < 2; i++ {
+		res, err := s.GetCarByID(context.TODO(), id)
+		if err != nil || !assert.Equal(t, car, res) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, "read through", res, car)
+		}
+	}
+
+	res, _ := s.GetCarByID(context.TODO(), id)
+	*res.TrimID = uuid.New()
+
+	if res, _ = s.GetCarByID(context.TODO(), id); *res.TrimID != trimID {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "callers get copies", *res.TrimID, trimID)
+	}
+
+	if _, err := s.UpdateCar(context.TODO(), id, car); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "update", err, nil)
+	}
+
+	if _, err := s.GetCarByID(context.TODO(), id); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 4, "read again after update", err, nil)
+	}
+
+	if _, err := s.DeleteCar(context.TODO(), id); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 5, "delete", err, nil)
+	}
+
+	if _, err := s.GetCarByID(context.TODO(), id); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 6, "deleted", err, sql.ErrNoRows)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.GetCarByID(context.TODO(), missing); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 7+i, "not found is not cached", err,
+				sql.ErrNoRows)
+		}
+	}
+
+	assert.Equal(t, Metrics{Hits: 3, Misses: 5, Invalidations: 2}, s.Metrics())
+}
+
+// TestEngineGetByID test function to test engines are cached and dropped when they change
+func TestEngineGetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEngine := datastore.NewMockEngine(ctrl)
+	s := New(datastore.NewMockCar(ctrl), mockEngine, NewLRU(10), time.Minute)
+
+	engine := models.Engine{EngineID: uuid.New(), CarRange: 500, Powertrain: &models.Powertrain{Type: "bev",
+		ChargingStandards: []string{"ccs2"}}}
+	id := engine.EngineID.String()
+
+	gomock.InOrder(
+		mockEngine.EXPECT().EngineGetByID(gomock.Any(), id).Return(engine, nil),
+		mockEngine.EXPECT().EngineUpdate(gomock.Any(), id, engine).Return(models.Engine{}, errors.New("db error")),
+		mockEngine.EXPECT().EngineGetByID(gomock.Any(), id).Return(engine, nil),
+		mockEngine.EXPECT().EngineDelete(gomock.Any(), id).Return(models.Engine{}, nil),
+		mockEngine.EXPECT().EngineGetByID(gomock.Any(), id).Return(models.Engine{}, sql.ErrNoRows),
+	)
+
+	for i := 0; i < 2; i++ {
+		if res, err := s.EngineGetByID(context.TODO(), id); err != nil || !assert.Equal(t, engine, res) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, "read through", res, engine)
+		}
+	}
+
+	if _, err := s.EngineUpdate(context.TODO(), id, engine); err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "failed update", err, "db error")
+	}
+
+	if _, err := s.EngineGetByID(context.TODO(), id); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "dropped after a failed update", err, nil)
+	}
+
+	if _, err := s.EngineDelete(context.TODO(), id); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 4, "delete", err, nil)
+	}
+
+	if _, err := s.EngineGetByID(context.TODO(), id); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 5, "deleted", err, sql.ErrNoRows)
+	}
+}
+
+// TestEngineGetByIDs test function to test engines which are not cached are read from the store in one batch
+func TestEngineGetByIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEngine := datastore.NewMockEngine(ctrl)
+	s := New(datastore.NewMockCar(ctrl), mockEngine, NewLRU(10), time.Minute)
+
+	first := models.Engine{EngineID: uuid.New(), CarRange: 500}
+	second := models.Engine{EngineID: uuid.New(), Displacement: 1400, NoOfCylinder: 4}
+	a, b, missing := first.EngineID.String(), second.EngineID.String(), uuid.New().String()
+
+	gomock.InOrder(
+		mockEngine.EXPECT().EngineGetByID(gomock.Any(), a).Return(first, nil),
+		mockEngine.EXPECT().EngineGetByIDs(gomock.Any(), []string{b, missing}).Return([]models.Engine{second}, nil),
+		mockEngine.EXPECT().EngineGetByIDs(gomock.Any(), []string{missing}).Return(nil, errors.New("db error")),
+	)
+
+	if _, err := s.EngineGetByID(context.TODO(), a); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "cache one engine", err, nil)
+	}
+
+	res, err := s.EngineGetByIDs(context.TODO(), []string{b, a, missing, b})
+	if err != nil || !assert.Equal(t, []models.Engine{second, first}, res) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "batch the misses", res, err)
+	}
+
+	res, err = s.EngineGetByIDs(context.TODO(), []string{a, b})
+	if err != nil || !assert.Equal(t, []models.Engine{first, second}, res) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "all cached", res, err)
+	}
+
+	if _, err = s.EngineGetByIDs(context.TODO(), []string{missing}); err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "store error", err, "db error")
+	}
+
+	assert.Equal(t, Metrics{Hits: 3, Misses: 4}, s.Metrics())
+}
+
+// TestConcurrentMisses test function to test concurrent misses of a car read the store once
+func TestConcurrentMisses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := datastore.NewMockCar(ctrl)
+	s := New(mockCar, datastore.NewMockEngine(ctrl), NewLRU(10), time.Minute)
+
+	const readers = 5
+
+	car := models.Car{ID: uuid.New(), Name: "Golf"}
+	id := car.ID.String()
+	loading, release := make(chan struct{}), make(chan struct{})
+
+	mockCar.EXPECT().GetCarByID(gomock.Any(), id).DoAndReturn(func(context.Context, string) (models.Car, error) {
+		close(loading)
+		<-release
+
+		return car, nil
+	})
+
+	var wg sync.WaitGroup
+
+	results := make([]models.Car, readers)
+
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			if i > 0 {
+				<-loading
+			}
+
+			results[i], _ = s.GetCarByID(context.TODO(), id)
+		}(i)
+	}
+
+	// the load is held until every reader missed and had time to join it
+	for s.Metrics().Misses < readers {
+		time.Sleep(time.Millisecond)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range results {
+		assert.Equal(t, car, results[i])
+	}
+
+	assert.Equal(t, uint64(readers-1), s.Metrics().Shared)
+}
+
+// TestRacedWrite test function to test a car read before a write to it is not cached after the write
+func TestRacedWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := datastore.NewMockCar(ctrl)
+	s := New(mockCar, datastore.NewMockEngine(ctrl), NewLRU(10), time.Minute)
+
+	old := models.Car{ID: uuid.New(), Name: "Golf"}
+	updated := old
+	updated.Name = "Golf GTI"
+	id := old.ID.String()
+
+	gomock.InOrder(
+		mockCar.EXPECT().GetCarByID(gomock.Any(), id).DoAndReturn(func(context.Context, string) (models.Car, error) {
+			_, _ = s.UpdateCar(context.TODO(), id, updated)
+			return old, nil
+		}),
+		mockCar.EXPECT().UpdateCar(gomock.Any(), id, updated).Return(updated, nil),
+		mockCar.EXPECT().GetCarByID(gomock.Any(), id).Return(updated, nil),
+	)
+
+	if res, _ := s.GetCarByID(context.TODO(), id); res != old {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "read during write", res, old)
+	}
+
+	if res, _ := s.GetCarByID(context.TODO(), id); res != updated {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "read after write", res, updated)
+	}
+}
+
+// TestCacheErrors test function to test a failing cache is read past and counted
+func TestCacheErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := datastore.NewMockCar(ctrl)
+	mockCache := datastore.NewMockCache(ctrl)
+	s := New(mockCar, datastore.NewMockEngine(ctrl), mockCache, time.Minute)
+
+	car := models.Car{ID: uuid.New(), Name: "Golf"}
+	id := car.ID.String()
+
+	gen := mockCache.EXPECT().Get(gomock.Any(), "generation").Return([]byte("0"), true, nil).Times(2)
+	mockCache.EXPECT().Get(gomock.Any(), "0:car:"+id).Return(nil, false, errors.New("connection refused")).After(gen)
+	mockCar.EXPECT().GetCarByID(gomock.Any(), id).Return(car, nil)
+	mockCache.EXPECT().Set(gomock.Any(), "0:car:"+id, gomock.Any(), time.Minute).
+		Return(errors.New("connection refused"))
+	mockCache.EXPECT().Get(gomock.Any(), "0:car:"+id).Return([]byte("{"), true, nil)
+	mockCar.EXPECT().GetCarByID(gomock.Any(), id).Return(car, nil)
+	mockCache.EXPECT().Set(gomock.Any(), "0:car:"+id, gomock.Any(), time.Minute).Return(nil)
+	mockCache.EXPECT().Get(gomock.Any(), "generation").Return(nil, false, errors.New("connection refused")).
+		After(gen)
+	mockCar.EXPECT().GetCarByID(gomock.Any(), id).Return(car, nil)
+
+	for i := 0; i < 3; i++ {
+		if res, err := s.GetCarByID(context.TODO(), id); err != nil || res != car {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, "read past the cache", res, car)
+		}
+	}
+
+	assert.Equal(t, Metrics{Misses: 3, Errors: 4}, s.Metrics())
+}
+
+// TestFlush test function to test a flush drops the entries of every store sharing the backend
+func TestFlush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := datastore.NewMockCar(ctrl)
+	lru := NewLRU(10)
+	a := New(mockCar, datastore.NewMockEngine(ctrl), lru, time.Minute)
+	b := New(mockCar, datastore.NewMockEngine(ctrl), lru, time.Minute)
+
+	car := models.Car{ID: uuid.New(), Name: "Golf"}
+	id := car.ID.String()
+
+	mockCar.EXPECT().GetCarByID(gomock.Any(), id).Return(car, nil).Times(3)
+
+	for i, s := range []Store{a, b, a} {
+		if i == 2 {
+			b.Flush(context.TODO())
+		}
+
+		if res, err := s.GetCarByID(context.TODO(), id); err != nil || res != car {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, "read through the shared cache", res,
+				car)
+		}
+	}
+
+	assert.Equal(t, uint64(1), b.Metrics().Hits, "the entry of the first store is shared")
+	assert.Equal(t, uint64(2), a.Metrics().Misses, "the flush of the second store dropped the entry")
+
+	_ = lru.Delete(context.TODO(), "generation")
+
+	if res, _ := b.GetCarByID(context.TODO(), id); res != car {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "lost generation", res, car)
+	}
+}
+
+// TestDetachedLoad test function to test a load shared by several callers is not cancelled with the caller which
+// started it
+func TestDetachedLoad(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := datastore.NewMockCar(ctrl)
+	s := New(mockCar, datastore.NewMockEngine(ctrl), NewLRU(10), time.Minute)
+
+	car := models.Car{ID: uuid.New(), Name: "Golf"}
+	id := car.ID.String()
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.TODO(), key{}, "request"))
+	cancel()
+
+	mockCar.EXPECT().GetCarByID(gomock.Any(), id).DoAndReturn(func(ctx context.Context,
+		_ string) (models.Car, error) {
+		assert.Nil(t, ctx.Err(), "the load is not cancelled")
+		assert.Equal(t, "request", ctx.Value(key{}), "the load keeps the values of the request")
+
+		_, ok := ctx.Deadline()
+		assert.True(t, ok, "the load is bounded")
+
+		return car, nil
+	})
+
+	if res, err := s.GetCarByID(ctx, id); err != nil || res != car {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "load after cancel", res, car)
+	}
+}
+
+type key struct{}
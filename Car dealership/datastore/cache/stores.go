@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// Prices is a datastore.Price which drops the cached car once its price is set
+type Prices struct {
+	datastore.Price
+	cache Store
+}
+
+func NewPrices(price datastore.Price, cache Store) Prices {
+	return Prices{Price: price, cache: cache}
+}
+
+// SetPrice sets the price and drops the cached car
+func (s Prices) SetPrice(ctx context.Context, change models.PriceChange) (models.PriceChange, error) {
+	defer s.cache.EvictCar(ctx, change.CarID.String())
+
+	return s.Price.SetPrice(ctx, change)
+}
+
+// Status is a datastore.Status which drops the cached car once its status moves
+type Status struct {
+	datastore.Status
+	cache Store
+}
+
+func NewStatus(status datastore.Status, cache Store) Status {
+	return Status{Status: status, cache: cache}
+}
+
+// Transition moves the car to its new status and drops the cached car
+func (s Status) Transition(ctx context.Context, change models.StatusChange) (models.StatusChange, error) {
+	defer s.cache.EvictCar(ctx, change.CarID.String())
+
+	return s.Status.Transition(ctx, change)
+}
+
+// Reservations is a datastore.Reservation which drops the cached car when a hold reserves it or puts it back in
+// stock
+type Reservations struct {
+	datastore.Reservation
+	cache Store
+}
+
+func NewReservations(reservation datastore.Reservation, cache Store) Reservations {
+	return Reservations{Reservation: reservation, cache: cache}
+}
+
+// CreateHold places the hold and drops the cached car
+func (s Reservations) CreateHold(ctx context.Context, r models.Reservation) (models.Reservation, error) {
+	defer s.cache.EvictCar(ctx, r.CarID.String())
+
+	return s.Reservation.CreateHold(ctx, r)
+}
+
+// EndHold ends the hold and drops the cached car
+func (s Reservations) EndHold(ctx context.Context, r models.Reservation) error {
+	defer s.cache.EvictCar(ctx, r.CarID.String())
+
+	return s.Reservation.EndHold(ctx, r)
+}
+
+// Orders is a datastore.Order which drops the cached car when an order sells it or puts it back in stock
+type Orders struct {
+	datastore.Order
+	cache Store
+}
+
+func NewOrders(order datastore.Order, cache Store) Orders {
+	return Orders{Order: order, cache: cache}
+}
+
+// CreateOrder sells the car and drops the cached car
+func (s Orders) CreateOrder(ctx context.Context, o models.SalesOrder) (models.SalesOrder, error) {
+	defer s.cache.EvictCar(ctx, o.CarID.String())
+
+	return s.Order.CreateOrder(ctx, o)
+}
+
+// CancelOrder cancels the order and drops the cached car
+func (s Orders) CancelOrder(ctx context.Context, o models.SalesOrder) error {
+	defer s.cache.EvictCar(ctx, o.CarID.String())
+
+	return s.Order.CancelOrder(ctx, o)
+}
+
+// Catalogue is a datastore.Catalogue which flushes the cache when the brands of cars are renamed, a rename
+// changes cars by brand so which cars it changed is not known
+type Catalogue struct {
+	datastore.Catalogue
+	cache Store
+}
+
+func NewCatalogue(catalogue datastore.Catalogue, cache Store) Catalogue {
+	return Catalogue{Catalogue: catalogue, cache: cache}
+}
+
+// RenameBrands renames the brands of cars and flushes the cache
+func (s Catalogue) RenameBrands(ctx context.Context, renames []models.BrandRename) (int, error) {
+	defer s.cache.Flush(ctx)
+
+	return s.Catalogue.RenameBrands(ctx, renames)
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+// TestStores test function to test the writes of other stores which change cars drop them from the cache
+func TestStores(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := datastore.NewMockCar(ctrl)
+	mockPrice := datastore.NewMockPrice(ctrl)
+	mockStatus := datastore.NewMockStatus(ctrl)
+	mockReservation := datastore.NewMockReservation(ctrl)
+	mockOrder := datastore.NewMockOrder(ctrl)
+	mockCatalogue := datastore.NewMockCatalogue(ctrl)
+
+	s := New(mockCar, datastore.NewMockEngine(ctrl), NewLRU(10), time.Minute)
+	car := models.Car{ID: uuid.New(), Name: "Golf"}
+	carID := car.ID
+
+	prices, statuses := NewPrices(mockPrice, s), NewStatus(mockStatus, s)
+	holds, orders := NewReservations(mockReservation, s), NewOrders(mockOrder, s)
+	catalogue := NewCatalogue(mockCatalogue, s)
+
+	mockCar.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(car, nil).Times(7)
+	mockPrice.EXPECT().SetPrice(gomock.Any(), gomock.Any()).Return(models.PriceChange{}, nil)
+	mockStatus.EXPECT().Transition(gomock.Any(), gomock.Any()).Return(models.StatusChange{}, nil)
+	mockReservation.EXPECT().CreateHold(gomock.Any(), gomock.Any()).Return(models.Reservation{}, nil)
+	mockReservation.EXPECT().EndHold(gomock.Any(), gomock.Any()).Return(nil)
+	mockOrder.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(models.SalesOrder{}, nil)
+	mockOrder.EXPECT().CancelOrder(gomock.Any(), gomock.Any()).Return(nil)
+	mockCatalogue.EXPECT().RenameBrands(gomock.Any(), gomock.Any()).Return(1, nil)
+
+	writes := []struct {
+		desc  string
+		write func() error
+	}{
+		{"price", func() error {
+			_, err := prices.SetPrice(context.TODO(), models.PriceChange{CarID: carID})
+			return err
+		}},
+		{"status", func() error {
+			_, err := statuses.Transition(context.TODO(), models.StatusChange{CarID: carID})
+			return err
+		}},
+		{"hold", func() error {
+			_, err := holds.CreateHold(context.TODO(), models.Reservation{CarID: carID})
+			return err
+		}},
+		{"end hold", func() error { return holds.EndHold(context.TODO(), models.Reservation{CarID: carID}) }},
+		{"order", func() error {
+			_, err := orders.CreateOrder(context.TODO(), models.SalesOrder{CarID: carID})
+			return err
+		}},
+		{"cancel order", func() error { return orders.CancelOrder(context.TODO(), models.SalesOrder{CarID: carID}) }},
+		{"rename brands", func() error {
+			_, err := catalogue.RenameBrands(context.TODO(), []models.BrandRename{{From: "vw", To: "Volkswagen"}})
+			return err
+		}},
+	}
+
+	for i, w := range writes {
+		if _, err := s.GetCarByID(context.TODO(), carID.String()); err != nil {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, w.desc, err, nil)
+		}
+
+		if err := w.write(); err != nil {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, w.desc, err, nil)
+		}
+	}
+
+	if s.Metrics().Hits != 0 || s.Metrics().Invalidations != uint64(len(writes)) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", len(writes), "every write dropped the car",
+			s.Metrics(), len(writes))
+	}
+}
@@ -3,12 +3,20 @@ package car
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
 
 	"github.com/google/uuid"
 )
 
+// carColumns are the Car columns read by the single table queries, in scan order
+const carColumns = "id,engine_id,name,year,brand,fuel_type,vin,msrp,list_price,cost,currency,status,trim_id"
+
+// powertrainColumns are the powertrain columns written with the engines of cars created in bulk
+const powertrainColumns = "engine_id,type,battery_kwh,charging_standards,max_charge_kw,motor_power_kw," +
+	"fuel_consumption,efficiency"
+
 type Store struct {
 	db *sql.DB
 }
@@ -19,20 +27,50 @@ func New(db *sql.DB) Store {
 
 // GetCarByID store layer function to get car details when car id is provided
 func (s Store) GetCarByID(ctx context.Context, id string) (models.Car, error) {
-	var c models.Car
+	var (
+		c             models.Car
+		vin, currency sql.NullString
+		trim          uuid.NullUUID
+	)
+
+	err := s.db.QueryRowContext(ctx, "SELECT "+carColumns+" FROM Car WHERE ID=?;", id).
+		Scan(&c.ID, &c.Engine.EngineID, &c.Name, &c.Year, &c.Brand, &c.FuelType, &vin,
+			&c.MSRP, &c.ListPrice, &c.Cost, &currency, &c.Status, &trim)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	c.VIN = vin.String
+	c.Currency = currency.String
+	c.TrimID = trimID(trim)
 
-	err := s.db.QueryRowContext(ctx, "SELECT * FROM Car WHERE ID=?;", id).
-		Scan(&c.ID, &c.Engine.EngineID, &c.Name, &c.Year, &c.Brand, &c.FuelType)
+	return c, nil
+}
+
+// GetCarByVIN store layer function to get car details when the vehicle identification number is provided
+func (s Store) GetCarByVIN(ctx context.Context, vin string) (models.Car, error) {
+	var (
+		c        models.Car
+		currency sql.NullString
+		trim     uuid.NullUUID
+	)
+
+	err := s.db.QueryRowContext(ctx, "SELECT "+carColumns+" FROM Car WHERE vin=?;", vin).
+		Scan(&c.ID, &c.Engine.EngineID, &c.Name, &c.Year, &c.Brand, &c.FuelType, &c.VIN,
+			&c.MSRP, &c.ListPrice, &c.Cost, &currency, &c.Status, &trim)
 	if err != nil {
 		return models.Car{}, err
 	}
 
+	c.Currency = currency.String
+	c.TrimID = trimID(trim)
+
 	return c, nil
 }
 
 // GetCarsByBrand store layer function to get all car records of brand name given
 func (s Store) GetCarsByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error) {
-	rows, err := s.db.QueryContext(ctx, "select * from Car where brand=?;", brand)
+	rows, err := s.db.QueryContext(ctx, "select "+carColumns+" from Car where brand=?;", brand)
 	if err != nil {
 		return nil, err
 	}
@@ -42,13 +80,21 @@ func (s Store) GetCarsByBrand(ctx context.Context, brand string, isEngine bool)
 	var car []models.Car
 
 	for rows.Next() {
-		var c models.Car
+		var (
+			c             models.Car
+			vin, currency sql.NullString
+			trim          uuid.NullUUID
+		)
 
-		err = rows.Scan(&c.ID, &c.Engine.EngineID, &c.Name, &c.Year, &c.Brand, &c.FuelType)
+		err = rows.Scan(&c.ID, &c.Engine.EngineID, &c.Name, &c.Year, &c.Brand, &c.FuelType, &vin,
+			&c.MSRP, &c.ListPrice, &c.Cost, &currency, &c.Status, &trim)
 		if err != nil {
 			return nil, err
 		}
 
+		c.VIN = vin.String
+		c.Currency = currency.String
+		c.TrimID = trimID(trim)
 		car = append(car, c)
 	}
 
@@ -62,8 +108,10 @@ func (s Store) GetCarsByBrand(ctx context.Context, brand string, isEngine bool)
 
 // CreateCar store layer function to create car record
 func (s Store) CreateCar(ctx context.Context, car *models.Car) (models.Car, error) {
-	_, err := s.db.ExecContext(ctx, "INSERT INTO Car (id,engine_id,name,year,brand,fuel_type) VALUES(?,?,?,?,?,?)",
-		car.ID.String(), car.Engine.EngineID, car.Name, car.Year, car.Brand, car.FuelType)
+	_, err := s.db.ExecContext(ctx, "INSERT INTO Car ("+carColumns+") VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?)",
+		car.ID.String(), car.Engine.EngineID, car.Name, car.Year, car.Brand, car.FuelType, nullString(car.VIN),
+		int64(car.MSRP), int64(car.ListPrice), int64(car.Cost), nullString(car.Currency), status(*car),
+		nullUUID(car.TrimID))
 	if err != nil {
 		return models.Car{}, err
 	}
@@ -71,15 +119,25 @@ func (s Store) CreateCar(ctx context.Context, car *models.Car) (models.Car, erro
 	return *car, nil
 }
 
-// UpdateCar store layer function to update car record
+// UpdateCar store layer function to update car record, the price and the status are left as they are and read
+// back since they only change through the price and status stores which record every change
 func (s Store) UpdateCar(ctx context.Context, id string, car models.Car) (models.Car, error) {
-	_, err := s.db.ExecContext(ctx, "UPDATE Car SET name=?,year=?,brand=?,fuel_type=? WHERE id=?",
-		car.Name, car.Year, car.Brand, car.FuelType, id)
+	_, err := s.db.ExecContext(ctx, "UPDATE Car SET name=?,year=?,brand=?,fuel_type=?,vin=?,trim_id=? WHERE id=?",
+		car.Name, car.Year, car.Brand, car.FuelType, nullString(car.VIN), nullUUID(car.TrimID), id)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	var currency sql.NullString
+
+	err = s.db.QueryRowContext(ctx, "SELECT msrp,list_price,cost,currency,status FROM Car WHERE id=?", id).
+		Scan(&car.MSRP, &car.ListPrice, &car.Cost, &currency, &car.Status)
 	if err != nil {
 		return models.Car{}, err
 	}
 
 	car.ID = uuid.MustParse(id)
+	car.Currency = currency.String
 
 	return car, nil
 }
@@ -93,3 +151,228 @@ func (s Store) DeleteCar(ctx context.Context, id string) (models.Car, error) {
 
 	return models.Car{}, nil
 }
+
+// CreateCars store layer function to create car and engine records of all cars in a single transaction
+func (s Store) CreateCars(ctx context.Context, cars []models.Car) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for i := range cars {
+		_, err = tx.ExecContext(ctx, "INSERT INTO Engine (id,displacement,cylinders,`range`) VALUES(?,?,?,?)",
+			cars[i].Engine.EngineID.String(), cars[i].Engine.Displacement, cars[i].Engine.NoOfCylinder,
+			cars[i].Engine.CarRange)
+
+		if p := cars[i].Engine.Powertrain; err == nil && p != nil {
+			_, err = tx.ExecContext(ctx, "INSERT INTO powertrain ("+powertrainColumns+") VALUES(?,?,?,?,?,?,?,?)",
+				cars[i].Engine.EngineID.String(), p.Type, p.BatteryKWh, strings.Join(p.ChargingStandards, ","),
+				p.MaxChargeKW, p.MotorPowerKW, p.FuelConsumption, p.Efficiency)
+		}
+
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO Car ("+carColumns+") VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?)",
+			cars[i].ID.String(), cars[i].Engine.EngineID, cars[i].Name, cars[i].Year, cars[i].Brand, cars[i].FuelType,
+			nullString(cars[i].VIN), int64(cars[i].MSRP), int64(cars[i].ListPrice), int64(cars[i].Cost),
+			nullString(cars[i].Currency), status(cars[i]), nullUUID(cars[i].TrimID))
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// StreamCars store layer function to call fn for every car matching filter, reading one row at a time
+func (s Store) StreamCars(ctx context.Context, filter models.CarFilter, isEngine bool,
+	fn func(models.Car) error) error {
+	where, args := whereClause(filter)
+
+	rows, err := s.db.QueryContext(ctx, selectCars(isEngine)+where, args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	return scanCars(rows, isEngine, fn)
+}
+
+// GetCars store layer function to get a page of the cars matching filter ordered by id, with the count of the cars
+// of every page
+func (s Store) GetCars(ctx context.Context, filter models.CarFilter, page models.Page,
+	isEngine bool) (models.CarPage, error) {
+	where, args := whereClause(filter)
+
+	res := models.CarPage{Cars: []models.Car{}}
+
+	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM Car c"+where, args...).Scan(&res.Total)
+	if err != nil {
+		return models.CarPage{}, err
+	}
+
+	if page.After != "" {
+		if where == "" {
+			where = " WHERE c.id>?"
+		} else {
+			where += " AND c.id>?"
+		}
+
+		args = append(args, page.After)
+	}
+
+	where += " ORDER BY c.id"
+
+	// one car more than the page is read to know whether there is a next page
+	if page.Limit > 0 {
+		where += " LIMIT ?"
+		args = append(args, page.Limit+1)
+	}
+
+	rows, err := s.db.QueryContext(ctx, selectCars(isEngine)+where, args...)
+	if err != nil {
+		return models.CarPage{}, err
+	}
+
+	defer rows.Close()
+
+	err = scanCars(rows, isEngine, func(c models.Car) error {
+		res.Cars = append(res.Cars, c)
+		return nil
+	})
+	if err != nil {
+		return models.CarPage{}, err
+	}
+
+	if page.Limit > 0 && len(res.Cars) > page.Limit {
+		res.Cars, res.HasNext = res.Cars[:page.Limit], true
+	}
+
+	return res, nil
+}
+
+// selectCars is the query of the cars of StreamCars and GetCars, with the engine of each car when isEngine is set
+func selectCars(isEngine bool) string {
+	if isEngine {
+		return "SELECT c.id,c.engine_id,c.name,c.year,c.brand,c.fuel_type,c.vin," +
+			"c.msrp,c.list_price,c.cost,c.currency,c.status,c.trim_id," +
+			"e.displacement,e.cylinders,e.`range` FROM Car c LEFT JOIN Engine e ON e.id=c.engine_id"
+	}
+
+	return "SELECT c.id,c.engine_id,c.name,c.year,c.brand,c.fuel_type,c.vin," +
+		"c.msrp,c.list_price,c.cost,c.currency,c.status,c.trim_id FROM Car c"
+}
+
+// scanCars calls fn for every row of a selectCars query
+func scanCars(rows *sql.Rows, isEngine bool, fn func(models.Car) error) error {
+	for rows.Next() {
+		var (
+			c                             models.Car
+			vin, currency                 sql.NullString
+			trim                          uuid.NullUUID
+			displacement, cylinders, rnge sql.NullInt64
+		)
+
+		dest := []interface{}{&c.ID, &c.Engine.EngineID, &c.Name, &c.Year, &c.Brand, &c.FuelType, &vin,
+			&c.MSRP, &c.ListPrice, &c.Cost, &currency, &c.Status, &trim}
+		if isEngine {
+			dest = append(dest, &displacement, &cylinders, &rnge)
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+
+		c.VIN = vin.String
+		c.Currency = currency.String
+		c.TrimID = trimID(trim)
+		c.Engine.Displacement = displacement.Int64
+		c.Engine.NoOfCylinder = cylinders.Int64
+		c.Engine.CarRange = rnge.Int64
+
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// whereClause builds the WHERE clause and its arguments for the non zero fields of filter
+func whereClause(filter models.CarFilter) (string, []interface{}) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+
+	if filter.Brand != "" {
+		conds = append(conds, "c.brand=?")
+		args = append(args, filter.Brand)
+	}
+
+	if filter.MinPrice != 0 {
+		conds = append(conds, "c.list_price>=?")
+		args = append(args, int64(filter.MinPrice))
+	}
+
+	if filter.MaxPrice != 0 {
+		conds = append(conds, "c.list_price<=?")
+		args = append(args, int64(filter.MaxPrice))
+	}
+
+	if statuses := filter.Statuses(); len(statuses) > 0 {
+		conds = append(conds, "c.status IN (?"+strings.Repeat(",?", len(statuses)-1)+")")
+
+		for _, st := range statuses {
+			args = append(args, st)
+		}
+	}
+
+	if filter.Currency != "" {
+		conds = append(conds, "c.currency=?")
+		args = append(args, strings.ToUpper(filter.Currency))
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// nullString stores an empty string as NULL so that optional unique columns such as vin do not collide
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// status is the status a new car is stored with, in stock unless it is given
+func status(car models.Car) string {
+	if car.Status == "" {
+		return models.StatusInStock
+	}
+
+	return car.Status
+}
+
+// nullUUID stores a missing trim as NULL
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+// trimID reads a trim that may be NULL
+func trimID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+
+	return &id.UUID
+}
@@ -2,6 +2,7 @@ package car
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"log"
 	"reflect"
@@ -13,6 +14,12 @@ import (
 	"github.com/google/uuid"
 )
 
+const insertCar = "INSERT INTO Car (id,engine_id,name,year,brand,fuel_type,vin,msrp,list_price,cost,currency," +
+	"status,trim_id) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?)"
+
+var carColumnNames = []string{"id", "engine_id", "name", "year", "brand", "fuel_type", "vin", "msrp", "list_price",
+	"cost", "currency", "status", "trim_id"}
+
 // TestGetByID function to test store layer GetbyId function
 func TestGetByID(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
@@ -30,7 +37,7 @@ func TestGetByID(t *testing.T) {
 
 		car1 = models.Car{ID: id, Name: "Q2", Year: 2009, Brand: "BMW", FuelType: "petrol", Engine: models.Engine{
 			EngineID: id,
-		}}
+		}, MSRP: 3450000, ListPrice: 3299950, Cost: 2800000, Currency: "USD", Status: models.StatusSold}
 		er = errors.New("all expectations were already fulfilled")
 	)
 
@@ -44,11 +51,14 @@ func TestGetByID(t *testing.T) {
 		{"car ID invalid", id1, models.Car{}, er},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "engine_id", "name", "year", "brand", "fuelType"}).
-		AddRow(car1.ID.String(), car1.Engine.EngineID.String(), car1.Name, car1.Year, car1.Brand, car1.FuelType)
+	rows := sqlmock.NewRows(carColumnNames).
+		AddRow(car1.ID.String(), car1.Engine.EngineID.String(), car1.Name, car1.Year, car1.Brand, car1.FuelType, nil,
+			car1.MSRP, car1.ListPrice, car1.Cost, car1.Currency, car1.Status, nil)
 
-	mock.ExpectQuery("SELECT * FROM Car WHERE ID=?;").WithArgs(id).WillReturnRows(rows)
-	mock.ExpectQuery("SELECT * FROM Car WHERE ID=?;").WithArgs(id1).WillReturnError(er)
+	mock.ExpectQuery("SELECT " + carColumns + " FROM Car WHERE ID=?;").WithArgs(id).
+		WillReturnRows(rows)
+	mock.ExpectQuery("SELECT " + carColumns + " FROM Car WHERE ID=?;").WithArgs(id1).
+		WillReturnError(er)
 
 	for i, tc := range testCases {
 		resp, err := a.GetCarByID(context.TODO(), tc.id.String())
@@ -63,6 +73,51 @@ func TestGetByID(t *testing.T) {
 	}
 }
 
+// TestGetByVIN function to test store layer GetCarByVIN function
+func TestGetByVIN(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	defer db.Close()
+
+	a := New(db)
+
+	id := uuid.New()
+	car := models.Car{ID: id, VIN: "WBA3A5C51CF256551", Name: "328i", Year: 2012, Brand: "BMW", FuelType: "petrol",
+		Engine: models.Engine{EngineID: id}, Status: models.StatusInStock}
+
+	testCases := []struct {
+		desc      string
+		vin       string
+		outputCar models.Car
+		err       error
+	}{
+		{desc: "success", vin: car.VIN, outputCar: car},
+		{desc: "unknown vin", vin: "1M8GDM9AXKP042788", err: sql.ErrNoRows},
+	}
+
+	mock.ExpectQuery("SELECT " + carColumns + " FROM Car WHERE vin=?;").WithArgs(car.VIN).
+		WillReturnRows(sqlmock.NewRows(carColumnNames).
+			AddRow(id.String(), id.String(), car.Name, car.Year, car.Brand, car.FuelType, car.VIN, 0, 0, 0, nil,
+				car.Status, nil))
+	mock.ExpectQuery("SELECT " + carColumns + " FROM Car WHERE vin=?;").
+		WithArgs("1M8GDM9AXKP042788").WillReturnError(sql.ErrNoRows)
+
+	for i, tc := range testCases {
+		resp, err := a.GetCarByVIN(context.TODO(), tc.vin)
+
+		if resp != tc.outputCar {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, resp, tc.outputCar)
+		}
+
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
+
 // TestGetbybrand function to test store layer GetbyBrand function
 func TestGetbybrand(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
@@ -79,13 +134,14 @@ func TestGetbybrand(t *testing.T) {
 		id1        = uuid.New()
 		id2        = uuid.New()
 		queryError = errors.New("query error")
-		er         = errors.New("sql: expected 5 destination arguments in Scan, not 6")
+		er         = errors.New("sql: expected 5 destination arguments in Scan, not 13")
 
-		car = models.Car{ID: id, Name: "GenX", Year: 2015, Brand: "Ferrari",
-			FuelType: "electric", Engine: models.Engine{EngineID: id}}
+		car = models.Car{ID: id, VIN: "ZFF67NFA1A0123456", Name: "GenX", Year: 2015, Brand: "Ferrari",
+			FuelType: "electric", Engine: models.Engine{EngineID: id}, MSRP: 21500000, ListPrice: 19999999,
+			Cost: 17000000, Currency: "EUR", Status: models.StatusInStock}
 
 		car1 = models.Car{ID: id1, Name: "Ferrari AQ", Year: 2020, Brand: "Ferrari",
-			FuelType: "electric", Engine: models.Engine{EngineID: id1}}
+			FuelType: "electric", Engine: models.Engine{EngineID: id1}, Status: models.StatusInTransit}
 
 		car2 = models.Car{ID: id2, Name: "X4", Brand: "Porsche",
 			FuelType: "electric", Engine: models.Engine{EngineID: id2}}
@@ -104,9 +160,11 @@ func TestGetbybrand(t *testing.T) {
 		{"row error", "BMW", []models.Car{}, true, errors.New("err")},
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "engine_id", "name", "year", "brand", "fuel_type"}).
-		AddRow(id.String(), id.String(), car.Name, car.Year, car.Brand, car.FuelType).
-		AddRow(id1.String(), id1.String(), car1.Name, car1.Year, car1.Brand, car1.FuelType)
+	rows := sqlmock.NewRows(carColumnNames).
+		AddRow(id.String(), id.String(), car.Name, car.Year, car.Brand, car.FuelType, car.VIN,
+			car.MSRP, car.ListPrice, car.Cost, car.Currency, car.Status, nil).
+		AddRow(id1.String(), id1.String(), car1.Name, car1.Year, car1.Brand, car1.FuelType, nil, 0, 0, 0, nil,
+			car1.Status, nil)
 
 	rows2 := sqlmock.NewRows([]string{"id", "engine_id", "name", "brand", "fuel_type"}).
 		AddRow(id2.String(), id2.String(), car2.Name, car2.Brand, car2.FuelType)
@@ -114,10 +172,12 @@ func TestGetbybrand(t *testing.T) {
 	rows3 := sqlmock.NewRows([]string{"id", "engine_id", "name", "brand", "fuel_type"}).
 		AddRow(id2.String(), id2.String(), car2.Name, car2.Year, car2.Brand).RowError(0, errors.New("err"))
 
-	mock.ExpectQuery("select * from Car where brand=?;").WithArgs("Ferrari").WillReturnRows(rows)
-	mock.ExpectQuery("select * from Car where brand=?;").WithArgs("").WillReturnError(queryError)
-	mock.ExpectQuery("select * from Car where brand=?;").WithArgs("Porsche").WillReturnRows(rows2)
-	mock.ExpectQuery("select * from Car where brand=?;").WithArgs("BMW").WillReturnRows(rows3)
+	query := "select " + carColumns + " from Car where brand=?;"
+
+	mock.ExpectQuery(query).WithArgs("Ferrari").WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("").WillReturnError(queryError)
+	mock.ExpectQuery(query).WithArgs("Porsche").WillReturnRows(rows2)
+	mock.ExpectQuery(query).WithArgs("BMW").WillReturnRows(rows3)
 
 	for i, tc := range testCases {
 		car, err := a.GetCarsByBrand(context.TODO(), tc.brand, tc.eng)
@@ -143,7 +203,8 @@ func TestCreatecar(t *testing.T) {
 
 	id := uuid.New()
 	car := models.Car{ID: id, Name: "AQ", Year: 2015, Brand: "Ferrari",
-		FuelType: "electric", Engine: models.Engine{EngineID: id}}
+		FuelType: "electric", Engine: models.Engine{EngineID: id}, MSRP: 2499990, ListPrice: 2399900,
+		Cost: 2000000, Currency: "EUR", Status: models.StatusInTransit}
 
 	car1 := models.Car{ID: uuid.Nil, Name: "AQ", Year: 2015, Brand: "Ferrari",
 		FuelType: "electric", Engine: models.Engine{EngineID: id}}
@@ -160,12 +221,14 @@ func TestCreatecar(t *testing.T) {
 		{"fail", car1, models.Car{}, queryErr},
 	}
 
-	mock.ExpectExec("INSERT INTO Car (id,engine_id,name,year,brand,fuel_type) VALUES(?,?,?,?,?,?)").
-		WithArgs(sqlmock.AnyArg(), car.Engine.EngineID, car.Name, car.Year, car.Brand, car.FuelType).
+	mock.ExpectExec(insertCar).
+		WithArgs(sqlmock.AnyArg(), car.Engine.EngineID, car.Name, car.Year, car.Brand, car.FuelType, nil,
+			car.MSRP, car.ListPrice, car.Cost, car.Currency, models.StatusInTransit, nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	mock.ExpectExec("INSERT INTO Car (id,engine_id,name,year,brand,fuel_type) VALUES(?,?,?,?,?,?)").
-		WithArgs(uuid.Nil, car.Engine.EngineID, car.Name, car.Year, car.Brand, car.FuelType).
+	mock.ExpectExec(insertCar).
+		WithArgs(uuid.Nil, car.Engine.EngineID, car.Name, car.Year, car.Brand, car.FuelType, nil, 0, 0, 0, nil,
+			models.StatusInStock, nil).
 		WillReturnError(queryErr)
 
 	for i, tc := range testCases {
@@ -193,7 +256,8 @@ func TestUpdatecar(t *testing.T) {
 	id := uuid.New()
 	id1 := uuid.Nil
 
-	car := models.Car{ID: id, Name: "BMW", Year: 2018, Brand: "Rolls-Royce", FuelType: "petrol"}
+	trim := uuid.New()
+	car := models.Car{ID: id, Name: "BMW", Year: 2018, Brand: "Rolls-Royce", FuelType: "petrol", TrimID: &trim}
 	updateFail := errors.New("update failed")
 
 	car1 := models.Car{ID: id1, Name: "BMW", Year: 2018, Brand: "Rolls-Royce", FuelType: "petrol"}
@@ -209,18 +273,25 @@ func TestUpdatecar(t *testing.T) {
 
 	defer db.Close()
 
-	mock.ExpectExec("UPDATE Car SET name=?,year=?,brand=?,fuel_type=? WHERE id=?").
-		WithArgs(car.Name, car.Year, car.Brand, car.FuelType, id).
+	mock.ExpectExec("UPDATE Car SET name=?,year=?,brand=?,fuel_type=?,vin=?,trim_id=? WHERE id=?").
+		WithArgs(car.Name, car.Year, car.Brand, car.FuelType, nil, trim.String(), id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
-	mock.ExpectExec("UPDATE Car SET name=?,year=?,brand=?,fuel_type=? WHERE id=?").
-		WithArgs(car1.Name, car1.Year, car1.Brand, car1.FuelType, id1).
+	mock.ExpectQuery("SELECT msrp,list_price,cost,currency,status FROM Car WHERE id=?").WithArgs(id.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"msrp", "list_price", "cost", "currency", "status"}).
+			AddRow(4500000, 4250000, 3900000, "GBP", models.StatusReserved))
+	mock.ExpectExec("UPDATE Car SET name=?,year=?,brand=?,fuel_type=?,vin=?,trim_id=? WHERE id=?").
+		WithArgs(car1.Name, car1.Year, car1.Brand, car1.FuelType, nil, nil, id1).
 		WillReturnError(updateFail)
 
 	for i, tc := range testCases {
-		_, err := a.UpdateCar(context.TODO(), tc.input.ID.String(), tc.input)
+		res, err := a.UpdateCar(context.TODO(), tc.input.ID.String(), tc.input)
 		if err != tc.err {
 			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
 		}
+
+		if err == nil && (res.ListPrice != 4250000 || res.Currency != "GBP" || res.Status != models.StatusReserved) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected the stored price", i, tc.desc, res)
+		}
 	}
 }
 
@@ -261,3 +332,217 @@ func TestDeleteCar(t *testing.T) {
 		}
 	}
 }
+
+// TestCreateCars function to test store layer bulk create function
+func TestCreateCars(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	id := uuid.New()
+	car := models.Car{ID: id, VIN: "ZFF67NFA1A0123456", Name: "AQ", Year: 2015, Brand: "Ferrari",
+		FuelType: "petrol", Engine: models.Engine{EngineID: id, Displacement: 300, NoOfCylinder: 8}}
+	electric := models.Car{ID: id, Name: "Model 3", Year: 2022, Brand: "Tesla", FuelType: "electric",
+		Engine: models.Engine{EngineID: id, CarRange: 500, Powertrain: &models.Powertrain{Type: "bev",
+			BatteryKWh: 75, ChargingStandards: []string{"type2", "ccs2"}, MaxChargeKW: 250}}}
+	insertErr := errors.New("insert failed")
+
+	testCases := []struct {
+		desc string
+		car  models.Car
+		err  error
+	}{
+		{"success", car, nil},
+		{"rolled back", car, insertErr},
+		{"with powertrain", electric, nil},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO Engine (id,displacement,cylinders,`range`) VALUES(?,?,?,?)").
+		WithArgs(id.String(), car.Engine.Displacement, car.Engine.NoOfCylinder, car.Engine.CarRange).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(insertCar).
+		WithArgs(id.String(), id, car.Name, car.Year, car.Brand, car.FuelType, car.VIN, 0, 0, 0, nil,
+			models.StatusInStock, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO Engine (id,displacement,cylinders,`range`) VALUES(?,?,?,?)").
+		WithArgs(id.String(), car.Engine.Displacement, car.Engine.NoOfCylinder, car.Engine.CarRange).
+		WillReturnError(insertErr)
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO Engine (id,displacement,cylinders,`range`) VALUES(?,?,?,?)").
+		WithArgs(id.String(), 0, 0, 500).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO powertrain ("+powertrainColumns+") VALUES(?,?,?,?,?,?,?,?)").
+		WithArgs(id.String(), "bev", 75.0, "type2,ccs2", 250.0, 0, 0.0, 0).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(insertCar).
+		WithArgs(id.String(), id, electric.Name, electric.Year, electric.Brand, electric.FuelType, nil, 0, 0, 0, nil,
+			models.StatusInStock, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	for i, tc := range testCases {
+		err := a.CreateCars(context.TODO(), []models.Car{tc.car})
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestStreamCars function to test store layer streaming function
+func TestStreamCars(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	id := uuid.New()
+	car := models.Car{ID: id, Name: "GenX", Year: 2015, Brand: "Ferrari", FuelType: "petrol",
+		Engine: models.Engine{EngineID: id, Displacement: 300, NoOfCylinder: 8}, Status: models.StatusInStock}
+	trim := uuid.New()
+	queryErr := errors.New("query error")
+
+	testCases := []struct {
+		desc     string
+		filter   models.CarFilter
+		isEngine bool
+		output   []models.Car
+		err      error
+	}{
+		{desc: "cars with engine", filter: models.CarFilter{Brand: "Ferrari"}, isEngine: true,
+			output: []models.Car{car}},
+		{desc: "cars in a price range", filter: models.CarFilter{MinPrice: 1000000, MaxPrice: 2000000, Currency: "eur"},
+			output: []models.Car{{ID: id, Name: car.Name, Year: car.Year, Brand: car.Brand,
+				FuelType: car.FuelType, Engine: models.Engine{EngineID: id}, ListPrice: 1500000, Currency: "EUR",
+				Status: models.StatusReserved, TrimID: &trim}}},
+		{desc: "cars in any of the statuses", filter: models.CarFilter{Status: "in_stock, Reserved"},
+			output: []models.Car{{ID: id, Name: car.Name, Year: car.Year, Brand: car.Brand,
+				FuelType: car.FuelType, Engine: models.Engine{EngineID: id}, Status: models.StatusInStock}}},
+		{desc: "query error", err: queryErr},
+	}
+
+	selectCars := "SELECT c.id,c.engine_id,c.name,c.year,c.brand,c.fuel_type,c.vin,c.msrp,c.list_price,c.cost," +
+		"c.currency,c.status,c.trim_id"
+
+	mock.ExpectQuery(selectCars + ",e.displacement,e.cylinders," +
+		"e.`range` FROM Car c LEFT JOIN Engine e ON e.id=c.engine_id WHERE c.brand=?").WithArgs("Ferrari").
+		WillReturnRows(sqlmock.NewRows(append(carColumnNames, "displacement", "cylinders", "range")).
+			AddRow(id.String(), id.String(), car.Name, car.Year, car.Brand, car.FuelType, nil, 0, 0, 0, nil, car.Status,
+				nil, 300, 8, nil))
+	mock.ExpectQuery(selectCars+" FROM Car c WHERE c.list_price>=? AND c.list_price<=? AND c.currency=?").
+		WithArgs(1000000, 2000000, "EUR").
+		WillReturnRows(sqlmock.NewRows(carColumnNames).
+			AddRow(id.String(), id.String(), car.Name, car.Year, car.Brand, car.FuelType, nil, 0, 1500000, 0, "EUR",
+				models.StatusReserved, trim.String()))
+	mock.ExpectQuery(selectCars+" FROM Car c WHERE c.status IN (?,?)").WithArgs("in_stock", "reserved").
+		WillReturnRows(sqlmock.NewRows(carColumnNames).
+			AddRow(id.String(), id.String(), car.Name, car.Year, car.Brand, car.FuelType, nil, 0, 0, 0, nil, car.Status,
+				nil))
+	mock.ExpectQuery(selectCars + " FROM Car c").
+		WillReturnError(queryErr)
+
+	for i, tc := range testCases {
+		var cars []models.Car
+
+		err := a.StreamCars(context.TODO(), tc.filter, tc.isEngine, func(c models.Car) error {
+			cars = append(cars, c)
+			return nil
+		})
+
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if !reflect.DeepEqual(cars, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, cars, tc.output)
+		}
+	}
+}
+
+func TestGetCars(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	id, id1 := uuid.New(), uuid.New()
+	car := models.Car{ID: id, Name: "GenX", Year: 2015, Brand: "Ferrari", FuelType: "petrol",
+		Engine: models.Engine{EngineID: id}, ListPrice: 1500000, Currency: "EUR", Status: models.StatusInStock}
+	car1 := car
+	car1.ID = id1
+	filter := models.CarFilter{Brand: "Ferrari", MinPrice: 1000000, Currency: "eur"}
+	queryErr := errors.New("query error")
+
+	testCases := []struct {
+		desc   string
+		page   models.Page
+		output models.CarPage
+		err    error
+	}{
+		{desc: "first page", page: models.Page{Limit: 1},
+			output: models.CarPage{Cars: []models.Car{car}, Total: 2, HasNext: true}},
+		{desc: "last page", page: models.Page{After: id.String(), Limit: 1},
+			output: models.CarPage{Cars: []models.Car{car1}, Total: 2}},
+		{desc: "every car", output: models.CarPage{Cars: []models.Car{car, car1}, Total: 2}},
+		{desc: "count error", err: queryErr},
+	}
+
+	selectCars := "SELECT c.id,c.engine_id,c.name,c.year,c.brand,c.fuel_type,c.vin,c.msrp,c.list_price,c.cost," +
+		"c.currency,c.status,c.trim_id FROM Car c WHERE c.brand=? AND c.list_price>=? AND c.currency=?"
+	count := "SELECT COUNT(*) FROM Car c WHERE c.brand=? AND c.list_price>=? AND c.currency=?"
+	rows := func(cars ...models.Car) *sqlmock.Rows {
+		rows := sqlmock.NewRows(carColumnNames)
+		for _, c := range cars {
+			rows.AddRow(c.ID.String(), c.Engine.EngineID.String(), c.Name, c.Year, c.Brand, c.FuelType, nil, 0, c.ListPrice, 0,
+				c.Currency, c.Status, nil)
+		}
+
+		return rows
+	}
+
+	mock.ExpectQuery(count).WithArgs("Ferrari", 1000000, "EUR").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(selectCars+" ORDER BY c.id LIMIT ?").WithArgs("Ferrari", 1000000, "EUR", 2).
+		WillReturnRows(rows(car, car1))
+	mock.ExpectQuery(count).WithArgs("Ferrari", 1000000, "EUR").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(selectCars+" AND c.id>? ORDER BY c.id LIMIT ?").WithArgs("Ferrari", 1000000, "EUR",
+		id.String(), 2).WillReturnRows(rows(car1))
+	mock.ExpectQuery(count).WithArgs("Ferrari", 1000000, "EUR").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(selectCars+" ORDER BY c.id").WithArgs("Ferrari", 1000000, "EUR").
+		WillReturnRows(rows(car, car1))
+	mock.ExpectQuery(count).WithArgs("Ferrari", 1000000, "EUR").WillReturnError(queryErr)
+
+	for i, tc := range testCases {
+		res, err := a.GetCars(context.TODO(), filter, tc.page, false)
+
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
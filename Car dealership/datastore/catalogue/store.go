@@ -0,0 +1,303 @@
+package catalogue
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+const (
+	brandColumns = "id,name,aliases"
+	modelColumns = "id,brand_id,name"
+	trimColumns  = "id,model_id,name,fuel_type,engine_id"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// CreateBrand store layer function to insert a brand, its aliases are stored as a comma separated list
+func (s Store) CreateBrand(ctx context.Context, b models.Brand) (models.Brand, error) {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO brand ("+brandColumns+") VALUES(?,?,?)",
+		b.ID.String(), b.Name, strings.Join(b.Aliases, ","))
+	if err != nil {
+		return models.Brand{}, err
+	}
+
+	return b, nil
+}
+
+// GetBrand store layer function to get a brand by its id
+func (s Store) GetBrand(ctx context.Context, id string) (models.Brand, error) {
+	return scanBrand(s.db.QueryRowContext(ctx, "SELECT "+brandColumns+" FROM brand WHERE id=?", id))
+}
+
+// GetBrands store layer function to get every brand by name
+func (s Store) GetBrands(ctx context.Context) ([]models.Brand, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+brandColumns+" FROM brand ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	brands := []models.Brand{}
+
+	for rows.Next() {
+		b, err := scanBrand(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		brands = append(brands, b)
+	}
+
+	return brands, rows.Err()
+}
+
+// UpdateBrand store layer function to rename a brand and replace its aliases
+func (s Store) UpdateBrand(ctx context.Context, b models.Brand) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE brand SET name=?,aliases=? WHERE id=?",
+		b.Name, strings.Join(b.Aliases, ","), b.ID.String())
+
+	return err
+}
+
+// DeleteBrand store layer function to delete a brand
+func (s Store) DeleteBrand(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM brand WHERE id=?", id)
+
+	return err
+}
+
+// CreateModel store layer function to insert a model
+func (s Store) CreateModel(ctx context.Context, m models.CarModel) (models.CarModel, error) {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO car_model ("+modelColumns+") VALUES(?,?,?)",
+		m.ID.String(), m.BrandID.String(), m.Name)
+	if err != nil {
+		return models.CarModel{}, err
+	}
+
+	return m, nil
+}
+
+// GetModel store layer function to get a model by its id
+func (s Store) GetModel(ctx context.Context, id string) (models.CarModel, error) {
+	var m models.CarModel
+
+	err := s.db.QueryRowContext(ctx, "SELECT "+modelColumns+" FROM car_model WHERE id=?", id).
+		Scan(&m.ID, &m.BrandID, &m.Name)
+	if err != nil {
+		return models.CarModel{}, err
+	}
+
+	return m, nil
+}
+
+// GetModels store layer function to get the models of a brand by name
+func (s Store) GetModels(ctx context.Context, brandID string) ([]models.CarModel, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+modelColumns+" FROM car_model WHERE brand_id=? ORDER BY name",
+		brandID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	list := []models.CarModel{}
+
+	for rows.Next() {
+		var m models.CarModel
+
+		if err = rows.Scan(&m.ID, &m.BrandID, &m.Name); err != nil {
+			return nil, err
+		}
+
+		list = append(list, m)
+	}
+
+	return list, rows.Err()
+}
+
+// UpdateModel store layer function to rename a model
+func (s Store) UpdateModel(ctx context.Context, m models.CarModel) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE car_model SET name=? WHERE id=?", m.Name, m.ID.String())
+
+	return err
+}
+
+// DeleteModel store layer function to delete a model
+func (s Store) DeleteModel(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM car_model WHERE id=?", id)
+
+	return err
+}
+
+// CreateTrim store layer function to insert a trim, its default engine is stored by the engine store
+func (s Store) CreateTrim(ctx context.Context, t models.Trim) (models.Trim, error) {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO car_trim ("+trimColumns+") VALUES(?,?,?,?,?)",
+		t.ID.String(), t.ModelID.String(), t.Name, t.FuelType, t.Engine.EngineID.String())
+	if err != nil {
+		return models.Trim{}, err
+	}
+
+	return t, nil
+}
+
+// GetTrim store layer function to get a trim by its id, only the id of its engine is read
+func (s Store) GetTrim(ctx context.Context, id string) (models.Trim, error) {
+	return scanTrim(s.db.QueryRowContext(ctx, "SELECT "+trimColumns+" FROM car_trim WHERE id=?", id))
+}
+
+// GetTrims store layer function to get the trims of a model by name, only the ids of their engines are read
+func (s Store) GetTrims(ctx context.Context, modelID string) ([]models.Trim, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+trimColumns+" FROM car_trim WHERE model_id=? ORDER BY name",
+		modelID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	trims := []models.Trim{}
+
+	for rows.Next() {
+		t, err := scanTrim(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		trims = append(trims, t)
+	}
+
+	return trims, rows.Err()
+}
+
+// UpdateTrim store layer function to rename a trim and change its fuel type
+func (s Store) UpdateTrim(ctx context.Context, t models.Trim) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE car_trim SET name=?,fuel_type=? WHERE id=?", t.Name, t.FuelType,
+		t.ID.String())
+
+	return err
+}
+
+// DeleteTrim store layer function to delete a trim no car was created from, sql.ErrNoRows is returned when the
+// trim is missing or still has cars. The cars are checked in the statement which deletes the trim so that a car
+// created from it in between is not left without its trim.
+func (s Store) DeleteTrim(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM car_trim WHERE id=? AND NOT EXISTS "+
+		"(SELECT 1 FROM Car WHERE trim_id=?)", id, id)
+	if err != nil {
+		return err
+	}
+
+	return datastore.Affected(res)
+}
+
+// CountTrimCars store layer function to count the cars created from a trim
+func (s Store) CountTrimCars(ctx context.Context, trimID string) (int, error) {
+	var n int
+
+	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM Car WHERE trim_id=?", trimID).Scan(&n)
+
+	return n, err
+}
+
+// GetBrandSpellings store layer function to count the cars of each way their brand is written. The brands are
+// compared as binary strings since the column collation would take BMW and bmw for the same spelling.
+func (s Store) GetBrandSpellings(ctx context.Context) ([]models.BrandSpelling, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT BINARY brand,COUNT(*) FROM Car GROUP BY BINARY brand ORDER BY BINARY brand")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	spellings := []models.BrandSpelling{}
+
+	for rows.Next() {
+		var sp models.BrandSpelling
+
+		if err = rows.Scan(&sp.Brand, &sp.Cars); err != nil {
+			return nil, err
+		}
+
+		spellings = append(spellings, sp)
+	}
+
+	return spellings, rows.Err()
+}
+
+// RenameBrands store layer function to rewrite the brand of cars, all renames are made in a single transaction
+// and the number of cars rewritten is returned
+func (s Store) RenameBrands(ctx context.Context, renames []models.BrandRename) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var updated int64
+
+	for i := 0; err == nil && i < len(renames); i++ {
+		var res sql.Result
+
+		res, err = tx.ExecContext(ctx, "UPDATE Car SET brand=? WHERE BINARY brand=?", renames[i].To, renames[i].From)
+		if err == nil {
+			var n int64
+
+			n, err = res.RowsAffected()
+			updated += n
+		}
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int(updated), nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBrand(row scanner) (models.Brand, error) {
+	var (
+		b       models.Brand
+		aliases string
+	)
+
+	if err := row.Scan(&b.ID, &b.Name, &aliases); err != nil {
+		return models.Brand{}, err
+	}
+
+	b.Aliases = []string{}
+	if aliases != "" {
+		b.Aliases = strings.Split(aliases, ",")
+	}
+
+	return b, nil
+}
+
+func scanTrim(row scanner) (models.Trim, error) {
+	var t models.Trim
+
+	if err := row.Scan(&t.ID, &t.ModelID, &t.Name, &t.FuelType, &t.Engine.EngineID); err != nil {
+		return models.Trim{}, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,247 @@
+package catalogue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+// TestBrands function to test store layer CreateBrand, GetBrand, GetBrands, UpdateBrand and DeleteBrand functions
+func TestBrands(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	s := New(db)
+
+	defer db.Close()
+
+	b := models.Brand{ID: uuid.New(), Name: "BMW", Aliases: []string{"bmw", "B.M.W."}}
+	bare := models.Brand{ID: uuid.New(), Name: "Audi", Aliases: []string{}}
+
+	mock.ExpectExec("INSERT INTO brand ("+brandColumns+") VALUES(?,?,?)").
+		WithArgs(b.ID.String(), "BMW", "bmw,B.M.W.").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT " + brandColumns + " FROM brand WHERE id=?").WithArgs(b.ID.String()).
+		WillReturnRows(sqlmock.NewRows(strings.Split(brandColumns, ",")).AddRow(b.ID.String(), "BMW", "bmw,B.M.W."))
+	mock.ExpectQuery("SELECT " + brandColumns + " FROM brand WHERE id=?").WithArgs(bare.ID.String()).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT " + brandColumns + " FROM brand ORDER BY name").
+		WillReturnRows(sqlmock.NewRows(strings.Split(brandColumns, ",")).AddRow(bare.ID.String(), "Audi", "").
+			AddRow(b.ID.String(), "BMW", "bmw,B.M.W."))
+	mock.ExpectExec("UPDATE brand SET name=?,aliases=? WHERE id=?").WithArgs("BMW", "bmw,B.M.W.", b.ID.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM brand WHERE id=?").WithArgs(b.ID.String()).
+		WillReturnError(errors.New("db error"))
+
+	res, err := s.CreateBrand(context.TODO(), b)
+	if err != nil || !reflect.DeepEqual(res, b) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "create", res, b)
+	}
+
+	res, err = s.GetBrand(context.TODO(), b.ID.String())
+	if err != nil || !reflect.DeepEqual(res, b) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "get", res, b)
+	}
+
+	if _, err = s.GetBrand(context.TODO(), bare.ID.String()); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "missing", err, sql.ErrNoRows)
+	}
+
+	list, err := s.GetBrands(context.TODO())
+	if err != nil || !reflect.DeepEqual(list, []models.Brand{bare, b}) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "list", list, []models.Brand{bare, b})
+	}
+
+	if err = s.UpdateBrand(context.TODO(), b); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 4, "update", err, nil)
+	}
+
+	if err = s.DeleteBrand(context.TODO(), b.ID.String()); err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 5, "delete error", err, "db error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestModels function to test store layer CreateModel, GetModel, GetModels, UpdateModel and DeleteModel functions
+func TestModels(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	s := New(db)
+
+	defer db.Close()
+
+	m := models.CarModel{ID: uuid.New(), BrandID: uuid.New(), Name: "X5"}
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(strings.Split(modelColumns, ",")).AddRow(m.ID.String(), m.BrandID.String(), "X5")
+	}
+
+	mock.ExpectExec("INSERT INTO car_model ("+modelColumns+") VALUES(?,?,?)").
+		WithArgs(m.ID.String(), m.BrandID.String(), "X5").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT " + modelColumns + " FROM car_model WHERE id=?").WithArgs(m.ID.String()).
+		WillReturnRows(row())
+	mock.ExpectQuery("SELECT " + modelColumns + " FROM car_model WHERE brand_id=? ORDER BY name").
+		WithArgs(m.BrandID.String()).WillReturnRows(row())
+	mock.ExpectExec("UPDATE car_model SET name=? WHERE id=?").WithArgs("X5", m.ID.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM car_model WHERE id=?").WithArgs(m.ID.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	res, err := s.CreateModel(context.TODO(), m)
+	if err != nil || res != m {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "create", res, m)
+	}
+
+	res, err = s.GetModel(context.TODO(), m.ID.String())
+	if err != nil || res != m {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "get", res, m)
+	}
+
+	list, err := s.GetModels(context.TODO(), m.BrandID.String())
+	if err != nil || !reflect.DeepEqual(list, []models.CarModel{m}) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "of brand", list, m)
+	}
+
+	if err = s.UpdateModel(context.TODO(), m); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "update", err, nil)
+	}
+
+	if err = s.DeleteModel(context.TODO(), m.ID.String()); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 4, "delete", err, nil)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestTrims function to test store layer CreateTrim, GetTrim, GetTrims, UpdateTrim, DeleteTrim and CountTrimCars
+// functions
+func TestTrims(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	s := New(db)
+
+	defer db.Close()
+
+	tr := models.Trim{ID: uuid.New(), ModelID: uuid.New(), Name: "xDrive40i", FuelType: "petrol",
+		Engine: models.Engine{EngineID: uuid.New()}}
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(strings.Split(trimColumns, ",")).AddRow(tr.ID.String(), tr.ModelID.String(),
+			"xDrive40i", "petrol", tr.Engine.EngineID.String())
+	}
+
+	mock.ExpectExec("INSERT INTO car_trim ("+trimColumns+") VALUES(?,?,?,?,?)").
+		WithArgs(tr.ID.String(), tr.ModelID.String(), "xDrive40i", "petrol", tr.Engine.EngineID.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT " + trimColumns + " FROM car_trim WHERE id=?").WithArgs(tr.ID.String()).
+		WillReturnRows(row())
+	mock.ExpectQuery("SELECT " + trimColumns + " FROM car_trim WHERE model_id=? ORDER BY name").
+		WithArgs(tr.ModelID.String()).WillReturnRows(row())
+	mock.ExpectExec("UPDATE car_trim SET name=?,fuel_type=? WHERE id=?").
+		WithArgs("xDrive40i", "petrol", tr.ID.String()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM car_trim WHERE id=? AND NOT EXISTS (SELECT 1 FROM Car WHERE trim_id=?)").
+		WithArgs(tr.ID.String(), tr.ID.String()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM car_trim WHERE id=? AND NOT EXISTS (SELECT 1 FROM Car WHERE trim_id=?)").
+		WithArgs(tr.ID.String(), tr.ID.String()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT COUNT(*) FROM Car WHERE trim_id=?").WithArgs(tr.ID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	res, err := s.CreateTrim(context.TODO(), tr)
+	if err != nil || res != tr {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "create", res, tr)
+	}
+
+	res, err = s.GetTrim(context.TODO(), tr.ID.String())
+	if err != nil || res != tr {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "get", res, tr)
+	}
+
+	list, err := s.GetTrims(context.TODO(), tr.ModelID.String())
+	if err != nil || !reflect.DeepEqual(list, []models.Trim{tr}) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "of model", list, tr)
+	}
+
+	if err = s.UpdateTrim(context.TODO(), tr); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "update", err, nil)
+	}
+
+	if err = s.DeleteTrim(context.TODO(), tr.ID.String()); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 4, "delete", err, nil)
+	}
+
+	if err = s.DeleteTrim(context.TODO(), tr.ID.String()); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 5, "delete with cars", err, sql.ErrNoRows)
+	}
+
+	n, err := s.CountTrimCars(context.TODO(), tr.ID.String())
+	if err != nil || n != 3 {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 6, "count cars", n, 3)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestBrandSpellings function to test store layer GetBrandSpellings and RenameBrands functions
+func TestBrandSpellings(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	s := New(db)
+
+	defer db.Close()
+
+	renames := []models.BrandRename{{From: "bmw", To: "BMW", Cars: 2}, {From: "B.M.W.", To: "BMW", Cars: 1}}
+	rename := "UPDATE Car SET brand=? WHERE BINARY brand=?"
+
+	mock.ExpectQuery("SELECT BINARY brand,COUNT(*) FROM Car GROUP BY BINARY brand ORDER BY BINARY brand").
+		WillReturnRows(sqlmock.NewRows([]string{"brand", "count"}).AddRow("B.M.W.", 1).AddRow("bmw", 2))
+	mock.ExpectBegin()
+	mock.ExpectExec(rename).WithArgs("BMW", "bmw").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(rename).WithArgs("BMW", "B.M.W.").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(rename).WithArgs("BMW", "bmw").WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	spellings, err := s.GetBrandSpellings(context.TODO())
+	expected := []models.BrandSpelling{{Brand: "B.M.W.", Cars: 1}, {Brand: "bmw", Cars: 2}}
+
+	if err != nil || !reflect.DeepEqual(spellings, expected) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "spellings", spellings, expected)
+	}
+
+	n, err := s.RenameBrands(context.TODO(), renames)
+	if err != nil || n != 3 {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "rename", n, 3)
+	}
+
+	if n, err = s.RenameBrands(context.TODO(), renames); err == nil || n != 0 {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "rolled back", err, "db error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
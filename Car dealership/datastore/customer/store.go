@@ -0,0 +1,50 @@
+package customer
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+const columns = "id,name,email,phone,created_at"
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// CreateCustomer store layer function to insert a customer
+func (s Store) CreateCustomer(ctx context.Context, c models.Customer) (models.Customer, error) {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO customer ("+columns+") VALUES(?,?,?,?,?)",
+		c.ID.String(), c.Name, c.Email, c.Phone, c.CreatedAt)
+	if err != nil {
+		return models.Customer{}, err
+	}
+
+	return c, nil
+}
+
+// GetCustomerByID store layer function to get a customer by its id
+func (s Store) GetCustomerByID(ctx context.Context, id string) (models.Customer, error) {
+	return scan(s.db.QueryRowContext(ctx, "SELECT "+columns+" FROM customer WHERE id=?", id))
+}
+
+// GetCustomerByEmail store layer function to get a customer by its email
+func (s Store) GetCustomerByEmail(ctx context.Context, email string) (models.Customer, error) {
+	return scan(s.db.QueryRowContext(ctx, "SELECT "+columns+" FROM customer WHERE email=?", email))
+}
+
+func scan(row *sql.Row) (models.Customer, error) {
+	var c models.Customer
+
+	err := row.Scan(&c.ID, &c.Name, &c.Email, &c.Phone, &c.CreatedAt)
+	if err != nil {
+		return models.Customer{}, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,101 @@
+package customer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+var columnNames = []string{"id", "name", "email", "phone", "created_at"}
+
+// TestCreateCustomer function to test store layer CreateCustomer function
+func TestCreateCustomer(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	c := models.Customer{ID: uuid.New(), Name: "Sahil Gupta", Email: "sahil@example.com", Phone: "+919876543210",
+		CreatedAt: now}
+	query := "INSERT INTO customer (id,name,email,phone,created_at) VALUES(?,?,?,?,?)"
+
+	testCases := []struct {
+		desc   string
+		output models.Customer
+		err    error
+	}{
+		{"success", c, nil},
+		{"error", models.Customer{}, errors.New("duplicate email")},
+	}
+
+	mock.ExpectExec(query).WithArgs(c.ID.String(), c.Name, c.Email, c.Phone, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(c.ID.String(), c.Name, c.Email, c.Phone, now).
+		WillReturnError(errors.New("duplicate email"))
+
+	for i, tc := range testCases {
+		res, err := a.CreateCustomer(context.TODO(), c)
+		if !reflect.DeepEqual(err, tc.err) || res != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
+
+// TestGetCustomer function to test store layer GetCustomerByID and GetCustomerByEmail functions
+func TestGetCustomer(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	c := models.Customer{ID: uuid.New(), Name: "Sahil Gupta", Email: "sahil@example.com", Phone: "", CreatedAt: now}
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(columnNames).AddRow(c.ID.String(), c.Name, c.Email, c.Phone, now)
+	}
+
+	mock.ExpectQuery("SELECT " + columns + " FROM customer WHERE id=?").WithArgs(c.ID.String()).
+		WillReturnRows(row())
+	mock.ExpectQuery("SELECT " + columns + " FROM customer WHERE id=?").WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT " + columns + " FROM customer WHERE email=?").WithArgs(c.Email).
+		WillReturnRows(row())
+
+	testCases := []struct {
+		desc   string
+		get    func() (models.Customer, error)
+		output models.Customer
+		err    error
+	}{
+		{desc: "by id", get: func() (models.Customer, error) { return a.GetCustomerByID(context.TODO(), c.ID.String()) },
+			output: c},
+		{desc: "not found", get: func() (models.Customer, error) { return a.GetCustomerByID(context.TODO(), "missing") },
+			err: sql.ErrNoRows},
+		{desc: "by email", get: func() (models.Customer, error) { return a.GetCustomerByEmail(context.TODO(), c.Email) },
+			output: c},
+	}
+
+	for i, tc := range testCases {
+		res, err := tc.get()
+		if !errors.Is(err, tc.err) || res != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
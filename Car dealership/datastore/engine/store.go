@@ -3,12 +3,16 @@ package engine
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"strings"
 
 	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
 
 	"github.com/google/uuid"
 )
 
+const powertrainColumns = "type,battery_kwh,charging_standards,max_charge_kw,motor_power_kw,fuel_consumption,efficiency"
+
 type Enginestore struct {
 	db *sql.DB
 }
@@ -27,28 +31,117 @@ func (s Enginestore) EngineGetByID(ctx context.Context, id string) (models.Engin
 		return models.Engine{}, err
 	}
 
+	engine.Powertrain, err = s.powertrain(ctx, id)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
 	return engine, nil
 }
 
+// EngineGetByIDs store layer function to get the details of many engines in two queries, engines which are not
+// found are left out
+func (s Enginestore) EngineGetByIDs(ctx context.Context, ids []string) ([]models.Engine, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i := range ids {
+		args[i] = ids[i]
+	}
+
+	in := "(?" + strings.Repeat(",?", len(ids)-1) + ")"
+
+	rows, err := s.db.QueryContext(ctx, "SELECT  *from Engine where id IN "+in, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var engines []models.Engine
+
+	for rows.Next() {
+		var engine models.Engine
+
+		if err = rows.Scan(&engine.EngineID, &engine.Displacement, &engine.NoOfCylinder, &engine.CarRange); err != nil {
+			return nil, err
+		}
+
+		engines = append(engines, engine)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	powertrains, err := s.powertrains(ctx, in, args)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range engines {
+		engines[i].Powertrain = powertrains[engines[i].EngineID.String()]
+	}
+
+	return engines, nil
+}
+
 // EngineCreate store layer function to create engine
 func (s Enginestore) EngineCreate(ctx context.Context, engine *models.Engine) (models.Engine, error) {
 	engine.EngineID = uuid.New()
 
-	_, err := s.db.ExecContext(ctx, "INSERT INTO Engine (id,displacement,cylinders,`range`) VALUES(?,?,?,?)",
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO Engine (id,displacement,cylinders,`range`) VALUES(?,?,?,?)",
 		engine.EngineID.String(), engine.Displacement, engine.NoOfCylinder, engine.CarRange)
+
+	if err == nil && engine.Powertrain != nil {
+		err = savePowertrain(ctx, tx, engine.EngineID.String(), *engine.Powertrain)
+	}
+
 	if err != nil {
+		_ = tx.Rollback()
+		return models.Engine{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
 		return models.Engine{}, err
 	}
 
 	return *engine, nil
 }
 
-// EngineUpdate store layer function to update engine details
+// EngineUpdate store layer function to update engine details, the powertrain is replaced with the one given and
+// removed when there is none
 func (s Enginestore) EngineUpdate(ctx context.Context, id string, engine models.Engine) (models.Engine, error) {
-	_, err := s.db.ExecContext(ctx,
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	_, err = tx.ExecContext(ctx,
 		"UPDATE Engine SET displacement=?,cylinders=?,`range`=? WHERE Id=?",
 		engine.Displacement, engine.NoOfCylinder, engine.CarRange, id)
+
+	switch {
+	case err != nil:
+	case engine.Powertrain != nil:
+		err = savePowertrain(ctx, tx, id, *engine.Powertrain)
+	default:
+		_, err = tx.ExecContext(ctx, "DELETE FROM powertrain WHERE engine_id=?", id)
+	}
+
 	if err != nil {
+		_ = tx.Rollback()
+		return models.Engine{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
 		return models.Engine{}, err
 	}
 
@@ -59,10 +152,99 @@ func (s Enginestore) EngineUpdate(ctx context.Context, id string, engine models.
 
 // EngineDelete to delete engine record
 func (s Enginestore) EngineDelete(ctx context.Context, id string) (models.Engine, error) {
-	_, err := s.db.ExecContext(ctx, "delete from Engine where id=?", id)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM powertrain WHERE engine_id=?", id)
+	if err == nil {
+		_, err = tx.ExecContext(ctx, "delete from Engine where id=?", id)
+	}
+
 	if err != nil {
+		_ = tx.Rollback()
+		return models.Engine{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
 		return models.Engine{}, err
 	}
 
 	return models.Engine{}, nil
 }
+
+// powertrain reads the powertrain of an engine, an engine without one has a nil powertrain
+func (s Enginestore) powertrain(ctx context.Context, engineID string) (*models.Powertrain, error) {
+	var (
+		p         models.Powertrain
+		standards string
+	)
+
+	err := s.db.QueryRowContext(ctx, "SELECT "+powertrainColumns+" FROM powertrain WHERE engine_id=?", engineID).
+		Scan(&p.Type, &p.BatteryKWh, &standards, &p.MaxChargeKW, &p.MotorPowerKW, &p.FuelConsumption, &p.Efficiency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.ChargingStandards = []string{}
+	if standards != "" {
+		p.ChargingStandards = strings.Split(standards, ",")
+	}
+
+	return &p, nil
+}
+
+// powertrains reads the powertrains of the engines whose ids are the args of the in clause, by engine id
+func (s Enginestore) powertrains(ctx context.Context, in string, args []interface{}) (map[string]*models.Powertrain,
+	error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT engine_id,"+powertrainColumns+" FROM powertrain WHERE engine_id IN "+in,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	powertrains := make(map[string]*models.Powertrain)
+
+	for rows.Next() {
+		var (
+			id        string
+			p         models.Powertrain
+			standards string
+		)
+
+		err = rows.Scan(&id, &p.Type, &p.BatteryKWh, &standards, &p.MaxChargeKW, &p.MotorPowerKW, &p.FuelConsumption,
+			&p.Efficiency)
+		if err != nil {
+			return nil, err
+		}
+
+		p.ChargingStandards = []string{}
+		if standards != "" {
+			p.ChargingStandards = strings.Split(standards, ",")
+		}
+
+		powertrains[id] = &p
+	}
+
+	return powertrains, rows.Err()
+}
+
+// savePowertrain writes the powertrain of an engine over the one it had, charging standards are stored as a comma
+// separated list
+func savePowertrain(ctx context.Context, tx *sql.Tx, engineID string, p models.Powertrain) error {
+	_, err := tx.ExecContext(ctx, "INSERT INTO powertrain (engine_id,"+powertrainColumns+") VALUES(?,?,?,?,?,?,?,?) "+
+		"ON DUPLICATE KEY UPDATE type=VALUES(type),battery_kwh=VALUES(battery_kwh),"+
+		"charging_standards=VALUES(charging_standards),max_charge_kw=VALUES(max_charge_kw),"+
+		"motor_power_kw=VALUES(motor_power_kw),fuel_consumption=VALUES(fuel_consumption),efficiency=VALUES(efficiency)",
+		engineID, p.Type, p.BatteryKWh, strings.Join(p.ChargingStandards, ","), p.MaxChargeKW, p.MotorPowerKW,
+		p.FuelConsumption, p.Efficiency)
+
+	return err
+}
@@ -2,7 +2,9 @@ package engine
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
@@ -11,6 +13,11 @@ import (
 	"github.com/google/uuid"
 )
 
+const savePowertrainQuery = "INSERT INTO powertrain (engine_id," + powertrainColumns + ") VALUES(?,?,?,?,?,?,?,?) " +
+	"ON DUPLICATE KEY UPDATE type=VALUES(type),battery_kwh=VALUES(battery_kwh)," +
+	"charging_standards=VALUES(charging_standards),max_charge_kw=VALUES(max_charge_kw)," +
+	"motor_power_kw=VALUES(motor_power_kw),fuel_consumption=VALUES(fuel_consumption),efficiency=VALUES(efficiency)"
+
 // TestEnginestore_EngineGetByID function to test Enginegetbyid function
 func TestEnginestore_EngineGetByID(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
@@ -28,13 +35,25 @@ func TestEnginestore_EngineGetByID(t *testing.T) {
 	}
 
 	engine := models.Engine{EngineID: id, Displacement: 1800, NoOfCylinder: 7, CarRange: 0}
+	electric := models.Engine{EngineID: id, CarRange: 500, Powertrain: &models.Powertrain{Type: "bev", BatteryKWh: 82,
+		ChargingStandards: []string{"type2", "ccs2"}, MaxChargeKW: 250, MotorPowerKW: 366, Efficiency: 160}}
 
 	queryErr := errors.New("query error")
+	powertrain := "SELECT " + powertrainColumns + " FROM powertrain WHERE engine_id=?"
+	engineRows := func(displacement, cylinders, carRange int64) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "displacement", "cylinders", "range"}).
+			AddRow(id.String(), displacement, cylinders, carRange)
+	}
 
-	rows := sqlmock.NewRows([]string{"id", "displacement", "cylinders", "range"}).
-		AddRow(id.String(), 1800, 7, 0)
-	mock.ExpectQuery("SELECT *from Engine where id=?").WithArgs(id.String()).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT *from Engine where id=?").WithArgs(id.String()).WillReturnRows(engineRows(1800, 7, 0))
+	mock.ExpectQuery(powertrain).WithArgs(id.String()).WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT *from Engine where id=?").WithArgs(id.String()).WillReturnRows(engineRows(0, 0, 500))
+	mock.ExpectQuery(powertrain).WithArgs(id.String()).WillReturnRows(sqlmock.NewRows([]string{"type", "battery_kwh",
+		"charging_standards", "max_charge_kw", "motor_power_kw", "fuel_consumption", "efficiency"}).
+		AddRow("bev", 82.0, "type2,ccs2", 250.0, 366, 0.0, 160))
 	mock.ExpectQuery("SELECT *from Engine where id=?").WithArgs(uuid.Nil).WillReturnError(queryErr)
+	mock.ExpectQuery("SELECT *from Engine where id=?").WithArgs(id.String()).WillReturnRows(engineRows(1800, 7, 0))
+	mock.ExpectQuery(powertrain).WithArgs(id.String()).WillReturnError(queryErr)
 
 	testcases := []struct {
 		desc   string
@@ -43,12 +62,14 @@ func TestEnginestore_EngineGetByID(t *testing.T) {
 		err    error
 	}{
 		{"success", engine.EngineID, engine, nil},
+		{"with powertrain", engine.EngineID, electric, nil},
 		{"failure", uuid.Nil, models.Engine{}, queryErr},
+		{"powertrain failure", engine.EngineID, models.Engine{}, queryErr},
 	}
 	for i, tc := range testcases {
 		resp, err := dbcheck.EngineGetByID(context.TODO(), tc.input.String())
 
-		if resp != tc.output {
+		if !reflect.DeepEqual(resp, tc.output) {
 			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, resp, tc.output)
 		}
 
@@ -77,24 +98,47 @@ func TestEnginestore_EngineCreate(t *testing.T) {
 
 	queryErr := errors.New("query error")
 
+	hybrid := models.Engine{Displacement: 1800, NoOfCylinder: 4, Powertrain: &models.Powertrain{Type: "hybrid",
+		BatteryKWh: 1.3, MotorPowerKW: 53, FuelConsumption: 4.5}}
+
+	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO Engine (id,displacement,cylinders,`range`) VALUES(?,?,?,?)").
 		WithArgs(sqlmock.AnyArg(), engine.Displacement, engine.NoOfCylinder, engine.CarRange).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO Engine (id,displacement,cylinders,`range`) VALUES(?,?,?,?)").
 		WithArgs(sqlmock.AnyArg(), engine.Displacement, engine.NoOfCylinder, engine.CarRange).
 		WillReturnError(queryErr)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO Engine (id,displacement,cylinders,`range`) VALUES(?,?,?,?)").
+		WithArgs(sqlmock.AnyArg(), hybrid.Displacement, hybrid.NoOfCylinder, hybrid.CarRange).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(savePowertrainQuery).
+		WithArgs(sqlmock.AnyArg(), "hybrid", 1.3, "", 0.0, 53, 4.5, 0).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO Engine (id,displacement,cylinders,`range`) VALUES(?,?,?,?)").
+		WithArgs(sqlmock.AnyArg(), hybrid.Displacement, hybrid.NoOfCylinder, hybrid.CarRange).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(savePowertrainQuery).
+		WithArgs(sqlmock.AnyArg(), "hybrid", 1.3, "", 0.0, 53, 4.5, 0).WillReturnError(queryErr)
+	mock.ExpectRollback()
 
 	testcases := []struct {
-		desc string
-		id   uuid.UUID
-		err  error
+		desc   string
+		engine models.Engine
+		err    error
 	}{
-		{"success", engine.EngineID, nil},
-		{"failure", uuid.Nil, queryErr},
+		{"success", engine, nil},
+		{"failure", engine, queryErr},
+		{"with powertrain", hybrid, nil},
+		{"powertrain failure", hybrid, queryErr},
 	}
 
 	for i, tc := range testcases {
-		_, err := dbcheck.EngineCreate(context.TODO(), &engine)
+		_, err := dbcheck.EngineCreate(context.TODO(), &tc.engine)
 
 		if err != tc.err {
 			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
@@ -130,13 +174,36 @@ func TestEnginestore_EngineUpdate(t *testing.T) {
 	engine := models.Engine{EngineID: id, Displacement: 1800, NoOfCylinder: 8, CarRange: 1}
 	Failed := errors.New("update failed")
 
+	electric := models.Engine{EngineID: id, CarRange: 450, Powertrain: &models.Powertrain{Type: "bev",
+		BatteryKWh: 64, ChargingStandards: []string{"ccs2"}, MaxChargeKW: 77, MotorPowerKW: 150, Efficiency: 150}}
+
+	mock.ExpectBegin()
 	mock.ExpectExec("UPDATE Engine SET displacement=?,cylinders=?,`range`=? WHERE Id=?").
 		WithArgs(engine.Displacement, engine.NoOfCylinder, engine.CarRange, engine.EngineID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DELETE FROM powertrain WHERE engine_id=?").WithArgs(id.String()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
 
+	mock.ExpectBegin()
 	mock.ExpectExec("UPDATE Engine SET displacement=?,cylinders=?,`range`=? WHERE Id=?").
 		WithArgs(engine.Displacement, engine.NoOfCylinder, engine.CarRange, engine.EngineID).
 		WillReturnError(Failed)
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE Engine SET displacement=?,cylinders=?,`range`=? WHERE Id=?").
+		WithArgs(0, 0, 450, id.String()).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(savePowertrainQuery).WithArgs(id.String(), "bev", 64.0, "ccs2", 77.0, 150, 0.0, 150).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE Engine SET displacement=?,cylinders=?,`range`=? WHERE Id=?").
+		WithArgs(0, 0, 450, id.String()).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(savePowertrainQuery).WithArgs(id.String(), "bev", 64.0, "ccs2", 77.0, 150, 0.0, 150).
+		WillReturnError(Failed)
+	mock.ExpectRollback()
 
 	testcases := []struct {
 		desc  string
@@ -145,6 +212,8 @@ func TestEnginestore_EngineUpdate(t *testing.T) {
 	}{
 		{"success", engine, nil},
 		{"failure", engine, Failed},
+		{"with powertrain", electric, nil},
+		{"powertrain failure", electric, Failed},
 	}
 
 	for i, tc := range testcases {
@@ -173,9 +242,17 @@ func TestEnginestore_EngineDelete(t *testing.T) {
 
 	deleteErr := errors.New("delete failed")
 
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM powertrain WHERE engine_id=?").WithArgs(id.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 	mock.ExpectExec("delete from Engine where id=?").WithArgs(id.String()).WillReturnResult(sqlmock.NewResult(
 		1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM powertrain WHERE engine_id=?").WithArgs(uuid.Nil).
+		WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectExec("delete  from Engine where id=?").WithArgs(uuid.Nil).WillReturnError(deleteErr)
+	mock.ExpectRollback()
 
 	cases := []struct {
 		desc string
@@ -193,3 +270,67 @@ func TestEnginestore_EngineDelete(t *testing.T) {
 		}
 	}
 }
+
+// TestEnginestore_EngineGetByIDs function to test engines and their powertrains are read in one query each
+func TestEnginestore_EngineGetByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	dbcheck := New(db)
+
+	defer db.Close()
+
+	combustion, electric := uuid.New(), uuid.New()
+	missing := uuid.New().String()
+	ids := []string{combustion.String(), electric.String(), missing}
+	queryErr := errors.New("query error")
+
+	engines := []models.Engine{{EngineID: combustion, Displacement: 1800, NoOfCylinder: 4, CarRange: 700},
+		{EngineID: electric, CarRange: 500, Powertrain: &models.Powertrain{Type: "bev", BatteryKWh: 82,
+			ChargingStandards: []string{"type2", "ccs2"}, MaxChargeKW: 250, MotorPowerKW: 366, Efficiency: 160}}}
+	engineRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "displacement", "cylinders", "range"}).
+			AddRow(combustion.String(), 1800, 4, 700).AddRow(electric.String(), 0, 0, 500)
+	}
+
+	mock.ExpectQuery("SELECT  *from Engine where id IN (?,?,?)").WithArgs(ids[0], ids[1], ids[2]).
+		WillReturnRows(engineRows())
+	mock.ExpectQuery("SELECT engine_id,"+powertrainColumns+" FROM powertrain WHERE engine_id IN (?,?,?)").
+		WithArgs(ids[0], ids[1], ids[2]).WillReturnRows(sqlmock.NewRows([]string{"engine_id", "type", "battery_kwh",
+		"charging_standards", "max_charge_kw", "motor_power_kw", "fuel_consumption", "efficiency"}).
+		AddRow(electric.String(), "bev", 82.0, "type2,ccs2", 250.0, 366, 0.0, 160))
+	mock.ExpectQuery("SELECT  *from Engine where id IN (?)").WithArgs(missing).WillReturnError(queryErr)
+	mock.ExpectQuery("SELECT  *from Engine where id IN (?,?,?)").WithArgs(ids[0], ids[1], ids[2]).
+		WillReturnRows(engineRows())
+	mock.ExpectQuery("SELECT engine_id,"+powertrainColumns+" FROM powertrain WHERE engine_id IN (?,?,?)").
+		WithArgs(ids[0], ids[1], ids[2]).WillReturnError(queryErr)
+
+	testcases := []struct {
+		desc   string
+		input  []string
+		output []models.Engine
+		err    error
+	}{
+		{"success", ids, engines, nil},
+		{"failure", []string{missing}, nil, queryErr},
+		{"powertrain failure", ids, nil, queryErr},
+		{"no ids", nil, nil, nil},
+	}
+	for i, tc := range testcases {
+		resp, err := dbcheck.EngineGetByIDs(context.TODO(), tc.input)
+
+		if !reflect.DeepEqual(resp, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, resp, tc.output)
+		}
+
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
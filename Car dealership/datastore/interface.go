@@ -2,21 +2,150 @@ package datastore
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
 )
 
 type Car interface {
 	GetCarByID(ctx context.Context, id string) (models.Car, error)
+	GetCarByVIN(ctx context.Context, vin string) (models.Car, error)
 	GetCarsByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error)
 	CreateCar(ctx context.Context, car *models.Car) (models.Car, error)
 	DeleteCar(ctx context.Context, id string) (models.Car, error)
 	UpdateCar(ctx context.Context, id string, car models.Car) (models.Car, error)
+	CreateCars(ctx context.Context, cars []models.Car) error
+	StreamCars(ctx context.Context, filter models.CarFilter, isEngine bool, fn func(models.Car) error) error
+	GetCars(ctx context.Context, filter models.CarFilter, page models.Page, isEngine bool) (models.CarPage, error)
 }
 
 type Engine interface {
 	EngineGetByID(ctx context.Context, id string) (models.Engine, error)
+	EngineGetByIDs(ctx context.Context, ids []string) ([]models.Engine, error)
 	EngineCreate(ctx context.Context, engine *models.Engine) (models.Engine, error)
 	EngineDelete(ctx context.Context, id string) (models.Engine, error)
 	EngineUpdate(ctx context.Context, id string, engine models.Engine) (models.Engine, error)
 }
+
+type Price interface {
+	SetPrice(ctx context.Context, change models.PriceChange) (models.PriceChange, error)
+	GetPriceHistory(ctx context.Context, carID string) ([]models.PriceChange, error)
+}
+
+type Status interface {
+	Transition(ctx context.Context, change models.StatusChange) (models.StatusChange, error)
+	GetStatusHistory(ctx context.Context, carID string) ([]models.StatusChange, error)
+}
+
+type Reservation interface {
+	CreateHold(ctx context.Context, r models.Reservation) (models.Reservation, error)
+	GetReservation(ctx context.Context, id string) (models.Reservation, error)
+	ExtendHold(ctx context.Context, id string, expiresAt, now time.Time) error
+	EndHold(ctx context.Context, r models.Reservation) error
+	GetExpiredHolds(ctx context.Context, now time.Time) ([]models.Reservation, error)
+}
+
+type Customer interface {
+	CreateCustomer(ctx context.Context, c models.Customer) (models.Customer, error)
+	GetCustomerByID(ctx context.Context, id string) (models.Customer, error)
+	GetCustomerByEmail(ctx context.Context, email string) (models.Customer, error)
+}
+
+type Lead interface {
+	CreateLead(ctx context.Context, l models.Lead) (models.Lead, error)
+	GetLeadByID(ctx context.Context, id string) (models.Lead, error)
+	GetLeads(ctx context.Context, filter models.LeadFilter) ([]models.Lead, error)
+	SetLeadStatus(ctx context.Context, id, from, to string, at time.Time) error
+	AssignLead(ctx context.Context, id, salesperson string, at time.Time) error
+}
+
+type Order interface {
+	CreateOrder(ctx context.Context, o models.SalesOrder) (models.SalesOrder, error)
+	GetOrder(ctx context.Context, id string) (models.SalesOrder, error)
+	GetOrders(ctx context.Context, filter models.OrderFilter) ([]models.SalesOrder, error)
+	UpdateOrder(ctx context.Context, o models.SalesOrder) error
+	AddPayment(ctx context.Context, o models.SalesOrder, p models.Payment) error
+	CancelOrder(ctx context.Context, o models.SalesOrder) error
+}
+
+type TestDrive interface {
+	ScheduleTestDrive(ctx context.Context, d models.TestDrive) ([]models.TestDrive, error)
+	RescheduleTestDrive(ctx context.Context, d models.TestDrive) ([]models.TestDrive, error)
+	CancelTestDrive(ctx context.Context, d models.TestDrive) error
+	GetTestDrive(ctx context.Context, id string) (models.TestDrive, error)
+	GetTestDrives(ctx context.Context, filter models.TestDriveFilter) ([]models.TestDrive, error)
+}
+
+type Lender interface {
+	GetRates(ctx context.Context, lender string) ([]models.LenderRate, error)
+	ReplaceRates(ctx context.Context, lender string, rates []models.LenderRate) error
+}
+
+type TradeIn interface {
+	FindCurve(ctx context.Context, brand, fuelType string) (models.DepreciationCurve, error)
+	GetCurves(ctx context.Context) ([]models.DepreciationCurve, error)
+	SaveCurve(ctx context.Context, c models.DepreciationCurve) error
+	CreateAppraisal(ctx context.Context, a models.Appraisal) (models.Appraisal, error)
+	GetAppraisal(ctx context.Context, id string) (models.Appraisal, error)
+	GetAppraisals(ctx context.Context, customerID string) ([]models.Appraisal, error)
+}
+
+type Maintenance interface {
+	CreateAppointment(ctx context.Context, a models.ServiceAppointment) (models.ServiceAppointment, error)
+	GetAppointment(ctx context.Context, id string) (models.ServiceAppointment, error)
+	GetAppointments(ctx context.Context, carID string) ([]models.ServiceAppointment, error)
+	CancelAppointment(ctx context.Context, id string, at time.Time) error
+	CreateRecord(ctx context.Context, r models.MaintenanceRecord) (models.MaintenanceRecord, error)
+	GetRecords(ctx context.Context, carID string) ([]models.MaintenanceRecord, error)
+}
+
+type Warranty interface {
+	CreateWarranty(ctx context.Context, w models.Warranty) (models.Warranty, error)
+	GetWarranty(ctx context.Context, id string) (models.Warranty, error)
+	GetWarranties(ctx context.Context, carID string) ([]models.Warranty, error)
+	GetExpiring(ctx context.Context, from, to time.Time) ([]models.Warranty, error)
+}
+
+// Blob is an object store such as S3, blobs are read and written whole under slash separated keys
+type Blob interface {
+	Put(ctx context.Context, key, contentType string, body io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+type Media interface {
+	CreateMedia(ctx context.Context, m models.Media) (models.Media, error)
+	GetMedia(ctx context.Context, id string) (models.Media, error)
+	GetCarMedia(ctx context.Context, carIDs ...string) ([]models.Media, error)
+	Reorder(ctx context.Context, carID string, ids []string) error
+	SetPrimary(ctx context.Context, carID, id string) error
+	DeleteMedia(ctx context.Context, carID, id string) error
+}
+
+type Catalogue interface {
+	CreateBrand(ctx context.Context, b models.Brand) (models.Brand, error)
+	GetBrand(ctx context.Context, id string) (models.Brand, error)
+	GetBrands(ctx context.Context) ([]models.Brand, error)
+	UpdateBrand(ctx context.Context, b models.Brand) error
+	DeleteBrand(ctx context.Context, id string) error
+	CreateModel(ctx context.Context, m models.CarModel) (models.CarModel, error)
+	GetModel(ctx context.Context, id string) (models.CarModel, error)
+	GetModels(ctx context.Context, brandID string) ([]models.CarModel, error)
+	UpdateModel(ctx context.Context, m models.CarModel) error
+	DeleteModel(ctx context.Context, id string) error
+	CreateTrim(ctx context.Context, t models.Trim) (models.Trim, error)
+	GetTrim(ctx context.Context, id string) (models.Trim, error)
+	GetTrims(ctx context.Context, modelID string) ([]models.Trim, error)
+	UpdateTrim(ctx context.Context, t models.Trim) error
+	DeleteTrim(ctx context.Context, id string) error
+	CountTrimCars(ctx context.Context, trimID string) (int, error)
+	GetBrandSpellings(ctx context.Context) ([]models.BrandSpelling, error)
+	RenameBrands(ctx context.Context, renames []models.BrandRename) (int, error)
+}
@@ -0,0 +1,150 @@
+package lead
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// selectLeads reads leads together with the contact details of their customer
+const selectLeads = "SELECT l.id,l.car_id,l.customer_id,l.status,l.salesperson,l.source,l.notes,l.created_at," +
+	"l.updated_at,c.name,c.email,c.phone,c.created_at FROM sales_lead l JOIN customer c ON c.id=l.customer_id"
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// CreateLead store layer function to insert a lead
+func (s Store) CreateLead(ctx context.Context, l models.Lead) (models.Lead, error) {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO sales_lead (id,car_id,customer_id,status,salesperson,source,notes,"+
+		"created_at,updated_at) VALUES(?,?,?,?,?,?,?,?,?)", l.ID.String(), l.CarID.String(), l.CustomerID.String(),
+		l.Status, l.Salesperson, l.Source, l.Notes, l.CreatedAt, l.UpdatedAt)
+	if err != nil {
+		return models.Lead{}, err
+	}
+
+	return l, nil
+}
+
+// GetLeadByID store layer function to get a lead by its id
+func (s Store) GetLeadByID(ctx context.Context, id string) (models.Lead, error) {
+	return scan(s.db.QueryRowContext(ctx, selectLeads+" WHERE l.id=?", id))
+}
+
+// GetLeads store layer function to get the leads passing the filter, most recently updated first
+func (s Store) GetLeads(ctx context.Context, filter models.LeadFilter) ([]models.Lead, error) {
+	where, args := whereClause(filter)
+
+	rows, err := s.db.QueryContext(ctx, selectLeads+where+" ORDER BY l.updated_at DESC,l.id", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	leads := make([]models.Lead, 0)
+
+	for rows.Next() {
+		l, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		leads = append(leads, l)
+	}
+
+	return leads, rows.Err()
+}
+
+// SetLeadStatus store layer function to move a lead from one status to another, the update only applies while
+// the lead is still in from so that sql.ErrNoRows is returned to the loser of two concurrent moves
+func (s Store) SetLeadStatus(ctx context.Context, id, from, to string, at time.Time) error {
+	res, err := s.db.ExecContext(ctx, "UPDATE sales_lead SET status=?,updated_at=? WHERE id=? AND status=?",
+		to, at, id, from)
+	if err != nil {
+		return err
+	}
+
+	return datastore.Affected(res)
+}
+
+// AssignLead store layer function to assign a lead to a salesperson, sql.ErrNoRows is returned when there is
+// no lead with the id
+func (s Store) AssignLead(ctx context.Context, id, salesperson string, at time.Time) error {
+	res, err := s.db.ExecContext(ctx, "UPDATE sales_lead SET salesperson=?,updated_at=? WHERE id=?",
+		salesperson, at, id)
+	if err != nil {
+		return err
+	}
+
+	return datastore.Affected(res)
+}
+
+func whereClause(filter models.LeadFilter) (string, []interface{}) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+
+	if statuses := filter.Statuses(); len(statuses) > 0 {
+		conds = append(conds, "l.status IN (?"+strings.Repeat(",?", len(statuses)-1)+")")
+
+		for _, s := range statuses {
+			args = append(args, s)
+		}
+	}
+
+	switch {
+	case filter.Unassigned:
+		conds = append(conds, "l.salesperson=''")
+	case filter.Salesperson != "":
+		conds = append(conds, "l.salesperson=?")
+		args = append(args, filter.Salesperson)
+	}
+
+	if filter.CarID != "" {
+		conds = append(conds, "l.car_id=?")
+		args = append(args, filter.CarID)
+	}
+
+	if filter.CustomerID != "" {
+		conds = append(conds, "l.customer_id=?")
+		args = append(args, filter.CustomerID)
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner) (models.Lead, error) {
+	var (
+		l models.Lead
+		c models.Customer
+	)
+
+	err := row.Scan(&l.ID, &l.CarID, &l.CustomerID, &l.Status, &l.Salesperson, &l.Source, &l.Notes, &l.CreatedAt,
+		&l.UpdatedAt, &c.Name, &c.Email, &c.Phone, &c.CreatedAt)
+	if err != nil {
+		return models.Lead{}, err
+	}
+
+	c.ID = l.CustomerID
+	l.Customer = &c
+
+	return l, nil
+}
@@ -0,0 +1,191 @@
+package lead
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+var columnNames = []string{"id", "car_id", "customer_id", "status", "salesperson", "source", "notes", "created_at",
+	"updated_at", "name", "email", "phone", "created_at"}
+
+var now = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// TestCreateLead function to test store layer CreateLead function
+func TestCreateLead(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	customerID := uuid.New()
+	l := models.Lead{ID: uuid.New(), CarID: uuid.New(), CustomerID: customerID, Status: models.LeadNew,
+		Source: "website", Notes: "wants a test drive", CreatedAt: now, UpdatedAt: now,
+		Customer: &models.Customer{ID: customerID, Name: "Sahil Gupta", Email: "sahil@example.com", CreatedAt: now}}
+	query := "INSERT INTO sales_lead (id,car_id,customer_id,status,salesperson,source,notes,created_at,updated_at) " +
+		"VALUES(?,?,?,?,?,?,?,?,?)"
+
+	mock.ExpectExec(query).WithArgs(l.ID.String(), l.CarID.String(), l.CustomerID.String(), "new", "", "website",
+		"wants a test drive", now, now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WillReturnError(errors.New("db error"))
+
+	res, err := a.CreateLead(context.TODO(), l)
+	if err != nil || !reflect.DeepEqual(res, l) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "success", res, l)
+	}
+
+	_, err = a.CreateLead(context.TODO(), l)
+	if err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "error", err, "db error")
+	}
+}
+
+// TestGetLeadByID function to test store layer GetLeadByID function
+func TestGetLeadByID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	customerID := uuid.New()
+	l := models.Lead{ID: uuid.New(), CarID: uuid.New(), CustomerID: customerID, Status: models.LeadNew,
+		Source: "website", Notes: "wants a test drive", CreatedAt: now, UpdatedAt: now,
+		Customer: &models.Customer{ID: customerID, Name: "Sahil Gupta", Email: "sahil@example.com", CreatedAt: now}}
+
+	mock.ExpectQuery(selectLeads + " WHERE l.id=?").WithArgs(l.ID.String()).
+		WillReturnRows(sqlmock.NewRows(columnNames).AddRow(l.ID.String(), l.CarID.String(), l.CustomerID.String(),
+			l.Status, l.Salesperson, l.Source, l.Notes, l.CreatedAt, l.UpdatedAt, l.Customer.Name, l.Customer.Email,
+			l.Customer.Phone, l.Customer.CreatedAt))
+	mock.ExpectQuery(selectLeads + " WHERE l.id=?").WithArgs("missing").WillReturnError(sql.ErrNoRows)
+
+	testCases := []struct {
+		desc   string
+		id     string
+		output models.Lead
+		err    error
+	}{
+		{"success", l.ID.String(), l, nil},
+		{"not found", "missing", models.Lead{}, sql.ErrNoRows},
+	}
+
+	for i, tc := range testCases {
+		res, err := a.GetLeadByID(context.TODO(), tc.id)
+		if !errors.Is(err, tc.err) || !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
+
+// TestGetLeads function to test store layer GetLeads function builds its filters
+func TestGetLeads(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	customerID := uuid.New()
+	l := models.Lead{ID: uuid.New(), CarID: uuid.New(), CustomerID: customerID, Status: models.LeadNew,
+		Source: "website", Notes: "wants a test drive", CreatedAt: now, UpdatedAt: now,
+		Customer: &models.Customer{ID: customerID, Name: "Sahil Gupta", Email: "sahil@example.com", CreatedAt: now}}
+	order := " ORDER BY l.updated_at DESC,l.id"
+
+	testCases := []struct {
+		desc   string
+		filter models.LeadFilter
+		query  string
+		args   []interface{}
+	}{
+		{desc: "no filter", query: selectLeads + order},
+		{desc: "status and salesperson", filter: models.LeadFilter{Status: "new, Contacted", Salesperson: "ravi"},
+			query: selectLeads + " WHERE l.status IN (?,?) AND l.salesperson=?" + order,
+			args:  []interface{}{"new", "contacted", "ravi"}},
+		{desc: "unassigned leads of a car", filter: models.LeadFilter{Unassigned: true, Salesperson: "ravi",
+			CarID: l.CarID.String()},
+			query: selectLeads + " WHERE l.salesperson='' AND l.car_id=?" + order,
+			args:  []interface{}{l.CarID.String()}},
+		{desc: "customer", filter: models.LeadFilter{CustomerID: l.CustomerID.String()},
+			query: selectLeads + " WHERE l.customer_id=?" + order,
+			args:  []interface{}{l.CustomerID.String()}},
+	}
+
+	for i, tc := range testCases {
+		args := make([]driver.Value, len(tc.args))
+		for j := range tc.args {
+			args[j] = tc.args[j]
+		}
+
+		mock.ExpectQuery(tc.query).WithArgs(args...).WillReturnRows(sqlmock.NewRows(columnNames).AddRow(l.ID.String(),
+			l.CarID.String(), l.CustomerID.String(), l.Status, l.Salesperson, l.Source, l.Notes, l.CreatedAt,
+			l.UpdatedAt, l.Customer.Name, l.Customer.Email, l.Customer.Phone, l.Customer.CreatedAt))
+
+		res, err := a.GetLeads(context.TODO(), tc.filter)
+		if err != nil || !reflect.DeepEqual(res, []models.Lead{l}) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, []models.Lead{l})
+		}
+	}
+
+	mock.ExpectQuery(selectLeads + order).WillReturnError(errors.New("db error"))
+
+	if _, err = a.GetLeads(context.TODO(), models.LeadFilter{}); err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", len(testCases), "error", err, "db error")
+	}
+}
+
+// TestSetLeadStatus function to test store layer SetLeadStatus and AssignLead functions
+func TestSetLeadStatus(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	setStatus := "UPDATE sales_lead SET status=?,updated_at=? WHERE id=? AND status=?"
+	assign := "UPDATE sales_lead SET salesperson=?,updated_at=? WHERE id=?"
+
+	mock.ExpectExec(setStatus).WithArgs("contacted", now, "1", "new").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(setStatus).WithArgs("contacted", now, "1", "new").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(assign).WithArgs("ravi", now, "1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(assign).WithArgs("ravi", now, "2").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	testCases := []struct {
+		desc string
+		run  func() error
+		err  error
+	}{
+		{"moved", func() error { return a.SetLeadStatus(context.TODO(), "1", "new", "contacted", now) }, nil},
+		{"moved concurrently", func() error { return a.SetLeadStatus(context.TODO(), "1", "new", "contacted", now) },
+			sql.ErrNoRows},
+		{"assigned", func() error { return a.AssignLead(context.TODO(), "1", "ravi", now) }, nil},
+		{"not found", func() error { return a.AssignLead(context.TODO(), "2", "ravi", now) }, sql.ErrNoRows},
+	}
+
+	for i, tc := range testCases {
+		if err := tc.run(); !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
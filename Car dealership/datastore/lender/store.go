@@ -0,0 +1,71 @@
+package lender
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+const columns = "lender,tier,min_term,max_term,apr"
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// GetRates store layer function to get the rate table of a lender, or of every lender when lender is empty
+func (s Store) GetRates(ctx context.Context, lender string) ([]models.LenderRate, error) {
+	query, args := "SELECT "+columns+" FROM lender_rate", []interface{}{}
+	if lender != "" {
+		query += " WHERE lender=?"
+		args = append(args, lender)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query+" ORDER BY lender,tier,min_term", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rates := []models.LenderRate{}
+
+	for rows.Next() {
+		var r models.LenderRate
+
+		if err = rows.Scan(&r.Lender, &r.Tier, &r.MinTerm, &r.MaxTerm, &r.APR); err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, r)
+	}
+
+	return rates, rows.Err()
+}
+
+// ReplaceRates store layer function to replace the rate table of a lender in one transaction, so that quotes
+// never see a half written table
+func (s Store) ReplaceRates(ctx context.Context, lender string, rates []models.LenderRate) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM lender_rate WHERE lender=?", lender)
+
+	for i := 0; err == nil && i < len(rates); i++ {
+		_, err = tx.ExecContext(ctx, "INSERT INTO lender_rate ("+columns+") VALUES(?,?,?,?,?)", lender,
+			rates[i].Tier, rates[i].MinTerm, rates[i].MaxTerm, rates[i].APR)
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,105 @@
+package lender
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// TestGetRates function to test store layer GetRates function
+func TestGetRates(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	queryErr := errors.New("query error")
+	rate := models.LenderRate{Lender: "acme", Tier: "prime", MinTerm: 12, MaxTerm: 60, APR: 699}
+	names := []string{"lender", "tier", "min_term", "max_term", "apr"}
+
+	testCases := []struct {
+		desc   string
+		lender string
+		output []models.LenderRate
+		err    error
+	}{
+		{desc: "one lender", lender: "acme", output: []models.LenderRate{rate}},
+		{desc: "every lender", output: []models.LenderRate{}},
+		{desc: "query error", lender: "acme", err: queryErr},
+	}
+
+	mock.ExpectQuery("SELECT " + columns + " FROM lender_rate WHERE lender=? ORDER BY lender,tier,min_term").
+		WithArgs("acme").WillReturnRows(sqlmock.NewRows(names).AddRow("acme", "prime", 12, 60, 699))
+	mock.ExpectQuery("SELECT " + columns + " FROM lender_rate ORDER BY lender,tier,min_term").
+		WillReturnRows(sqlmock.NewRows(names))
+	mock.ExpectQuery("SELECT " + columns + " FROM lender_rate WHERE lender=? ORDER BY lender,tier,min_term").
+		WithArgs("acme").WillReturnError(queryErr)
+
+	for i, tc := range testCases {
+		res, err := a.GetRates(context.TODO(), tc.lender)
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
+
+// TestReplaceRates function to test store layer ReplaceRates function
+func TestReplaceRates(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	insertErr := errors.New("insert failed")
+	rates := []models.LenderRate{{Tier: "prime", MinTerm: 12, MaxTerm: 36, APR: 599},
+		{Tier: "prime", MinTerm: 37, MaxTerm: 72, APR: 649}}
+	insert := "INSERT INTO lender_rate (" + columns + ") VALUES(?,?,?,?,?)"
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM lender_rate WHERE lender=?").WithArgs("acme").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(insert).WithArgs("acme", "prime", 12, 36, 599).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insert).WithArgs("acme", "prime", 37, 72, 649).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM lender_rate WHERE lender=?").WithArgs("acme").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(insert).WithArgs("acme", "prime", 12, 36, 599).WillReturnError(insertErr)
+	mock.ExpectRollback()
+
+	testCases := []struct {
+		desc string
+		err  error
+	}{
+		{"success", nil},
+		{"insert error", insertErr},
+	}
+
+	for i, tc := range testCases {
+		if err := a.ReplaceRates(context.TODO(), "acme", rates); err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
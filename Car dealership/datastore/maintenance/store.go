@@ -0,0 +1,220 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	appointmentColumns = "id,car_id,customer_id,scheduled_at,work,status,created_at,updated_at"
+	recordColumns      = "id,car_id,appointment_id,work,labour_hours,odometer,technician,completed_at"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// CreateAppointment store layer function to insert a service appointment
+func (s Store) CreateAppointment(ctx context.Context, a models.ServiceAppointment) (models.ServiceAppointment, error) {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO service_appointment ("+appointmentColumns+") VALUES(?,?,?,?,?,?,?,?)",
+		a.ID.String(), a.CarID.String(), a.CustomerID.String(), a.ScheduledAt, a.Work, a.Status, a.CreatedAt,
+		a.UpdatedAt)
+	if err != nil {
+		return models.ServiceAppointment{}, err
+	}
+
+	return a, nil
+}
+
+// GetAppointment store layer function to get a service appointment by its id
+func (s Store) GetAppointment(ctx context.Context, id string) (models.ServiceAppointment, error) {
+	return scanAppointment(s.db.QueryRowContext(ctx, "SELECT "+appointmentColumns+" FROM service_appointment "+
+		"WHERE id=?", id))
+}
+
+// GetAppointments store layer function to get the service appointments of a car, latest first
+func (s Store) GetAppointments(ctx context.Context, carID string) ([]models.ServiceAppointment, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+appointmentColumns+" FROM service_appointment WHERE car_id=? "+
+		"ORDER BY scheduled_at DESC,id", carID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	appointments := []models.ServiceAppointment{}
+
+	for rows.Next() {
+		a, err := scanAppointment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		appointments = append(appointments, a)
+	}
+
+	return appointments, rows.Err()
+}
+
+// CancelAppointment store layer function to cancel a booked appointment, sql.ErrNoRows is returned when it is
+// no longer booked
+func (s Store) CancelAppointment(ctx context.Context, id string, at time.Time) error {
+	res, err := s.db.ExecContext(ctx, "UPDATE service_appointment SET status=?,updated_at=? WHERE id=? AND status=?",
+		models.AppointmentCancelled, at, id, models.AppointmentBooked)
+	if err != nil {
+		return err
+	}
+
+	return datastore.Affected(res)
+}
+
+// CreateRecord store layer function to insert a maintenance record with its parts in one transaction. A record
+// of an appointment completes it, and sql.ErrNoRows is returned when the appointment of the car is no longer
+// booked.
+func (s Store) CreateRecord(ctx context.Context, r models.MaintenanceRecord) (models.MaintenanceRecord, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.MaintenanceRecord{}, err
+	}
+
+	var appointmentID sql.NullString
+
+	if r.AppointmentID != nil {
+		appointmentID = sql.NullString{String: r.AppointmentID.String(), Valid: true}
+
+		var res sql.Result
+
+		res, err = tx.ExecContext(ctx, "UPDATE service_appointment SET status=?,updated_at=? WHERE id=? AND car_id=? "+
+			"AND status=?", models.AppointmentCompleted, r.CompletedAt, appointmentID.String, r.CarID.String(),
+			models.AppointmentBooked)
+		if err == nil {
+			err = datastore.Affected(res)
+		}
+	}
+
+	if err == nil {
+		_, err = tx.ExecContext(ctx, "INSERT INTO maintenance_record ("+recordColumns+") VALUES(?,?,?,?,?,?,?,?)",
+			r.ID.String(), r.CarID.String(), appointmentID, r.Work, r.LabourHours, r.Odometer, r.Technician,
+			r.CompletedAt)
+	}
+
+	for i := 0; err == nil && i < len(r.Parts); i++ {
+		_, err = tx.ExecContext(ctx, "INSERT INTO maintenance_part (record_id,position,number,description,quantity) "+
+			"VALUES(?,?,?,?,?)", r.ID.String(), i, r.Parts[i].Number, r.Parts[i].Description, r.Parts[i].Quantity)
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return models.MaintenanceRecord{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.MaintenanceRecord{}, err
+	}
+
+	return r, nil
+}
+
+// GetRecords store layer function to get the maintenance history of a car with the parts fitted, latest first
+func (s Store) GetRecords(ctx context.Context, carID string) ([]models.MaintenanceRecord, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+recordColumns+" FROM maintenance_record WHERE car_id=? "+
+		"ORDER BY completed_at DESC,id", carID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	records := []models.MaintenanceRecord{}
+	index := map[string]int{}
+
+	for rows.Next() {
+		r, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		index[r.ID.String()] = len(records)
+		records = append(records, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	parts, err := s.db.QueryContext(ctx, "SELECT p.record_id,p.number,p.description,p.quantity FROM maintenance_part p "+
+		"JOIN maintenance_record m ON m.id=p.record_id WHERE m.car_id=? ORDER BY p.record_id,p.position", carID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer parts.Close()
+
+	for parts.Next() {
+		var (
+			recordID string
+			p        models.Part
+		)
+
+		if err = parts.Scan(&recordID, &p.Number, &p.Description, &p.Quantity); err != nil {
+			return nil, err
+		}
+
+		if i, ok := index[recordID]; ok {
+			records[i].Parts = append(records[i].Parts, p)
+		}
+	}
+
+	return records, parts.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAppointment(row scanner) (models.ServiceAppointment, error) {
+	var a models.ServiceAppointment
+
+	err := row.Scan(&a.ID, &a.CarID, &a.CustomerID, &a.ScheduledAt, &a.Work, &a.Status, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return models.ServiceAppointment{}, err
+	}
+
+	return a, nil
+}
+
+func scanRecord(row scanner) (models.MaintenanceRecord, error) {
+	var (
+		r             models.MaintenanceRecord
+		appointmentID sql.NullString
+	)
+
+	err := row.Scan(&r.ID, &r.CarID, &appointmentID, &r.Work, &r.LabourHours, &r.Odometer, &r.Technician,
+		&r.CompletedAt)
+	if err != nil {
+		return models.MaintenanceRecord{}, err
+	}
+
+	if appointmentID.Valid {
+		id, err := uuid.Parse(appointmentID.String)
+		if err != nil {
+			return models.MaintenanceRecord{}, err
+		}
+
+		r.AppointmentID = &id
+	}
+
+	r.Parts = []models.Part{}
+
+	return r, nil
+}
@@ -0,0 +1,200 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+// TestAppointments function to test store layer CreateAppointment, GetAppointment, GetAppointments and
+// CancelAppointment functions
+func TestAppointments(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	at := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	appointment := models.ServiceAppointment{ID: uuid.New(), CarID: uuid.New(), CustomerID: uuid.New(),
+		ScheduledAt: at.Add(48 * time.Hour), Work: "annual service", Status: models.AppointmentBooked, CreatedAt: at,
+		UpdatedAt: at}
+	names := strings.Split(appointmentColumns, ",")
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(names).AddRow(appointment.ID.String(), appointment.CarID.String(),
+			appointment.CustomerID.String(), appointment.ScheduledAt, "annual service", "booked", at, at)
+	}
+
+	mock.ExpectExec("INSERT INTO service_appointment ("+appointmentColumns+") VALUES(?,?,?,?,?,?,?,?)").
+		WithArgs(appointment.ID.String(), appointment.CarID.String(), appointment.CustomerID.String(),
+			appointment.ScheduledAt, "annual service", "booked", at, at).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT " + appointmentColumns + " FROM service_appointment WHERE id=?").
+		WithArgs(appointment.ID.String()).WillReturnRows(row())
+	mock.ExpectQuery("SELECT " + appointmentColumns + " FROM service_appointment WHERE car_id=? " +
+		"ORDER BY scheduled_at DESC,id").WithArgs(appointment.CarID.String()).WillReturnRows(row())
+	mock.ExpectExec("UPDATE service_appointment SET status=?,updated_at=? WHERE id=? AND status=?").
+		WithArgs("cancelled", at, appointment.ID.String(), "booked").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	res, err := a.CreateAppointment(context.TODO(), appointment)
+	if err != nil || res != appointment {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "create", res, appointment)
+	}
+
+	res, err = a.GetAppointment(context.TODO(), appointment.ID.String())
+	if err != nil || res != appointment {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "get", res, appointment)
+	}
+
+	list, err := a.GetAppointments(context.TODO(), appointment.CarID.String())
+	if err != nil || !reflect.DeepEqual(list, []models.ServiceAppointment{appointment}) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "list", list, appointment)
+	}
+
+	if err = a.CancelAppointment(context.TODO(), appointment.ID.String(), at); err != sql.ErrNoRows {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "not booked", err, sql.ErrNoRows)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestCreateRecord function to test store layer CreateRecord function
+func TestCreateRecord(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	at := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	appointmentID := uuid.New()
+	record := models.MaintenanceRecord{ID: uuid.New(), CarID: uuid.New(), AppointmentID: &appointmentID,
+		Work: "oil change", Parts: []models.Part{{Number: "OF-1", Description: "oil filter", Quantity: 1}},
+		LabourHours: 1.5, Odometer: 15200, Technician: "ravi", CompletedAt: at}
+	walkIn := record
+	walkIn.AppointmentID = nil
+	walkIn.Parts = nil
+
+	complete := "UPDATE service_appointment SET status=?,updated_at=? WHERE id=? AND car_id=? AND status=?"
+	insert := "INSERT INTO maintenance_record (" + recordColumns + ") VALUES(?,?,?,?,?,?,?,?)"
+	insertPart := "INSERT INTO maintenance_part (record_id,position,number,description,quantity) VALUES(?,?,?,?,?)"
+	partErr := errors.New("insert failed")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(complete).WithArgs("completed", at, appointmentID.String(), record.CarID.String(), "booked").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insert).WithArgs(record.ID.String(), record.CarID.String(), appointmentID.String(),
+		"oil change", 1.5, 15200, "ravi", at).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertPart).WithArgs(record.ID.String(), 0, "OF-1", "oil filter", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insert).WithArgs(record.ID.String(), record.CarID.String(), nil, "oil change", 1.5, 15200,
+		"ravi", at).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(complete).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(complete).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertPart).WillReturnError(partErr)
+	mock.ExpectRollback()
+
+	testCases := []struct {
+		desc   string
+		input  models.MaintenanceRecord
+		output models.MaintenanceRecord
+		err    error
+	}{
+		{desc: "appointment", input: record, output: record},
+		{desc: "walk in", input: walkIn, output: walkIn},
+		{desc: "appointment not booked", input: record, err: sql.ErrNoRows},
+		{desc: "part not written", input: record, err: partErr},
+	}
+
+	for i, tc := range testCases {
+		res, err := a.CreateRecord(context.TODO(), tc.input)
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestGetRecords function to test store layer GetRecords function
+func TestGetRecords(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	at := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	carID, appointmentID := uuid.New(), uuid.New()
+	first, second := uuid.New(), uuid.New()
+	queryErr := errors.New("query error")
+
+	records := []models.MaintenanceRecord{
+		{ID: second, CarID: carID, AppointmentID: &appointmentID, Work: "brakes", LabourHours: 2,
+			Odometer: 30100, Technician: "ravi", CompletedAt: at,
+			Parts: []models.Part{{Number: "BP-2", Description: "brake pads", Quantity: 4},
+				{Number: "BF-1", Description: "brake fluid", Quantity: 1}}},
+		{ID: first, CarID: carID, Work: "oil change", LabourHours: 0.75, Odometer: 15200, Technician: "ravi",
+			CompletedAt: at.AddDate(-1, 0, 0), Parts: []models.Part{}},
+	}
+
+	query := "SELECT " + recordColumns + " FROM maintenance_record WHERE car_id=? ORDER BY completed_at DESC,id"
+	partQuery := "SELECT p.record_id,p.number,p.description,p.quantity FROM maintenance_part p " +
+		"JOIN maintenance_record m ON m.id=p.record_id WHERE m.car_id=? ORDER BY p.record_id,p.position"
+
+	mock.ExpectQuery(query).WithArgs(carID.String()).WillReturnRows(sqlmock.NewRows(strings.Split(recordColumns, ",")).
+		AddRow(second.String(), carID.String(), appointmentID.String(), "brakes", 2.0, 30100, "ravi", at).
+		AddRow(first.String(), carID.String(), nil, "oil change", 0.75, 15200, "ravi", at.AddDate(-1, 0, 0)))
+	mock.ExpectQuery(partQuery).WithArgs(carID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"record_id", "number", "description", "quantity"}).
+			AddRow(second.String(), "BP-2", "brake pads", 4).AddRow(second.String(), "BF-1", "brake fluid", 1))
+	mock.ExpectQuery(query).WithArgs(carID.String()).WillReturnError(queryErr)
+
+	res, err := a.GetRecords(context.TODO(), carID.String())
+	if err != nil || !reflect.DeepEqual(res, records) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "history", res, records)
+	}
+
+	if _, err = a.GetRecords(context.TODO(), carID.String()); err != queryErr {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "query error", err, queryErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
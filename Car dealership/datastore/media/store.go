@@ -0,0 +1,127 @@
+package media
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+const columns = "id,car_id,position,is_primary,file_name,content_type,size,width,height,created_at"
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// CreateMedia store layer function to insert the record of an uploaded image
+func (s Store) CreateMedia(ctx context.Context, m models.Media) (models.Media, error) {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO car_media ("+columns+") VALUES(?,?,?,?,?,?,?,?,?,?)",
+		m.ID.String(), m.CarID.String(), m.Position, m.Primary, m.FileName, m.ContentType, m.Size, m.Width, m.Height,
+		m.CreatedAt)
+	if err != nil {
+		return models.Media{}, err
+	}
+
+	return m, nil
+}
+
+// GetMedia store layer function to get the record of an image by its id
+func (s Store) GetMedia(ctx context.Context, id string) (models.Media, error) {
+	return scanMedia(s.db.QueryRowContext(ctx, "SELECT "+columns+" FROM car_media WHERE id=?", id))
+}
+
+// GetCarMedia store layer function to get the images of the cars in order, grouped by car
+func (s Store) GetCarMedia(ctx context.Context, carIDs ...string) ([]models.Media, error) {
+	media := []models.Media{}
+
+	if len(carIDs) == 0 {
+		return media, nil
+	}
+
+	args := make([]interface{}, len(carIDs))
+	for i := range carIDs {
+		args[i] = carIDs[i]
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT "+columns+" FROM car_media WHERE car_id IN (?"+
+		strings.Repeat(",?", len(carIDs)-1)+") ORDER BY car_id,position,id", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		m, err := scanMedia(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		media = append(media, m)
+	}
+
+	return media, rows.Err()
+}
+
+// Reorder store layer function to put the images of a car in the order of ids in one transaction
+func (s Store) Reorder(ctx context.Context, carID string, ids []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; err == nil && i < len(ids); i++ {
+		_, err = tx.ExecContext(ctx, "UPDATE car_media SET position=? WHERE id=? AND car_id=?", i, ids[i], carID)
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetPrimary store layer function to make an image the primary image of its car and every other image not
+func (s Store) SetPrimary(ctx context.Context, carID, id string) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE car_media SET is_primary=(id=?) WHERE car_id=?", id, carID)
+
+	return err
+}
+
+// DeleteMedia store layer function to delete the record of an image of a car, sql.ErrNoRows is returned when the
+// car has no such image
+func (s Store) DeleteMedia(ctx context.Context, carID, id string) error {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM car_media WHERE id=? AND car_id=?", id, carID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err == nil && n == 0 {
+		err = sql.ErrNoRows
+	}
+
+	return err
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMedia(row scanner) (models.Media, error) {
+	var m models.Media
+
+	err := row.Scan(&m.ID, &m.CarID, &m.Position, &m.Primary, &m.FileName, &m.ContentType, &m.Size, &m.Width,
+		&m.Height, &m.CreatedAt)
+	if err != nil {
+		return models.Media{}, err
+	}
+
+	return m, nil
+}
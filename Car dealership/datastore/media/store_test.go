@@ -0,0 +1,130 @@
+package media
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+// TestMedia function to test store layer CreateMedia, GetMedia and GetCarMedia functions
+func TestMedia(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	s := New(db)
+
+	defer db.Close()
+
+	at := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	m := models.Media{ID: uuid.New(), CarID: uuid.New(), Position: 0, Primary: true, FileName: "front.jpg",
+		ContentType: "image/jpeg", Size: 2048, Width: 1600, Height: 1200, CreatedAt: at}
+	other := uuid.New().String()
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(strings.Split(columns, ",")).AddRow(m.ID.String(), m.CarID.String(), 0, true,
+			"front.jpg", "image/jpeg", 2048, 1600, 1200, at)
+	}
+
+	mock.ExpectExec("INSERT INTO car_media ("+columns+") VALUES(?,?,?,?,?,?,?,?,?,?)").
+		WithArgs(m.ID.String(), m.CarID.String(), 0, true, "front.jpg", "image/jpeg", int64(2048), 1600, 1200, at).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT " + columns + " FROM car_media WHERE id=?").WithArgs(m.ID.String()).
+		WillReturnRows(row())
+	mock.ExpectQuery("SELECT "+columns+" FROM car_media WHERE car_id IN (?,?) ORDER BY car_id,position,id").
+		WithArgs(m.CarID.String(), other).WillReturnRows(row())
+	mock.ExpectQuery("SELECT " + columns + " FROM car_media WHERE car_id IN (?) ORDER BY car_id,position,id").
+		WithArgs(other).WillReturnError(errors.New("db error"))
+
+	res, err := s.CreateMedia(context.TODO(), m)
+	if err != nil || res != m {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "create", res, m)
+	}
+
+	res, err = s.GetMedia(context.TODO(), m.ID.String())
+	if err != nil || res != m {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "get", res, m)
+	}
+
+	list, err := s.GetCarMedia(context.TODO(), m.CarID.String(), other)
+	if err != nil || !reflect.DeepEqual(list, []models.Media{m}) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "of cars", list, m)
+	}
+
+	if _, err = s.GetCarMedia(context.TODO(), other); err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "error", err, "db error")
+	}
+
+	list, err = s.GetCarMedia(context.TODO())
+	if err != nil || len(list) != 0 {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 4, "no cars", list, nil)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestArrange function to test store layer Reorder, SetPrimary and DeleteMedia functions
+func TestArrange(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	s := New(db)
+
+	defer db.Close()
+
+	carID, first, second := uuid.New().String(), uuid.New().String(), uuid.New().String()
+	reorder := "UPDATE car_media SET position=? WHERE id=? AND car_id=?"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(reorder).WithArgs(0, second, carID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(reorder).WithArgs(1, first, carID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(reorder).WithArgs(0, second, carID).WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+	mock.ExpectExec("UPDATE car_media SET is_primary=(id=?) WHERE car_id=?").WithArgs(second, carID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM car_media WHERE id=? AND car_id=?").WithArgs(first, carID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM car_media WHERE id=? AND car_id=?").WithArgs(first, carID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	testCases := []struct {
+		desc string
+		call func() error
+		err  bool
+		is   error
+	}{
+		{desc: "reorder", call: func() error { return s.Reorder(context.TODO(), carID, []string{second, first}) }},
+		{desc: "reorder error", call: func() error { return s.Reorder(context.TODO(), carID, []string{second}) },
+			err: true},
+		{desc: "primary", call: func() error { return s.SetPrimary(context.TODO(), carID, second) }},
+		{desc: "delete", call: func() error { return s.DeleteMedia(context.TODO(), carID, first) }},
+		{desc: "delete missing", call: func() error { return s.DeleteMedia(context.TODO(), carID, first) },
+			err: true, is: sql.ErrNoRows},
+	}
+
+	for i, tc := range testCases {
+		err := tc.call()
+		if (err != nil) != tc.err || tc.is != nil && !errors.Is(err, tc.is) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.is)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
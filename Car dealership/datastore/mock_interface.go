@@ -6,7 +6,9 @@ package datastore
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
@@ -50,6 +52,20 @@ func (mr *MockCarMockRecorder) CreateCar(ctx, car interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCar", reflect.TypeOf((*MockCar)(nil).CreateCar), ctx, car)
 }
 
+// CreateCars mocks base method.
+func (m *MockCar) CreateCars(ctx context.Context, cars []models.Car) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCars", ctx, cars)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCars indicates an expected call of CreateCars.
+func (mr *MockCarMockRecorder) CreateCars(ctx, cars interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCars", reflect.TypeOf((*MockCar)(nil).CreateCars), ctx, cars)
+}
+
 // DeleteCar mocks base method.
 func (m *MockCar) DeleteCar(ctx context.Context, id string) (models.Car, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +96,36 @@ func (mr *MockCarMockRecorder) GetCarByID(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarByID", reflect.TypeOf((*MockCar)(nil).GetCarByID), ctx, id)
 }
 
+// GetCarByVIN mocks base method.
+func (m *MockCar) GetCarByVIN(ctx context.Context, vin string) (models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarByVIN", ctx, vin)
+	ret0, _ := ret[0].(models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarByVIN indicates an expected call of GetCarByVIN.
+func (mr *MockCarMockRecorder) GetCarByVIN(ctx, vin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarByVIN", reflect.TypeOf((*MockCar)(nil).GetCarByVIN), ctx, vin)
+}
+
+// GetCars mocks base method.
+func (m *MockCar) GetCars(ctx context.Context, filter models.CarFilter, page models.Page, isEngine bool) (models.CarPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCars", ctx, filter, page, isEngine)
+	ret0, _ := ret[0].(models.CarPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCars indicates an expected call of GetCars.
+func (mr *MockCarMockRecorder) GetCars(ctx, filter, page, isEngine interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCars", reflect.TypeOf((*MockCar)(nil).GetCars), ctx, filter, page, isEngine)
+}
+
 // GetCarsByBrand mocks base method.
 func (m *MockCar) GetCarsByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +141,20 @@ func (mr *MockCarMockRecorder) GetCarsByBrand(ctx, brand, isEngine interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarsByBrand", reflect.TypeOf((*MockCar)(nil).GetCarsByBrand), ctx, brand, isEngine)
 }
 
+// StreamCars mocks base method.
+func (m *MockCar) StreamCars(ctx context.Context, filter models.CarFilter, isEngine bool, fn func(models.Car) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamCars", ctx, filter, isEngine, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamCars indicates an expected call of StreamCars.
+func (mr *MockCarMockRecorder) StreamCars(ctx, filter, isEngine, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamCars", reflect.TypeOf((*MockCar)(nil).StreamCars), ctx, filter, isEngine, fn)
+}
+
 // UpdateCar mocks base method.
 func (m *MockCar) UpdateCar(ctx context.Context, id string, car models.Car) (models.Car, error) {
 	m.ctrl.T.Helper()
@@ -178,6 +238,21 @@ func (mr *MockEngineMockRecorder) EngineGetByID(ctx, id interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EngineGetByID", reflect.TypeOf((*MockEngine)(nil).EngineGetByID), ctx, id)
 }
 
+// EngineGetByIDs mocks base method.
+func (m *MockEngine) EngineGetByIDs(ctx context.Context, ids []string) ([]models.Engine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EngineGetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]models.Engine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EngineGetByIDs indicates an expected call of EngineGetByIDs.
+func (mr *MockEngineMockRecorder) EngineGetByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EngineGetByIDs", reflect.TypeOf((*MockEngine)(nil).EngineGetByIDs), ctx, ids)
+}
+
 // EngineUpdate mocks base method.
 func (m *MockEngine) EngineUpdate(ctx context.Context, id string, engine models.Engine) (models.Engine, error) {
 	m.ctrl.T.Helper()
@@ -192,3 +267,1470 @@ func (mr *MockEngineMockRecorder) EngineUpdate(ctx, id, engine interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EngineUpdate", reflect.TypeOf((*MockEngine)(nil).EngineUpdate), ctx, id, engine)
 }
+
+// MockPrice is a mock of Price interface.
+type MockPrice struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceMockRecorder
+}
+
+// MockPriceMockRecorder is the mock recorder for MockPrice.
+type MockPriceMockRecorder struct {
+	mock *MockPrice
+}
+
+// NewMockPrice creates a new mock instance.
+func NewMockPrice(ctrl *gomock.Controller) *MockPrice {
+	mock := &MockPrice{ctrl: ctrl}
+	mock.recorder = &MockPriceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrice) EXPECT() *MockPriceMockRecorder {
+	return m.recorder
+}
+
+// GetPriceHistory mocks base method.
+func (m *MockPrice) GetPriceHistory(ctx context.Context, carID string) ([]models.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceHistory", ctx, carID)
+	ret0, _ := ret[0].([]models.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceHistory indicates an expected call of GetPriceHistory.
+func (mr *MockPriceMockRecorder) GetPriceHistory(ctx, carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceHistory", reflect.TypeOf((*MockPrice)(nil).GetPriceHistory), ctx, carID)
+}
+
+// SetPrice mocks base method.
+func (m *MockPrice) SetPrice(ctx context.Context, change models.PriceChange) (models.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrice", ctx, change)
+	ret0, _ := ret[0].(models.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPrice indicates an expected call of SetPrice.
+func (mr *MockPriceMockRecorder) SetPrice(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrice", reflect.TypeOf((*MockPrice)(nil).SetPrice), ctx, change)
+}
+
+// MockStatus is a mock of Status interface.
+type MockStatus struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusMockRecorder
+}
+
+// MockStatusMockRecorder is the mock recorder for MockStatus.
+type MockStatusMockRecorder struct {
+	mock *MockStatus
+}
+
+// NewMockStatus creates a new mock instance.
+func NewMockStatus(ctrl *gomock.Controller) *MockStatus {
+	mock := &MockStatus{ctrl: ctrl}
+	mock.recorder = &MockStatusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatus) EXPECT() *MockStatusMockRecorder {
+	return m.recorder
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockStatus) GetStatusHistory(ctx context.Context, carID string) ([]models.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", ctx, carID)
+	ret0, _ := ret[0].([]models.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockStatusMockRecorder) GetStatusHistory(ctx, carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockStatus)(nil).GetStatusHistory), ctx, carID)
+}
+
+// Transition mocks base method.
+func (m *MockStatus) Transition(ctx context.Context, change models.StatusChange) (models.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", ctx, change)
+	ret0, _ := ret[0].(models.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockStatusMockRecorder) Transition(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockStatus)(nil).Transition), ctx, change)
+}
+
+// MockReservation is a mock of Reservation interface.
+type MockReservation struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationMockRecorder
+}
+
+// MockReservationMockRecorder is the mock recorder for MockReservation.
+type MockReservationMockRecorder struct {
+	mock *MockReservation
+}
+
+// NewMockReservation creates a new mock instance.
+func NewMockReservation(ctrl *gomock.Controller) *MockReservation {
+	mock := &MockReservation{ctrl: ctrl}
+	mock.recorder = &MockReservationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservation) EXPECT() *MockReservationMockRecorder {
+	return m.recorder
+}
+
+// CreateHold mocks base method.
+func (m *MockReservation) CreateHold(ctx context.Context, r models.Reservation) (models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, r)
+	ret0, _ := ret[0].(models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockReservationMockRecorder) CreateHold(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockReservation)(nil).CreateHold), ctx, r)
+}
+
+// EndHold mocks base method.
+func (m *MockReservation) EndHold(ctx context.Context, r models.Reservation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndHold", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndHold indicates an expected call of EndHold.
+func (mr *MockReservationMockRecorder) EndHold(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndHold", reflect.TypeOf((*MockReservation)(nil).EndHold), ctx, r)
+}
+
+// ExtendHold mocks base method.
+func (m *MockReservation) ExtendHold(ctx context.Context, id string, expiresAt, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendHold", ctx, id, expiresAt, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendHold indicates an expected call of ExtendHold.
+func (mr *MockReservationMockRecorder) ExtendHold(ctx, id, expiresAt, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendHold", reflect.TypeOf((*MockReservation)(nil).ExtendHold), ctx, id, expiresAt, now)
+}
+
+// GetExpiredHolds mocks base method.
+func (m *MockReservation) GetExpiredHolds(ctx context.Context, now time.Time) ([]models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredHolds", ctx, now)
+	ret0, _ := ret[0].([]models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredHolds indicates an expected call of GetExpiredHolds.
+func (mr *MockReservationMockRecorder) GetExpiredHolds(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredHolds", reflect.TypeOf((*MockReservation)(nil).GetExpiredHolds), ctx, now)
+}
+
+// GetReservation mocks base method.
+func (m *MockReservation) GetReservation(ctx context.Context, id string) (models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservation", ctx, id)
+	ret0, _ := ret[0].(models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservation indicates an expected call of GetReservation.
+func (mr *MockReservationMockRecorder) GetReservation(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservation", reflect.TypeOf((*MockReservation)(nil).GetReservation), ctx, id)
+}
+
+// MockCustomer is a mock of Customer interface.
+type MockCustomer struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerMockRecorder
+}
+
+// MockCustomerMockRecorder is the mock recorder for MockCustomer.
+type MockCustomerMockRecorder struct {
+	mock *MockCustomer
+}
+
+// NewMockCustomer creates a new mock instance.
+func NewMockCustomer(ctrl *gomock.Controller) *MockCustomer {
+	mock := &MockCustomer{ctrl: ctrl}
+	mock.recorder = &MockCustomerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomer) EXPECT() *MockCustomerMockRecorder {
+	return m.recorder
+}
+
+// CreateCustomer mocks base method.
+func (m *MockCustomer) CreateCustomer(ctx context.Context, c models.Customer) (models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomer", ctx, c)
+	ret0, _ := ret[0].(models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomer indicates an expected call of CreateCustomer.
+func (mr *MockCustomerMockRecorder) CreateCustomer(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomer", reflect.TypeOf((*MockCustomer)(nil).CreateCustomer), ctx, c)
+}
+
+// GetCustomerByEmail mocks base method.
+func (m *MockCustomer) GetCustomerByEmail(ctx context.Context, email string) (models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerByEmail", ctx, email)
+	ret0, _ := ret[0].(models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerByEmail indicates an expected call of GetCustomerByEmail.
+func (mr *MockCustomerMockRecorder) GetCustomerByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerByEmail", reflect.TypeOf((*MockCustomer)(nil).GetCustomerByEmail), ctx, email)
+}
+
+// GetCustomerByID mocks base method.
+func (m *MockCustomer) GetCustomerByID(ctx context.Context, id string) (models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerByID", ctx, id)
+	ret0, _ := ret[0].(models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerByID indicates an expected call of GetCustomerByID.
+func (mr *MockCustomerMockRecorder) GetCustomerByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerByID", reflect.TypeOf((*MockCustomer)(nil).GetCustomerByID), ctx, id)
+}
+
+// MockLead is a mock of Lead interface.
+type MockLead struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeadMockRecorder
+}
+
+// MockLeadMockRecorder is the mock recorder for MockLead.
+type MockLeadMockRecorder struct {
+	mock *MockLead
+}
+
+// NewMockLead creates a new mock instance.
+func NewMockLead(ctrl *gomock.Controller) *MockLead {
+	mock := &MockLead{ctrl: ctrl}
+	mock.recorder = &MockLeadMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLead) EXPECT() *MockLeadMockRecorder {
+	return m.recorder
+}
+
+// AssignLead mocks base method.
+func (m *MockLead) AssignLead(ctx context.Context, id, salesperson string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignLead", ctx, id, salesperson, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignLead indicates an expected call of AssignLead.
+func (mr *MockLeadMockRecorder) AssignLead(ctx, id, salesperson, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignLead", reflect.TypeOf((*MockLead)(nil).AssignLead), ctx, id, salesperson, at)
+}
+
+// CreateLead mocks base method.
+func (m *MockLead) CreateLead(ctx context.Context, l models.Lead) (models.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLead", ctx, l)
+	ret0, _ := ret[0].(models.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLead indicates an expected call of CreateLead.
+func (mr *MockLeadMockRecorder) CreateLead(ctx, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLead", reflect.TypeOf((*MockLead)(nil).CreateLead), ctx, l)
+}
+
+// GetLeadByID mocks base method.
+func (m *MockLead) GetLeadByID(ctx context.Context, id string) (models.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeadByID", ctx, id)
+	ret0, _ := ret[0].(models.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeadByID indicates an expected call of GetLeadByID.
+func (mr *MockLeadMockRecorder) GetLeadByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeadByID", reflect.TypeOf((*MockLead)(nil).GetLeadByID), ctx, id)
+}
+
+// GetLeads mocks base method.
+func (m *MockLead) GetLeads(ctx context.Context, filter models.LeadFilter) ([]models.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeads", ctx, filter)
+	ret0, _ := ret[0].([]models.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeads indicates an expected call of GetLeads.
+func (mr *MockLeadMockRecorder) GetLeads(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeads", reflect.TypeOf((*MockLead)(nil).GetLeads), ctx, filter)
+}
+
+// SetLeadStatus mocks base method.
+func (m *MockLead) SetLeadStatus(ctx context.Context, id, from, to string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLeadStatus", ctx, id, from, to, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLeadStatus indicates an expected call of SetLeadStatus.
+func (mr *MockLeadMockRecorder) SetLeadStatus(ctx, id, from, to, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLeadStatus", reflect.TypeOf((*MockLead)(nil).SetLeadStatus), ctx, id, from, to, at)
+}
+
+// MockOrder is a mock of Order interface.
+type MockOrder struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderMockRecorder
+}
+
+// MockOrderMockRecorder is the mock recorder for MockOrder.
+type MockOrderMockRecorder struct {
+	mock *MockOrder
+}
+
+// NewMockOrder creates a new mock instance.
+func NewMockOrder(ctrl *gomock.Controller) *MockOrder {
+	mock := &MockOrder{ctrl: ctrl}
+	mock.recorder = &MockOrderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrder) EXPECT() *MockOrderMockRecorder {
+	return m.recorder
+}
+
+// AddPayment mocks base method.
+func (m *MockOrder) AddPayment(ctx context.Context, o models.SalesOrder, p models.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPayment", ctx, o, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPayment indicates an expected call of AddPayment.
+func (mr *MockOrderMockRecorder) AddPayment(ctx, o, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPayment", reflect.TypeOf((*MockOrder)(nil).AddPayment), ctx, o, p)
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrder) CancelOrder(ctx context.Context, o models.SalesOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, o)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderMockRecorder) CancelOrder(ctx, o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrder)(nil).CancelOrder), ctx, o)
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrder) CreateOrder(ctx context.Context, o models.SalesOrder) (models.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, o)
+	ret0, _ := ret[0].(models.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderMockRecorder) CreateOrder(ctx, o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrder)(nil).CreateOrder), ctx, o)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrder) GetOrder(ctx context.Context, id string) (models.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id)
+	ret0, _ := ret[0].(models.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderMockRecorder) GetOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrder)(nil).GetOrder), ctx, id)
+}
+
+// GetOrders mocks base method.
+func (m *MockOrder) GetOrders(ctx context.Context, filter models.OrderFilter) ([]models.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", ctx, filter)
+	ret0, _ := ret[0].([]models.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockOrderMockRecorder) GetOrders(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockOrder)(nil).GetOrders), ctx, filter)
+}
+
+// UpdateOrder mocks base method.
+func (m *MockOrder) UpdateOrder(ctx context.Context, o models.SalesOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrder", ctx, o)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrder indicates an expected call of UpdateOrder.
+func (mr *MockOrderMockRecorder) UpdateOrder(ctx, o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*MockOrder)(nil).UpdateOrder), ctx, o)
+}
+
+// MockTestDrive is a mock of TestDrive interface.
+type MockTestDrive struct {
+	ctrl     *gomock.Controller
+	recorder *MockTestDriveMockRecorder
+}
+
+// MockTestDriveMockRecorder is the mock recorder for MockTestDrive.
+type MockTestDriveMockRecorder struct {
+	mock *MockTestDrive
+}
+
+// NewMockTestDrive creates a new mock instance.
+func NewMockTestDrive(ctrl *gomock.Controller) *MockTestDrive {
+	mock := &MockTestDrive{ctrl: ctrl}
+	mock.recorder = &MockTestDriveMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTestDrive) EXPECT() *MockTestDriveMockRecorder {
+	return m.recorder
+}
+
+// CancelTestDrive mocks base method.
+func (m *MockTestDrive) CancelTestDrive(ctx context.Context, d models.TestDrive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTestDrive", ctx, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelTestDrive indicates an expected call of CancelTestDrive.
+func (mr *MockTestDriveMockRecorder) CancelTestDrive(ctx, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTestDrive", reflect.TypeOf((*MockTestDrive)(nil).CancelTestDrive), ctx, d)
+}
+
+// GetTestDrive mocks base method.
+func (m *MockTestDrive) GetTestDrive(ctx context.Context, id string) (models.TestDrive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTestDrive", ctx, id)
+	ret0, _ := ret[0].(models.TestDrive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTestDrive indicates an expected call of GetTestDrive.
+func (mr *MockTestDriveMockRecorder) GetTestDrive(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestDrive", reflect.TypeOf((*MockTestDrive)(nil).GetTestDrive), ctx, id)
+}
+
+// GetTestDrives mocks base method.
+func (m *MockTestDrive) GetTestDrives(ctx context.Context, filter models.TestDriveFilter) ([]models.TestDrive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTestDrives", ctx, filter)
+	ret0, _ := ret[0].([]models.TestDrive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTestDrives indicates an expected call of GetTestDrives.
+func (mr *MockTestDriveMockRecorder) GetTestDrives(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestDrives", reflect.TypeOf((*MockTestDrive)(nil).GetTestDrives), ctx, filter)
+}
+
+// RescheduleTestDrive mocks base method.
+func (m *MockTestDrive) RescheduleTestDrive(ctx context.Context, d models.TestDrive) ([]models.TestDrive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleTestDrive", ctx, d)
+	ret0, _ := ret[0].([]models.TestDrive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RescheduleTestDrive indicates an expected call of RescheduleTestDrive.
+func (mr *MockTestDriveMockRecorder) RescheduleTestDrive(ctx, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleTestDrive", reflect.TypeOf((*MockTestDrive)(nil).RescheduleTestDrive), ctx, d)
+}
+
+// ScheduleTestDrive mocks base method.
+func (m *MockTestDrive) ScheduleTestDrive(ctx context.Context, d models.TestDrive) ([]models.TestDrive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleTestDrive", ctx, d)
+	ret0, _ := ret[0].([]models.TestDrive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleTestDrive indicates an expected call of ScheduleTestDrive.
+func (mr *MockTestDriveMockRecorder) ScheduleTestDrive(ctx, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleTestDrive", reflect.TypeOf((*MockTestDrive)(nil).ScheduleTestDrive), ctx, d)
+}
+
+// MockLender is a mock of Lender interface.
+type MockLender struct {
+	ctrl     *gomock.Controller
+	recorder *MockLenderMockRecorder
+}
+
+// MockLenderMockRecorder is the mock recorder for MockLender.
+type MockLenderMockRecorder struct {
+	mock *MockLender
+}
+
+// NewMockLender creates a new mock instance.
+func NewMockLender(ctrl *gomock.Controller) *MockLender {
+	mock := &MockLender{ctrl: ctrl}
+	mock.recorder = &MockLenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLender) EXPECT() *MockLenderMockRecorder {
+	return m.recorder
+}
+
+// GetRates mocks base method.
+func (m *MockLender) GetRates(ctx context.Context, lender string) ([]models.LenderRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates", ctx, lender)
+	ret0, _ := ret[0].([]models.LenderRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates.
+func (mr *MockLenderMockRecorder) GetRates(ctx, lender interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockLender)(nil).GetRates), ctx, lender)
+}
+
+// ReplaceRates mocks base method.
+func (m *MockLender) ReplaceRates(ctx context.Context, lender string, rates []models.LenderRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRates", ctx, lender, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRates indicates an expected call of ReplaceRates.
+func (mr *MockLenderMockRecorder) ReplaceRates(ctx, lender, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRates", reflect.TypeOf((*MockLender)(nil).ReplaceRates), ctx, lender, rates)
+}
+
+// MockTradeIn is a mock of TradeIn interface.
+type MockTradeIn struct {
+	ctrl     *gomock.Controller
+	recorder *MockTradeInMockRecorder
+}
+
+// MockTradeInMockRecorder is the mock recorder for MockTradeIn.
+type MockTradeInMockRecorder struct {
+	mock *MockTradeIn
+}
+
+// NewMockTradeIn creates a new mock instance.
+func NewMockTradeIn(ctrl *gomock.Controller) *MockTradeIn {
+	mock := &MockTradeIn{ctrl: ctrl}
+	mock.recorder = &MockTradeInMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTradeIn) EXPECT() *MockTradeInMockRecorder {
+	return m.recorder
+}
+
+// CreateAppraisal mocks base method.
+func (m *MockTradeIn) CreateAppraisal(ctx context.Context, a models.Appraisal) (models.Appraisal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAppraisal", ctx, a)
+	ret0, _ := ret[0].(models.Appraisal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAppraisal indicates an expected call of CreateAppraisal.
+func (mr *MockTradeInMockRecorder) CreateAppraisal(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAppraisal", reflect.TypeOf((*MockTradeIn)(nil).CreateAppraisal), ctx, a)
+}
+
+// FindCurve mocks base method.
+func (m *MockTradeIn) FindCurve(ctx context.Context, brand, fuelType string) (models.DepreciationCurve, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCurve", ctx, brand, fuelType)
+	ret0, _ := ret[0].(models.DepreciationCurve)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCurve indicates an expected call of FindCurve.
+func (mr *MockTradeInMockRecorder) FindCurve(ctx, brand, fuelType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCurve", reflect.TypeOf((*MockTradeIn)(nil).FindCurve), ctx, brand, fuelType)
+}
+
+// GetAppraisal mocks base method.
+func (m *MockTradeIn) GetAppraisal(ctx context.Context, id string) (models.Appraisal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppraisal", ctx, id)
+	ret0, _ := ret[0].(models.Appraisal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppraisal indicates an expected call of GetAppraisal.
+func (mr *MockTradeInMockRecorder) GetAppraisal(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppraisal", reflect.TypeOf((*MockTradeIn)(nil).GetAppraisal), ctx, id)
+}
+
+// GetAppraisals mocks base method.
+func (m *MockTradeIn) GetAppraisals(ctx context.Context, customerID string) ([]models.Appraisal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppraisals", ctx, customerID)
+	ret0, _ := ret[0].([]models.Appraisal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppraisals indicates an expected call of GetAppraisals.
+func (mr *MockTradeInMockRecorder) GetAppraisals(ctx, customerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppraisals", reflect.TypeOf((*MockTradeIn)(nil).GetAppraisals), ctx, customerID)
+}
+
+// GetCurves mocks base method.
+func (m *MockTradeIn) GetCurves(ctx context.Context) ([]models.DepreciationCurve, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurves", ctx)
+	ret0, _ := ret[0].([]models.DepreciationCurve)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurves indicates an expected call of GetCurves.
+func (mr *MockTradeInMockRecorder) GetCurves(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurves", reflect.TypeOf((*MockTradeIn)(nil).GetCurves), ctx)
+}
+
+// SaveCurve mocks base method.
+func (m *MockTradeIn) SaveCurve(ctx context.Context, c models.DepreciationCurve) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCurve", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCurve indicates an expected call of SaveCurve.
+func (mr *MockTradeInMockRecorder) SaveCurve(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCurve", reflect.TypeOf((*MockTradeIn)(nil).SaveCurve), ctx, c)
+}
+
+// MockMaintenance is a mock of Maintenance interface.
+type MockMaintenance struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceMockRecorder
+}
+
+// MockMaintenanceMockRecorder is the mock recorder for MockMaintenance.
+type MockMaintenanceMockRecorder struct {
+	mock *MockMaintenance
+}
+
+// NewMockMaintenance creates a new mock instance.
+func NewMockMaintenance(ctrl *gomock.Controller) *MockMaintenance {
+	mock := &MockMaintenance{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenance) EXPECT() *MockMaintenanceMockRecorder {
+	return m.recorder
+}
+
+// CancelAppointment mocks base method.
+func (m *MockMaintenance) CancelAppointment(ctx context.Context, id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelAppointment", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelAppointment indicates an expected call of CancelAppointment.
+func (mr *MockMaintenanceMockRecorder) CancelAppointment(ctx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAppointment", reflect.TypeOf((*MockMaintenance)(nil).CancelAppointment), ctx, id, at)
+}
+
+// CreateAppointment mocks base method.
+func (m *MockMaintenance) CreateAppointment(ctx context.Context, a models.ServiceAppointment) (models.ServiceAppointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAppointment", ctx, a)
+	ret0, _ := ret[0].(models.ServiceAppointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAppointment indicates an expected call of CreateAppointment.
+func (mr *MockMaintenanceMockRecorder) CreateAppointment(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAppointment", reflect.TypeOf((*MockMaintenance)(nil).CreateAppointment), ctx, a)
+}
+
+// CreateRecord mocks base method.
+func (m *MockMaintenance) CreateRecord(ctx context.Context, r models.MaintenanceRecord) (models.MaintenanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRecord", ctx, r)
+	ret0, _ := ret[0].(models.MaintenanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRecord indicates an expected call of CreateRecord.
+func (mr *MockMaintenanceMockRecorder) CreateRecord(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRecord", reflect.TypeOf((*MockMaintenance)(nil).CreateRecord), ctx, r)
+}
+
+// GetAppointment mocks base method.
+func (m *MockMaintenance) GetAppointment(ctx context.Context, id string) (models.ServiceAppointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppointment", ctx, id)
+	ret0, _ := ret[0].(models.ServiceAppointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppointment indicates an expected call of GetAppointment.
+func (mr *MockMaintenanceMockRecorder) GetAppointment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppointment", reflect.TypeOf((*MockMaintenance)(nil).GetAppointment), ctx, id)
+}
+
+// GetAppointments mocks base method.
+func (m *MockMaintenance) GetAppointments(ctx context.Context, carID string) ([]models.ServiceAppointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppointments", ctx, carID)
+	ret0, _ := ret[0].([]models.ServiceAppointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppointments indicates an expected call of GetAppointments.
+func (mr *MockMaintenanceMockRecorder) GetAppointments(ctx, carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppointments", reflect.TypeOf((*MockMaintenance)(nil).GetAppointments), ctx, carID)
+}
+
+// GetRecords mocks base method.
+func (m *MockMaintenance) GetRecords(ctx context.Context, carID string) ([]models.MaintenanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecords", ctx, carID)
+	ret0, _ := ret[0].([]models.MaintenanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecords indicates an expected call of GetRecords.
+func (mr *MockMaintenanceMockRecorder) GetRecords(ctx, carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecords", reflect.TypeOf((*MockMaintenance)(nil).GetRecords), ctx, carID)
+}
+
+// MockWarranty is a mock of Warranty interface.
+type MockWarranty struct {
+	ctrl     *gomock.Controller
+	recorder *MockWarrantyMockRecorder
+}
+
+// MockWarrantyMockRecorder is the mock recorder for MockWarranty.
+type MockWarrantyMockRecorder struct {
+	mock *MockWarranty
+}
+
+// NewMockWarranty creates a new mock instance.
+func NewMockWarranty(ctrl *gomock.Controller) *MockWarranty {
+	mock := &MockWarranty{ctrl: ctrl}
+	mock.recorder = &MockWarrantyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWarranty) EXPECT() *MockWarrantyMockRecorder {
+	return m.recorder
+}
+
+// CreateWarranty mocks base method.
+func (m *MockWarranty) CreateWarranty(ctx context.Context, w models.Warranty) (models.Warranty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWarranty", ctx, w)
+	ret0, _ := ret[0].(models.Warranty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWarranty indicates an expected call of CreateWarranty.
+func (mr *MockWarrantyMockRecorder) CreateWarranty(ctx, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWarranty", reflect.TypeOf((*MockWarranty)(nil).CreateWarranty), ctx, w)
+}
+
+// GetExpiring mocks base method.
+func (m *MockWarranty) GetExpiring(ctx context.Context, from, to time.Time) ([]models.Warranty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiring", ctx, from, to)
+	ret0, _ := ret[0].([]models.Warranty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiring indicates an expected call of GetExpiring.
+func (mr *MockWarrantyMockRecorder) GetExpiring(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiring", reflect.TypeOf((*MockWarranty)(nil).GetExpiring), ctx, from, to)
+}
+
+// GetWarranties mocks base method.
+func (m *MockWarranty) GetWarranties(ctx context.Context, carID string) ([]models.Warranty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWarranties", ctx, carID)
+	ret0, _ := ret[0].([]models.Warranty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWarranties indicates an expected call of GetWarranties.
+func (mr *MockWarrantyMockRecorder) GetWarranties(ctx, carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarranties", reflect.TypeOf((*MockWarranty)(nil).GetWarranties), ctx, carID)
+}
+
+// GetWarranty mocks base method.
+func (m *MockWarranty) GetWarranty(ctx context.Context, id string) (models.Warranty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWarranty", ctx, id)
+	ret0, _ := ret[0].(models.Warranty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWarranty indicates an expected call of GetWarranty.
+func (mr *MockWarrantyMockRecorder) GetWarranty(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarranty", reflect.TypeOf((*MockWarranty)(nil).GetWarranty), ctx, id)
+}
+
+// MockBlob is a mock of Blob interface.
+type MockBlob struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobMockRecorder
+}
+
+// MockBlobMockRecorder is the mock recorder for MockBlob.
+type MockBlobMockRecorder struct {
+	mock *MockBlob
+}
+
+// NewMockBlob creates a new mock instance.
+func NewMockBlob(ctrl *gomock.Controller) *MockBlob {
+	mock := &MockBlob{ctrl: ctrl}
+	mock.recorder = &MockBlobMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlob) EXPECT() *MockBlobMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlob) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlobMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlob)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockBlob) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBlobMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBlob)(nil).Get), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockBlob) Put(ctx context.Context, key, contentType string, body io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, contentType, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockBlobMockRecorder) Put(ctx, key, contentType, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlob)(nil).Put), ctx, key, contentType, body)
+}
+
+// MockCache is a mock of Cache interface.
+type MockCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCacheMockRecorder
+}
+
+// MockCacheMockRecorder is the mock recorder for MockCache.
+type MockCacheMockRecorder struct {
+	mock *MockCache
+}
+
+// NewMockCache creates a new mock instance.
+func NewMockCache(ctrl *gomock.Controller) *MockCache {
+	mock := &MockCache{ctrl: ctrl}
+	mock.recorder = &MockCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCache) EXPECT() *MockCacheMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCache) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCacheMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCache)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCacheMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache)(nil).Get), ctx, key)
+}
+
+// Set mocks base method.
+func (m *MockCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, key, value, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockCacheMockRecorder) Set(ctx, key, value, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCache)(nil).Set), ctx, key, value, ttl)
+}
+
+// MockMedia is a mock of Media interface.
+type MockMedia struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaMockRecorder
+}
+
+// MockMediaMockRecorder is the mock recorder for MockMedia.
+type MockMediaMockRecorder struct {
+	mock *MockMedia
+}
+
+// NewMockMedia creates a new mock instance.
+func NewMockMedia(ctrl *gomock.Controller) *MockMedia {
+	mock := &MockMedia{ctrl: ctrl}
+	mock.recorder = &MockMediaMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMedia) EXPECT() *MockMediaMockRecorder {
+	return m.recorder
+}
+
+// CreateMedia mocks base method.
+func (m_2 *MockMedia) CreateMedia(ctx context.Context, m models.Media) (models.Media, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "CreateMedia", ctx, m)
+	ret0, _ := ret[0].(models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMedia indicates an expected call of CreateMedia.
+func (mr *MockMediaMockRecorder) CreateMedia(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedia", reflect.TypeOf((*MockMedia)(nil).CreateMedia), ctx, m)
+}
+
+// DeleteMedia mocks base method.
+func (m *MockMedia) DeleteMedia(ctx context.Context, carID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedia", ctx, carID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMedia indicates an expected call of DeleteMedia.
+func (mr *MockMediaMockRecorder) DeleteMedia(ctx, carID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedia", reflect.TypeOf((*MockMedia)(nil).DeleteMedia), ctx, carID, id)
+}
+
+// GetCarMedia mocks base method.
+func (m *MockMedia) GetCarMedia(ctx context.Context, carIDs ...string) ([]models.Media, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range carIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetCarMedia", varargs...)
+	ret0, _ := ret[0].([]models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarMedia indicates an expected call of GetCarMedia.
+func (mr *MockMediaMockRecorder) GetCarMedia(ctx interface{}, carIDs ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, carIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarMedia", reflect.TypeOf((*MockMedia)(nil).GetCarMedia), varargs...)
+}
+
+// GetMedia mocks base method.
+func (m *MockMedia) GetMedia(ctx context.Context, id string) (models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedia", ctx, id)
+	ret0, _ := ret[0].(models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMedia indicates an expected call of GetMedia.
+func (mr *MockMediaMockRecorder) GetMedia(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedia", reflect.TypeOf((*MockMedia)(nil).GetMedia), ctx, id)
+}
+
+// Reorder mocks base method.
+func (m *MockMedia) Reorder(ctx context.Context, carID string, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, carID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockMediaMockRecorder) Reorder(ctx, carID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockMedia)(nil).Reorder), ctx, carID, ids)
+}
+
+// SetPrimary mocks base method.
+func (m *MockMedia) SetPrimary(ctx context.Context, carID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrimary", ctx, carID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrimary indicates an expected call of SetPrimary.
+func (mr *MockMediaMockRecorder) SetPrimary(ctx, carID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimary", reflect.TypeOf((*MockMedia)(nil).SetPrimary), ctx, carID, id)
+}
+
+// MockCatalogue is a mock of Catalogue interface.
+type MockCatalogue struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogueMockRecorder
+}
+
+// MockCatalogueMockRecorder is the mock recorder for MockCatalogue.
+type MockCatalogueMockRecorder struct {
+	mock *MockCatalogue
+}
+
+// NewMockCatalogue creates a new mock instance.
+func NewMockCatalogue(ctrl *gomock.Controller) *MockCatalogue {
+	mock := &MockCatalogue{ctrl: ctrl}
+	mock.recorder = &MockCatalogueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogue) EXPECT() *MockCatalogueMockRecorder {
+	return m.recorder
+}
+
+// CountTrimCars mocks base method.
+func (m *MockCatalogue) CountTrimCars(ctx context.Context, trimID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTrimCars", ctx, trimID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTrimCars indicates an expected call of CountTrimCars.
+func (mr *MockCatalogueMockRecorder) CountTrimCars(ctx, trimID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTrimCars", reflect.TypeOf((*MockCatalogue)(nil).CountTrimCars), ctx, trimID)
+}
+
+// CreateBrand mocks base method.
+func (m *MockCatalogue) CreateBrand(ctx context.Context, b models.Brand) (models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBrand", ctx, b)
+	ret0, _ := ret[0].(models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBrand indicates an expected call of CreateBrand.
+func (mr *MockCatalogueMockRecorder) CreateBrand(ctx, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBrand", reflect.TypeOf((*MockCatalogue)(nil).CreateBrand), ctx, b)
+}
+
+// CreateModel mocks base method.
+func (m_2 *MockCatalogue) CreateModel(ctx context.Context, m models.CarModel) (models.CarModel, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "CreateModel", ctx, m)
+	ret0, _ := ret[0].(models.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateModel indicates an expected call of CreateModel.
+func (mr *MockCatalogueMockRecorder) CreateModel(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModel", reflect.TypeOf((*MockCatalogue)(nil).CreateModel), ctx, m)
+}
+
+// CreateTrim mocks base method.
+func (m *MockCatalogue) CreateTrim(ctx context.Context, t models.Trim) (models.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrim", ctx, t)
+	ret0, _ := ret[0].(models.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrim indicates an expected call of CreateTrim.
+func (mr *MockCatalogueMockRecorder) CreateTrim(ctx, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrim", reflect.TypeOf((*MockCatalogue)(nil).CreateTrim), ctx, t)
+}
+
+// DeleteBrand mocks base method.
+func (m *MockCatalogue) DeleteBrand(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBrand", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBrand indicates an expected call of DeleteBrand.
+func (mr *MockCatalogueMockRecorder) DeleteBrand(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBrand", reflect.TypeOf((*MockCatalogue)(nil).DeleteBrand), ctx, id)
+}
+
+// DeleteModel mocks base method.
+func (m *MockCatalogue) DeleteModel(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteModel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteModel indicates an expected call of DeleteModel.
+func (mr *MockCatalogueMockRecorder) DeleteModel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteModel", reflect.TypeOf((*MockCatalogue)(nil).DeleteModel), ctx, id)
+}
+
+// DeleteTrim mocks base method.
+func (m *MockCatalogue) DeleteTrim(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrim", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrim indicates an expected call of DeleteTrim.
+func (mr *MockCatalogueMockRecorder) DeleteTrim(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrim", reflect.TypeOf((*MockCatalogue)(nil).DeleteTrim), ctx, id)
+}
+
+// GetBrand mocks base method.
+func (m *MockCatalogue) GetBrand(ctx context.Context, id string) (models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrand", ctx, id)
+	ret0, _ := ret[0].(models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrand indicates an expected call of GetBrand.
+func (mr *MockCatalogueMockRecorder) GetBrand(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrand", reflect.TypeOf((*MockCatalogue)(nil).GetBrand), ctx, id)
+}
+
+// GetBrandSpellings mocks base method.
+func (m *MockCatalogue) GetBrandSpellings(ctx context.Context) ([]models.BrandSpelling, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrandSpellings", ctx)
+	ret0, _ := ret[0].([]models.BrandSpelling)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrandSpellings indicates an expected call of GetBrandSpellings.
+func (mr *MockCatalogueMockRecorder) GetBrandSpellings(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrandSpellings", reflect.TypeOf((*MockCatalogue)(nil).GetBrandSpellings), ctx)
+}
+
+// GetBrands mocks base method.
+func (m *MockCatalogue) GetBrands(ctx context.Context) ([]models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrands", ctx)
+	ret0, _ := ret[0].([]models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrands indicates an expected call of GetBrands.
+func (mr *MockCatalogueMockRecorder) GetBrands(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrands", reflect.TypeOf((*MockCatalogue)(nil).GetBrands), ctx)
+}
+
+// GetModel mocks base method.
+func (m *MockCatalogue) GetModel(ctx context.Context, id string) (models.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModel", ctx, id)
+	ret0, _ := ret[0].(models.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModel indicates an expected call of GetModel.
+func (mr *MockCatalogueMockRecorder) GetModel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModel", reflect.TypeOf((*MockCatalogue)(nil).GetModel), ctx, id)
+}
+
+// GetModels mocks base method.
+func (m *MockCatalogue) GetModels(ctx context.Context, brandID string) ([]models.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModels", ctx, brandID)
+	ret0, _ := ret[0].([]models.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModels indicates an expected call of GetModels.
+func (mr *MockCatalogueMockRecorder) GetModels(ctx, brandID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModels", reflect.TypeOf((*MockCatalogue)(nil).GetModels), ctx, brandID)
+}
+
+// GetTrim mocks base method.
+func (m *MockCatalogue) GetTrim(ctx context.Context, id string) (models.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrim", ctx, id)
+	ret0, _ := ret[0].(models.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrim indicates an expected call of GetTrim.
+func (mr *MockCatalogueMockRecorder) GetTrim(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrim", reflect.TypeOf((*MockCatalogue)(nil).GetTrim), ctx, id)
+}
+
+// GetTrims mocks base method.
+func (m *MockCatalogue) GetTrims(ctx context.Context, modelID string) ([]models.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrims", ctx, modelID)
+	ret0, _ := ret[0].([]models.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrims indicates an expected call of GetTrims.
+func (mr *MockCatalogueMockRecorder) GetTrims(ctx, modelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrims", reflect.TypeOf((*MockCatalogue)(nil).GetTrims), ctx, modelID)
+}
+
+// RenameBrands mocks base method.
+func (m *MockCatalogue) RenameBrands(ctx context.Context, renames []models.BrandRename) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameBrands", ctx, renames)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameBrands indicates an expected call of RenameBrands.
+func (mr *MockCatalogueMockRecorder) RenameBrands(ctx, renames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameBrands", reflect.TypeOf((*MockCatalogue)(nil).RenameBrands), ctx, renames)
+}
+
+// UpdateBrand mocks base method.
+func (m *MockCatalogue) UpdateBrand(ctx context.Context, b models.Brand) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBrand", ctx, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBrand indicates an expected call of UpdateBrand.
+func (mr *MockCatalogueMockRecorder) UpdateBrand(ctx, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBrand", reflect.TypeOf((*MockCatalogue)(nil).UpdateBrand), ctx, b)
+}
+
+// UpdateModel mocks base method.
+func (m_2 *MockCatalogue) UpdateModel(ctx context.Context, m models.CarModel) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "UpdateModel", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateModel indicates an expected call of UpdateModel.
+func (mr *MockCatalogueMockRecorder) UpdateModel(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateModel", reflect.TypeOf((*MockCatalogue)(nil).UpdateModel), ctx, m)
+}
+
+// UpdateTrim mocks base method.
+func (m *MockCatalogue) UpdateTrim(ctx context.Context, t models.Trim) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrim", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTrim indicates an expected call of UpdateTrim.
+func (mr *MockCatalogueMockRecorder) UpdateTrim(ctx, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrim", reflect.TypeOf((*MockCatalogue)(nil).UpdateTrim), ctx, t)
+}
@@ -0,0 +1,348 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/status"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+const columns = "id,dealership,invoice_number,car_id,customer_id,salesperson,price,currency,tax_rate,tax,total,paid," +
+	"status,notes,created_at,updated_at"
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// CreateOrder store layer function to sell a car in one transaction: the car is moved from in stock to sold, the
+// next invoice number of the dealership is taken and the order is inserted with its fees. A car held for the
+// customer of the order is sold from reserved instead, and the hold is closed as purchased. The car is only sold
+// while it is still in stock or held for the customer so that of two concurrent orders one fails with
+// sql.ErrNoRows, and invoice numbers have no gaps as a failed order rolls its number back.
+func (s Store) CreateOrder(ctx context.Context, o models.SalesOrder) (models.SalesOrder, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	from := models.StatusInStock
+
+	held, err := closeHold(ctx, tx, o)
+	if held {
+		from = models.StatusReserved
+	}
+
+	if err == nil {
+		err = status.Move(ctx, tx, models.StatusChange{ID: uuid.New(), CarID: o.CarID, From: from,
+			To: models.StatusSold, Actor: o.Salesperson, Reason: "sales order " + o.ID.String(),
+			ChangedAt: o.CreatedAt})
+	}
+
+	if err == nil {
+		o.InvoiceNumber, err = nextInvoiceNumber(ctx, tx, o.Dealership)
+	}
+
+	if err == nil {
+		_, err = tx.ExecContext(ctx, "INSERT INTO sales_order ("+columns+") VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)",
+			o.ID.String(), o.Dealership, o.InvoiceNumber, o.CarID.String(), o.CustomerID.String(), o.Salesperson,
+			o.Price, o.Currency, o.TaxRate, o.Tax, o.Total, o.Paid, o.Status, o.Notes, o.CreatedAt, o.UpdatedAt)
+	}
+
+	if err == nil {
+		err = insertFees(ctx, tx, o)
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return models.SalesOrder{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	return o, nil
+}
+
+// closeHold ends the active hold the customer of the order has on its car as purchased, held is false when the
+// customer holds no unexpired hold on the car
+func closeHold(ctx context.Context, tx *sql.Tx, o models.SalesOrder) (held bool, err error) {
+	res, err := tx.ExecContext(ctx, "UPDATE reservation SET status=?,ended_at=? WHERE car_id=? AND holder=? AND "+
+		"status=? AND expires_at>?", models.ReservationPurchased, o.CreatedAt, o.CarID.String(), o.CustomerID.String(),
+		models.ReservationActive, o.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+
+	return n > 0, err
+}
+
+// GetOrder store layer function to get an order by its id with its fees and payments
+func (s Store) GetOrder(ctx context.Context, id string) (models.SalesOrder, error) {
+	o, err := scan(s.db.QueryRowContext(ctx, "SELECT "+columns+" FROM sales_order WHERE id=?", id))
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT description,amount FROM order_fee WHERE order_id=? ORDER BY position",
+		id)
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	defer rows.Close()
+
+	o.Fees = []models.Fee{}
+
+	for rows.Next() {
+		var f models.Fee
+
+		if err = rows.Scan(&f.Description, &f.Amount); err != nil {
+			return models.SalesOrder{}, err
+		}
+
+		o.Fees = append(o.Fees, f)
+	}
+
+	if err = rows.Err(); err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	o.Payments, err = s.payments(ctx, id)
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	return o, nil
+}
+
+// GetOrders store layer function to get the orders passing the filter, newest first. Fees and payments are
+// only read with a single order, the totals are stored with the order.
+func (s Store) GetOrders(ctx context.Context, filter models.OrderFilter) ([]models.SalesOrder, error) {
+	where, args := whereClause(filter)
+
+	rows, err := s.db.QueryContext(ctx, "SELECT "+columns+" FROM sales_order"+where+" ORDER BY created_at DESC,id",
+		args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orders := make([]models.SalesOrder, 0)
+
+	for rows.Next() {
+		o, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, o)
+	}
+
+	return orders, rows.Err()
+}
+
+// UpdateOrder store layer function to update the terms of an open order and replace its fees in one transaction.
+// The update only applies while the order is open and o.Paid has not changed, sql.ErrNoRows is returned otherwise
+// so that a payment racing the update can not leave more paid than the new total.
+func (s Store) UpdateOrder(ctx context.Context, o models.SalesOrder) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE sales_order SET salesperson=?,price=?,currency=?,tax_rate=?,tax=?,"+
+		"total=?,notes=?,updated_at=? WHERE id=? AND status=? AND paid=?", o.Salesperson, o.Price, o.Currency,
+		o.TaxRate, o.Tax, o.Total, o.Notes, o.UpdatedAt, o.ID.String(), models.OrderOpen, o.Paid)
+	if err == nil {
+		err = datastore.Affected(res)
+	}
+
+	if err == nil {
+		_, err = tx.ExecContext(ctx, "DELETE FROM order_fee WHERE order_id=?", o.ID.String())
+	}
+
+	if err == nil {
+		err = insertFees(ctx, tx, o)
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AddPayment store layer function to record a payment against an open order, o is the order with the payment
+// applied. The order is only updated while it is open and its paid amount is still the one before the payment,
+// sql.ErrNoRows is returned otherwise.
+func (s Store) AddPayment(ctx context.Context, o models.SalesOrder, p models.Payment) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE sales_order SET paid=?,status=?,updated_at=? WHERE id=? AND status=? "+
+		"AND paid=?", o.Paid, o.Status, o.UpdatedAt, o.ID.String(), models.OrderOpen, o.Paid-p.Amount)
+	if err == nil {
+		err = datastore.Affected(res)
+	}
+
+	if err == nil {
+		_, err = tx.ExecContext(ctx, "INSERT INTO order_payment (id,order_id,amount,method,reference,received_at) "+
+			"VALUES(?,?,?,?,?,?)", p.ID.String(), o.ID.String(), p.Amount, p.Method, p.Reference, p.ReceivedAt)
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CancelOrder store layer function to cancel an open order without payments and put its car back in stock in
+// one transaction, sql.ErrNoRows is returned when the order can not be cancelled or the car is no longer sold
+func (s Store) CancelOrder(ctx context.Context, o models.SalesOrder) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE sales_order SET status=?,updated_at=? WHERE id=? AND status=? AND paid=0",
+		models.OrderCancelled, o.UpdatedAt, o.ID.String(), models.OrderOpen)
+	if err == nil {
+		err = datastore.Affected(res)
+	}
+
+	if err == nil {
+		err = status.Move(ctx, tx, models.StatusChange{ID: uuid.New(), CarID: o.CarID, From: models.StatusSold,
+			To: models.StatusInStock, Actor: o.Salesperson, Reason: "sales order " + o.ID.String() + " cancelled",
+			ChangedAt: o.UpdatedAt})
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s Store) payments(ctx context.Context, orderID string) ([]models.Payment, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id,order_id,amount,method,reference,received_at FROM order_payment "+
+		"WHERE order_id=? ORDER BY received_at,id", orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	payments := []models.Payment{}
+
+	for rows.Next() {
+		var p models.Payment
+
+		if err = rows.Scan(&p.ID, &p.OrderID, &p.Amount, &p.Method, &p.Reference, &p.ReceivedAt); err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, p)
+	}
+
+	return payments, rows.Err()
+}
+
+// nextInvoiceNumber takes the next number of the dealership's invoice sequence, the sequence row stays locked
+// until tx ends so that concurrent orders are numbered one after the other
+func nextInvoiceNumber(ctx context.Context, tx *sql.Tx, dealership string) (string, error) {
+	_, err := tx.ExecContext(ctx, "INSERT INTO invoice_sequence (dealership,last_number) VALUES(?,1) "+
+		"ON DUPLICATE KEY UPDATE last_number=last_number+1", dealership)
+	if err != nil {
+		return "", err
+	}
+
+	var n int64
+
+	err = tx.QueryRowContext(ctx, "SELECT last_number FROM invoice_sequence WHERE dealership=?", dealership).Scan(&n)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%06d", dealership, n), nil
+}
+
+func insertFees(ctx context.Context, tx *sql.Tx, o models.SalesOrder) error {
+	for i, f := range o.Fees {
+		_, err := tx.ExecContext(ctx, "INSERT INTO order_fee (order_id,position,description,amount) VALUES(?,?,?,?)",
+			o.ID.String(), i, f.Description, f.Amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func whereClause(filter models.OrderFilter) (string, []interface{}) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+
+	if filter.Status != "" {
+		conds = append(conds, "status=?")
+		args = append(args, filter.Status)
+	}
+
+	if filter.Salesperson != "" {
+		conds = append(conds, "salesperson=?")
+		args = append(args, filter.Salesperson)
+	}
+
+	if filter.CustomerID != "" {
+		conds = append(conds, "customer_id=?")
+		args = append(args, filter.CustomerID)
+	}
+
+	if filter.CarID != "" {
+		conds = append(conds, "car_id=?")
+		args = append(args, filter.CarID)
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner) (models.SalesOrder, error) {
+	var o models.SalesOrder
+
+	err := row.Scan(&o.ID, &o.Dealership, &o.InvoiceNumber, &o.CarID, &o.CustomerID, &o.Salesperson, &o.Price,
+		&o.Currency, &o.TaxRate, &o.Tax, &o.Total, &o.Paid, &o.Status, &o.Notes, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	return o, nil
+}
@@ -0,0 +1,363 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+const (
+	moveCarQuery  = "UPDATE Car SET status=? WHERE id=? AND status=?"
+	insertHistory = "INSERT INTO status_history (id,car_id,from_status,to_status,actor,reason,changed_at) " +
+		"VALUES(?,?,?,?,?,?,?)"
+	insertOrder = "INSERT INTO sales_order (" + columns + ") VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
+	insertFee   = "INSERT INTO order_fee (order_id,position,description,amount) VALUES(?,?,?,?)"
+	nextNumber  = "INSERT INTO invoice_sequence (dealership,last_number) VALUES(?,1) " +
+		"ON DUPLICATE KEY UPDATE last_number=last_number+1"
+	lastNumber = "SELECT last_number FROM invoice_sequence WHERE dealership=?"
+)
+
+var columnNames = []string{"id", "dealership", "invoice_number", "car_id", "customer_id", "salesperson", "price",
+	"currency", "tax_rate", "tax", "total", "paid", "status", "notes", "created_at", "updated_at"}
+
+var now = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// TestCreateOrder function to test store layer CreateOrder function sells the car and numbers the invoice
+func TestCreateOrder(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	o := models.SalesOrder{ID: uuid.New(), Dealership: "MAIN", CarID: uuid.New(), CustomerID: uuid.New(),
+		Salesperson: "ravi", Price: 2400000, Currency: "EUR", TaxRate: 1900,
+		Fees:   []models.Fee{{Description: "registration", Amount: 15000}},
+		Status: models.OrderOpen, CreatedAt: now, UpdatedAt: now}
+	o.Compute()
+
+	carID := o.CarID.String()
+	numbered := o
+	numbered.InvoiceNumber = "MAIN-000042"
+
+	testCases := []struct {
+		desc   string
+		output models.SalesOrder
+		err    error
+	}{
+		{"success", numbered, nil},
+		{"held for the customer", numbered, nil},
+		{"car no longer in stock", models.SalesOrder{}, sql.ErrNoRows},
+	}
+
+	closeHold := "UPDATE reservation SET status=?,ended_at=? WHERE car_id=? AND holder=? AND status=? AND expires_at>?"
+	holder := o.CustomerID.String()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(closeHold).WithArgs("purchased", now, carID, holder, "active", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(moveCarQuery).WithArgs("sold", carID, "in_stock").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertHistory).WithArgs(sqlmock.AnyArg(), carID, "in_stock", "sold", "ravi",
+		"sales order "+o.ID.String(), now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(nextNumber).WithArgs("MAIN").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(lastNumber).WithArgs("MAIN").WillReturnRows(sqlmock.NewRows([]string{"last_number"}).AddRow(42))
+	mock.ExpectExec(insertOrder).WithArgs(o.ID.String(), "MAIN", "MAIN-000042", carID, o.CustomerID.String(), "ravi",
+		o.Price, "EUR", 1900, o.Tax, o.Total, o.Paid, "open", "", now, now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertFee).WithArgs(o.ID.String(), 0, "registration", models.Money(15000)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(closeHold).WithArgs("purchased", now, carID, holder, "active", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(moveCarQuery).WithArgs("sold", carID, "reserved").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertHistory).WithArgs(sqlmock.AnyArg(), carID, "reserved", "sold", "ravi",
+		"sales order "+o.ID.String(), now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(nextNumber).WithArgs("MAIN").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(lastNumber).WithArgs("MAIN").WillReturnRows(sqlmock.NewRows([]string{"last_number"}).AddRow(42))
+	mock.ExpectExec(insertOrder).WithArgs(o.ID.String(), "MAIN", "MAIN-000042", carID, o.CustomerID.String(), "ravi",
+		o.Price, "EUR", 1900, o.Tax, o.Total, o.Paid, "open", "", now, now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertFee).WithArgs(o.ID.String(), 0, "registration", models.Money(15000)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(closeHold).WithArgs("purchased", now, carID, holder, "active", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(moveCarQuery).WithArgs("sold", carID, "in_stock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	for i, tc := range testCases {
+		res, err := a.CreateOrder(context.TODO(), o)
+		if !errors.Is(err, tc.err) || !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestGetOrder function to test store layer GetOrder function reads the fees and payments of the order
+func TestGetOrder(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	o := models.SalesOrder{ID: uuid.New(), Dealership: "MAIN", CarID: uuid.New(), CustomerID: uuid.New(),
+		Salesperson: "ravi", Price: 2400000, Currency: "EUR", TaxRate: 1900,
+		Fees:   []models.Fee{{Description: "registration", Amount: 15000}},
+		Status: models.OrderOpen, CreatedAt: now, UpdatedAt: now}
+	o.Compute()
+
+	o.InvoiceNumber = "MAIN-000042"
+	o.Payments = []models.Payment{{ID: uuid.New(), OrderID: o.ID, Amount: 500000, Method: "card", Reference: "tx-1",
+		ReceivedAt: now}}
+	o.Paid = 500000
+	id := o.ID.String()
+
+	mock.ExpectQuery("SELECT " + columns + " FROM sales_order WHERE id=?").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(columnNames).AddRow(o.ID.String(), o.Dealership, o.InvoiceNumber,
+			o.CarID.String(), o.CustomerID.String(), o.Salesperson, int64(o.Price), o.Currency, o.TaxRate, int64(o.Tax),
+			int64(o.Total), int64(o.Paid), o.Status, o.Notes, o.CreatedAt, o.UpdatedAt))
+	mock.ExpectQuery("SELECT description,amount FROM order_fee WHERE order_id=? ORDER BY position").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"description", "amount"}).AddRow("registration", 15000))
+	mock.ExpectQuery("SELECT id,order_id,amount,method,reference,received_at FROM order_payment " +
+		"WHERE order_id=? ORDER BY received_at,id").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "amount", "method", "reference", "received_at"}).
+			AddRow(o.Payments[0].ID.String(), id, 500000, "card", "tx-1", now))
+	mock.ExpectQuery("SELECT " + columns + " FROM sales_order WHERE id=?").WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	testCases := []struct {
+		desc   string
+		id     string
+		output models.SalesOrder
+		err    error
+	}{
+		{"success", id, o, nil},
+		{"not found", "missing", models.SalesOrder{}, sql.ErrNoRows},
+	}
+
+	for i, tc := range testCases {
+		res, err := a.GetOrder(context.TODO(), tc.id)
+		if !errors.Is(err, tc.err) || !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
+
+// TestGetOrders function to test store layer GetOrders function builds its filters
+func TestGetOrders(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	o := models.SalesOrder{ID: uuid.New(), Dealership: "MAIN", CarID: uuid.New(), CustomerID: uuid.New(),
+		Salesperson: "ravi", Price: 2400000, Currency: "EUR", TaxRate: 1900,
+		Fees:   []models.Fee{{Description: "registration", Amount: 15000}},
+		Status: models.OrderOpen, CreatedAt: now, UpdatedAt: now}
+	o.Compute()
+
+	o.Fees = nil
+	order := " ORDER BY created_at DESC,id"
+
+	mock.ExpectQuery("SELECT " + columns + " FROM sales_order" + order).
+		WillReturnRows(sqlmock.NewRows(columnNames).AddRow(o.ID.String(), o.Dealership, o.InvoiceNumber,
+			o.CarID.String(), o.CustomerID.String(), o.Salesperson, int64(o.Price), o.Currency, o.TaxRate, int64(o.Tax),
+			int64(o.Total), int64(o.Paid), o.Status, o.Notes, o.CreatedAt, o.UpdatedAt))
+	mock.ExpectQuery("SELECT "+columns+" FROM sales_order WHERE status=? AND salesperson=? AND customer_id=?"+order).
+		WithArgs("paid", "ravi", o.CustomerID.String()).WillReturnRows(sqlmock.NewRows(columnNames))
+	mock.ExpectQuery("SELECT " + columns + " FROM sales_order WHERE car_id=?" + order).
+		WithArgs(o.CarID.String()).WillReturnError(errors.New("db error"))
+
+	testCases := []struct {
+		desc   string
+		filter models.OrderFilter
+		output []models.SalesOrder
+		err    bool
+	}{
+		{desc: "no filter", output: []models.SalesOrder{o}},
+		{desc: "filtered", filter: models.OrderFilter{Status: "paid", Salesperson: "ravi",
+			CustomerID: o.CustomerID.String()}, output: []models.SalesOrder{}},
+		{desc: "error", filter: models.OrderFilter{CarID: o.CarID.String()}, err: true},
+	}
+
+	for i, tc := range testCases {
+		res, err := a.GetOrders(context.TODO(), tc.filter)
+		if (err != nil) != tc.err || !tc.err && !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
+
+// TestUpdateOrder function to test store layer UpdateOrder function replaces the fees of open orders
+func TestUpdateOrder(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	o := models.SalesOrder{ID: uuid.New(), Dealership: "MAIN", CarID: uuid.New(), CustomerID: uuid.New(),
+		Salesperson: "ravi", Price: 2400000, Currency: "EUR", TaxRate: 1900,
+		Fees:   []models.Fee{{Description: "registration", Amount: 15000}},
+		Status: models.OrderOpen, CreatedAt: now, UpdatedAt: now}
+	o.Compute()
+
+	id := o.ID.String()
+	update := "UPDATE sales_order SET salesperson=?,price=?,currency=?,tax_rate=?,tax=?,total=?,notes=?," +
+		"updated_at=? WHERE id=? AND status=? AND paid=?"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs("ravi", o.Price, "EUR", 1900, o.Tax, o.Total, "", now, id, "open", o.Paid).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM order_fee WHERE order_id=?").WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertFee).WithArgs(id, 0, "registration", models.Money(15000)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	testCases := []struct {
+		desc string
+		err  error
+	}{
+		{"success", nil},
+		{"paid concurrently", sql.ErrNoRows},
+	}
+
+	for i, tc := range testCases {
+		if err := a.UpdateOrder(context.TODO(), o); !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
+
+// TestAddPayment function to test store layer AddPayment function
+func TestAddPayment(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	o := models.SalesOrder{ID: uuid.New(), Dealership: "MAIN", CarID: uuid.New(), CustomerID: uuid.New(),
+		Salesperson: "ravi", Price: 2400000, Currency: "EUR", TaxRate: 1900,
+		Fees:   []models.Fee{{Description: "registration", Amount: 15000}},
+		Status: models.OrderOpen, CreatedAt: now, UpdatedAt: now}
+	o.Compute()
+
+	o.Paid = o.Total
+	o.Status = models.OrderPaid
+	id := o.ID.String()
+	p := models.Payment{ID: uuid.New(), OrderID: o.ID, Amount: 1000000, Method: "transfer", ReceivedAt: now}
+	update := "UPDATE sales_order SET paid=?,status=?,updated_at=? WHERE id=? AND status=? AND paid=?"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs(o.Total, "paid", now, id, "open", o.Total-p.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO order_payment (id,order_id,amount,method,reference,received_at) VALUES(?,?,?,?,?,?)").
+		WithArgs(p.ID.String(), id, p.Amount, "transfer", "", now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	testCases := []struct {
+		desc string
+		err  error
+	}{
+		{"success", nil},
+		{"paid concurrently", sql.ErrNoRows},
+	}
+
+	for i, tc := range testCases {
+		if err := a.AddPayment(context.TODO(), o, p); !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
+
+// TestCancelOrder function to test store layer CancelOrder function puts the car back in stock
+func TestCancelOrder(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	o := models.SalesOrder{ID: uuid.New(), Dealership: "MAIN", CarID: uuid.New(), CustomerID: uuid.New(),
+		Salesperson: "ravi", Price: 2400000, Currency: "EUR", TaxRate: 1900,
+		Fees:   []models.Fee{{Description: "registration", Amount: 15000}},
+		Status: models.OrderOpen, CreatedAt: now, UpdatedAt: now}
+	o.Compute()
+
+	id := o.ID.String()
+	carID := o.CarID.String()
+	cancel := "UPDATE sales_order SET status=?,updated_at=? WHERE id=? AND status=? AND paid=0"
+
+	mock.ExpectBegin()
+	mock.ExpectExec(cancel).WithArgs("cancelled", now, id, "open").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(moveCarQuery).WithArgs("in_stock", carID, "sold").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertHistory).WithArgs(sqlmock.AnyArg(), carID, "sold", "in_stock", "ravi",
+		"sales order "+id+" cancelled", now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(cancel).WithArgs("cancelled", now, id, "open").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(moveCarQuery).WithArgs("in_stock", carID, "sold").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	testCases := []struct {
+		desc string
+		err  error
+	}{
+		{"success", nil},
+		{"car delivered", sql.ErrNoRows},
+	}
+
+	for i, tc := range testCases {
+		if err := a.CancelOrder(context.TODO(), o); !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,85 @@
+package price
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// SetPrice store layer function to update the price of a car and record the change in its price history,
+// both in one transaction. sql.ErrNoRows is returned when the car does not exist
+func (s Store) SetPrice(ctx context.Context, change models.PriceChange) (models.PriceChange, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.PriceChange{}, err
+	}
+
+	var id string
+
+	// lock the car row so that concurrent changes are recorded in the order they are applied
+	err = tx.QueryRowContext(ctx, "SELECT id FROM Car WHERE id=? FOR UPDATE", change.CarID.String()).Scan(&id)
+	if err != nil {
+		_ = tx.Rollback()
+		return models.PriceChange{}, err
+	}
+
+	p := change.Price
+
+	_, err = tx.ExecContext(ctx, "UPDATE Car SET msrp=?,list_price=?,cost=?,currency=? WHERE id=?",
+		int64(p.MSRP), int64(p.ListPrice), int64(p.Cost), p.Currency, id)
+	if err != nil {
+		_ = tx.Rollback()
+		return models.PriceChange{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO price_history "+
+		"(id,car_id,msrp,list_price,cost,currency,actor,reason,changed_at) VALUES(?,?,?,?,?,?,?,?,?)",
+		change.ID.String(), id, int64(p.MSRP), int64(p.ListPrice), int64(p.Cost), p.Currency, change.Actor,
+		change.Reason, change.ChangedAt)
+	if err != nil {
+		_ = tx.Rollback()
+		return models.PriceChange{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.PriceChange{}, err
+	}
+
+	return change, nil
+}
+
+// GetPriceHistory store layer function to get every price change of a car, oldest first
+func (s Store) GetPriceHistory(ctx context.Context, carID string) ([]models.PriceChange, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id,car_id,msrp,list_price,cost,currency,actor,reason,changed_at "+
+		"FROM price_history WHERE car_id=? ORDER BY changed_at,id", carID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	changes := []models.PriceChange{}
+
+	for rows.Next() {
+		var c models.PriceChange
+
+		err = rows.Scan(&c.ID, &c.CarID, &c.Price.MSRP, &c.Price.ListPrice, &c.Price.Cost, &c.Price.Currency,
+			&c.Actor, &c.Reason, &c.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
@@ -0,0 +1,131 @@
+package price
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+const insertHistory = "INSERT INTO price_history (id,car_id,msrp,list_price,cost,currency,actor,reason,changed_at) " +
+	"VALUES(?,?,?,?,?,?,?,?,?)"
+
+// TestSetPrice function to test store layer SetPrice function
+func TestSetPrice(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	id := uuid.New()
+	change := models.PriceChange{ID: uuid.New(), CarID: id, Actor: "sahil", Reason: "launch",
+		Price:     models.Price{MSRP: 4999900, ListPrice: 4750000, Cost: 4100000, Currency: "USD"},
+		ChangedAt: time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC)}
+	insertErr := errors.New("insert failed")
+
+	testCases := []struct {
+		desc   string
+		output models.PriceChange
+		err    error
+	}{
+		{"success", change, nil},
+		{"car not found", models.PriceChange{}, sql.ErrNoRows},
+		{"history not written", models.PriceChange{}, insertErr},
+	}
+
+	lock := "SELECT id FROM Car WHERE id=? FOR UPDATE"
+	update := "UPDATE Car SET msrp=?,list_price=?,cost=?,currency=? WHERE id=?"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lock).WithArgs(id.String()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id.String()))
+	mock.ExpectExec(update).WithArgs(4999900, 4750000, 4100000, "USD", id.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertHistory).WithArgs(change.ID.String(), id.String(), 4999900, 4750000, 4100000, "USD",
+		"sahil", "launch", change.ChangedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lock).WithArgs(id.String()).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lock).WithArgs(id.String()).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id.String()))
+	mock.ExpectExec(update).WithArgs(4999900, 4750000, 4100000, "USD", id.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertHistory).WillReturnError(insertErr)
+	mock.ExpectRollback()
+
+	for i, tc := range testCases {
+		res, err := a.SetPrice(context.TODO(), change)
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if res != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestGetPriceHistory function to test store layer GetPriceHistory function
+func TestGetPriceHistory(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	id := uuid.New()
+	changeID := uuid.New()
+	at := time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC)
+	queryErr := errors.New("query error")
+
+	testCases := []struct {
+		desc   string
+		output []models.PriceChange
+		err    error
+	}{
+		{desc: "success", output: []models.PriceChange{{ID: changeID, CarID: id, Actor: "sahil", Reason: "launch",
+			Price: models.Price{MSRP: 100, ListPrice: 90, Cost: 80, Currency: "INR"}, ChangedAt: at}}},
+		{desc: "no changes", output: []models.PriceChange{}},
+		{desc: "query error", err: queryErr},
+	}
+
+	query := "SELECT id,car_id,msrp,list_price,cost,currency,actor,reason,changed_at " +
+		"FROM price_history WHERE car_id=? ORDER BY changed_at,id"
+	columns := []string{"id", "car_id", "msrp", "list_price", "cost", "currency", "actor", "reason", "changed_at"}
+
+	mock.ExpectQuery(query).WithArgs(id.String()).WillReturnRows(sqlmock.NewRows(columns).
+		AddRow(changeID.String(), id.String(), 100, 90, 80, "INR", "sahil", "launch", at))
+	mock.ExpectQuery(query).WithArgs(id.String()).WillReturnRows(sqlmock.NewRows(columns))
+	mock.ExpectQuery(query).WithArgs(id.String()).WillReturnError(queryErr)
+
+	for i, tc := range testCases {
+		res, err := a.GetPriceHistory(context.TODO(), id.String())
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package reservation
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/status"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+const columns = "id,car_id,holder,status,created_at,expires_at,ended_at"
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// CreateHold store layer function to reserve a car and record the hold in one transaction. The car is only
+// reserved while it is still in stock so that of two concurrent holds one fails with sql.ErrNoRows
+func (s Store) CreateHold(ctx context.Context, r models.Reservation) (models.Reservation, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	err = moveCar(ctx, tx, r.CarID, models.StatusInStock, models.StatusReserved, r.Holder, "hold placed",
+		r.CreatedAt)
+	if err != nil {
+		_ = tx.Rollback()
+		return models.Reservation{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO reservation (id,car_id,holder,status,created_at,expires_at) "+
+		"VALUES(?,?,?,?,?,?)", r.ID.String(), r.CarID.String(), r.Holder, r.Status, r.CreatedAt, r.ExpiresAt)
+	if err != nil {
+		_ = tx.Rollback()
+		return models.Reservation{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.Reservation{}, err
+	}
+
+	return r, nil
+}
+
+// GetReservation store layer function to get a reservation by its id
+func (s Store) GetReservation(ctx context.Context, id string) (models.Reservation, error) {
+	return scan(s.db.QueryRowContext(ctx, "SELECT "+columns+" FROM reservation WHERE id=?", id))
+}
+
+// ExtendHold store layer function to move the expiry of an active hold which has not expired by now,
+// sql.ErrNoRows is returned otherwise
+func (s Store) ExtendHold(ctx context.Context, id string, expiresAt, now time.Time) error {
+	res, err := s.db.ExecContext(ctx, "UPDATE reservation SET expires_at=? WHERE id=? AND status=? AND expires_at>?",
+		expiresAt, id, models.ReservationActive, now)
+	if err != nil {
+		return err
+	}
+
+	return datastore.Affected(res)
+}
+
+// EndHold store layer function to end an active hold with r.Status, released or expired, and put the car back
+// in stock in one transaction. A hold only expires if its expiry has passed by r.EndedAt so that an extension
+// racing the sweeper wins, sql.ErrNoRows is returned when the hold can not be ended
+func (s Store) EndHold(ctx context.Context, r models.Reservation) error {
+	query := "UPDATE reservation SET status=?,ended_at=? WHERE id=? AND status=?"
+	args := []interface{}{r.Status, *r.EndedAt, r.ID.String(), models.ReservationActive}
+
+	if r.Status == models.ReservationExpired {
+		query += " AND expires_at<=?"
+		args = append(args, *r.EndedAt)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err == nil {
+		err = datastore.Affected(res)
+	}
+
+	if err == nil {
+		err = moveCar(ctx, tx, r.CarID, models.StatusReserved, models.StatusInStock, r.Holder,
+			"hold "+r.Status, *r.EndedAt)
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetExpiredHolds store layer function to get the active holds whose expiry has passed by now
+func (s Store) GetExpiredHolds(ctx context.Context, now time.Time) ([]models.Reservation, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+columns+" FROM reservation WHERE status=? AND expires_at<=? "+
+		"ORDER BY expires_at", models.ReservationActive, now)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var holds []models.Reservation
+
+	for rows.Next() {
+		r, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		holds = append(holds, r)
+	}
+
+	return holds, rows.Err()
+}
+
+// moveCar changes the status of a car which is still in from and records the transition in its status history
+func moveCar(ctx context.Context, tx *sql.Tx, carID uuid.UUID, from, to, actor, reason string, at time.Time) error {
+	return status.Move(ctx, tx, models.StatusChange{ID: uuid.New(), CarID: carID, From: from, To: to, Actor: actor,
+		Reason: reason, ChangedAt: at})
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner) (models.Reservation, error) {
+	var (
+		r     models.Reservation
+		ended sql.NullTime
+	)
+
+	err := row.Scan(&r.ID, &r.CarID, &r.Holder, &r.Status, &r.CreatedAt, &r.ExpiresAt, &ended)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	if ended.Valid {
+		r.EndedAt = &ended.Time
+	}
+
+	return r, nil
+}
@@ -0,0 +1,210 @@
+package reservation
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+const (
+	moveCarQuery  = "UPDATE Car SET status=? WHERE id=? AND status=?"
+	insertHistory = "INSERT INTO status_history (id,car_id,from_status,to_status,actor,reason,changed_at) " +
+		"VALUES(?,?,?,?,?,?,?)"
+)
+
+var columnNames = []string{"id", "car_id", "holder", "status", "created_at", "expires_at", "ended_at"}
+
+// TestCreateHold function to test store layer CreateHold function
+func TestCreateHold(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	r := models.Reservation{ID: uuid.New(), CarID: uuid.New(), Holder: "sahil", Status: models.ReservationActive,
+		CreatedAt: now, ExpiresAt: now.Add(48 * time.Hour)}
+	carID := r.CarID.String()
+
+	testCases := []struct {
+		desc   string
+		output models.Reservation
+		err    error
+	}{
+		{"success", r, nil},
+		{"car already held", models.Reservation{}, sql.ErrNoRows},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(moveCarQuery).WithArgs("reserved", carID, "in_stock").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertHistory).WithArgs(sqlmock.AnyArg(), carID, "in_stock", "reserved", "sahil", "hold placed",
+		now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO reservation (id,car_id,holder,status,created_at,expires_at) VALUES(?,?,?,?,?,?)").
+		WithArgs(r.ID.String(), carID, "sahil", "active", now, r.ExpiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(moveCarQuery).WithArgs("reserved", carID, "in_stock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	for i, tc := range testCases {
+		res, err := a.CreateHold(context.TODO(), r)
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if res != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestEndHold function to test store layer EndHold function
+func TestEndHold(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	now := time.Date(2022, 3, 3, 10, 0, 0, 0, time.UTC)
+	released := models.Reservation{ID: uuid.New(), CarID: uuid.New(), Holder: "sahil",
+		Status: models.ReservationReleased, EndedAt: &now}
+	expired := released
+	expired.Status = models.ReservationExpired
+	carID := released.CarID.String()
+	update := "UPDATE reservation SET status=?,ended_at=? WHERE id=? AND status=?"
+
+	testCases := []struct {
+		desc  string
+		input models.Reservation
+		err   error
+	}{
+		{"released", released, nil},
+		{"expired", expired, nil},
+		{"extended meanwhile", expired, sql.ErrNoRows},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs("released", now, released.ID.String(), "active").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(moveCarQuery).WithArgs("in_stock", carID, "reserved").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertHistory).WithArgs(sqlmock.AnyArg(), carID, "reserved", "in_stock", "sahil",
+		"hold released", now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update+" AND expires_at<=?").WithArgs("expired", now, expired.ID.String(), "active", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(moveCarQuery).WithArgs("in_stock", carID, "reserved").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertHistory).WithArgs(sqlmock.AnyArg(), carID, "reserved", "in_stock", "sahil",
+		"hold expired", now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update+" AND expires_at<=?").WithArgs("expired", now, expired.ID.String(), "active", now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	for i, tc := range testCases {
+		err := a.EndHold(context.TODO(), tc.input)
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestExtendHold function to test store layer ExtendHold function
+func TestExtendHold(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	id := uuid.NewString()
+	now := time.Date(2022, 3, 2, 10, 0, 0, 0, time.UTC)
+	until := now.Add(24 * time.Hour)
+	query := "UPDATE reservation SET expires_at=? WHERE id=? AND status=? AND expires_at>?"
+
+	mock.ExpectExec(query).WithArgs(until, id, "active", now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(until, id, "active", now).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	for i, want := range []error{nil, sql.ErrNoRows} {
+		if err := a.ExtendHold(context.TODO(), id, until, now); err != want {
+			t.Errorf("\n[TEST %v] Failed \nGot %v\n Expected %v", i, err, want)
+		}
+	}
+}
+
+// TestGetHolds function to test store layer GetReservation and GetExpiredHolds functions
+func TestGetHolds(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	now := time.Date(2022, 3, 3, 10, 0, 0, 0, time.UTC)
+	ended := now.Add(-time.Hour)
+	r := models.Reservation{ID: uuid.New(), CarID: uuid.New(), Holder: "sahil", Status: models.ReservationReleased,
+		CreatedAt: now.Add(-48 * time.Hour), ExpiresAt: now, EndedAt: &ended}
+	active := models.Reservation{ID: uuid.New(), CarID: uuid.New(), Holder: "sahil",
+		Status: models.ReservationActive, CreatedAt: now.Add(-49 * time.Hour), ExpiresAt: now.Add(-time.Hour)}
+
+	mock.ExpectQuery("SELECT " + columns + " FROM reservation WHERE id=?").WithArgs(r.ID.String()).
+		WillReturnRows(sqlmock.NewRows(columnNames).
+			AddRow(r.ID.String(), r.CarID.String(), r.Holder, r.Status, r.CreatedAt, r.ExpiresAt, ended))
+	mock.ExpectQuery("SELECT " + columns + " FROM reservation WHERE id=?").WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT "+columns+" FROM reservation WHERE status=? AND expires_at<=? ORDER BY expires_at").
+		WithArgs("active", now).WillReturnRows(sqlmock.NewRows(columnNames).
+		AddRow(active.ID.String(), active.CarID.String(), active.Holder, active.Status, active.CreatedAt,
+			active.ExpiresAt, nil))
+
+	res, err := a.GetReservation(context.TODO(), r.ID.String())
+	if err != nil || !reflect.DeepEqual(res, r) {
+		t.Errorf("\n[TEST 0] Failed \nGot %v, %v\n Expected %v", res, err, r)
+	}
+
+	if _, err = a.GetReservation(context.TODO(), "missing"); err != sql.ErrNoRows {
+		t.Errorf("\n[TEST 1] Failed \nGot %v\n Expected %v", err, sql.ErrNoRows)
+	}
+
+	holds, err := a.GetExpiredHolds(context.TODO(), now)
+	if err != nil || !reflect.DeepEqual(holds, []models.Reservation{active}) {
+		t.Errorf("\n[TEST 2] Failed \nGot %v, %v\n Expected %v", holds, err, active)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,15 @@
+package datastore
+
+import "database/sql"
+
+// Affected returns sql.ErrNoRows when a statement matched no rows, for updates which only apply to a row still in
+// the state they expect. The driver counts matched rows through ClientFoundRows, so an update which leaves a row as
+// it was is not taken for a missing row
+func Affected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err == nil && n == 0 {
+		err = sql.ErrNoRows
+	}
+
+	return err
+}
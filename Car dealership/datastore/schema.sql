@@ -1,6 +1,6 @@
 USE CarDealership;
 
-Drop table if exists car, engine;
+Drop table if exists car, car_trim, car_model, brand, powertrain, car_media, warranty_component, warranty, maintenance_part, maintenance_record, service_appointment, appraisal, depreciation_curve, lender_rate, test_drive, order_payment, order_fee, sales_order, invoice_sequence, sales_lead, customer, reservation, status_history, price_history, engine;
 
 create table car (
                      id varchar(36) NOT NULL,
@@ -9,7 +9,17 @@ create table car (
                      brand varchar(50) NOT NULL,
                      fuel varchar(50)NOT null,
                      engineId varchar(36) NOT NULL,
-                     PRIMARY KEY (id)
+                     vin varchar(17),
+                     msrp bigint NOT NULL DEFAULT 0,
+                     list_price bigint NOT NULL DEFAULT 0,
+                     cost bigint NOT NULL DEFAULT 0,
+                     currency char(3),
+                     status varchar(20) NOT NULL DEFAULT 'in_stock',
+                     trim_id varchar(36),
+                     PRIMARY KEY (id),
+                     UNIQUE KEY uq_car_vin (vin),
+                     KEY idx_car_status (status),
+                     KEY idx_car_trim (trim_id)
 );
 
 create table engine(
@@ -18,4 +28,302 @@ create table engine(
                        noOfCylinders int,
                        engineRange int,
                        PRIMARY KEY (engineId)
-);
\ No newline at end of file
+);
+
+-- amounts are in minor units, hundredths of the currency
+create table price_history(
+                       id varchar(36) NOT NULL,
+                       car_id varchar(36) NOT NULL,
+                       msrp bigint NOT NULL,
+                       list_price bigint NOT NULL,
+                       cost bigint NOT NULL,
+                       currency char(3) NOT NULL,
+                       actor varchar(100) NOT NULL,
+                       reason varchar(255) NOT NULL,
+                       changed_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       KEY idx_price_history_car (car_id, changed_at)
+);
+
+create table status_history(
+                       id varchar(36) NOT NULL,
+                       car_id varchar(36) NOT NULL,
+                       from_status varchar(20) NOT NULL,
+                       to_status varchar(20) NOT NULL,
+                       actor varchar(100) NOT NULL,
+                       reason varchar(255) NOT NULL,
+                       changed_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       KEY idx_status_history_car (car_id, changed_at)
+);
+
+create table reservation(
+                       id varchar(36) NOT NULL,
+                       car_id varchar(36) NOT NULL,
+                       holder varchar(100) NOT NULL,
+                       status varchar(20) NOT NULL,
+                       created_at datetime NOT NULL,
+                       expires_at datetime NOT NULL,
+                       ended_at datetime NULL,
+                       PRIMARY KEY (id),
+                       KEY idx_reservation_car (car_id),
+                       KEY idx_reservation_expiry (status, expires_at)
+);
+
+create table customer(
+                       id varchar(36) NOT NULL,
+                       name varchar(100) NOT NULL,
+                       email varchar(255) NOT NULL,
+                       phone varchar(20) NOT NULL DEFAULT '',
+                       created_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       UNIQUE KEY uq_customer_email (email)
+);
+
+create table sales_lead(
+                       id varchar(36) NOT NULL,
+                       car_id varchar(36) NOT NULL,
+                       customer_id varchar(36) NOT NULL,
+                       status varchar(20) NOT NULL DEFAULT 'new',
+                       salesperson varchar(100) NOT NULL DEFAULT '',
+                       source varchar(50) NOT NULL DEFAULT '',
+                       notes text NOT NULL,
+                       created_at datetime NOT NULL,
+                       updated_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       KEY idx_sales_lead_status (status, salesperson),
+                       KEY idx_sales_lead_car (car_id),
+                       KEY idx_sales_lead_customer (customer_id)
+);
+
+create table invoice_sequence(
+                       dealership varchar(10) NOT NULL,
+                       last_number bigint NOT NULL,
+                       PRIMARY KEY (dealership)
+);
+
+create table sales_order(
+                       id varchar(36) NOT NULL,
+                       dealership varchar(10) NOT NULL,
+                       invoice_number varchar(20) NOT NULL,
+                       car_id varchar(36) NOT NULL,
+                       customer_id varchar(36) NOT NULL,
+                       salesperson varchar(100) NOT NULL,
+                       price bigint NOT NULL,
+                       currency char(3) NOT NULL,
+                       tax_rate int NOT NULL DEFAULT 0,
+                       tax bigint NOT NULL DEFAULT 0,
+                       total bigint NOT NULL,
+                       paid bigint NOT NULL DEFAULT 0,
+                       status varchar(20) NOT NULL DEFAULT 'open',
+                       notes text NOT NULL,
+                       created_at datetime NOT NULL,
+                       updated_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       UNIQUE KEY uq_sales_order_invoice (invoice_number),
+                       KEY idx_sales_order_car (car_id),
+                       KEY idx_sales_order_customer (customer_id),
+                       KEY idx_sales_order_status (status, created_at)
+);
+
+create table order_fee(
+                       order_id varchar(36) NOT NULL,
+                       position int NOT NULL,
+                       description varchar(255) NOT NULL,
+                       amount bigint NOT NULL,
+                       PRIMARY KEY (order_id, position)
+);
+
+create table order_payment(
+                       id varchar(36) NOT NULL,
+                       order_id varchar(36) NOT NULL,
+                       amount bigint NOT NULL,
+                       method varchar(50) NOT NULL,
+                       reference varchar(100) NOT NULL DEFAULT '',
+                       received_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       KEY idx_order_payment_order (order_id, received_at)
+);
+
+create table test_drive(
+                       id varchar(36) NOT NULL,
+                       car_id varchar(36) NOT NULL,
+                       customer_id varchar(36) NOT NULL,
+                       salesperson varchar(100) NOT NULL,
+                       start_at datetime NOT NULL,
+                       end_at datetime NOT NULL,
+                       status varchar(20) NOT NULL DEFAULT 'scheduled',
+                       notes text NOT NULL,
+                       sequence int NOT NULL DEFAULT 0,
+                       created_at datetime NOT NULL,
+                       updated_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       KEY idx_test_drive_car (car_id, start_at),
+                       KEY idx_test_drive_salesperson (salesperson, start_at)
+);
+
+create table lender_rate(
+                       lender varchar(100) NOT NULL,
+                       tier varchar(50) NOT NULL,
+                       min_term int NOT NULL,
+                       max_term int NOT NULL,
+                       apr int NOT NULL,
+                       PRIMARY KEY (lender, tier, min_term)
+);
+
+create table depreciation_curve(
+                       brand varchar(50) NOT NULL,
+                       fuel_type varchar(20) NOT NULL,
+                       currency char(3) NOT NULL,
+                       base_value bigint NOT NULL,
+                       value_per_litre bigint NOT NULL DEFAULT 0,
+                       retention varchar(255) NOT NULL,
+                       mileage_per_year int NOT NULL,
+                       mileage_rate int NOT NULL DEFAULT 0,
+                       PRIMARY KEY (brand, fuel_type)
+);
+
+create table appraisal(
+                       id varchar(36) NOT NULL,
+                       customer_id varchar(36) NOT NULL,
+                       brand varchar(50) NOT NULL,
+                       name varchar(100) NOT NULL,
+                       year int NOT NULL,
+                       fuel_type varchar(20) NOT NULL,
+                       displacement bigint NOT NULL DEFAULT 0,
+                       mileage int NOT NULL,
+                       vehicle_condition varchar(20) NOT NULL,
+                       currency char(3) NOT NULL,
+                       new_value bigint NOT NULL,
+                       age int NOT NULL,
+                       retention int NOT NULL,
+                       mileage_adjustment int NOT NULL,
+                       condition_adjustment int NOT NULL,
+                       value bigint NOT NULL,
+                       low bigint NOT NULL,
+                       high bigint NOT NULL,
+                       appraiser varchar(100) NOT NULL,
+                       notes text NOT NULL,
+                       created_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       KEY idx_appraisal_customer (customer_id, created_at)
+);
+
+create table service_appointment(
+                       id varchar(36) NOT NULL,
+                       car_id varchar(36) NOT NULL,
+                       customer_id varchar(36) NOT NULL,
+                       scheduled_at datetime NOT NULL,
+                       work text NOT NULL,
+                       status varchar(20) NOT NULL DEFAULT 'booked',
+                       created_at datetime NOT NULL,
+                       updated_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       KEY idx_service_appointment_car (car_id, scheduled_at)
+);
+
+create table maintenance_record(
+                       id varchar(36) NOT NULL,
+                       car_id varchar(36) NOT NULL,
+                       appointment_id varchar(36) NULL,
+                       work text NOT NULL,
+                       labour_hours decimal(6,2) NOT NULL DEFAULT 0,
+                       odometer int NOT NULL,
+                       technician varchar(100) NOT NULL,
+                       completed_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       UNIQUE KEY uq_maintenance_record_appointment (appointment_id),
+                       KEY idx_maintenance_record_car (car_id, completed_at)
+);
+
+create table maintenance_part(
+                       record_id varchar(36) NOT NULL,
+                       position int NOT NULL,
+                       number varchar(50) NOT NULL,
+                       description varchar(255) NOT NULL,
+                       quantity int NOT NULL,
+                       PRIMARY KEY (record_id, position)
+);
+
+create table warranty(
+                       id varchar(36) NOT NULL,
+                       car_id varchar(36) NOT NULL,
+                       kind varchar(20) NOT NULL,
+                       provider varchar(100) NOT NULL,
+                       start_date date NOT NULL,
+                       duration_months int NOT NULL,
+                       mileage_limit int NOT NULL DEFAULT 0,
+                       expires_at date NOT NULL,
+                       created_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       KEY idx_warranty_car (car_id, start_date),
+                       KEY idx_warranty_expires (expires_at)
+);
+
+create table warranty_component(
+                       warranty_id varchar(36) NOT NULL,
+                       component varchar(20) NOT NULL,
+                       PRIMARY KEY (warranty_id, component)
+);
+
+create table car_media(
+                       id varchar(36) NOT NULL,
+                       car_id varchar(36) NOT NULL,
+                       position int NOT NULL,
+                       is_primary boolean NOT NULL DEFAULT false,
+                       file_name varchar(255) NOT NULL,
+                       content_type varchar(50) NOT NULL,
+                       size bigint NOT NULL,
+                       width int NOT NULL,
+                       height int NOT NULL,
+                       created_at datetime NOT NULL,
+                       PRIMARY KEY (id),
+                       KEY idx_car_media_car (car_id, position)
+);
+
+-- battery and charge in kWh and kW, fuel consumption in litres per 100 km and efficiency in Wh per km
+create table powertrain(
+                       engine_id varchar(36) NOT NULL,
+                       type varchar(20) NOT NULL,
+                       battery_kwh double NOT NULL DEFAULT 0,
+                       charging_standards varchar(100) NOT NULL DEFAULT '',
+                       max_charge_kw double NOT NULL DEFAULT 0,
+                       motor_power_kw int NOT NULL DEFAULT 0,
+                       fuel_consumption double NOT NULL DEFAULT 0,
+                       efficiency int NOT NULL DEFAULT 0,
+                       PRIMARY KEY (engine_id)
+);
+
+-- aliases are a comma separated list of alternative spellings
+create table brand(
+                       id varchar(36) NOT NULL,
+                       name varchar(50) NOT NULL,
+                       aliases varchar(255) NOT NULL DEFAULT '',
+                       PRIMARY KEY (id),
+                       UNIQUE KEY uq_brand_name (name)
+);
+
+create table car_model(
+                       id varchar(36) NOT NULL,
+                       brand_id varchar(36) NOT NULL,
+                       name varchar(50) NOT NULL,
+                       PRIMARY KEY (id),
+                       UNIQUE KEY uq_car_model_name (brand_id, name),
+                       FOREIGN KEY (brand_id) REFERENCES brand (id)
+);
+
+-- engine_id is the default engine of the trim, inherited by cars created from it
+create table car_trim(
+                       id varchar(36) NOT NULL,
+                       model_id varchar(36) NOT NULL,
+                       name varchar(50) NOT NULL,
+                       fuel_type varchar(50) NOT NULL,
+                       engine_id varchar(36) NOT NULL,
+                       PRIMARY KEY (id),
+                       UNIQUE KEY uq_car_trim_name (model_id, name),
+                       FOREIGN KEY (model_id) REFERENCES car_model (id),
+                       FOREIGN KEY (engine_id) REFERENCES engine (engineId) ON DELETE RESTRICT
+);
+
+-- car is created before the catalogue, so the trim of a car is only referenced once car_trim exists
+ALTER TABLE car ADD FOREIGN KEY (trim_id) REFERENCES car_trim (id) ON DELETE RESTRICT;
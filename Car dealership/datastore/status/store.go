@@ -0,0 +1,85 @@
+package status
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// Transition store layer function to move a car from change.From to change.To and record the transition,
+// both in one transaction. The update only applies while the car is still in change.From, otherwise
+// sql.ErrNoRows is returned so that concurrent transitions of the same car cannot both succeed
+func (s Store) Transition(ctx context.Context, change models.StatusChange) (models.StatusChange, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.StatusChange{}, err
+	}
+
+	if err = Move(ctx, tx, change); err != nil {
+		_ = tx.Rollback()
+		return models.StatusChange{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.StatusChange{}, err
+	}
+
+	return change, nil
+}
+
+// Move moves a car which is still in change.From to change.To and records the transition in its status history
+// as part of tx, sql.ErrNoRows is returned when the car is no longer in change.From. Stores which change the
+// status of a car together with their own rows, such as holds and orders, move it with Move.
+func Move(ctx context.Context, tx *sql.Tx, change models.StatusChange) error {
+	res, err := tx.ExecContext(ctx, "UPDATE Car SET status=? WHERE id=? AND status=?",
+		change.To, change.CarID.String(), change.From)
+	if err != nil {
+		return err
+	}
+
+	if err = datastore.Affected(res); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO status_history (id,car_id,from_status,to_status,actor,reason,changed_at) "+
+		"VALUES(?,?,?,?,?,?,?)", change.ID.String(), change.CarID.String(), change.From, change.To, change.Actor,
+		change.Reason, change.ChangedAt)
+
+	return err
+}
+
+// GetStatusHistory store layer function to get every status transition of a car, oldest first
+func (s Store) GetStatusHistory(ctx context.Context, carID string) ([]models.StatusChange, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id,car_id,from_status,to_status,actor,reason,changed_at "+
+		"FROM status_history WHERE car_id=? ORDER BY changed_at,id", carID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	changes := []models.StatusChange{}
+
+	for rows.Next() {
+		var c models.StatusChange
+
+		err = rows.Scan(&c.ID, &c.CarID, &c.From, &c.To, &c.Actor, &c.Reason, &c.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
@@ -0,0 +1,124 @@
+package status
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+const (
+	update        = "UPDATE Car SET status=? WHERE id=? AND status=?"
+	insertHistory = "INSERT INTO status_history (id,car_id,from_status,to_status,actor,reason,changed_at) " +
+		"VALUES(?,?,?,?,?,?,?)"
+)
+
+// TestTransition function to test store layer Transition function
+func TestTransition(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	id := uuid.New()
+	change := models.StatusChange{ID: uuid.New(), CarID: id, From: models.StatusInStock, To: models.StatusSold,
+		Actor: "sahil", Reason: "cash sale", ChangedAt: time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC)}
+	insertErr := errors.New("insert failed")
+
+	testCases := []struct {
+		desc   string
+		output models.StatusChange
+		err    error
+	}{
+		{"success", change, nil},
+		{"status changed concurrently", models.StatusChange{}, sql.ErrNoRows},
+		{"history not written", models.StatusChange{}, insertErr},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs("sold", id.String(), "in_stock").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertHistory).WithArgs(change.ID.String(), id.String(), "in_stock", "sold", "sahil", "cash sale",
+		change.ChangedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs("sold", id.String(), "in_stock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).WithArgs("sold", id.String(), "in_stock").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insertHistory).WillReturnError(insertErr)
+	mock.ExpectRollback()
+
+	for i, tc := range testCases {
+		res, err := a.Transition(context.TODO(), change)
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if res != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestGetStatusHistory function to test store layer GetStatusHistory function
+func TestGetStatusHistory(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	id := uuid.New()
+	changeID := uuid.New()
+	at := time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC)
+	queryErr := errors.New("query error")
+
+	testCases := []struct {
+		desc   string
+		output []models.StatusChange
+		err    error
+	}{
+		{desc: "success", output: []models.StatusChange{{ID: changeID, CarID: id, From: "in_transit", To: "in_stock",
+			Actor: "yard", ChangedAt: at}}},
+		{desc: "query error", err: queryErr},
+	}
+
+	query := "SELECT id,car_id,from_status,to_status,actor,reason,changed_at " +
+		"FROM status_history WHERE car_id=? ORDER BY changed_at,id"
+
+	mock.ExpectQuery(query).WithArgs(id.String()).WillReturnRows(sqlmock.NewRows([]string{"id", "car_id",
+		"from_status", "to_status", "actor", "reason", "changed_at"}).
+		AddRow(changeID.String(), id.String(), "in_transit", "in_stock", "yard", "", at))
+	mock.ExpectQuery(query).WithArgs(id.String()).WillReturnError(queryErr)
+
+	for i, tc := range testCases {
+		res, err := a.GetStatusHistory(context.TODO(), id.String())
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package testdrive
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+const columns = "id,car_id,customer_id,salesperson,start_at,end_at,status,notes,sequence,created_at,updated_at"
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// ScheduleTestDrive store layer function to book a test drive unless it overlaps a scheduled test drive of the
+// same car or the same salesperson, the overlapping test drives are returned instead. The overlap is read with a
+// locking read in the same transaction as the insert, so that InnoDB's next-key locks keep a concurrent booking
+// of the same period from slipping in between.
+func (s Store) ScheduleTestDrive(ctx context.Context, d models.TestDrive) ([]models.TestDrive, error) {
+	return s.save(ctx, d, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO test_drive ("+columns+") VALUES(?,?,?,?,?,?,?,?,?,?,?)",
+			d.ID.String(), d.CarID.String(), d.CustomerID.String(), d.Salesperson, d.Start, d.End, d.Status, d.Notes,
+			d.Sequence, d.CreatedAt, d.UpdatedAt)
+
+		return err
+	})
+}
+
+// RescheduleTestDrive store layer function to move a scheduled test drive to d.Start and d.End with d.Salesperson,
+// the overlapping test drives are returned like ScheduleTestDrive does. sql.ErrNoRows is returned when the test
+// drive is no longer scheduled.
+func (s Store) RescheduleTestDrive(ctx context.Context, d models.TestDrive) ([]models.TestDrive, error) {
+	return s.save(ctx, d, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, "UPDATE test_drive SET salesperson=?,start_at=?,end_at=?,sequence=?,"+
+			"updated_at=? WHERE id=? AND status=?", d.Salesperson, d.Start, d.End, d.Sequence, d.UpdatedAt,
+			d.ID.String(), models.TestDriveScheduled)
+		if err != nil {
+			return err
+		}
+
+		return datastore.Affected(res)
+	})
+}
+
+// CancelTestDrive store layer function to cancel a scheduled test drive, sql.ErrNoRows is returned when it is
+// no longer scheduled
+func (s Store) CancelTestDrive(ctx context.Context, d models.TestDrive) error {
+	res, err := s.db.ExecContext(ctx, "UPDATE test_drive SET status=?,sequence=?,updated_at=? WHERE id=? AND status=?",
+		models.TestDriveCancelled, d.Sequence, d.UpdatedAt, d.ID.String(), models.TestDriveScheduled)
+	if err != nil {
+		return err
+	}
+
+	return datastore.Affected(res)
+}
+
+// GetTestDrive store layer function to get a test drive by its id
+func (s Store) GetTestDrive(ctx context.Context, id string) (models.TestDrive, error) {
+	return scan(s.db.QueryRowContext(ctx, "SELECT "+columns+" FROM test_drive WHERE id=?", id))
+}
+
+// GetTestDrives store layer function to get the test drives passing the filter ordered by their start
+func (s Store) GetTestDrives(ctx context.Context, filter models.TestDriveFilter) ([]models.TestDrive, error) {
+	where, args := whereClause(filter)
+
+	rows, err := s.db.QueryContext(ctx, "SELECT "+columns+" FROM test_drive"+where+" ORDER BY start_at,id", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return collect(rows)
+}
+
+// save runs write in a transaction after checking that d does not overlap another scheduled test drive
+func (s Store) save(ctx context.Context, d models.TestDrive,
+	write func(tx *sql.Tx) error) ([]models.TestDrive, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT "+columns+" FROM test_drive WHERE status=? AND start_at<? AND end_at>? "+
+		"AND (car_id=? OR salesperson=?) AND id<>? ORDER BY start_at,id FOR UPDATE", models.TestDriveScheduled, d.End,
+		d.Start, d.CarID.String(), d.Salesperson, d.ID.String())
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	conflicts, err := collect(rows)
+	if err == nil && len(conflicts) == 0 {
+		err = write(tx)
+	}
+
+	if err != nil || len(conflicts) > 0 {
+		_ = tx.Rollback()
+		return conflicts, err
+	}
+
+	return nil, tx.Commit()
+}
+
+func whereClause(filter models.TestDriveFilter) (string, []interface{}) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+
+	if filter.CarID != "" {
+		conds = append(conds, "car_id=?")
+		args = append(args, filter.CarID)
+	}
+
+	if filter.Salesperson != "" {
+		conds = append(conds, "salesperson=?")
+		args = append(args, filter.Salesperson)
+	}
+
+	if filter.Status != "" {
+		conds = append(conds, "status=?")
+		args = append(args, filter.Status)
+	}
+
+	if !filter.To.IsZero() {
+		conds = append(conds, "start_at<?")
+		args = append(args, filter.To)
+	}
+
+	if !filter.From.IsZero() {
+		conds = append(conds, "end_at>?")
+		args = append(args, filter.From)
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func collect(rows *sql.Rows) ([]models.TestDrive, error) {
+	defer rows.Close()
+
+	drives := make([]models.TestDrive, 0)
+
+	for rows.Next() {
+		d, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		drives = append(drives, d)
+	}
+
+	return drives, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner) (models.TestDrive, error) {
+	var d models.TestDrive
+
+	err := row.Scan(&d.ID, &d.CarID, &d.CustomerID, &d.Salesperson, &d.Start, &d.End, &d.Status, &d.Notes,
+		&d.Sequence, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return models.TestDrive{}, err
+	}
+
+	return d, nil
+}
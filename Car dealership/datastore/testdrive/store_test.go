@@ -0,0 +1,180 @@
+package testdrive
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+const overlapQuery = "SELECT " + columns + " FROM test_drive WHERE status=? AND start_at<? AND end_at>? " +
+	"AND (car_id=? OR salesperson=?) AND id<>? ORDER BY start_at,id FOR UPDATE"
+
+var columnNames = []string{"id", "car_id", "customer_id", "salesperson", "start_at", "end_at", "status", "notes",
+	"sequence", "created_at", "updated_at"}
+
+var now = time.Date(2022, 3, 1, 8, 0, 0, 0, time.UTC)
+
+// TestScheduleTestDrive function to test store layer ScheduleTestDrive function only books free periods
+func TestScheduleTestDrive(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	d := models.TestDrive{ID: uuid.New(), CarID: uuid.New(), CustomerID: uuid.New(), Salesperson: "ravi",
+		Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour), Status: models.TestDriveScheduled,
+		CreatedAt: now, UpdatedAt: now}
+	other := models.TestDrive{ID: uuid.New(), CarID: uuid.New(), CustomerID: uuid.New(), Salesperson: "ravi",
+		Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour), Status: models.TestDriveScheduled,
+		CreatedAt: now, UpdatedAt: now}
+	other.CarID = d.CarID
+	insert := "INSERT INTO test_drive (" + columns + ") VALUES(?,?,?,?,?,?,?,?,?,?,?)"
+	overlapArgs := []driver.Value{"scheduled", d.End, d.Start, d.CarID.String(), "ravi", d.ID.String()}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(overlapQuery).WithArgs(overlapArgs...).WillReturnRows(sqlmock.NewRows(columnNames))
+	mock.ExpectExec(insert).WithArgs(d.ID.String(), d.CarID.String(), d.CustomerID.String(), "ravi", d.Start, d.End,
+		"scheduled", "", 0, now, now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(overlapQuery).WithArgs(overlapArgs...).
+		WillReturnRows(sqlmock.NewRows(columnNames).AddRow(other.ID.String(), other.CarID.String(),
+			other.CustomerID.String(), other.Salesperson, other.Start, other.End, other.Status, other.Notes,
+			other.Sequence, other.CreatedAt, other.UpdatedAt))
+	mock.ExpectRollback()
+
+	testCases := []struct {
+		desc      string
+		conflicts []models.TestDrive
+	}{
+		{"free", nil},
+		{"car booked", []models.TestDrive{other}},
+	}
+
+	for i, tc := range testCases {
+		conflicts, err := a.ScheduleTestDrive(context.TODO(), d)
+		if err != nil || !reflect.DeepEqual(conflicts, tc.conflicts) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, conflicts, tc.conflicts)
+		}
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestRescheduleTestDrive function to test store layer RescheduleTestDrive and CancelTestDrive functions
+func TestRescheduleTestDrive(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	d := models.TestDrive{ID: uuid.New(), CarID: uuid.New(), CustomerID: uuid.New(), Salesperson: "ravi",
+		Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour), Status: models.TestDriveScheduled,
+		CreatedAt: now, UpdatedAt: now}
+	d.Sequence = 1
+	id := d.ID.String()
+	update := "UPDATE test_drive SET salesperson=?,start_at=?,end_at=?,sequence=?,updated_at=? WHERE id=? AND status=?"
+	cancel := "UPDATE test_drive SET status=?,sequence=?,updated_at=? WHERE id=? AND status=?"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(overlapQuery).WillReturnRows(sqlmock.NewRows(columnNames))
+	mock.ExpectExec(update).WithArgs("ravi", d.Start, d.End, 1, now, id, "scheduled").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(overlapQuery).WillReturnRows(sqlmock.NewRows(columnNames))
+	mock.ExpectExec(update).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	mock.ExpectExec(cancel).WithArgs("cancelled", 1, now, id, "scheduled").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(cancel).WithArgs("cancelled", 1, now, id, "scheduled").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	testCases := []struct {
+		desc string
+		run  func() error
+		err  error
+	}{
+		{"rescheduled", func() error { _, err := a.RescheduleTestDrive(context.TODO(), d); return err }, nil},
+		{"cancelled meanwhile", func() error { _, err := a.RescheduleTestDrive(context.TODO(), d); return err },
+			sql.ErrNoRows},
+		{"cancelled", func() error { return a.CancelTestDrive(context.TODO(), d) }, nil},
+		{"already cancelled", func() error { return a.CancelTestDrive(context.TODO(), d) }, sql.ErrNoRows},
+	}
+
+	for i, tc := range testCases {
+		if err := tc.run(); !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
+
+// TestGetTestDrives function to test store layer GetTestDrive and GetTestDrives functions
+func TestGetTestDrives(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	d := models.TestDrive{ID: uuid.New(), CarID: uuid.New(), CustomerID: uuid.New(), Salesperson: "ravi",
+		Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour), Status: models.TestDriveScheduled,
+		CreatedAt: now, UpdatedAt: now}
+	from := now
+	to := now.Add(24 * time.Hour)
+
+	mock.ExpectQuery("SELECT " + columns + " FROM test_drive WHERE id=?").WithArgs(d.ID.String()).
+		WillReturnRows(sqlmock.NewRows(columnNames).AddRow(d.ID.String(), d.CarID.String(), d.CustomerID.String(),
+			d.Salesperson, d.Start, d.End, d.Status, d.Notes, d.Sequence, d.CreatedAt, d.UpdatedAt))
+	mock.ExpectQuery("SELECT "+columns+" FROM test_drive WHERE car_id=? AND status=? AND start_at<? AND end_at>? "+
+		"ORDER BY start_at,id").WithArgs(d.CarID.String(), "scheduled", to, from).
+		WillReturnRows(sqlmock.NewRows(columnNames).AddRow(d.ID.String(), d.CarID.String(), d.CustomerID.String(),
+			d.Salesperson, d.Start, d.End, d.Status, d.Notes, d.Sequence, d.CreatedAt, d.UpdatedAt))
+	mock.ExpectQuery("SELECT " + columns + " FROM test_drive WHERE salesperson=? ORDER BY start_at,id").WithArgs("asha").
+		WillReturnRows(sqlmock.NewRows(columnNames))
+
+	res, err := a.GetTestDrive(context.TODO(), d.ID.String())
+	if err != nil || res != d {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "by id", res, d)
+	}
+
+	testCases := []struct {
+		desc   string
+		filter models.TestDriveFilter
+		output []models.TestDrive
+	}{
+		{"car on a day", models.TestDriveFilter{CarID: d.CarID.String(), Status: "scheduled", From: from, To: to},
+			[]models.TestDrive{d}},
+		{"salesperson", models.TestDriveFilter{Salesperson: "asha"}, []models.TestDrive{}},
+	}
+
+	for i, tc := range testCases {
+		drives, err := a.GetTestDrives(context.TODO(), tc.filter)
+		if err != nil || !reflect.DeepEqual(drives, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i+1, tc.desc, drives, tc.output)
+		}
+	}
+}
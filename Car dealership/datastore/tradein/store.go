@@ -0,0 +1,181 @@
+package tradein
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+const (
+	curveColumns = "brand,fuel_type,currency,base_value,value_per_litre,retention,mileage_per_year,mileage_rate"
+
+	columns = "id,customer_id,brand,name,year,fuel_type,displacement,mileage,vehicle_condition,currency,new_value," +
+		"age,retention,mileage_adjustment,condition_adjustment,value,low,high,appraiser,notes,created_at"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// FindCurve store layer function to get the depreciation curve of a brand and fuel type. A curve of the brand
+// for any fuel type comes next, then one of any brand for the fuel type and last the curve for every car.
+func (s Store) FindCurve(ctx context.Context, brand, fuelType string) (models.DepreciationCurve, error) {
+	return scanCurve(s.db.QueryRowContext(ctx, "SELECT "+curveColumns+" FROM depreciation_curve WHERE brand IN (?,?) "+
+		"AND fuel_type IN (?,?) ORDER BY brand=?,fuel_type=? LIMIT 1", brand, models.AnyMatch, fuelType,
+		models.AnyMatch, models.AnyMatch, models.AnyMatch))
+}
+
+// GetCurves store layer function to get every depreciation curve
+func (s Store) GetCurves(ctx context.Context) ([]models.DepreciationCurve, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+curveColumns+" FROM depreciation_curve ORDER BY brand,fuel_type")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	curves := []models.DepreciationCurve{}
+
+	for rows.Next() {
+		c, err := scanCurve(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		curves = append(curves, c)
+	}
+
+	return curves, rows.Err()
+}
+
+// SaveCurve store layer function to insert the depreciation curve of a brand and fuel type, or replace it
+func (s Store) SaveCurve(ctx context.Context, c models.DepreciationCurve) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO depreciation_curve ("+curveColumns+") VALUES(?,?,?,?,?,?,?,?) "+
+		"ON DUPLICATE KEY UPDATE currency=VALUES(currency),base_value=VALUES(base_value),"+
+		"value_per_litre=VALUES(value_per_litre),retention=VALUES(retention),"+
+		"mileage_per_year=VALUES(mileage_per_year),mileage_rate=VALUES(mileage_rate)",
+		c.Brand, c.FuelType, c.Currency, c.BaseValue, c.ValuePerLitre, joinRetention(c.Retention), c.MileagePerYear,
+		c.MileageRate)
+
+	return err
+}
+
+// CreateAppraisal store layer function to insert an appraisal
+func (s Store) CreateAppraisal(ctx context.Context, a models.Appraisal) (models.Appraisal, error) {
+	v, val := a.Vehicle, a.Valuation
+
+	_, err := s.db.ExecContext(ctx, "INSERT INTO appraisal ("+columns+") "+
+		"VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)", a.ID.String(), a.CustomerID.String(), v.Brand, v.Name,
+		v.Year, v.FuelType, v.Displacement, v.Mileage, v.Condition, val.Currency, val.NewValue, val.Age,
+		val.Retention, val.MileageAdjustment, val.ConditionAdjustment, val.Value, val.Low, val.High, a.Appraiser,
+		a.Notes, a.CreatedAt)
+	if err != nil {
+		return models.Appraisal{}, err
+	}
+
+	return a, nil
+}
+
+// GetAppraisal store layer function to get an appraisal by its id
+func (s Store) GetAppraisal(ctx context.Context, id string) (models.Appraisal, error) {
+	return scan(s.db.QueryRowContext(ctx, "SELECT "+columns+" FROM appraisal WHERE id=?", id))
+}
+
+// GetAppraisals store layer function to get the appraisals of a customer, newest first
+func (s Store) GetAppraisals(ctx context.Context, customerID string) ([]models.Appraisal, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+columns+" FROM appraisal WHERE customer_id=? "+
+		"ORDER BY created_at DESC,id", customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	appraisals := []models.Appraisal{}
+
+	for rows.Next() {
+		a, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		appraisals = append(appraisals, a)
+	}
+
+	return appraisals, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner) (models.Appraisal, error) {
+	var a models.Appraisal
+
+	v, val := &a.Vehicle, &a.Valuation
+
+	err := row.Scan(&a.ID, &a.CustomerID, &v.Brand, &v.Name, &v.Year, &v.FuelType, &v.Displacement, &v.Mileage,
+		&v.Condition, &val.Currency, &val.NewValue, &val.Age, &val.Retention, &val.MileageAdjustment,
+		&val.ConditionAdjustment, &val.Value, &val.Low, &val.High, &a.Appraiser, &a.Notes, &a.CreatedAt)
+	if err != nil {
+		return models.Appraisal{}, err
+	}
+
+	return a, nil
+}
+
+func scanCurve(row scanner) (models.DepreciationCurve, error) {
+	var (
+		c         models.DepreciationCurve
+		retention string
+	)
+
+	err := row.Scan(&c.Brand, &c.FuelType, &c.Currency, &c.BaseValue, &c.ValuePerLitre, &retention,
+		&c.MileagePerYear, &c.MileageRate)
+	if err != nil {
+		return models.DepreciationCurve{}, err
+	}
+
+	c.Retention, err = splitRetention(retention)
+	if err != nil {
+		return models.DepreciationCurve{}, err
+	}
+
+	return c, nil
+}
+
+// joinRetention stores the retention of a curve as a comma separated list of basis points
+func joinRetention(retention []int) string {
+	parts := make([]string, len(retention))
+	for i, r := range retention {
+		parts[i] = strconv.Itoa(r)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func splitRetention(s string) ([]int, error) {
+	retention := []int{}
+
+	if s == "" {
+		return retention, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		r, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+
+		retention = append(retention, r)
+	}
+
+	return retention, nil
+}
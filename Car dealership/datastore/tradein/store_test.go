@@ -0,0 +1,164 @@
+package tradein
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+var curveNames = strings.Split(curveColumns, ",")
+
+// TestFindCurve function to test store layer FindCurve function
+func TestFindCurve(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	curve := models.DepreciationCurve{Brand: "bmw", FuelType: "*", Currency: "USD", BaseValue: 3000000,
+		ValuePerLitre: 400000, Retention: []int{8000, 7000}, MileagePerYear: 15000, MileageRate: 300}
+	query := "SELECT " + curveColumns + " FROM depreciation_curve WHERE brand IN (?,?) AND fuel_type IN (?,?) " +
+		"ORDER BY brand=?,fuel_type=? LIMIT 1"
+
+	testCases := []struct {
+		desc   string
+		output models.DepreciationCurve
+		err    error
+	}{
+		{desc: "success", output: curve},
+		{desc: "no curve", err: sql.ErrNoRows},
+	}
+
+	mock.ExpectQuery(query).WithArgs("bmw", "*", "diesel", "*", "*", "*").WillReturnRows(sqlmock.NewRows(curveNames).
+		AddRow("bmw", "*", "USD", 3000000, 400000, "8000,7000", 15000, 300))
+	mock.ExpectQuery(query).WithArgs("bmw", "*", "diesel", "*", "*", "*").WillReturnError(sql.ErrNoRows)
+
+	for i, tc := range testCases {
+		res, err := a.FindCurve(context.TODO(), "bmw", "diesel")
+		if err != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
+
+// TestCurves function to test store layer GetCurves and SaveCurve functions
+func TestCurves(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	curve := models.DepreciationCurve{Brand: "*", FuelType: "*", Currency: "USD", BaseValue: 2500000,
+		Retention: []int{8500}, MileagePerYear: 12000, MileageRate: 250}
+	saveErr := errors.New("save failed")
+	save := "INSERT INTO depreciation_curve (" + curveColumns + ") VALUES(?,?,?,?,?,?,?,?) " +
+		"ON DUPLICATE KEY UPDATE currency=VALUES(currency),base_value=VALUES(base_value)," +
+		"value_per_litre=VALUES(value_per_litre),retention=VALUES(retention)," +
+		"mileage_per_year=VALUES(mileage_per_year),mileage_rate=VALUES(mileage_rate)"
+
+	mock.ExpectQuery("SELECT " + curveColumns + " FROM depreciation_curve ORDER BY brand,fuel_type").
+		WillReturnRows(sqlmock.NewRows(curveNames).AddRow("*", "*", "USD", 2500000, 0, "8500", 12000, 250))
+	mock.ExpectQuery("SELECT " + curveColumns + " FROM depreciation_curve ORDER BY brand,fuel_type").
+		WillReturnRows(sqlmock.NewRows(curveNames).AddRow("*", "*", "USD", 2500000, 0, "85%", 12000, 250))
+	mock.ExpectExec(save).WithArgs("*", "*", "USD", 2500000, 0, "8500", 12000, 250).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(save).WillReturnError(saveErr)
+
+	res, err := a.GetCurves(context.TODO())
+	if err != nil || !reflect.DeepEqual(res, []models.DepreciationCurve{curve}) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "curves", res, curve)
+	}
+
+	if _, err = a.GetCurves(context.TODO()); err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "malformed retention", err, "an error")
+	}
+
+	if err = a.SaveCurve(context.TODO(), curve); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "save", err, nil)
+	}
+
+	if err = a.SaveCurve(context.TODO(), curve); err != saveErr {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "save error", err, saveErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestAppraisals function to test store layer CreateAppraisal, GetAppraisal and GetAppraisals functions
+func TestAppraisals(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	a := New(db)
+
+	defer db.Close()
+
+	appraisal := models.Appraisal{ID: uuid.New(), CustomerID: uuid.New(), Appraiser: "sahil", Notes: "scratches",
+		Vehicle: models.TradeInVehicle{Brand: "Honda", Name: "City", Year: 2016, FuelType: "petrol",
+			Displacement: 1497, Mileage: 64000, Condition: models.ConditionGood},
+		Valuation: models.Valuation{Currency: "INR", NewValue: 120000000, Age: 6, Retention: 4200,
+			MileageAdjustment: 10000, ConditionAdjustment: 10000, Value: 50400000, Low: 46600000, High: 54200000},
+		CreatedAt: time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)}
+	values := []driver.Value{appraisal.ID.String(), appraisal.CustomerID.String(), "Honda", "City", 2016, "petrol",
+		1497, 64000, "good", "INR", 120000000, 6, 4200, 10000, 10000, 50400000, 46600000, 54200000, "sahil",
+		"scratches", appraisal.CreatedAt}
+	names := strings.Split(columns, ",")
+
+	mock.ExpectExec("INSERT INTO appraisal (" + columns + ") VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)").
+		WithArgs(values...).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT " + columns + " FROM appraisal WHERE id=?").WithArgs(appraisal.ID.String()).
+		WillReturnRows(sqlmock.NewRows(names).AddRow(values...))
+	mock.ExpectQuery("SELECT " + columns + " FROM appraisal WHERE customer_id=? ORDER BY created_at DESC,id").
+		WithArgs(appraisal.CustomerID.String()).WillReturnRows(sqlmock.NewRows(names).AddRow(values...))
+	mock.ExpectQuery("SELECT " + columns + " FROM appraisal WHERE id=?").WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	res, err := a.CreateAppraisal(context.TODO(), appraisal)
+	if err != nil || res != appraisal {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "create", res, appraisal)
+	}
+
+	res, err = a.GetAppraisal(context.TODO(), appraisal.ID.String())
+	if err != nil || res != appraisal {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "get", res, appraisal)
+	}
+
+	list, err := a.GetAppraisals(context.TODO(), appraisal.CustomerID.String())
+	if err != nil || !reflect.DeepEqual(list, []models.Appraisal{appraisal}) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "list", list, appraisal)
+	}
+
+	if _, err = a.GetAppraisal(context.TODO(), "missing"); err != sql.ErrNoRows {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "not found", err, sql.ErrNoRows)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
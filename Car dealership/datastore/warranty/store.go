@@ -0,0 +1,142 @@
+package warranty
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+const columns = "id,car_id,kind,provider,start_date,duration_months,mileage_limit,expires_at,created_at"
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) Store {
+	return Store{db: db}
+}
+
+// CreateWarranty store layer function to insert a warranty with the components it covers in one transaction
+func (s Store) CreateWarranty(ctx context.Context, w models.Warranty) (models.Warranty, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Warranty{}, err
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO warranty ("+columns+") VALUES(?,?,?,?,?,?,?,?,?)",
+		w.ID.String(), w.CarID.String(), w.Kind, w.Provider, w.StartDate, w.DurationMonths, w.MileageLimit, w.ExpiresAt, w.CreatedAt)
+
+	for i := 0; err == nil && i < len(w.Components); i++ {
+		_, err = tx.ExecContext(ctx, "INSERT INTO warranty_component (warranty_id,component) VALUES(?,?)",
+			w.ID.String(), w.Components[i])
+	}
+
+	if err != nil {
+		_ = tx.Rollback()
+		return models.Warranty{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return models.Warranty{}, err
+	}
+
+	return w, nil
+}
+
+// GetWarranty store layer function to get a warranty by its id
+func (s Store) GetWarranty(ctx context.Context, id string) (models.Warranty, error) {
+	warranties, err := s.get(ctx, "w.id=?", "w.id", id)
+	if err != nil {
+		return models.Warranty{}, err
+	}
+
+	if len(warranties) == 0 {
+		return models.Warranty{}, sql.ErrNoRows
+	}
+
+	return warranties[0], nil
+}
+
+// GetWarranties store layer function to get the warranties of a car, earliest start first
+func (s Store) GetWarranties(ctx context.Context, carID string) ([]models.Warranty, error) {
+	return s.get(ctx, "w.car_id=?", "w.start_date,w.id", carID)
+}
+
+// GetExpiring store layer function to get the warranties expiring from from up to but not including to, soonest
+// first
+func (s Store) GetExpiring(ctx context.Context, from, to time.Time) ([]models.Warranty, error) {
+	return s.get(ctx, "w.expires_at>=? AND w.expires_at<?", "w.expires_at,w.id", from, to)
+}
+
+// get selects the warranties matching where in order and attaches the components they cover
+func (s Store) get(ctx context.Context, where, order string, args ...interface{}) ([]models.Warranty, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+columns+" FROM warranty w WHERE "+where+" ORDER BY "+order, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	warranties := []models.Warranty{}
+	index := map[string]int{}
+
+	for rows.Next() {
+		w, err := scanWarranty(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		index[w.ID.String()] = len(warranties)
+		warranties = append(warranties, w)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(warranties) == 0 {
+		return warranties, nil
+	}
+
+	components, err := s.db.QueryContext(ctx, "SELECT c.warranty_id,c.component FROM warranty_component c "+
+		"JOIN warranty w ON w.id=c.warranty_id WHERE "+where+" ORDER BY c.warranty_id,c.component", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer components.Close()
+
+	for components.Next() {
+		var warrantyID, component string
+
+		if err = components.Scan(&warrantyID, &component); err != nil {
+			return nil, err
+		}
+
+		if i, ok := index[warrantyID]; ok {
+			warranties[i].Components = append(warranties[i].Components, component)
+		}
+	}
+
+	return warranties, components.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWarranty(row scanner) (models.Warranty, error) {
+	var w models.Warranty
+
+	err := row.Scan(&w.ID, &w.CarID, &w.Kind, &w.Provider, &w.StartDate, &w.DurationMonths, &w.MileageLimit,
+		&w.ExpiresAt, &w.CreatedAt)
+	if err != nil {
+		return models.Warranty{}, err
+	}
+
+	w.Components = []string{}
+
+	return w, nil
+}
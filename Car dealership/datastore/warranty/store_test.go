@@ -0,0 +1,130 @@
+package warranty
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+)
+
+// TestCreateWarranty function to test store layer CreateWarranty function
+func TestCreateWarranty(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	s := New(db)
+
+	defer db.Close()
+
+	start := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	w := models.Warranty{ID: uuid.New(), CarID: uuid.New(), Kind: "manufacturer", Provider: "BMW", StartDate: start,
+		DurationMonths: 36, MileageLimit: 100000, Components: []string{"engine", "powertrain"},
+		ExpiresAt: start.AddDate(3, 0, 0), CreatedAt: start}
+	insert := "INSERT INTO warranty (" + columns + ") VALUES(?,?,?,?,?,?,?,?,?)"
+	component := "INSERT INTO warranty_component (warranty_id,component) VALUES(?,?)"
+	args := []driver.Value{w.ID.String(), w.CarID.String(), "manufacturer", "BMW", start, 36, 100000, w.ExpiresAt, start}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insert).WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(component).WithArgs(w.ID.String(), "engine").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(component).WithArgs(w.ID.String(), "powertrain").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insert).WithArgs(args...).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(component).WithArgs(w.ID.String(), "engine").WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	testCases := []struct {
+		desc   string
+		output models.Warranty
+		err    bool
+	}{
+		{desc: "success", output: w},
+		{desc: "component error", err: true},
+	}
+
+	for i, tc := range testCases {
+		res, err := s.CreateWarranty(context.TODO(), w)
+
+		if (err != nil) != tc.err || !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestGetWarranties function to test store layer GetWarranty, GetWarranties and GetExpiring functions
+func TestGetWarranties(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+
+	s := New(db)
+
+	defer db.Close()
+
+	start := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	w := models.Warranty{ID: uuid.New(), CarID: uuid.New(), Kind: "extended", Provider: "CarCare", StartDate: start,
+		DurationMonths: 24, Components: []string{"engine", "powertrain"}, ExpiresAt: start.AddDate(2, 0, 0),
+		CreatedAt: start}
+	rows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(strings.Split(columns, ",")).AddRow(w.ID.String(), w.CarID.String(), "extended",
+			"CarCare", start, 24, 0, w.ExpiresAt, start)
+	}
+	components := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"warranty_id", "component"}).AddRow(w.ID.String(), "engine").
+			AddRow(w.ID.String(), "powertrain")
+	}
+	join := "SELECT c.warranty_id,c.component FROM warranty_component c JOIN warranty w ON w.id=c.warranty_id WHERE "
+	order := " ORDER BY c.warranty_id,c.component"
+	from, to := start.AddDate(2, 0, -30), start.AddDate(2, 0, 0).Add(time.Second)
+
+	mock.ExpectQuery("SELECT " + columns + " FROM warranty w WHERE w.id=? ORDER BY w.id").WithArgs(w.ID.String()).
+		WillReturnRows(rows())
+	mock.ExpectQuery(join + "w.id=?" + order).WithArgs(w.ID.String()).WillReturnRows(components())
+	mock.ExpectQuery("SELECT " + columns + " FROM warranty w WHERE w.id=? ORDER BY w.id").WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows(strings.Split(columns, ",")))
+	mock.ExpectQuery("SELECT " + columns + " FROM warranty w WHERE w.car_id=? ORDER BY w.start_date,w.id").
+		WithArgs(w.CarID.String()).WillReturnRows(rows())
+	mock.ExpectQuery(join + "w.car_id=?" + order).WithArgs(w.CarID.String()).WillReturnRows(components())
+	mock.ExpectQuery("SELECT "+columns+" FROM warranty w WHERE w.expires_at>=? AND w.expires_at<? "+
+		"ORDER BY w.expires_at,w.id").WithArgs(from, to).WillReturnError(errors.New("db error"))
+
+	res, err := s.GetWarranty(context.TODO(), w.ID.String())
+	if err != nil || !reflect.DeepEqual(res, w) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "get", res, w)
+	}
+
+	if _, err = s.GetWarranty(context.TODO(), "missing"); err != sql.ErrNoRows {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "not found", err, sql.ErrNoRows)
+	}
+
+	list, err := s.GetWarranties(context.TODO(), w.CarID.String())
+	if err != nil || !reflect.DeepEqual(list, []models.Warranty{w}) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "of a car", list, w)
+	}
+
+	if _, err = s.GetExpiring(context.TODO(), from, to); err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "expiring error", err, "db error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
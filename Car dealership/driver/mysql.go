@@ -13,6 +13,11 @@ func ConnectToSQL() *sql.DB {
 		Net:    "tcp",
 		Addr:   "127.0.0.1:3306",
 		DBName: "CarDealership",
+		// scan DATETIME columns such as price_history.changed_at into time.Time
+		ParseTime: true,
+		// report the rows an UPDATE matched rather than changed, so that datastore.Affected does not take an update
+		// to the values a row already holds for a missing row
+		ClientFoundRows: true,
 	}
 
 	// get a database handle
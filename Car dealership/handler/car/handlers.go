@@ -13,12 +13,16 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// NextCursorHeader holds the after cursor of the next page of /cars, it is left out on the last page
+const NextCursorHeader = "X-Next-Cursor"
+
 type handler struct {
-	service service.Cars
+	service  service.Cars
+	listings service.Listings
 }
 
-func New(c service.Cars) handler { //nolint
-	return handler{service: c}
+func New(c service.Cars, l service.Listings) handler { //nolint
+	return handler{service: c, listings: l}
 }
 
 // GetCarByID handler layer function to get car record by giving car id
@@ -53,7 +57,6 @@ func (c handler) GetCarByID(w http.ResponseWriter, r *http.Request) {
 func (c handler) GetCarByBrand(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	brand := r.URL.Query().Get("brand")
 	isEngine := r.URL.Query().Get("isEngine")
 
 	isEng, err := strconv.ParseBool(isEngine)
@@ -62,16 +65,38 @@ func (c handler) GetCarByBrand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := c.service.GetCarByBrand(ctx, brand, isEng)
+	filter, err := models.ParseCarFilter(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	page, err := models.ParsePage(r.URL.Query())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := c.listings.ListCars(ctx, filter, page, isEng)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		_, _ = w.Write([]byte("DB error"))
+
+		return
 	}
 
-	body, _ := json.Marshal(resp)
+	body, _ := json.Marshal(resp.Cars)
 
 	w.Header().Set("Content-Type", "application/json")
 
+	if next := resp.NextCursor(); next != "" {
+		w.Header().Set(NextCursorHeader, next)
+	}
+
 	_, _ = w.Write(body)
 	w.WriteHeader(http.StatusOK)
 }
@@ -65,7 +65,7 @@ func TestWrite(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mocks := service.NewMockCars(ctrl)
-	mockhandler := New(mocks)
+	mockhandler := New(mocks, nil)
 	id := uuid.New()
 
 	testCar := models.Car{
@@ -103,7 +103,7 @@ func TestGetByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockService := service.NewMockCars(ctrl)
-	s := New(mockService)
+	s := New(mockService, nil)
 
 	id1 := uuid.New()
 	id2 := uuid.New()
@@ -165,8 +165,8 @@ func TestGetByID(t *testing.T) {
 func TestCarGetbyBrand(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockService := service.NewMockCars(ctrl)
-	s := New(mockService)
+	mockListings := service.NewMockListings(ctrl)
+	s := New(nil, mockListings)
 
 	var (
 		id  = uuid.New()
@@ -190,13 +190,13 @@ func TestCarGetbyBrand(t *testing.T) {
 		mock       []*gomock.Call
 	}{
 		{desc: "success case", brand: "Tesla", isEngine: "true", statusCode: http.StatusOK,
-			mock: []*gomock.Call{mockService.EXPECT().GetCarByBrand(gomock.Any(), "Tesla", true).
-				Return(cars, nil)},
+			mock: []*gomock.Call{mockListings.EXPECT().ListCars(gomock.Any(), models.CarFilter{Brand: "Tesla"},
+				models.Page{Limit: models.DefaultPageLimit}, true).Return(models.CarPage{Cars: cars, Total: 2}, nil)},
 		},
 		{
 			desc: "error", brand: "Maruti", isEngine: "false", statusCode: http.StatusInternalServerError,
-			mock: []*gomock.Call{mockService.EXPECT().GetCarByBrand(gomock.Any(), "Maruti", false).
-				Return([]models.Car{}, errors.New("error"))},
+			mock: []*gomock.Call{mockListings.EXPECT().ListCars(gomock.Any(), models.CarFilter{Brand: "Maruti"},
+				models.Page{Limit: models.DefaultPageLimit}, false).Return(models.CarPage{}, errors.New("error"))},
 		},
 		{
 			desc: "error", brand: "Maruti", isEngine: "hello", statusCode: http.StatusBadRequest,
@@ -215,12 +215,105 @@ func TestCarGetbyBrand(t *testing.T) {
 	}
 }
 
+// TestCarGetbyBrandPrice handler layer test function to test the price range and status filters of GetbyBrand function
+func TestCarGetbyBrandPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockListings := service.NewMockListings(ctrl)
+	s := New(nil, mockListings)
+
+	cheap := models.Car{ID: uuid.New(), Name: "model 3", Brand: "Tesla", ListPrice: 4499000, Currency: "USD",
+		Status: models.StatusInStock}
+
+	testCases := []struct {
+		desc       string
+		query      string
+		statusCode int
+		filter     *models.CarFilter
+	}{
+		{"min and max price", "&minPrice=40000&maxPrice=50000.00&currency=USD", http.StatusOK,
+			&models.CarFilter{Brand: "Tesla", MinPrice: 4000000, MaxPrice: 5000000, Currency: "USD"}},
+		{"other currency", "&currency=EUR", http.StatusOK, &models.CarFilter{Brand: "Tesla", Currency: "EUR"}},
+		{"status", "&status=in_stock,reserved", http.StatusOK,
+			&models.CarFilter{Brand: "Tesla", Status: "in_stock,reserved"}},
+		{"price without currency", "&minPrice=50000", http.StatusBadRequest, nil},
+		{"malformed price", "&maxPrice=cheap&currency=USD", http.StatusBadRequest, nil},
+		{"unknown status", "&status=lost", http.StatusBadRequest, nil},
+	}
+
+	for _, tc := range testCases {
+		if tc.filter != nil {
+			mockListings.EXPECT().ListCars(gomock.Any(), *tc.filter, models.Page{Limit: models.DefaultPageLimit},
+				false).Return(models.CarPage{Cars: []models.Car{cheap}, Total: 1}, nil)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/cars?brand=Tesla&isEngine=false"+tc.query, nil)
+		res := httptest.NewRecorder()
+
+		s.GetCarByBrand(res, req)
+
+		assert.Equal(t, tc.statusCode, res.Code, tc.desc)
+
+		if tc.filter != nil {
+			var cars []models.Car
+
+			_ = json.Unmarshal(res.Body.Bytes(), &cars)
+			assert.Equal(t, []models.Car{cheap}, cars, tc.desc)
+		}
+	}
+}
+
+// TestCarGetbyBrandPage handler layer test function to test the limit and after parameters of GetbyBrand function
+func TestCarGetbyBrandPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockListings := service.NewMockListings(ctrl)
+	s := New(nil, mockListings)
+
+	first := models.Car{ID: uuid.New(), Name: "model 3", Brand: "Tesla"}
+	second := models.Car{ID: uuid.New(), Name: "model y", Brand: "Tesla"}
+
+	testCases := []struct {
+		desc       string
+		query      string
+		statusCode int
+		page       *models.Page
+		res        models.CarPage
+		next       string
+	}{
+		{"default limit", "", http.StatusOK, &models.Page{Limit: models.DefaultPageLimit},
+			models.CarPage{Cars: []models.Car{first}, Total: 1}, ""},
+		{"next page", "&limit=2", http.StatusOK, &models.Page{Limit: 2},
+			models.CarPage{Cars: []models.Car{first, second}, Total: 3, HasNext: true}, second.ID.String()},
+		{"after", "&limit=2&after=" + second.ID.String(), http.StatusOK,
+			&models.Page{After: second.ID.String(), Limit: 2}, models.CarPage{Cars: []models.Car{first}, Total: 3}, ""},
+		{"limit above the maximum", "&limit=101", http.StatusBadRequest, nil, models.CarPage{}, ""},
+		{"zero limit", "&limit=0", http.StatusBadRequest, nil, models.CarPage{}, ""},
+		{"after is not a cursor", "&after=first", http.StatusBadRequest, nil, models.CarPage{}, ""},
+	}
+
+	for _, tc := range testCases {
+		if tc.page != nil {
+			mockListings.EXPECT().ListCars(gomock.Any(), models.CarFilter{Brand: "Tesla"}, *tc.page, false).
+				Return(tc.res, nil)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/cars?brand=Tesla&isEngine=false"+tc.query, nil)
+		res := httptest.NewRecorder()
+
+		s.GetCarByBrand(res, req)
+
+		assert.Equal(t, tc.statusCode, res.Code, tc.desc)
+		assert.Equal(t, tc.next, res.Header().Get(NextCursorHeader), tc.desc)
+	}
+}
+
 // TestCreateCar handler layer test function to test handler layer Create function
 func TestCreateCar(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockService := service.NewMockCars(ctrl)
-	s := New(mockService)
+	s := New(mockService, nil)
 
 	id := uuid.New()
 	car := models.Car{ID: id,
@@ -268,7 +361,7 @@ func TestUpdateCar(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockService := service.NewMockCars(ctrl)
-	s := New(mockService)
+	s := New(mockService, nil)
 
 	id := uuid.New()
 	car := models.Car{
@@ -320,7 +413,7 @@ func TestDeleteCar(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockService := service.NewMockCars(ctrl)
-	s := New(mockService)
+	s := New(mockService, nil)
 
 	id1 := uuid.New()
 	id2 := uuid.New()
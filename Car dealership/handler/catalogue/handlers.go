@@ -0,0 +1,305 @@
+package catalogue
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/catalogue"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.Catalogue
+}
+
+func New(s service.Catalogue) handler { //nolint
+	return handler{service: s}
+}
+
+// CreateBrand handler layer function to add the brand in the body to the catalogue
+func (h handler) CreateBrand(w http.ResponseWriter, r *http.Request) {
+	var b models.Brand
+
+	if !decode(w, r, &b) {
+		return
+	}
+
+	resp, err := h.service.CreateBrand(r.Context(), b)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetBrands handler layer function to list the brands in the catalogue
+func (h handler) GetBrands(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetBrands(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetBrand handler layer function to get a brand by its id
+func (h handler) GetBrand(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetBrand(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// UpdateBrand handler layer function to replace the name and aliases of a brand with those in the body
+func (h handler) UpdateBrand(w http.ResponseWriter, r *http.Request) {
+	var b models.Brand
+
+	if !decode(w, r, &b) {
+		return
+	}
+
+	resp, err := h.service.UpdateBrand(r.Context(), mux.Vars(r)["id"], b)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// DeleteBrand handler layer function to delete a brand without models
+func (h handler) DeleteBrand(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteBrand(r.Context(), mux.Vars(r)["id"]); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	_, _ = w.Write([]byte("deleted"))
+}
+
+// CreateModel handler layer function to add the model in the body to a brand
+func (h handler) CreateModel(w http.ResponseWriter, r *http.Request) {
+	var m models.CarModel
+
+	if !decode(w, r, &m) {
+		return
+	}
+
+	resp, err := h.service.CreateModel(r.Context(), mux.Vars(r)["id"], m)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetModels handler layer function to list the models of a brand
+func (h handler) GetModels(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetModels(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetModel handler layer function to get a model by its id
+func (h handler) GetModel(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetModel(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// UpdateModel handler layer function to rename a model to the name in the body
+func (h handler) UpdateModel(w http.ResponseWriter, r *http.Request) {
+	var m models.CarModel
+
+	if !decode(w, r, &m) {
+		return
+	}
+
+	resp, err := h.service.UpdateModel(r.Context(), mux.Vars(r)["id"], m)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// DeleteModel handler layer function to delete a model without trims
+func (h handler) DeleteModel(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteModel(r.Context(), mux.Vars(r)["id"]); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	_, _ = w.Write([]byte("deleted"))
+}
+
+// CreateTrim handler layer function to add the trim in the body to a model
+func (h handler) CreateTrim(w http.ResponseWriter, r *http.Request) {
+	var t models.Trim
+
+	if !decode(w, r, &t) {
+		return
+	}
+
+	resp, err := h.service.CreateTrim(r.Context(), mux.Vars(r)["id"], t)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetTrims handler layer function to list the trims of a model
+func (h handler) GetTrims(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetTrims(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetTrim handler layer function to get a trim by its id
+func (h handler) GetTrim(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetTrim(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// UpdateTrim handler layer function to replace a trim with the one in the body
+func (h handler) UpdateTrim(w http.ResponseWriter, r *http.Request) {
+	var t models.Trim
+
+	if !decode(w, r, &t) {
+		return
+	}
+
+	resp, err := h.service.UpdateTrim(r.Context(), mux.Vars(r)["id"], t)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// DeleteTrim handler layer function to delete a trim no car was created from
+func (h handler) DeleteTrim(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteTrim(r.Context(), mux.Vars(r)["id"]); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	_, _ = w.Write([]byte("deleted"))
+}
+
+// MigrateBrands handler layer function to rewrite the brands of existing cars to the catalogue names, the dryRun
+// and createMissing query parameters are booleans
+func (h handler) MigrateBrands(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var dryRun, createMissing bool
+
+	for _, o := range []struct {
+		name string
+		dst  *bool
+	}{
+		{"dryRun", &dryRun},
+		{"createMissing", &createMissing},
+	} {
+		v := query.Get(o.name)
+		if v == "" {
+			continue
+		}
+
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(o.name + " must be true or false"))
+
+			return
+		}
+
+		*o.dst = b
+	}
+
+	resp, err := h.service.MigrateBrands(r.Context(), dryRun, createMissing)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// decode reads the JSON body into v, answering 400 when it can not
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return false
+	}
+
+	return true
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, catalogue.ErrBrandNotFound), errors.Is(err, catalogue.ErrModelNotFound),
+		errors.Is(err, catalogue.ErrTrimNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, catalogue.ErrInvalidCatalogue):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, catalogue.ErrDuplicate), errors.Is(err, catalogue.ErrInUse):
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,162 @@
+package catalogue
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/catalogue"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+// TestBrands handler layer test function to test handler layer CreateBrand, GetBrands, GetBrand, UpdateBrand and
+// DeleteBrand functions
+func TestBrands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockCatalogue(ctrl)
+	h := New(mockService)
+
+	const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+	brand := models.Brand{Name: "BMW", Aliases: []string{"bmw"}}
+
+	testCases := []struct {
+		desc       string
+		handler    http.HandlerFunc
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "create", handler: h.CreateBrand, body: `{"Name":"BMW","Aliases":["bmw"]}`,
+			statusCode: http.StatusCreated,
+			mock:       mockService.EXPECT().CreateBrand(gomock.Any(), brand).Return(brand, nil)},
+		{desc: "create bad body", handler: h.CreateBrand, body: `{"Name":`, statusCode: http.StatusBadRequest},
+		{desc: "create duplicate", handler: h.CreateBrand, body: `{"Name":"B.M.W."}`, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().CreateBrand(gomock.Any(), models.Brand{Name: "B.M.W."}).
+				Return(models.Brand{}, catalogue.ErrDuplicate)},
+		{desc: "list", handler: h.GetBrands, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetBrands(gomock.Any()).Return([]models.Brand{brand}, nil)},
+		{desc: "get not found", handler: h.GetBrand, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetBrand(gomock.Any(), id).Return(models.Brand{}, catalogue.ErrBrandNotFound)},
+		{desc: "update invalid", handler: h.UpdateBrand, body: `{"Name":""}`, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().UpdateBrand(gomock.Any(), id, models.Brand{}).
+				Return(models.Brand{}, catalogue.ErrInvalidCatalogue)},
+		{desc: "delete in use", handler: h.DeleteBrand, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().DeleteBrand(gomock.Any(), id).Return(catalogue.ErrInUse)},
+		{desc: "delete error", handler: h.DeleteBrand, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().DeleteBrand(gomock.Any(), id).Return(errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/catalogue/brands/"+id, strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		tc.handler(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestModelsAndTrims handler layer test function to test the handler layer functions of models and trims
+func TestModelsAndTrims(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockCatalogue(ctrl)
+	h := New(mockService)
+
+	const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+	trim := models.Trim{Name: "GTI", FuelType: "petrol", Engine: models.Engine{Displacement: 1984}}
+
+	testCases := []struct {
+		desc       string
+		handler    http.HandlerFunc
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "create model", handler: h.CreateModel, body: `{"Name":"Golf"}`, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().CreateModel(gomock.Any(), id, models.CarModel{Name: "Golf"}).
+				Return(models.CarModel{Name: "Golf"}, nil)},
+		{desc: "models of unknown brand", handler: h.GetModels, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetModels(gomock.Any(), id).Return(nil, catalogue.ErrBrandNotFound)},
+		{desc: "get model", handler: h.GetModel, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetModel(gomock.Any(), id).Return(models.CarModel{Name: "Golf"}, nil)},
+		{desc: "update model", handler: h.UpdateModel, body: `{"Name":"Golf"}`, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().UpdateModel(gomock.Any(), id, models.CarModel{Name: "Golf"}).
+				Return(models.CarModel{Name: "Golf"}, nil)},
+		{desc: "delete model", handler: h.DeleteModel, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().DeleteModel(gomock.Any(), id).Return(nil)},
+		{desc: "create trim", handler: h.CreateTrim, statusCode: http.StatusCreated,
+			body: `{"Name":"GTI","FuelType":"petrol","Engine":{"Displacement":1984}}`,
+			mock: mockService.EXPECT().CreateTrim(gomock.Any(), id, trim).Return(trim, nil)},
+		{desc: "create trim bad body", handler: h.CreateTrim, body: `[]`, statusCode: http.StatusBadRequest},
+		{desc: "trims of unknown model", handler: h.GetTrims, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetTrims(gomock.Any(), id).Return(nil, catalogue.ErrModelNotFound)},
+		{desc: "get trim", handler: h.GetTrim, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetTrim(gomock.Any(), id).Return(trim, nil)},
+		{desc: "update trim duplicate", handler: h.UpdateTrim, body: `{"Name":"GTI"}`, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().UpdateTrim(gomock.Any(), id, models.Trim{Name: "GTI"}).
+				Return(models.Trim{}, catalogue.ErrDuplicate)},
+		{desc: "delete trim in use", handler: h.DeleteTrim, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().DeleteTrim(gomock.Any(), id).Return(catalogue.ErrInUse)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/catalogue/models/"+id, strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		tc.handler(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestMigrateBrands handler layer test function to test handler layer MigrateBrands function
+func TestMigrateBrands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockCatalogue(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		query      string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "migrate", query: "", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().MigrateBrands(gomock.Any(), false, false).Return(models.BrandMigration{}, nil)},
+		{desc: "dry run creating missing", query: "?dryRun=true&createMissing=1", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().MigrateBrands(gomock.Any(), true, true).
+				Return(models.BrandMigration{DryRun: true}, nil)},
+		{desc: "dryRun not a boolean", query: "?dryRun=maybe", statusCode: http.StatusBadRequest},
+		{desc: "error", query: "?createMissing=false", statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().MigrateBrands(gomock.Any(), false, false).
+				Return(models.BrandMigration{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/catalogue/migrations/brands"+tc.query, nil)
+		res := httptest.NewRecorder()
+
+		h.MigrateBrands(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package compare
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/compare"
+)
+
+type handler struct {
+	service service.Comparer
+}
+
+func New(s service.Comparer) handler { //nolint
+	return handler{service: s}
+}
+
+// Compare handler layer function to compare the cars whose ids are given in the query, either comma separated in
+// ids or as repeated id parameters
+func (h handler) Compare(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	ids := query["id"]
+
+	for _, list := range query["ids"] {
+		ids = append(ids, strings.Split(list, ",")...)
+	}
+
+	resp, err := h.service.Compare(r.Context(), ids)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, compare.ErrCarNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, compare.ErrInvalidComparison):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,54 @@
+package compare
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/compare"
+
+	"github.com/golang/mock/gomock"
+)
+
+// TestCompare handler layer test function to test handler layer Compare function
+func TestCompare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockComparer(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		query      string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "comma separated", query: "?ids=a,b,c", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Compare(gomock.Any(), []string{"a", "b", "c"}).Return(models.Comparison{}, nil)},
+		{desc: "repeated", query: "?id=a&id=b", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Compare(gomock.Any(), []string{"a", "b"}).Return(models.Comparison{}, nil)},
+		{desc: "invalid", query: "?ids=a", statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Compare(gomock.Any(), []string{"a"}).
+				Return(models.Comparison{}, compare.ErrInvalidComparison)},
+		{desc: "not found", query: "?ids=a,b", statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Compare(gomock.Any(), []string{"a", "b"}).
+				Return(models.Comparison{}, compare.ErrCarNotFound)},
+		{desc: "error", query: "?ids=c,d", statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Compare(gomock.Any(), []string{"c", "d"}).
+				Return(models.Comparison{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/cars/compare"+tc.query, nil)
+		res := httptest.NewRecorder()
+
+		h.Compare(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package customer
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/customer"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.Customers
+}
+
+func New(s service.Customers) handler { //nolint
+	return handler{service: s}
+}
+
+// CreateCustomer handler layer function to create a customer from the body
+func (h handler) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	var c models.Customer
+
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.CreateCustomer(r.Context(), c)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetCustomer handler layer function to get a customer by its id
+func (h handler) GetCustomer(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetCustomer(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, customer.ErrCustomerNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, customer.ErrInvalidCustomer):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, customer.ErrDuplicateEmail):
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
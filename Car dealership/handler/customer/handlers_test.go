@@ -0,0 +1,91 @@
+package customer
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/customer"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// TestCreateCustomer handler layer test function to test handler layer CreateCustomer function
+func TestCreateCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockCustomers(ctrl)
+	h := New(mockService)
+
+	c := models.Customer{Name: "Sahil", Email: "sahil@example.com"}
+	body := `{"Name":"Sahil","Email":"sahil@example.com"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().CreateCustomer(gomock.Any(), c).Return(c, nil)},
+		{desc: "malformed body", body: `{"Name":`, statusCode: http.StatusBadRequest},
+		{desc: "invalid", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().CreateCustomer(gomock.Any(), c).
+				Return(models.Customer{}, customer.ErrInvalidCustomer)},
+		{desc: "duplicate email", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().CreateCustomer(gomock.Any(), c).
+				Return(models.Customer{}, customer.ErrDuplicateEmail)},
+		{desc: "error", body: body, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().CreateCustomer(gomock.Any(), c).Return(models.Customer{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/customers", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		h.CreateCustomer(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetCustomer handler layer test function to test handler layer GetCustomer function
+func TestGetCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockCustomers(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetCustomer(gomock.Any(), id).Return(models.Customer{}, nil)},
+		{desc: "not found", statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetCustomer(gomock.Any(), id).
+				Return(models.Customer{}, customer.ErrCustomerNotFound)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/customers/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.GetCustomer(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
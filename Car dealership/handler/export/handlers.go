@@ -0,0 +1,110 @@
+package export
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/export"
+)
+
+var contentTypes = map[string]string{
+	export.FormatCSV:    "text/csv",
+	export.FormatNDJSON: "application/x-ndjson",
+	export.FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+type handler struct {
+	service service.Exporter
+}
+
+func New(e service.Exporter) handler { //nolint
+	return handler{service: e}
+}
+
+// Export handler layer function to stream the cars matching the /cars filters as a file download
+func (h handler) Export(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	filter, err := models.ParseCarFilter(query)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	opts := models.ExportOptions{
+		Format: strings.ToLower(query.Get("format")),
+		Filter: filter,
+	}
+
+	if opts.Format == "" {
+		opts.Format = export.FormatCSV
+	}
+
+	if isEngine := query.Get("isEngine"); isEngine != "" {
+		isEng, err := strconv.ParseBool(isEngine)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		opts.IsEngine = isEng
+	}
+
+	if columns := query.Get("columns"); columns != "" {
+		opts.Columns = strings.Split(columns, ",")
+	}
+
+	w.Header().Set("Content-Type", contentTypes[opts.Format])
+	w.Header().Set("Content-Disposition", `attachment; filename="cars.`+opts.Format+`"`)
+
+	tw := &trackingWriter{ResponseWriter: w}
+
+	err = h.service.Export(ctx, tw, opts)
+	if err == nil {
+		return
+	}
+
+	log.Println(err)
+
+	// once rows were sent the status can no longer change, the client sees a truncated file
+	if tw.written {
+		return
+	}
+
+	w.Header().Del("Content-Disposition")
+	w.Header().Set("Content-Type", "text/plain")
+
+	if errors.Is(err, export.ErrUnknownFormat) || errors.Is(err, export.ErrUnknownColumn) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+// trackingWriter records whether any part of the body has been sent
+type trackingWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (t *trackingWriter) Write(b []byte) (int, error) {
+	t.written = true
+	return t.ResponseWriter.Write(b)
+}
+
+func (t *trackingWriter) Flush() {
+	if f, ok := t.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
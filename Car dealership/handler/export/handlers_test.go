@@ -0,0 +1,72 @@
+package export
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/export"
+
+	"github.com/golang/mock/gomock"
+)
+
+// TestExport handler layer test function to test handler layer Export function
+func TestExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockExporter(ctrl)
+	h := New(mockService)
+
+	write := func(_ interface{}, w io.Writer, _ models.ExportOptions) error {
+		_, err := w.Write([]byte("id\n"))
+		return err
+	}
+
+	testCases := []struct {
+		desc        string
+		query       string
+		statusCode  int
+		contentType string
+		mock        *gomock.Call
+	}{
+		{desc: "default csv", query: "?brand=Tesla&isEngine=true", statusCode: http.StatusOK,
+			contentType: "text/csv",
+			mock: mockService.EXPECT().Export(gomock.Any(), gomock.Any(), models.ExportOptions{Format: export.FormatCSV,
+				Filter: models.CarFilter{Brand: "Tesla"}, IsEngine: true}).DoAndReturn(write)},
+		{desc: "selected columns", query: "?format=ndjson&columns=id,name", statusCode: http.StatusOK,
+			contentType: "application/x-ndjson",
+			mock: mockService.EXPECT().Export(gomock.Any(), gomock.Any(), models.ExportOptions{
+				Format: export.FormatNDJSON, Columns: []string{"id", "name"}}).DoAndReturn(write)},
+		{desc: "price range", query: "?minPrice=20000&maxPrice=30000.50&currency=EUR", statusCode: http.StatusOK,
+			contentType: "text/csv",
+			mock: mockService.EXPECT().Export(gomock.Any(), gomock.Any(), models.ExportOptions{Format: export.FormatCSV,
+				Filter: models.CarFilter{MinPrice: 2000000, MaxPrice: 3000050, Currency: "EUR"}}).DoAndReturn(write)},
+		{desc: "invalid price", query: "?minPrice=20k", statusCode: http.StatusBadRequest},
+		{desc: "invalid isEngine", query: "?isEngine=maybe", statusCode: http.StatusBadRequest},
+		{desc: "unknown format", query: "?format=pdf", statusCode: http.StatusBadRequest, contentType: "text/plain",
+			mock: mockService.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(export.ErrUnknownFormat)},
+		{desc: "store error", statusCode: http.StatusInternalServerError, contentType: "text/plain",
+			mock: mockService.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/cars/export"+tc.query, nil)
+		res := httptest.NewRecorder()
+
+		h.Export(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+
+		if tc.contentType != "" && res.Header().Get("Content-Type") != tc.contentType {
+			t.Errorf("%v: Expected Content-Type: %v, Got: %v", tc.desc, tc.contentType, res.Header().Get("Content-Type"))
+		}
+	}
+}
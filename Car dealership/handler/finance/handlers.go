@@ -0,0 +1,119 @@
+package finance
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/finance"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.Finance
+}
+
+func New(s service.Finance) handler { //nolint
+	return handler{service: s}
+}
+
+// Quote handler layer function to quote the loan given in the body with its amortization schedule
+func (h handler) Quote(w http.ResponseWriter, r *http.Request) {
+	var req models.LoanRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Quote(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Offers handler layer function to quote the loan given in the body with every lender, cheapest first
+func (h handler) Offers(w http.ResponseWriter, r *http.Request) {
+	var req models.LoanRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Offers(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetRates handler layer function to get the rate tables, of the lender given in the query or of every lender
+func (h handler) GetRates(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetRates(r.Context(), r.URL.Query().Get("lender"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// SetRates handler layer function to replace the rate table of a lender with the rates given in the body
+func (h handler) SetRates(w http.ResponseWriter, r *http.Request) {
+	var rates []models.LenderRate
+
+	if err := json.NewDecoder(r.Body).Decode(&rates); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.SetRates(r.Context(), mux.Vars(r)["lender"], rates)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, finance.ErrCarNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, finance.ErrInvalidLoan), errors.Is(err, finance.ErrInvalidRate),
+		errors.Is(err, finance.ErrNoRate):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
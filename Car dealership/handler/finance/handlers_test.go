@@ -0,0 +1,164 @@
+package finance
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/finance"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// TestQuote handler layer test function to test handler layer Quote function
+func TestQuote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockFinance(ctrl)
+	h := New(mockService)
+
+	req := models.LoanRequest{CarID: uuid.MustParse(id), DownPayment: 500000, APR: 699, TermMonths: 60}
+	body := `{"CarID":"` + id + `","DownPayment":"5000.00","APR":699,"TermMonths":60}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Quote(gomock.Any(), req).Return(models.LoanQuote{}, nil)},
+		{desc: "malformed body", body: `{"DownPayment":"5000.001"}`, statusCode: http.StatusBadRequest},
+		{desc: "invalid loan", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Quote(gomock.Any(), req).Return(models.LoanQuote{}, finance.ErrInvalidLoan)},
+		{desc: "no rate", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Quote(gomock.Any(), req).Return(models.LoanQuote{}, finance.ErrNoRate)},
+		{desc: "car not found", body: body, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Quote(gomock.Any(), req).Return(models.LoanQuote{}, finance.ErrCarNotFound)},
+		{desc: "error", body: body, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Quote(gomock.Any(), req).Return(models.LoanQuote{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		r := httptest.NewRequest(http.MethodPost, "/finance/quote", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		h.Quote(res, r)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestOffers handler layer test function to test handler layer Offers function
+func TestOffers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockFinance(ctrl)
+	h := New(mockService)
+
+	req := models.LoanRequest{Price: 2000000, Currency: "USD", TermMonths: 60, Tier: "prime"}
+	body := `{"Price":"20000","Currency":"USD","TermMonths":60,"Tier":"prime"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Offers(gomock.Any(), req).Return([]models.LoanQuote{}, nil)},
+		{desc: "malformed body", body: `{"TermMonths":"sixty"}`, statusCode: http.StatusBadRequest},
+		{desc: "invalid loan", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Offers(gomock.Any(), req).Return(nil, finance.ErrInvalidLoan)},
+	}
+
+	for _, tc := range testCases {
+		r := httptest.NewRequest(http.MethodPost, "/finance/offers", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		h.Offers(res, r)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetRates handler layer test function to test handler layer GetRates function
+func TestGetRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockFinance(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		query      string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "one lender", query: "?lender=acme", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetRates(gomock.Any(), "acme").Return([]models.LenderRate{}, nil)},
+		{desc: "every lender", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetRates(gomock.Any(), "").Return([]models.LenderRate{}, nil)},
+		{desc: "error", statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().GetRates(gomock.Any(), "").Return(nil, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		r := httptest.NewRequest(http.MethodGet, "/finance/rates"+tc.query, nil)
+		res := httptest.NewRecorder()
+
+		h.GetRates(res, r)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestSetRates handler layer test function to test handler layer SetRates function
+func TestSetRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockFinance(ctrl)
+	h := New(mockService)
+
+	rates := []models.LenderRate{{Tier: "prime", MinTerm: 12, MaxTerm: 72, APR: 599}}
+	body := `[{"Tier":"prime","MinTerm":12,"MaxTerm":72,"APR":599}]`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().SetRates(gomock.Any(), "acme", rates).Return(rates, nil)},
+		{desc: "malformed body", body: `{"Tier":"prime"}`, statusCode: http.StatusBadRequest},
+		{desc: "invalid table", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().SetRates(gomock.Any(), "acme", rates).Return(nil, finance.ErrInvalidRate)},
+	}
+
+	for _, tc := range testCases {
+		r := httptest.NewRequest(http.MethodPut, "/finance/lenders/acme/rates", strings.NewReader(tc.body))
+		r = mux.SetURLVars(r, map[string]string{"lender": "acme"})
+		res := httptest.NewRecorder()
+
+		h.SetRates(res, r)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
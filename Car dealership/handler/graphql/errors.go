@@ -0,0 +1,33 @@
+package graphql
+
+import (
+	"errors"
+	"log"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/rpc"
+
+	"google.golang.org/grpc/codes"
+)
+
+// codedError is an error of the services with the code the gRPC API gives it, sent as the code extension
+type codedError struct {
+	error
+	code codes.Code
+}
+
+func (e codedError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code.String()}
+}
+
+// public returns an error of the services as it is sent to clients, the message of internal errors is logged
+// rather than sent
+func public(err error) error {
+	code := rpc.Code(err)
+
+	if code == codes.Internal {
+		log.Println(err)
+		return codedError{error: errors.New("internal error"), code: code}
+	}
+
+	return codedError{error: err, code: code}
+}
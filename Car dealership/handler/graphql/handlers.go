@@ -0,0 +1,128 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// maxBody bounds the size of a query sent with POST
+const maxBody = 1 << 20
+
+type handler struct {
+	schema  graphql.Schema
+	engines datastore.Engine
+	limits  Limits
+}
+
+func New(schema graphql.Schema, engines datastore.Engine, limits Limits) handler { //nolint
+	return handler{schema: schema, engines: engines, limits: limits}
+}
+
+// request is a GraphQL request, sent as JSON with POST or as the query, operationName and variables query
+// parameters with GET
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Query handler layer function to execute a GraphQL query or mutation. Queries which do not parse, are not valid
+// for the schema or are over the limits are refused with 400, mutations are only executed when sent with POST.
+func (h handler) Query(w http.ResponseWriter, r *http.Request) {
+	var req request
+
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+
+		if v := r.URL.Query().Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte("variables are not a JSON object"))
+
+				return
+			}
+		}
+	} else if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody)).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("body is not a GraphQL request"))
+
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		writeResult(w, http.StatusBadRequest, &graphql.Result{Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormatError(err)}})
+
+		return
+	}
+
+	if v := graphql.ValidateDocument(&h.schema, doc, nil); !v.IsValid {
+		writeResult(w, http.StatusBadRequest, &graphql.Result{Errors: v.Errors})
+		return
+	}
+
+	if err = h.limits.check(doc, req.Variables); err != nil {
+		writeResult(w, http.StatusBadRequest, &graphql.Result{Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormatError(err)}})
+
+		return
+	}
+
+	if op := operation(doc, req.OperationName); r.Method == http.MethodGet && op != nil &&
+		op.Operation == ast.OperationTypeMutation {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_, _ = w.Write([]byte("mutations are sent with POST"))
+
+		return
+	}
+
+	ctx := withLoader(r.Context(), newEngineLoader(r.Context(), h.engines))
+
+	writeResult(w, http.StatusOK, graphql.Execute(graphql.ExecuteParams{Schema: h.schema, AST: doc,
+		OperationName: req.OperationName, Args: req.Variables, Context: ctx}))
+}
+
+// operation returns the operation of the document with the name, or its only operation when no name is given
+func operation(doc *ast.Document, name string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+
+	for _, d := range doc.Definitions {
+		op, ok := d.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		if name == "" && found != nil {
+			return nil
+		}
+
+		if name == "" || (op.Name != nil && op.Name.Value == name) {
+			found = op
+		}
+	}
+
+	return found
+}
+
+func writeResult(w http.ResponseWriter, status int, res *graphql.Result) {
+	body, err := json.Marshal(res)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_, _ = w.Write(body)
+}
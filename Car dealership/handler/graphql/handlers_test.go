@@ -0,0 +1,283 @@
+package graphql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const listQuery = `query($after: String) {
+	cars(filter: {brand: "Volkswagen", status: "in_stock"}, first: 2, after: $after) {
+		totalCount
+		nodes { name listPrice engine { id range powertrain { type } } }
+		pageInfo { hasNextPage endCursor }
+	}
+}`
+
+func newHandler(t *testing.T, ctrl *gomock.Controller) (handler, *service.MockCars, *service.MockListings,
+	*datastore.MockEngine) {
+	mockCars := service.NewMockCars(ctrl)
+	mockListings := service.NewMockListings(ctrl)
+	mockEngine := datastore.NewMockEngine(ctrl)
+
+	schema, err := NewSchema(mockCars, mockListings, service.NewMockVINs(ctrl))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return New(schema, mockEngine, Limits{MaxDepth: 5, MaxComplexity: 100}), mockCars, mockListings, mockEngine
+}
+
+func post(h handler, body interface{}) *httptest.ResponseRecorder {
+	b, _ := json.Marshal(body)
+	res := httptest.NewRecorder()
+
+	h.Query(res, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(b))))
+
+	return res
+}
+
+// TestQuery handler layer test function to test queries are parsed, limited and executed
+func TestQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	h, mockCars, _, _ := newHandler(t, ctrl)
+
+	id := uuid.New().String()
+
+	testCases := []struct {
+		desc       string
+		method     string
+		target     string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "not found", method: http.MethodPost, body: `{"query":"{ car(id: \"` + id + `\") { name } }"}`,
+			statusCode: http.StatusOK,
+			mock:       mockCars.EXPECT().GetCarByID(gomock.Any(), id).Return(models.Car{}, sql.ErrNoRows)},
+		{desc: "get", method: http.MethodGet, target: "?query=" + url.QueryEscape(`{ car(id: "`+id+`") { name } }`),
+			statusCode: http.StatusOK,
+			mock:       mockCars.EXPECT().GetCarByID(gomock.Any(), id).Return(models.Car{Name: "Golf"}, nil)},
+		{desc: "mutation with get", method: http.MethodGet,
+			target:     "?query=" + url.QueryEscape(`mutation { deleteCar(id: "`+id+`") }`),
+			statusCode: http.StatusMethodNotAllowed},
+		{desc: "invalid body", method: http.MethodPost, body: `{"query":`, statusCode: http.StatusBadRequest},
+		{desc: "invalid variables", method: http.MethodGet, target: "?query=%7Bcar%7D&variables=1",
+			statusCode: http.StatusBadRequest},
+		{desc: "syntax error", method: http.MethodPost, body: `{"query":"{ car("}`,
+			statusCode: http.StatusBadRequest},
+		{desc: "unknown field", method: http.MethodPost, body: `{"query":"{ truck { name } }"}`,
+			statusCode: http.StatusBadRequest},
+		{desc: "too complex", method: http.MethodPost,
+			body:       `{"query":"{ cars(filter: {brand: \"VW\"}, first: 50) { nodes { id name } } }"}`,
+			statusCode: http.StatusBadRequest},
+		{desc: "too complex through variables", method: http.MethodPost,
+			body: `{"query":"query($n: Int) { cars(filter: {brand: \"VW\"}, first: $n) { nodes { id } } }",` +
+				`"variables":{"n":60}}`,
+			statusCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(tc.method, "/graphql"+tc.target, strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		h.Query(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+
+	mockCars.EXPECT().GetCarByID(gomock.Any(), id).Return(models.Car{}, errors.New("db password is hunter2"))
+	res := post(h, request{Query: `{ car(id: "` + id + `") { name } }`})
+
+	var result struct {
+		Errors []struct {
+			Message    string
+			Extensions map[string]interface{}
+		}
+	}
+
+	_ = json.Unmarshal(res.Body.Bytes(), &result)
+
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, "internal error", result.Errors[0].Message, "internal errors are not sent to clients")
+		assert.Equal(t, "Internal", result.Errors[0].Extensions["code"], "errors carry the code of the gRPC API")
+	}
+}
+
+// TestListCars handler layer test function to test cars are paginated and their engines read in one batch
+func TestListCars(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	h, _, mockListings, mockEngine := newHandler(t, ctrl)
+
+	combustion := models.Engine{EngineID: uuid.New(), Displacement: 1400, NoOfCylinder: 4, CarRange: 700}
+	electric := models.Engine{EngineID: uuid.New(), CarRange: 450, Powertrain: &models.Powertrain{Type: "bev"}}
+	cars := []models.Car{
+		{ID: uuid.New(), Name: "Golf", Status: "in_stock", ListPrice: 2399900,
+			Engine: models.Engine{EngineID: combustion.EngineID}},
+		{ID: uuid.New(), Name: "ID.3", Status: "in_stock", ListPrice: 3599900,
+			Engine: models.Engine{EngineID: electric.EngineID}},
+		{ID: uuid.New(), Name: "Passat", Status: "in_stock", Engine: models.Engine{EngineID: combustion.EngineID}},
+		{ID: uuid.New(), Name: "Caddy", Status: "in_stock"},
+	}
+	filter := models.CarFilter{Brand: "Volkswagen", Status: "in_stock"}
+
+	gomock.InOrder(
+		mockListings.EXPECT().ListCars(gomock.Any(), filter, models.Page{Limit: 2}, false).
+			Return(models.CarPage{Cars: cars[:2], Total: 4, HasNext: true}, nil),
+		mockEngine.EXPECT().EngineGetByIDs(gomock.Any(), []string{combustion.EngineID.String(),
+			electric.EngineID.String()}).Return([]models.Engine{electric, combustion}, nil),
+		mockListings.EXPECT().ListCars(gomock.Any(), filter, models.Page{After: cars[1].ID.String(), Limit: 2}, false).
+			Return(models.CarPage{Cars: cars[2:], Total: 4}, nil),
+		mockEngine.EXPECT().EngineGetByIDs(gomock.Any(), []string{combustion.EngineID.String()}).
+			Return(nil, errors.New("db error")),
+	)
+
+	type page struct {
+		Data struct {
+			Cars struct {
+				TotalCount int
+				Nodes      []struct {
+					Name      string
+					ListPrice string
+					Engine    *struct {
+						ID         string
+						Range      int
+						Powertrain *struct{ Type string }
+					}
+				}
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+			}
+		}
+		Errors []struct{ Message string }
+	}
+
+	var first, second page
+
+	_ = json.Unmarshal(post(h, request{Query: listQuery}).Body.Bytes(), &first)
+
+	cursor := first.Data.Cars.PageInfo.EndCursor
+	_ = json.Unmarshal(post(h, request{Query: listQuery, Variables: map[string]interface{}{"after": cursor}}).
+		Body.Bytes(), &second)
+
+	if assert.Len(t, first.Data.Cars.Nodes, 2) {
+		assert.Equal(t, 4, first.Data.Cars.TotalCount)
+		assert.Equal(t, "23999.00", first.Data.Cars.Nodes[0].ListPrice)
+		assert.Equal(t, combustion.EngineID.String(), first.Data.Cars.Nodes[0].Engine.ID)
+		assert.Equal(t, "bev", first.Data.Cars.Nodes[1].Engine.Powertrain.Type)
+		assert.True(t, first.Data.Cars.PageInfo.HasNextPage)
+	}
+
+	if assert.Len(t, second.Data.Cars.Nodes, 2) {
+		assert.Equal(t, "Passat", second.Data.Cars.Nodes[0].Name)
+		assert.Nil(t, second.Data.Cars.Nodes[0].Engine, "engines which failed to load are null")
+		assert.Nil(t, second.Data.Cars.Nodes[1].Engine, "cars without an engine have a null one")
+		assert.False(t, second.Data.Cars.PageInfo.HasNextPage)
+		assert.Len(t, second.Errors, 1)
+	}
+
+	res := post(h, request{Query: listQuery, Variables: map[string]interface{}{"after": "bm90IGEgY3Vyc29y"}})
+	assert.Contains(t, res.Body.String(), errInvalidCursor.Error())
+}
+
+// TestMutations handler layer test function to test cars are created, updated and deleted through the car service
+func TestMutations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	h, mockCars, _, mockEngine := newHandler(t, ctrl)
+
+	id, trimID := uuid.New(), uuid.New()
+	engine := models.Engine{EngineID: uuid.New(), CarRange: 450, Powertrain: &models.Powertrain{Type: "bev",
+		BatteryKWh: 77, ChargingStandards: []string{"ccs2"}, MaxChargeKW: 135}}
+	given := models.Car{Name: "ID.4", Year: 2022, Brand: "Volkswagen", FuelType: "Electric", TrimID: &trimID,
+		Engine: models.Engine{CarRange: 450, Powertrain: &models.Powertrain{Type: "bev", BatteryKWh: 77,
+			ChargingStandards: []string{"ccs2"}, MaxChargeKW: 135}}}
+	created := given
+	created.ID, created.Engine = id, engine
+
+	input := map[string]interface{}{"name": "ID.4", "year": 2022, "brand": "Volkswagen", "fuelType": "Electric",
+		"trimId": trimID.String(), "engine": map[string]interface{}{"range": 450, "powertrain": map[string]interface{}{
+			"type": "bev", "batteryKWh": 77, "chargingStandards": []string{"ccs2"}, "maxChargeKW": 135}}}
+
+	gomock.InOrder(
+		mockCars.EXPECT().CreateCar(gomock.Any(), &given).Return(created, nil),
+		mockEngine.EXPECT().EngineGetByIDs(gomock.Any(), []string{engine.EngineID.String()}).
+			Return([]models.Engine{engine}, nil),
+		mockCars.EXPECT().UpdateCar(gomock.Any(), id.String(), given).Return(models.Car{}, sql.ErrNoRows),
+		mockCars.EXPECT().DeleteCar(gomock.Any(), id.String()).Return(models.Car{}, nil),
+	)
+
+	testCases := []struct {
+		desc     string
+		query    string
+		expected string
+	}{
+		{"create", `mutation($input: CarInput!) { createCar(input: $input) { id engine { range } } }`,
+			`{"data":{"createCar":{"engine":{"range":450},"id":"` + id.String() + `"}}}`},
+		{"update missing", `mutation($id: ID!, $input: CarInput!) { updateCar(id: $id, input: $input) { id } }`,
+			`"code":"NotFound"`},
+		{"delete", `mutation($id: ID!) { deleteCar(id: $id) }`, `{"data":{"deleteCar":true}}`},
+	}
+
+	for i, tc := range testCases {
+		res := post(h, request{Query: tc.query, Variables: map[string]interface{}{"id": id.String(), "input": input}})
+
+		if !strings.Contains(res.Body.String(), tc.expected) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res.Body.String(), tc.expected)
+		}
+	}
+}
+
+// TestLimits test function to test queries are measured through fragments and paginated fields
+func TestLimits(t *testing.T) {
+	limits := Limits{MaxDepth: 4, MaxComplexity: 50}
+
+	testCases := []struct {
+		desc  string
+		query string
+		err   bool
+	}{
+		{"within limits", `{ cars(filter: {brand: "VW"}, first: 5) { nodes { engine { id } } } }`, false},
+		{"too deep", `{ cars(filter: {brand: "VW"}) { nodes { engine { powertrain { type } } } } }`, true},
+		{"too deep through a fragment", `{ cars(filter: {brand: "VW"}, first: 1) { nodes { engine { ...e } } } }
+			fragment e on Engine { powertrain { type } }`, true},
+		{"too complex by default page size", `{ cars(filter: {brand: "VW"}) { nodes { id name } } }`, true},
+		{"default of a variable", `query($n: Int = 2) { cars(filter: {brand: "VW"}, first: $n) { nodes { id name } } }`,
+			false},
+		{"too complex by the default of a variable",
+			`query($n: Int = 100) { cars(filter: {brand: "VW"}, first: $n) { nodes { id name } } }`, true},
+		{"introspection is not counted",
+			`{ __schema { types { name fields { name type { name ofType { name } } } } } }`, false},
+	}
+
+	for i, tc := range testCases {
+		doc, err := parser.Parse(parser.ParseParams{Source: tc.query})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = limits.check(doc, nil); (err != nil) != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Limits bound the queries which are executed, a query over either limit is refused before any of it is resolved.
+// The depth of a query is how deeply its fields nest. Its complexity counts one for every field, with the fields
+// under a paginated one counted once for every car of the page.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// check measures every operation of a validated document, fields of the introspection schema are not counted as
+// they are answered from the schema alone
+func (l Limits) check(doc *ast.Document, variables map[string]interface{}) error {
+	m := measure{fragments: make(map[string]*ast.FragmentDefinition), variables: variables}
+
+	for _, d := range doc.Definitions {
+		if f, ok := d.(*ast.FragmentDefinition); ok {
+			m.fragments[f.Name.Value] = f
+		}
+	}
+
+	for _, d := range doc.Definitions {
+		op, ok := d.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		m.defaults = make(map[string]ast.Value, len(op.VariableDefinitions))
+
+		for _, v := range op.VariableDefinitions {
+			if v.DefaultValue != nil {
+				m.defaults[v.Variable.Name.Value] = v.DefaultValue
+			}
+		}
+
+		depth, complexity := m.selectionSet(op.SelectionSet)
+
+		if depth > l.MaxDepth {
+			return fmt.Errorf("query depth %d is over the limit of %d", depth, l.MaxDepth)
+		}
+
+		if complexity > l.MaxComplexity {
+			return fmt.Errorf("query complexity %d is over the limit of %d", complexity, l.MaxComplexity)
+		}
+	}
+
+	return nil
+}
+
+// measure holds what the fields of an operation refer to, defaults are the default values of the variables of the
+// operation being measured, which stand in for the variables the request leaves out
+type measure struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	defaults  map[string]ast.Value
+}
+
+// selectionSet returns the depth and complexity of a selection set, validation has ruled out fragment cycles
+func (m measure) selectionSet(set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, s := range set.Selections {
+		var d, c int
+
+		switch s := s.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+
+			d, c = m.selectionSet(s.SelectionSet)
+			d, c = d+1, 1+c*m.pageSize(s)
+		case *ast.InlineFragment:
+			d, c = m.selectionSet(s.SelectionSet)
+		case *ast.FragmentSpread:
+			if f, ok := m.fragments[s.Name.Value]; ok {
+				d, c = m.selectionSet(f.SelectionSet)
+			}
+		}
+
+		if d > depth {
+			depth = d
+		}
+
+		complexity += c
+	}
+
+	return depth, complexity
+}
+
+// pageSize returns how many times the fields under a field are resolved, the first argument of a paginated field
+// or one. A first given by a variable is the value of the variable, or its default when the request leaves it out.
+func (m measure) pageSize(f *ast.Field) int {
+	for _, a := range f.Arguments {
+		if a.Name.Value != "first" {
+			continue
+		}
+
+		n := defaultFirst
+
+		switch v := a.Value.(type) {
+		case *ast.IntValue:
+			n, _ = strconv.Atoi(v.Value)
+		case *ast.Variable:
+			first, ok := m.variables[v.Name.Value]
+			if !ok {
+				if d, isInt := m.defaults[v.Name.Value].(*ast.IntValue); isInt {
+					first, _ = strconv.Atoi(d.Value)
+				}
+			}
+
+			switch first := first.(type) {
+			case float64:
+				n = int(first)
+			case int:
+				n = first
+			}
+		}
+
+		if n < 0 || n > maxFirst {
+			n = maxFirst
+		}
+
+		return n
+	}
+
+	if f.Name.Value == "cars" {
+		return defaultFirst
+	}
+
+	return 1
+}
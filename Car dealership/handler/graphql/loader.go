@@ -0,0 +1,94 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+type loaderKey struct{}
+
+// engineLoader batches the engine lookups of a request. The engine fields of a query are resolved to thunks which
+// the executor runs once every field at their depth has been resolved, the first of them reads every engine asked
+// for so far in one call to the store and the rest find theirs loaded.
+type engineLoader struct {
+	ctx     context.Context
+	engines datastore.Engine
+
+	mu      sync.Mutex
+	pending []string
+	loaded  map[string]*models.Engine
+	errs    map[string]error
+}
+
+func newEngineLoader(ctx context.Context, engines datastore.Engine) *engineLoader {
+	return &engineLoader{ctx: ctx, engines: engines, loaded: make(map[string]*models.Engine),
+		errs: make(map[string]error)}
+}
+
+func withLoader(ctx context.Context, l *engineLoader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+func loaderFrom(ctx context.Context) *engineLoader {
+	return ctx.Value(loaderKey{}).(*engineLoader)
+}
+
+// load queues the engine for the next batch unless it was queued before, and returns a thunk of it. Engines which
+// are not found, as cars without an engine, are null.
+func (l *engineLoader) load(id uuid.UUID) func() (interface{}, error) {
+	if id == uuid.Nil {
+		return func() (interface{}, error) { return nil, nil }
+	}
+
+	key := id.String()
+
+	l.mu.Lock()
+	if _, ok := l.loaded[key]; !ok {
+		l.loaded[key] = nil
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			l.batch()
+		}
+
+		if err := l.errs[key]; err != nil {
+			return nil, public(err)
+		}
+
+		if engine := l.loaded[key]; engine != nil {
+			return *engine, nil
+		}
+
+		return nil, nil
+	}
+}
+
+// batch reads the pending engines, an error is the error of each of them
+func (l *engineLoader) batch() {
+	ids := l.pending
+	l.pending = nil
+
+	engines, err := l.engines.EngineGetByIDs(l.ctx, ids)
+	if err != nil {
+		for _, id := range ids {
+			l.errs[id] = err
+		}
+
+		return
+	}
+
+	for i := range engines {
+		l.loaded[engines[i].EngineID.String()] = &engines[i]
+	}
+}
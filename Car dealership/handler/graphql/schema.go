@@ -0,0 +1,435 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+const (
+	// defaultFirst and maxFirst are the page sizes of cars when first is left out and at most
+	defaultFirst = models.DefaultPageLimit
+	maxFirst     = models.MaxPageLimit
+
+	cursorPrefix = "cursor:"
+)
+
+var errInvalidCursor = errors.New("after is not a cursor of the cars")
+
+// resolver resolves the fields of the schema through the same services as the car handlers, engines are read
+// through the loader of the request
+type resolver struct {
+	cars     service.Cars
+	listings service.Listings
+	vins     service.VINs
+}
+
+// NewSchema returns the schema of cars and engines served at /graphql
+func NewSchema(cars service.Cars, listings service.Listings, vins service.VINs) (graphql.Schema, error) {
+	r := resolver{cars: cars, listings: listings, vins: vins}
+
+	powertrainType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Powertrain",
+		Fields: graphql.Fields{
+			"type":              &graphql.Field{Type: graphql.String},
+			"batteryKWh":        &graphql.Field{Type: graphql.Float},
+			"chargingStandards": &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"maxChargeKW":       &graphql.Field{Type: graphql.Float},
+			"motorPowerKW":      &graphql.Field{Type: graphql.Int},
+			"fuelConsumption":   &graphql.Field{Type: graphql.Float},
+			"efficiency":        &graphql.Field{Type: graphql.Int},
+		},
+	})
+
+	engineType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Engine",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: func(p graphql.ResolveParams) (
+				interface{}, error) {
+				return p.Source.(models.Engine).EngineID.String(), nil
+			}},
+			"displacement": &graphql.Field{Type: graphql.Int},
+			"noOfCylinder": &graphql.Field{Type: graphql.Int},
+			"range": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(models.Engine).CarRange, nil
+			}},
+			"powertrain": &graphql.Field{Type: powertrainType},
+		},
+	})
+
+	carType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Car",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: func(p graphql.ResolveParams) (
+				interface{}, error) {
+				return p.Source.(models.Car).ID.String(), nil
+			}},
+			"vin":      &graphql.Field{Type: graphql.String},
+			"name":     &graphql.Field{Type: graphql.String},
+			"year":     &graphql.Field{Type: graphql.Int},
+			"brand":    &graphql.Field{Type: graphql.String},
+			"fuelType": &graphql.Field{Type: graphql.String},
+			"engine": &graphql.Field{Type: engineType, Description: "Loaded in one batch for every car of a query",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loaderFrom(p.Context).load(p.Source.(models.Car).Engine.EngineID), nil
+				}},
+			"trimId": &graphql.Field{Type: graphql.ID, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if id := p.Source.(models.Car).TrimID; id != nil {
+					return id.String(), nil
+				}
+
+				return nil, nil
+			}},
+			"status": &graphql.Field{Type: graphql.String},
+			"msrp": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				car := p.Source.(models.Car)
+				return car.MSRP.Format(car.Currency), nil
+			}},
+			"listPrice": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{},
+				error) {
+				car := p.Source.(models.Car)
+				return car.ListPrice.Format(car.Currency), nil
+			}},
+			"cost": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				car := p.Source.(models.Car)
+				return car.Cost.Format(car.Currency), nil
+			}},
+			"currency": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"endCursor":   &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CarConnection",
+		Fields: graphql.Fields{
+			"totalCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"nodes":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(carType)))},
+			"pageInfo":   &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	})
+
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CarFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"brand":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"minPrice": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"maxPrice": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"currency": &graphql.InputObjectFieldConfig{Type: graphql.String,
+				Description: "Required with minPrice or maxPrice"},
+			"status": &graphql.InputObjectFieldConfig{Type: graphql.String,
+				Description: "Comma separated statuses, a car matches when it is in any of them"},
+		},
+	})
+
+	powertrainInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "PowertrainInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"type":              &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"batteryKWh":        &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"chargingStandards": &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.String)},
+			"maxChargeKW":       &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"motorPowerKW":      &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"fuelConsumption":   &graphql.InputObjectFieldConfig{Type: graphql.Float},
+			"efficiency":        &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		},
+	})
+
+	engineInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "EngineInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"displacement": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"noOfCylinder": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"range":        &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"powertrain":   &graphql.InputObjectFieldConfig{Type: powertrainInput},
+		},
+	})
+
+	// prices and status are left out as they are of cars sent to /car, they change through their own endpoints
+	carInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CarInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"vin":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"name":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"year":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"brand":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"fuelType": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"engine":   &graphql.InputObjectFieldConfig{Type: engineInput},
+			"trimId":   &graphql.InputObjectFieldConfig{Type: graphql.ID},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"car": &graphql.Field{
+				Type:    carType,
+				Args:    idArgs(),
+				Resolve: r.car,
+			},
+			"carByVIN": &graphql.Field{
+				Type: carType,
+				Args: graphql.FieldConfigArgument{
+					"vin": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: r.carByVIN,
+			},
+			"cars": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType),
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: graphql.NewNonNull(filterType)},
+					"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultFirst,
+						Description: fmt.Sprintf("Cars in the page, from 1 to %d", maxFirst)},
+					"after": &graphql.ArgumentConfig{Type: graphql.String, Description: "endCursor of the page before"},
+				},
+				Resolve: r.listCars,
+			},
+			"engine": &graphql.Field{
+				Type: engineType,
+				Args: idArgs(),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id, err := uuid.Parse(p.Args["id"].(string))
+					if err != nil {
+						return nil, fmt.Errorf("engine id %q is not a uuid", p.Args["id"])
+					}
+
+					return loaderFrom(p.Context).load(id), nil
+				},
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createCar": &graphql.Field{
+				Type: carType,
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(carInput)},
+				},
+				Resolve: r.createCar,
+			},
+			"updateCar": &graphql.Field{
+				Type: carType,
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(carInput)},
+				},
+				Resolve: r.updateCar,
+			},
+			"deleteCar": &graphql.Field{
+				Type:    graphql.Boolean,
+				Args:    idArgs(),
+				Resolve: r.deleteCar,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func idArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}}
+}
+
+func (r resolver) car(p graphql.ResolveParams) (interface{}, error) {
+	car, err := r.cars.GetCarByID(p.Context, p.Args["id"].(string))
+	if err != nil {
+		return nil, public(err)
+	}
+
+	return car, nil
+}
+
+func (r resolver) carByVIN(p graphql.ResolveParams) (interface{}, error) {
+	car, err := r.vins.GetCarByVIN(p.Context, p.Args["vin"].(string))
+	if err != nil {
+		return nil, public(err)
+	}
+
+	return car, nil
+}
+
+// listCars lists a page of the cars of a brand which pass the filter, the cars are filtered and paged by the store.
+// Engines are left to the engine field so that they are only read when they are asked for.
+func (r resolver) listCars(p graphql.ResolveParams) (interface{}, error) {
+	filter, err := parseFilter(p.Args["filter"].(map[string]interface{}))
+	if err != nil {
+		return nil, public(err)
+	}
+
+	first, ok := p.Args["first"].(int)
+	if !ok {
+		first = defaultFirst
+	}
+
+	if first < 1 || first > maxFirst {
+		return nil, fmt.Errorf("first must be between 1 and %d", maxFirst)
+	}
+
+	page := models.Page{Limit: first}
+
+	if after, ok := p.Args["after"].(string); ok {
+		if page.After, err = parseCursor(after); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := r.listings.ListCars(p.Context, filter, page, false)
+	if err != nil {
+		return nil, public(err)
+	}
+
+	return newConnection(res), nil
+}
+
+func (r resolver) createCar(p graphql.ResolveParams) (interface{}, error) {
+	car, err := parseCar(p.Args["input"].(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.cars.CreateCar(p.Context, &car)
+	if err != nil {
+		return nil, public(err)
+	}
+
+	return created, nil
+}
+
+func (r resolver) updateCar(p graphql.ResolveParams) (interface{}, error) {
+	car, err := parseCar(p.Args["input"].(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.cars.UpdateCar(p.Context, p.Args["id"].(string), car)
+	if err != nil {
+		return nil, public(err)
+	}
+
+	return updated, nil
+}
+
+func (r resolver) deleteCar(p graphql.ResolveParams) (interface{}, error) {
+	if _, err := r.cars.DeleteCar(p.Context, p.Args["id"].(string)); err != nil {
+		return nil, public(err)
+	}
+
+	return true, nil
+}
+
+// connection is a page of cars
+type connection struct {
+	TotalCount int          `json:"totalCount"`
+	Nodes      []models.Car `json:"nodes"`
+	PageInfo   pageInfo     `json:"pageInfo"`
+}
+
+type pageInfo struct {
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   *string `json:"endCursor"`
+}
+
+// newConnection returns a page of cars as a connection, the cursor of a page is the id of its last car
+func newConnection(res models.CarPage) connection {
+	c := connection{TotalCount: res.Total, Nodes: res.Cars, PageInfo: pageInfo{HasNextPage: res.HasNext}}
+
+	if len(res.Cars) > 0 {
+		last := res.Cars[len(res.Cars)-1].ID.String()
+		cursor := base64.StdEncoding.EncodeToString([]byte(cursorPrefix + last))
+		c.PageInfo.EndCursor = &cursor
+	}
+
+	return c
+}
+
+// parseCursor returns the id of the last car of the page a cursor ends
+func parseCursor(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(b) <= len(cursorPrefix) || string(b[:len(cursorPrefix)]) != cursorPrefix {
+		return "", errInvalidCursor
+	}
+
+	id, err := uuid.Parse(string(b[len(cursorPrefix):]))
+	if err != nil {
+		return "", errInvalidCursor
+	}
+
+	return id.String(), nil
+}
+
+// parseFilter reads the filter argument as the query parameters of /cars are read
+func parseFilter(args map[string]interface{}) (models.CarFilter, error) {
+	query := make(map[string][]string, len(args))
+
+	for k, v := range args {
+		if s, ok := v.(string); ok {
+			query[k] = []string{s}
+		}
+	}
+
+	return models.ParseCarFilter(query)
+}
+
+// parseCar reads the input argument of the car mutations
+func parseCar(args map[string]interface{}) (models.Car, error) {
+	car := models.Car{Name: args["name"].(string), Year: args["year"].(int), Brand: args["brand"].(string),
+		FuelType: args["fuelType"].(string)}
+	car.VIN, _ = args["vin"].(string)
+
+	if s, ok := args["trimId"].(string); ok {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return models.Car{}, fmt.Errorf("trim id %q is not a uuid", s)
+		}
+
+		car.TrimID = &id
+	}
+
+	engine, ok := args["engine"].(map[string]interface{})
+	if !ok {
+		return car, nil
+	}
+
+	car.Engine = models.Engine{Displacement: toInt64(engine["displacement"]),
+		NoOfCylinder: toInt64(engine["noOfCylinder"]), CarRange: toInt64(engine["range"])}
+
+	if p, ok := engine["powertrain"].(map[string]interface{}); ok {
+		powertrain := models.Powertrain{Type: p["type"].(string), MotorPowerKW: toInt64(p["motorPowerKW"]),
+			Efficiency: toInt64(p["efficiency"])}
+		powertrain.BatteryKWh, _ = p["batteryKWh"].(float64)
+		powertrain.MaxChargeKW, _ = p["maxChargeKW"].(float64)
+		powertrain.FuelConsumption, _ = p["fuelConsumption"].(float64)
+
+		standards, _ := p["chargingStandards"].([]interface{})
+		powertrain.ChargingStandards = make([]string, 0, len(standards))
+
+		for _, s := range standards {
+			if s, ok := s.(string); ok {
+				powertrain.ChargingStandards = append(powertrain.ChargingStandards, s)
+			}
+		}
+
+		car.Engine.Powertrain = &powertrain
+	}
+
+	return car, nil
+}
+
+func toInt64(v interface{}) int64 {
+	i, _ := v.(int)
+	return int64(i)
+}
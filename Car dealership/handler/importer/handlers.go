@@ -0,0 +1,123 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/importer"
+
+	"github.com/gorilla/mux"
+)
+
+// maxImportSize is the largest import file accepted in a single request
+const maxImportSize = 32 << 20
+
+type handler struct {
+	service service.Importer
+}
+
+func New(i service.Importer) handler { //nolint
+	return handler{service: i}
+}
+
+// Import handler layer function to start a bulk import of cars from a CSV or NDJSON body
+func (h handler) Import(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	opts := models.ImportOptions{Format: format(r)}
+
+	for _, o := range []struct {
+		name string
+		dst  *bool
+	}{
+		{"dryRun", &opts.DryRun},
+		{"skipInvalid", &opts.SkipInvalid},
+		{"atomic", &opts.Atomic},
+	} {
+		v := query.Get(o.name)
+		if v == "" {
+			continue
+		}
+
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		*o.dst = b
+	}
+
+	job, err := h.service.Import(ctx, http.MaxBytesReader(w, r.Body, maxImportSize), opts)
+	if err != nil {
+		log.Println(err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	body, err := json.Marshal(job)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/cars/import/"+job.ID.String())
+	w.WriteHeader(http.StatusAccepted)
+
+	_, _ = w.Write(body)
+}
+
+// GetJob handler layer function to get the progress of an import job
+func (h handler) GetJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+
+	job, err := h.service.GetJob(ctx, id)
+	if errors.Is(err, importer.ErrJobNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	body, err := json.Marshal(job)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
+
+// format picks the import format from the format query parameter, falling back to the content type
+func format(r *http.Request) string {
+	if f := r.URL.Query().Get("format"); f != "" {
+		return strings.ToLower(f)
+	}
+
+	switch ct := r.Header.Get("Content-Type"); {
+	case strings.HasPrefix(ct, "text/csv"):
+		return importer.FormatCSV
+	case strings.HasPrefix(ct, "application/x-ndjson"), strings.HasPrefix(ct, "application/ndjson"):
+		return importer.FormatNDJSON
+	default:
+		return ""
+	}
+}
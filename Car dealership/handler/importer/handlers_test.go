@@ -0,0 +1,93 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/importer"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// TestImport handler layer test function to test handler layer Import function
+func TestImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockImporter(ctrl)
+	h := New(mockService)
+
+	job := models.ImportJob{ID: uuid.New(), Status: models.ImportPending}
+
+	testCases := []struct {
+		desc        string
+		query       string
+		contentType string
+		statusCode  int
+		mock        *gomock.Call
+	}{
+		{desc: "csv by content type", contentType: "text/csv", statusCode: http.StatusAccepted,
+			mock: mockService.EXPECT().Import(gomock.Any(), gomock.Any(),
+				models.ImportOptions{Format: importer.FormatCSV}).Return(job, nil)},
+		{desc: "ndjson dry run", query: "?format=ndjson&dryRun=true", statusCode: http.StatusAccepted,
+			mock: mockService.EXPECT().Import(gomock.Any(), gomock.Any(),
+				models.ImportOptions{Format: importer.FormatNDJSON, DryRun: true}).Return(job, nil)},
+		{desc: "invalid option", query: "?atomic=maybe", statusCode: http.StatusBadRequest},
+		{desc: "rejected", query: "?format=xml", statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Import(gomock.Any(), gomock.Any(), models.ImportOptions{Format: "xml"}).
+				Return(models.ImportJob{}, importer.ErrUnknownFormat)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/cars/import"+tc.query, bytes.NewBufferString("name\n"))
+		req.Header.Set("Content-Type", tc.contentType)
+		res := httptest.NewRecorder()
+
+		h.Import(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetJob handler layer test function to test handler layer GetJob function
+func TestGetJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockImporter(ctrl)
+	h := New(mockService)
+
+	id := uuid.New()
+
+	testCases := []struct {
+		desc       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", statusCode: http.StatusOK, mock: mockService.EXPECT().GetJob(gomock.Any(), id.String()).
+			Return(models.ImportJob{ID: id}, nil)},
+		{desc: "not found", statusCode: http.StatusNotFound, mock: mockService.EXPECT().
+			GetJob(gomock.Any(), id.String()).Return(models.ImportJob{}, importer.ErrJobNotFound)},
+		{desc: "error", statusCode: http.StatusInternalServerError, mock: mockService.EXPECT().
+			GetJob(gomock.Any(), id.String()).Return(models.ImportJob{}, errors.New("error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/cars/import/"+id.String(), nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id.String()})
+		res := httptest.NewRecorder()
+
+		h.GetJob(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
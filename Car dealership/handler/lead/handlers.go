@@ -0,0 +1,147 @@
+package lead
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/customer"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/lead"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.Leads
+}
+
+func New(s service.Leads) handler { //nolint
+	return handler{service: s}
+}
+
+// Capture handler layer function to record a lead for the car and customer given in the body
+func (h handler) Capture(w http.ResponseWriter, r *http.Request) {
+	var l models.Lead
+
+	if err := json.NewDecoder(r.Body).Decode(&l); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Capture(r.Context(), l)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetLead handler layer function to get a lead by its id
+func (h handler) GetLead(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetLead(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetLeads handler layer function to list the leads passing the status, salesperson, carId, customerId
+// and unassigned filters
+func (h handler) GetLeads(w http.ResponseWriter, r *http.Request) {
+	filter, err := models.ParseLeadFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := h.service.GetLeads(r.Context(), filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Transition handler layer function to move a lead to the Status given in the body
+func (h handler) Transition(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Status string
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Transition(r.Context(), mux.Vars(r)["id"], body.Status)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Assign handler layer function to assign a lead to the Salesperson given in the body
+func (h handler) Assign(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Salesperson string
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Assign(r.Context(), mux.Vars(r)["id"], body.Salesperson)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, lead.ErrLeadNotFound), errors.Is(err, lead.ErrCarNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, lead.ErrInvalidLead), errors.Is(err, customer.ErrInvalidCustomer),
+		errors.Is(err, models.ErrUnknownLeadStatus):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, lead.ErrIllegalTransition), errors.Is(err, lead.ErrLeadClosed),
+		errors.Is(err, lead.ErrLeadConflict):
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
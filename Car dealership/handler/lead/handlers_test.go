@@ -0,0 +1,184 @@
+package lead
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/customer"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/lead"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// TestCapture handler layer test function to test handler layer Capture function
+func TestCapture(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockLeads(ctrl)
+	h := New(mockService)
+
+	l := models.Lead{CarID: uuid.MustParse(id), Source: "website",
+		Customer: &models.Customer{Name: "Sahil", Email: "sahil@example.com"}}
+	body := `{"CarID":"` + id + `","Source":"website","Customer":{"Name":"Sahil","Email":"sahil@example.com"}}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().Capture(gomock.Any(), l).Return(l, nil)},
+		{desc: "malformed body", body: `{"CarID":`, statusCode: http.StatusBadRequest},
+		{desc: "invalid customer", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Capture(gomock.Any(), l).Return(models.Lead{}, customer.ErrInvalidCustomer)},
+		{desc: "car not found", body: body, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Capture(gomock.Any(), l).Return(models.Lead{}, lead.ErrCarNotFound)},
+		{desc: "error", body: body, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Capture(gomock.Any(), l).Return(models.Lead{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/leads", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		h.Capture(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetLeads handler layer test function to test handler layer GetLeads and GetLead functions
+func TestGetLeads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockLeads(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		target     string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "filtered", target: "/leads?status=new,contacted&salesperson=ravi", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetLeads(gomock.Any(),
+				models.LeadFilter{Status: "new,contacted", Salesperson: "ravi"}).Return([]models.Lead{}, nil)},
+		{desc: "unknown status", target: "/leads?status=hot", statusCode: http.StatusBadRequest},
+		{desc: "error", target: "/leads", statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().GetLeads(gomock.Any(), models.LeadFilter{}).Return(nil, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+		res := httptest.NewRecorder()
+
+		h.GetLeads(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+
+	mockService.EXPECT().GetLead(gomock.Any(), id).Return(models.Lead{}, lead.ErrLeadNotFound)
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/leads/"+id, nil), map[string]string{"id": id})
+	res := httptest.NewRecorder()
+
+	h.GetLead(res, req)
+
+	if res.Code != http.StatusNotFound {
+		t.Errorf("%v: Expected Status Code: %v, Got: %v", "not found", http.StatusNotFound, res.Code)
+	}
+}
+
+// TestTransition handler layer test function to test handler layer Transition function
+func TestTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockLeads(ctrl)
+	h := New(mockService)
+
+	body := `{"Status":"won"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Transition(gomock.Any(), id, "won").Return(models.Lead{}, nil)},
+		{desc: "malformed body", body: `{"Status":`, statusCode: http.StatusBadRequest},
+		{desc: "unknown status", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Transition(gomock.Any(), id, "won").
+				Return(models.Lead{}, models.ErrUnknownLeadStatus)},
+		{desc: "illegal move", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Transition(gomock.Any(), id, "won").
+				Return(models.Lead{}, lead.ErrIllegalTransition)},
+		{desc: "conflict", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Transition(gomock.Any(), id, "won").Return(models.Lead{}, lead.ErrLeadConflict)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/leads/"+id+"/status", strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.Transition(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestAssign handler layer test function to test handler layer Assign function
+func TestAssign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockLeads(ctrl)
+	h := New(mockService)
+
+	body := `{"Salesperson":"ravi"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Assign(gomock.Any(), id, "ravi").Return(models.Lead{}, nil)},
+		{desc: "malformed body", body: `{"Salesperson":`, statusCode: http.StatusBadRequest},
+		{desc: "missing salesperson", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Assign(gomock.Any(), id, "ravi").Return(models.Lead{}, lead.ErrInvalidLead)},
+		{desc: "closed", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Assign(gomock.Any(), id, "ravi").Return(models.Lead{}, lead.ErrLeadClosed)},
+		{desc: "not found", body: body, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Assign(gomock.Any(), id, "ravi").Return(models.Lead{}, lead.ErrLeadNotFound)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPut, "/leads/"+id+"/assignee", strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.Assign(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
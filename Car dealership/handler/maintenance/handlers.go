@@ -0,0 +1,169 @@
+package maintenance
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/maintenance"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.Maintenance
+}
+
+func New(s service.Maintenance) handler { //nolint
+	return handler{service: s}
+}
+
+// Book handler layer function to book the service appointment given in the body
+func (h handler) Book(w http.ResponseWriter, r *http.Request) {
+	var a models.ServiceAppointment
+
+	if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Book(r.Context(), a)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetAppointment handler layer function to get a service appointment by its id
+func (h handler) GetAppointment(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetAppointment(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetAppointments handler layer function to get the service appointments of a car
+func (h handler) GetAppointments(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetAppointments(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// CancelAppointment handler layer function to cancel a booked service appointment
+func (h handler) CancelAppointment(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.CancelAppointment(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Complete handler layer function to record the maintenance given in the body for a booked appointment
+func (h handler) Complete(w http.ResponseWriter, r *http.Request) {
+	var record models.MaintenanceRecord
+
+	if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Complete(r.Context(), mux.Vars(r)["id"], record)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// Record handler layer function to record the maintenance given in the body for a car without an appointment
+func (h handler) Record(w http.ResponseWriter, r *http.Request) {
+	var record models.MaintenanceRecord
+
+	if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Record(r.Context(), mux.Vars(r)["id"], record)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetHistory handler layer function to get the maintenance history of a car
+func (h handler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetHistory(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetReminder handler layer function to get when a car is next due for a service
+func (h handler) GetReminder(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetReminder(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, maintenance.ErrAppointmentNotFound), errors.Is(err, maintenance.ErrCarNotFound),
+		errors.Is(err, maintenance.ErrCustomerNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, maintenance.ErrInvalidAppointment), errors.Is(err, maintenance.ErrInvalidRecord):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, maintenance.ErrAppointmentClosed):
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,185 @@
+package maintenance
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/maintenance"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// TestBook handler layer test function to test handler layer Book function
+func TestBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockMaintenance(ctrl)
+	h := New(mockService)
+
+	a := models.ServiceAppointment{CarID: uuid.MustParse(id), CustomerID: uuid.MustParse(id), Work: "brakes",
+		ScheduledAt: time.Date(2022, 3, 2, 9, 0, 0, 0, time.UTC)}
+	body := `{"CarID":"` + id + `","CustomerID":"` + id + `","Work":"brakes","ScheduledAt":"2022-03-02T09:00:00Z"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().Book(gomock.Any(), a).Return(a, nil)},
+		{desc: "malformed body", body: `{"ScheduledAt":"tomorrow"}`, statusCode: http.StatusBadRequest},
+		{desc: "invalid", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Book(gomock.Any(), a).
+				Return(models.ServiceAppointment{}, maintenance.ErrInvalidAppointment)},
+		{desc: "car not found", body: body, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Book(gomock.Any(), a).
+				Return(models.ServiceAppointment{}, maintenance.ErrCarNotFound)},
+		{desc: "error", body: body, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Book(gomock.Any(), a).Return(models.ServiceAppointment{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/service/appointments", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		h.Book(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestAppointments handler layer test function to test handler layer GetAppointment, GetAppointments and
+// CancelAppointment functions
+func TestAppointments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockMaintenance(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		handler    http.HandlerFunc
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "get", handler: h.GetAppointment, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetAppointment(gomock.Any(), id).Return(models.ServiceAppointment{}, nil)},
+		{desc: "not found", handler: h.GetAppointment, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetAppointment(gomock.Any(), id).
+				Return(models.ServiceAppointment{}, maintenance.ErrAppointmentNotFound)},
+		{desc: "of a car", handler: h.GetAppointments, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetAppointments(gomock.Any(), id).Return([]models.ServiceAppointment{}, nil)},
+		{desc: "cancel", handler: h.CancelAppointment, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().CancelAppointment(gomock.Any(), id).Return(models.ServiceAppointment{}, nil)},
+		{desc: "cancel closed", handler: h.CancelAppointment, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().CancelAppointment(gomock.Any(), id).
+				Return(models.ServiceAppointment{}, maintenance.ErrAppointmentClosed)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/service/appointments/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		tc.handler(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestRecords handler layer test function to test handler layer Complete and Record functions
+func TestRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockMaintenance(ctrl)
+	h := New(mockService)
+
+	record := models.MaintenanceRecord{Work: "oil change", Technician: "ravi", LabourHours: 0.5, Odometer: 15200,
+		Parts: []models.Part{{Number: "OF-1", Description: "oil filter", Quantity: 1}}}
+	body := `{"Work":"oil change","Technician":"ravi","LabourHours":0.5,"Odometer":15200,` +
+		`"Parts":[{"Number":"OF-1","Description":"oil filter","Quantity":1}]}`
+
+	testCases := []struct {
+		desc       string
+		handler    http.HandlerFunc
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "complete", handler: h.Complete, body: body, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().Complete(gomock.Any(), id, record).Return(record, nil)},
+		{desc: "complete closed", handler: h.Complete, body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Complete(gomock.Any(), id, record).
+				Return(models.MaintenanceRecord{}, maintenance.ErrAppointmentClosed)},
+		{desc: "record", handler: h.Record, body: body, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().Record(gomock.Any(), id, record).Return(record, nil)},
+		{desc: "invalid record", handler: h.Record, body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Record(gomock.Any(), id, record).
+				Return(models.MaintenanceRecord{}, maintenance.ErrInvalidRecord)},
+		{desc: "malformed body", handler: h.Record, body: `{"Odometer":"15200"}`, statusCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/car/"+id+"/maintenance", strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		tc.handler(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestHistory handler layer test function to test handler layer GetHistory and GetReminder functions
+func TestHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockMaintenance(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		handler    http.HandlerFunc
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "history", handler: h.GetHistory, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetHistory(gomock.Any(), id).Return([]models.MaintenanceRecord{}, nil)},
+		{desc: "history of unknown car", handler: h.GetHistory, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetHistory(gomock.Any(), id).Return(nil, maintenance.ErrCarNotFound)},
+		{desc: "reminder", handler: h.GetReminder, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetReminder(gomock.Any(), id).Return(models.ServiceReminder{}, nil)},
+		{desc: "reminder error", handler: h.GetReminder, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().GetReminder(gomock.Any(), id).
+				Return(models.ServiceReminder{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/car/"+id+"/maintenance", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		tc.handler(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package media
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/media"
+
+	"github.com/gorilla/mux"
+)
+
+// fileField is the multipart form field an image is uploaded in
+const fileField = "file"
+
+type handler struct {
+	service service.Media
+}
+
+func New(s service.Media) handler { //nolint
+	return handler{service: s}
+}
+
+// Upload handler layer function to add the image uploaded in the file field of a multipart form to a car. The
+// image is streamed to the service, which stops reading once it is too large.
+func (h handler) Upload(w http.ResponseWriter, r *http.Request) {
+	form, err := r.MultipartReader()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	for {
+		part, err := form.NextPart()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+
+			return
+		}
+
+		if part.FormName() != fileField {
+			continue
+		}
+
+		resp, err := h.service.Upload(r.Context(), mux.Vars(r)["id"], part.FileName(), part.Header.Get("Content-Type"),
+			part)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, resp)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = w.Write([]byte("the image must be uploaded in the " + fileField + " field"))
+}
+
+// GetMedia handler layer function to get the images of a car in order
+func (h handler) GetMedia(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetMedia(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Reorder handler layer function to put the images of a car in the order given in the body
+func (h handler) Reorder(w http.ResponseWriter, r *http.Request) {
+	var order models.MediaOrder
+
+	if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Reorder(r.Context(), mux.Vars(r)["id"], order.IDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// SetPrimary handler layer function to make an image the primary image of its car
+func (h handler) SetPrimary(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	resp, err := h.service.SetPrimary(r.Context(), params["id"], params["mediaID"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Delete handler layer function to delete an image of a car
+func (h handler) Delete(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	if err := h.service.Delete(r.Context(), params["id"], params["mediaID"]); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	_, _ = w.Write([]byte("deleted"))
+}
+
+// Serve handler layer function to serve a rendition of an image. Renditions never change once uploaded, so they
+// may be cached for good.
+func (h handler) Serve(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	body, contentType, err := h.service.Open(r.Context(), params["id"], params["rendition"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	defer body.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+
+	if _, err = io.Copy(w, body); err != nil {
+		log.Println(err)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, media.ErrMediaNotFound), errors.Is(err, media.ErrCarNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, media.ErrInvalidMedia):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, media.ErrMediaTooLarge):
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, media.ErrUnsupportedMedia):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
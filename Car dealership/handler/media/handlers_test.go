@@ -0,0 +1,178 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/media"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// form builds a multipart body with a caption field and the image in the field given
+func form(t *testing.T, field string) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("caption", "front")
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="`+field+`"; filename="front.jpg"`)
+	header.Set("Content-Type", "image/jpeg")
+
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = part.Write([]byte("jpeg"))
+	_ = mw.Close()
+
+	return &body, mw.FormDataContentType()
+}
+
+// TestUpload handler layer test function to test handler layer Upload function
+func TestUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockMedia(ctrl)
+	h := New(mockService)
+
+	upload := func(err error) *gomock.Call {
+		return mockService.EXPECT().Upload(gomock.Any(), id, "front.jpg", "image/jpeg", gomock.Any()).
+			DoAndReturn(func(_, _, _, _ interface{}, body io.Reader) (models.Media, error) {
+				data, _ := io.ReadAll(body)
+				if string(data) != "jpeg" {
+					t.Errorf("Expected the image to be streamed, Got: %q", data)
+				}
+
+				return models.Media{}, err
+			})
+	}
+
+	testCases := []struct {
+		desc        string
+		field       string
+		contentType string
+		statusCode  int
+		mock        *gomock.Call
+	}{
+		{desc: "success", field: "file", statusCode: http.StatusCreated, mock: upload(nil)},
+		{desc: "too large", field: "file", statusCode: http.StatusRequestEntityTooLarge,
+			mock: upload(media.ErrMediaTooLarge)},
+		{desc: "unsupported", field: "file", statusCode: http.StatusUnsupportedMediaType,
+			mock: upload(media.ErrUnsupportedMedia)},
+		{desc: "car not found", field: "file", statusCode: http.StatusNotFound, mock: upload(media.ErrCarNotFound)},
+		{desc: "no file field", field: "photo", statusCode: http.StatusBadRequest},
+		{desc: "not multipart", field: "file", contentType: "application/json", statusCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		body, contentType := form(t, tc.field)
+		if tc.contentType != "" {
+			contentType = tc.contentType
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/car/"+id+"/media", body)
+		req.Header.Set("Content-Type", contentType)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.Upload(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestArrange handler layer test function to test handler layer GetMedia, Reorder, SetPrimary and Delete functions
+func TestArrange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockMedia(ctrl)
+	h := New(mockService)
+
+	mediaID := uuid.New()
+
+	testCases := []struct {
+		desc       string
+		handler    http.HandlerFunc
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "list", handler: h.GetMedia, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetMedia(gomock.Any(), id).Return([]models.Media{}, nil)},
+		{desc: "reorder", handler: h.Reorder, body: `{"IDs":["` + mediaID.String() + `"]}`, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Reorder(gomock.Any(), id, []uuid.UUID{mediaID}).Return([]models.Media{}, nil)},
+		{desc: "reorder invalid", handler: h.Reorder, body: `{"IDs":[]}`, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Reorder(gomock.Any(), id, []uuid.UUID{}).Return(nil, media.ErrInvalidMedia)},
+		{desc: "reorder malformed", handler: h.Reorder, body: `{"IDs":["abc"]}`, statusCode: http.StatusBadRequest},
+		{desc: "primary", handler: h.SetPrimary, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().SetPrimary(gomock.Any(), id, mediaID.String()).Return([]models.Media{}, nil)},
+		{desc: "delete", handler: h.Delete, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Delete(gomock.Any(), id, mediaID.String()).Return(nil)},
+		{desc: "delete missing", handler: h.Delete, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Delete(gomock.Any(), id, mediaID.String()).Return(media.ErrMediaNotFound)},
+		{desc: "delete error", handler: h.Delete, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Delete(gomock.Any(), id, mediaID.String()).Return(errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPut, "/car/"+id+"/media", strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id, "mediaID": mediaID.String()})
+		res := httptest.NewRecorder()
+
+		tc.handler(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestServe handler layer test function to test handler layer Serve function
+func TestServe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockMedia(ctrl)
+	h := New(mockService)
+
+	mockService.EXPECT().Open(gomock.Any(), id, "thumbnail").
+		Return(io.NopCloser(strings.NewReader("png")), "image/png", nil)
+	mockService.EXPECT().Open(gomock.Any(), id, "huge").Return(nil, "", media.ErrMediaNotFound)
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/media/"+id+"/thumbnail", nil),
+		map[string]string{"id": id, "rendition": "thumbnail"})
+	res := httptest.NewRecorder()
+
+	h.Serve(res, req)
+
+	if res.Code != http.StatusOK || res.Header().Get("Content-Type") != "image/png" || res.Body.String() != "png" {
+		t.Errorf("%v: Expected Status Code: %v, Got: %v", "serve", http.StatusOK, res.Code)
+	}
+
+	req = mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/media/"+id+"/huge", nil),
+		map[string]string{"id": id, "rendition": "huge"})
+	res = httptest.NewRecorder()
+
+	h.Serve(res, req)
+
+	if res.Code != http.StatusNotFound {
+		t.Errorf("%v: Expected Status Code: %v, Got: %v", "unknown rendition", http.StatusNotFound, res.Code)
+	}
+}
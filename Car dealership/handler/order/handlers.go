@@ -0,0 +1,166 @@
+package order
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/order"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.Orders
+}
+
+func New(s service.Orders) handler { //nolint
+	return handler{service: s}
+}
+
+// CreateOrder handler layer function to sell the car in the body to the customer in the body
+func (h handler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	var o models.SalesOrder
+
+	if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.CreateOrder(r.Context(), o)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetOrder handler layer function to get an order by its id
+func (h handler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetOrder(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetOrders handler layer function to list the orders passing the status, salesperson, customerId and carId filters
+func (h handler) GetOrders(w http.ResponseWriter, r *http.Request) {
+	filter, err := models.ParseOrderFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := h.service.GetOrders(r.Context(), filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// UpdateOrder handler layer function to replace the terms of an open order with the ones in the body
+func (h handler) UpdateOrder(w http.ResponseWriter, r *http.Request) {
+	var o models.SalesOrder
+
+	if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.UpdateOrder(r.Context(), mux.Vars(r)["id"], o)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// CancelOrder handler layer function to cancel an open order without payments
+func (h handler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.CancelOrder(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// AddPayment handler layer function to record the payment in the body against an order
+func (h handler) AddPayment(w http.ResponseWriter, r *http.Request) {
+	var p models.Payment
+
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.AddPayment(r.Context(), mux.Vars(r)["id"], p)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetInvoice handler layer function to get the invoice of an order
+func (h handler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetInvoice(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, order.ErrOrderNotFound), errors.Is(err, order.ErrCarNotFound),
+		errors.Is(err, order.ErrCustomerNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, order.ErrInvalidOrder), errors.Is(err, order.ErrInvalidPayment),
+		errors.Is(err, models.ErrUnknownOrderStatus):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, order.ErrCarNotAvailable), errors.Is(err, order.ErrOrderClosed),
+		errors.Is(err, order.ErrOrderHasPayments), errors.Is(err, order.ErrOrderConflict):
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,224 @@
+package order
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/order"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// TestCreateOrder handler layer test function to test handler layer CreateOrder function
+func TestCreateOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockOrders(ctrl)
+	h := New(mockService)
+
+	o := models.SalesOrder{CarID: uuid.MustParse(id), CustomerID: uuid.MustParse(id), Salesperson: "ravi",
+		Price: 2400000, TaxRate: 1900, Fees: []models.Fee{{Description: "registration", Amount: 15000}}}
+	body := `{"CarID":"` + id + `","CustomerID":"` + id + `","Salesperson":"ravi","Price":"24000.00",` +
+		`"TaxRate":1900,"Fees":[{"Description":"registration","Amount":150}]}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().CreateOrder(gomock.Any(), o).Return(o, nil)},
+		{desc: "malformed body", body: `{"Price":"24000.001"}`, statusCode: http.StatusBadRequest},
+		{desc: "invalid", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().CreateOrder(gomock.Any(), o).Return(models.SalesOrder{}, order.ErrInvalidOrder)},
+		{desc: "customer not found", body: body, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().CreateOrder(gomock.Any(), o).
+				Return(models.SalesOrder{}, order.ErrCustomerNotFound)},
+		{desc: "car not in stock", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().CreateOrder(gomock.Any(), o).
+				Return(models.SalesOrder{}, order.ErrCarNotAvailable)},
+		{desc: "error", body: body, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().CreateOrder(gomock.Any(), o).Return(models.SalesOrder{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		h.CreateOrder(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetOrders handler layer test function to test handler layer GetOrders and GetOrder functions
+func TestGetOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockOrders(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		target     string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "filtered", target: "/orders?status=Open&salesperson=ravi", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetOrders(gomock.Any(), models.OrderFilter{Status: "open", Salesperson: "ravi"}).
+				Return([]models.SalesOrder{}, nil)},
+		{desc: "unknown status", target: "/orders?status=shipped", statusCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+		res := httptest.NewRecorder()
+
+		h.GetOrders(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+
+	mockService.EXPECT().GetOrder(gomock.Any(), id).Return(models.SalesOrder{}, order.ErrOrderNotFound)
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/orders/"+id, nil), map[string]string{"id": id})
+	res := httptest.NewRecorder()
+
+	h.GetOrder(res, req)
+
+	if res.Code != http.StatusNotFound {
+		t.Errorf("%v: Expected Status Code: %v, Got: %v", "not found", http.StatusNotFound, res.Code)
+	}
+}
+
+// TestUpdateOrder handler layer test function to test handler layer UpdateOrder and CancelOrder functions
+func TestUpdateOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockOrders(ctrl)
+	h := New(mockService)
+
+	o := models.SalesOrder{Salesperson: "asha", Price: 2300000}
+	body := `{"Salesperson":"asha","Price":"23000"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().UpdateOrder(gomock.Any(), id, o).Return(o, nil)},
+		{desc: "malformed body", body: `{"Price":`, statusCode: http.StatusBadRequest},
+		{desc: "closed", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().UpdateOrder(gomock.Any(), id, o).Return(models.SalesOrder{}, order.ErrOrderClosed)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPut, "/orders/"+id, strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.UpdateOrder(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+
+	mockService.EXPECT().CancelOrder(gomock.Any(), id).Return(models.SalesOrder{}, order.ErrOrderHasPayments)
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/orders/"+id, nil), map[string]string{"id": id})
+	res := httptest.NewRecorder()
+
+	h.CancelOrder(res, req)
+
+	if res.Code != http.StatusConflict {
+		t.Errorf("%v: Expected Status Code: %v, Got: %v", "has payments", http.StatusConflict, res.Code)
+	}
+}
+
+// TestAddPayment handler layer test function to test handler layer AddPayment function
+func TestAddPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockOrders(ctrl)
+	h := New(mockService)
+
+	p := models.Payment{Amount: 500000, Method: "card"}
+	body := `{"Amount":"5000","Method":"card"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().AddPayment(gomock.Any(), id, p).Return(models.SalesOrder{}, nil)},
+		{desc: "malformed body", body: `{"Amount":`, statusCode: http.StatusBadRequest},
+		{desc: "over the balance", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().AddPayment(gomock.Any(), id, p).
+				Return(models.SalesOrder{}, order.ErrInvalidPayment)},
+		{desc: "conflict", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().AddPayment(gomock.Any(), id, p).
+				Return(models.SalesOrder{}, order.ErrOrderConflict)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/orders/"+id+"/payments", strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.AddPayment(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetInvoice handler layer test function to test handler layer GetInvoice function
+func TestGetInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockOrders(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetInvoice(gomock.Any(), id).Return(models.Invoice{Number: "MAIN-000001"}, nil)},
+		{desc: "not found", statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetInvoice(gomock.Any(), id).Return(models.Invoice{}, order.ErrOrderNotFound)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/orders/"+id+"/invoice", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.GetInvoice(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package price
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/price"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.Prices
+}
+
+func New(p service.Prices) handler { //nolint
+	return handler{service: p}
+}
+
+// GetPrice handler layer function to get the current price of a car
+func (h handler) GetPrice(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetPrice(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// SetPrice handler layer function to set the price of a car, the body carries the price, the actor and the reason
+func (h handler) SetPrice(w http.ResponseWriter, r *http.Request) {
+	var change models.PriceChange
+
+	if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.SetPrice(r.Context(), mux.Vars(r)["id"], change)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetPriceHistory handler layer function to get every price change of a car
+func (h handler) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetPriceHistory(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, price.ErrCarNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, price.ErrInvalidPrice):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
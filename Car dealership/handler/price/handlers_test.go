@@ -0,0 +1,88 @@
+package price
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/price"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// TestSetPrice handler layer test function to test handler layer SetPrice function
+func TestSetPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockPrices(ctrl)
+	h := New(mockService)
+
+	change := models.PriceChange{Price: models.Price{MSRP: 2499990, ListPrice: 2399900, Currency: "EUR"},
+		Actor: "sahil", Reason: "launch"}
+	body := `{"Price":{"MSRP":"24999.90","ListPrice":23999,"Currency":"EUR"},"Actor":"sahil","Reason":"launch"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().SetPrice(gomock.Any(), id, change).Return(change, nil)},
+		{desc: "inexact amount", body: `{"Price":{"MSRP":24999.999}}`, statusCode: http.StatusBadRequest},
+		{desc: "invalid price", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().SetPrice(gomock.Any(), id, change).
+				Return(models.PriceChange{}, price.ErrInvalidPrice)},
+		{desc: "not found", body: body, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().SetPrice(gomock.Any(), id, change).
+				Return(models.PriceChange{}, price.ErrCarNotFound)},
+		{desc: "error", body: body, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().SetPrice(gomock.Any(), id, change).
+				Return(models.PriceChange{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPut, "/car/"+id+"/price", strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.SetPrice(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetPrice handler layer test function to test handler layer GetPrice and GetPriceHistory functions
+func TestGetPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockPrices(ctrl)
+	h := New(mockService)
+
+	mockService.EXPECT().GetPrice(gomock.Any(), id).Return(models.Price{ListPrice: 2399900, Currency: "EUR"}, nil)
+	mockService.EXPECT().GetPriceHistory(gomock.Any(), id).Return(nil, price.ErrCarNotFound)
+
+	req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/car/"+id+"/price", nil), map[string]string{"id": id})
+	res := httptest.NewRecorder()
+
+	h.GetPrice(res, req)
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, `{"MSRP":"0.00","ListPrice":"23999.00","Cost":"0.00","Currency":"EUR"}`, res.Body.String())
+
+	res = httptest.NewRecorder()
+
+	h.GetPriceHistory(res, req)
+
+	assert.Equal(t, http.StatusNotFound, res.Code)
+}
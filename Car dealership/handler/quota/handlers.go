@@ -0,0 +1,52 @@
+package quota
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+)
+
+type handler struct {
+	service service.Quotas
+}
+
+func New(q service.Quotas) handler { //nolint
+	return handler{service: q}
+}
+
+// GetUsage handler layer function to get the requests of every client on the day in the query, today by default
+func (h handler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	var day time.Time
+
+	if s := r.URL.Query().Get("day"); s != "" {
+		var err error
+
+		if day, err = time.Parse("2006-01-02", s); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("day is not written as YYYY-MM-DD"))
+
+			return
+		}
+	}
+
+	resp, err := h.service.Usage(r.Context(), day)
+	if err != nil {
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,51 @@
+package quota
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+
+	"github.com/golang/mock/gomock"
+)
+
+// TestGetUsage handler layer test function to test handler layer GetUsage function
+func TestGetUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockQuotas(ctrl)
+	h := New(mockService)
+
+	day := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	usage := []models.QuotaUsage{{Client: "partner", Role: "partner", Day: "2022-03-01", Requests: 10}}
+
+	testCases := []struct {
+		desc       string
+		query      string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "today", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Usage(gomock.Any(), time.Time{}).Return(usage, nil)},
+		{desc: "day", query: "?day=2022-03-01", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Usage(gomock.Any(), day).Return(usage, nil)},
+		{desc: "invalid day", query: "?day=01-03-2022", statusCode: http.StatusBadRequest},
+		{desc: "error", query: "?day=2022-03-01", statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Usage(gomock.Any(), day).Return(nil, errors.New("error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/admin/quotas"+tc.query, nil)
+		res := httptest.NewRecorder()
+
+		h.GetUsage(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
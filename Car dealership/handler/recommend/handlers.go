@@ -0,0 +1,75 @@
+package recommend
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/recommend"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.Recommender
+}
+
+func New(s service.Recommender) handler { //nolint
+	return handler{service: s}
+}
+
+// Similar handler layer function to suggest cars like the car with the id, as many as the limit in the query and
+// ranked by the weights in it
+func (h handler) Similar(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var limit int
+
+	if l := query.Get("limit"); l != "" {
+		var err error
+
+		if limit, err = strconv.Atoi(l); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("limit must be a number"))
+
+			return
+		}
+	}
+
+	resp, err := h.service.Similar(r.Context(), mux.Vars(r)["id"], query.Get("weights"), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, recommend.ErrCarNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, recommend.ErrInvalidWeights), errors.Is(err, recommend.ErrInvalidLimit):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
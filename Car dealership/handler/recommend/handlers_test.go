@@ -0,0 +1,62 @@
+package recommend
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/recommend"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+// TestSimilar handler layer test function to test handler layer Similar function
+func TestSimilar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockRecommender(ctrl)
+	h := New(mockService)
+
+	const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+	testCases := []struct {
+		desc       string
+		query      string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", query: "", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Similar(gomock.Any(), id, "", 0).Return(models.Recommendations{}, nil)},
+		{desc: "limit and weights", query: "?limit=3&weights=brand:0", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Similar(gomock.Any(), id, "brand:0", 3).Return(models.Recommendations{}, nil)},
+		{desc: "limit not a number", query: "?limit=many", statusCode: http.StatusBadRequest},
+		{desc: "invalid weights", query: "?weights=colour:1", statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Similar(gomock.Any(), id, "colour:1", 0).
+				Return(models.Recommendations{}, recommend.ErrInvalidWeights)},
+		{desc: "invalid limit", query: "?limit=100", statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Similar(gomock.Any(), id, "", 100).
+				Return(models.Recommendations{}, recommend.ErrInvalidLimit)},
+		{desc: "not found", query: "?limit=1", statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Similar(gomock.Any(), id, "", 1).
+				Return(models.Recommendations{}, recommend.ErrCarNotFound)},
+		{desc: "error", query: "?limit=2", statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Similar(gomock.Any(), id, "", 2).
+				Return(models.Recommendations{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/car/"+id+"/similar"+tc.query, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.Similar(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
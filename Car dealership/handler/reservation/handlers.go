@@ -0,0 +1,117 @@
+package reservation
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/reservation"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.Reservations
+}
+
+func New(s service.Reservations) handler { //nolint
+	return handler{service: s}
+}
+
+// Hold handler layer function to place a hold on the car given in the body, a car that is not in stock
+// is rejected with 409
+func (h handler) Hold(w http.ResponseWriter, r *http.Request) {
+	var hold models.Reservation
+
+	if err := json.NewDecoder(r.Body).Decode(&hold); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Hold(r.Context(), hold)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetReservation handler layer function to get a reservation by its id
+func (h handler) GetReservation(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetReservation(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Extend handler layer function to move the expiry of an active hold to the ExpiresAt given in the body
+func (h handler) Extend(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ExpiresAt time.Time
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Extend(r.Context(), mux.Vars(r)["id"], body.ExpiresAt)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Release handler layer function to end an active hold and put the car back in stock
+func (h handler) Release(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.Release(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, reservation.ErrCarNotFound), errors.Is(err, reservation.ErrReservationNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, reservation.ErrInvalidHold):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, reservation.ErrCarNotAvailable), errors.Is(err, reservation.ErrHoldNotActive):
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
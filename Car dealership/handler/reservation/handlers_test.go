@@ -0,0 +1,167 @@
+package reservation
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/reservation"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// TestHold handler layer test function to test handler layer Hold function
+func TestHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockReservations(ctrl)
+	h := New(mockService)
+
+	carID := uuid.MustParse(id)
+	hold := models.Reservation{CarID: carID, Holder: "sahil"}
+	body := `{"CarID":"` + id + `","Holder":"sahil"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().Hold(gomock.Any(), hold).Return(hold, nil)},
+		{desc: "malformed body", body: `{"CarID":`, statusCode: http.StatusBadRequest},
+		{desc: "invalid hold", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Hold(gomock.Any(), hold).Return(models.Reservation{}, reservation.ErrInvalidHold)},
+		{desc: "car not found", body: body, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Hold(gomock.Any(), hold).Return(models.Reservation{}, reservation.ErrCarNotFound)},
+		{desc: "not available", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Hold(gomock.Any(), hold).
+				Return(models.Reservation{}, reservation.ErrCarNotAvailable)},
+		{desc: "error", body: body, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Hold(gomock.Any(), hold).Return(models.Reservation{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/reservations", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		h.Hold(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetReservation handler layer test function to test handler layer GetReservation function
+func TestGetReservation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockReservations(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetReservation(gomock.Any(), id).Return(models.Reservation{}, nil)},
+		{desc: "not found", statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetReservation(gomock.Any(), id).
+				Return(models.Reservation{}, reservation.ErrReservationNotFound)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/reservations/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.GetReservation(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestExtend handler layer test function to test handler layer Extend function
+func TestExtend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockReservations(ctrl)
+	h := New(mockService)
+
+	until := time.Date(2022, 3, 4, 10, 0, 0, 0, time.UTC)
+	body := `{"ExpiresAt":"2022-03-04T10:00:00Z"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Extend(gomock.Any(), id, until).Return(models.Reservation{}, nil)},
+		{desc: "malformed body", body: `{"ExpiresAt":"tomorrow"}`, statusCode: http.StatusBadRequest},
+		{desc: "not active", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Extend(gomock.Any(), id, until).
+				Return(models.Reservation{}, reservation.ErrHoldNotActive)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/reservations/"+id+"/extend", strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.Extend(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestRelease handler layer test function to test handler layer Release function
+func TestRelease(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockReservations(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Release(gomock.Any(), id).Return(models.Reservation{}, nil)},
+		{desc: "not active", statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Release(gomock.Any(), id).
+				Return(models.Reservation{}, reservation.ErrHoldNotActive)},
+		{desc: "not found", statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Release(gomock.Any(), id).
+				Return(models.Reservation{}, reservation.ErrReservationNotFound)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/reservations/"+id+"/release", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.Release(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
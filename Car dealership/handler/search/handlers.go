@@ -0,0 +1,59 @@
+package search
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+)
+
+type handler struct {
+	service service.Searcher
+}
+
+func New(s service.Searcher) handler { //nolint
+	return handler{service: s}
+}
+
+// Search handler layer function to find cars by name, brand, fuel type or year
+func (h handler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	q := r.URL.Query().Get("q")
+	if q == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	limit := 0
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		limit = n
+	}
+
+	resp, err := h.service.Search(ctx, q, limit)
+	if err != nil {
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,46 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+
+	"github.com/golang/mock/gomock"
+)
+
+// TestSearch handler layer test function to test handler layer Search function
+func TestSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockSearcher(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		query      string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", query: "?q=tesla&limit=5", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Search(gomock.Any(), "tesla", 5).Return([]models.SearchResult{}, nil)},
+		{desc: "missing query", query: "", statusCode: http.StatusBadRequest},
+		{desc: "invalid limit", query: "?q=tesla&limit=-1", statusCode: http.StatusBadRequest},
+		{desc: "error", query: "?q=tesla", statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Search(gomock.Any(), "tesla", 0).Return(nil, errors.New("error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/cars/search"+tc.query, nil)
+		res := httptest.NewRecorder()
+
+		h.Search(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
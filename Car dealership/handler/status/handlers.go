@@ -0,0 +1,83 @@
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/status"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.Statuses
+}
+
+func New(s service.Statuses) handler { //nolint
+	return handler{service: s}
+}
+
+// Transition handler layer function to move a car to the status given in the body,
+// illegal moves are rejected with 409
+func (h handler) Transition(w http.ResponseWriter, r *http.Request) {
+	var change models.StatusChange
+
+	if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Transition(r.Context(), mux.Vars(r)["id"], change)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetStatusHistory handler layer function to get every status transition of a car
+func (h handler) GetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetStatusHistory(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, status.ErrCarNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, models.ErrUnknownStatus), errors.Is(err, status.ErrMissingActor):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, status.ErrIllegalTransition), errors.Is(err, status.ErrGuardFailed),
+		errors.Is(err, status.ErrStatusConflict):
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
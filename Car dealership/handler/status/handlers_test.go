@@ -0,0 +1,98 @@
+package status
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/status"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// TestTransition handler layer test function to test handler layer Transition function
+func TestTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockStatuses(ctrl)
+	h := New(mockService)
+
+	change := models.StatusChange{To: models.StatusSold, Actor: "sahil"}
+	body := `{"To":"sold","Actor":"sahil"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Transition(gomock.Any(), id, change).Return(change, nil)},
+		{desc: "malformed body", body: `{"To":`, statusCode: http.StatusBadRequest},
+		{desc: "unknown status", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Transition(gomock.Any(), id, change).
+				Return(models.StatusChange{}, models.ErrUnknownStatus)},
+		{desc: "not found", body: body, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Transition(gomock.Any(), id, change).
+				Return(models.StatusChange{}, status.ErrCarNotFound)},
+		{desc: "illegal move", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Transition(gomock.Any(), id, change).
+				Return(models.StatusChange{}, status.ErrIllegalTransition)},
+		{desc: "guard failed", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Transition(gomock.Any(), id, change).
+				Return(models.StatusChange{}, status.ErrGuardFailed)},
+		{desc: "error", body: body, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Transition(gomock.Any(), id, change).
+				Return(models.StatusChange{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/car/"+id+"/status", strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.Transition(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetStatusHistory handler layer test function to test handler layer GetStatusHistory function
+func TestGetStatusHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockStatuses(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetStatusHistory(gomock.Any(), id).Return([]models.StatusChange{}, nil)},
+		{desc: "not found", statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetStatusHistory(gomock.Any(), id).Return(nil, status.ErrCarNotFound)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/car/"+id+"/status/history", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.GetStatusHistory(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package testdrive
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/testdrive"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.TestDrives
+}
+
+func New(s service.TestDrives) handler { //nolint
+	return handler{service: s}
+}
+
+// Book handler layer function to book the test drive given in the body, a time at which the car or the
+// salesperson is busy is rejected with 409
+func (h handler) Book(w http.ResponseWriter, r *http.Request) {
+	var d models.TestDrive
+
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Book(r.Context(), d)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetTestDrive handler layer function to get a test drive by its id
+func (h handler) GetTestDrive(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetTestDrive(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Reschedule handler layer function to move a test drive to the Start and End given in the body
+func (h handler) Reschedule(w http.ResponseWriter, r *http.Request) {
+	var d models.TestDrive
+
+	if err := json.NewDecoder(r.Body).Decode(&d); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Reschedule(r.Context(), mux.Vars(r)["id"], d)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Cancel handler layer function to cancel a scheduled test drive
+func (h handler) Cancel(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.Cancel(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetSlots handler layer function to list the free test drive slots of a car on the date given in the query,
+// the optional duration is in minutes
+func (h handler) GetSlots(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var length time.Duration
+
+	if duration := query.Get("duration"); duration != "" {
+		minutes, err := strconv.Atoi(duration)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("duration must be a number of minutes"))
+
+			return
+		}
+
+		length = time.Duration(minutes) * time.Minute
+	}
+
+	resp, err := h.service.GetSlots(r.Context(), mux.Vars(r)["id"], query.Get("date"), length, query.Get("salesperson"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Calendar handler layer function to get the iCalendar feed of the test drives of a salesperson
+func (h handler) Calendar(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.Calendar(r.Context(), mux.Vars(r)["name"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+
+	_, _ = w.Write(resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, testdrive.ErrTestDriveNotFound), errors.Is(err, testdrive.ErrCarNotFound),
+		errors.Is(err, testdrive.ErrCustomerNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, testdrive.ErrInvalidTestDrive):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, testdrive.ErrSlotTaken), errors.Is(err, testdrive.ErrTestDriveClosed),
+		errors.Is(err, testdrive.ErrCarNotAvailable):
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,241 @@
+package testdrive
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/testdrive"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// TestBook handler layer test function to test handler layer Book function
+func TestBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockTestDrives(ctrl)
+	h := New(mockService)
+
+	d := models.TestDrive{CarID: uuid.MustParse(id), Salesperson: "sahil",
+		Start: time.Date(2022, 3, 2, 10, 0, 0, 0, time.UTC)}
+	body := `{"CarID":"` + id + `","Salesperson":"sahil","Start":"2022-03-02T10:00:00Z"}`
+	taken := fmt.Errorf("%w: the car is booked", testdrive.ErrSlotTaken)
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().Book(gomock.Any(), d).Return(d, nil)},
+		{desc: "malformed body", body: `{"Start":"tomorrow"}`, statusCode: http.StatusBadRequest},
+		{desc: "invalid", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Book(gomock.Any(), d).Return(models.TestDrive{}, testdrive.ErrInvalidTestDrive)},
+		{desc: "car not found", body: body, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Book(gomock.Any(), d).Return(models.TestDrive{}, testdrive.ErrCarNotFound)},
+		{desc: "slot taken", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Book(gomock.Any(), d).Return(models.TestDrive{}, taken)},
+		{desc: "error", body: body, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Book(gomock.Any(), d).Return(models.TestDrive{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/test-drives", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		h.Book(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetTestDrive handler layer test function to test handler layer GetTestDrive function
+func TestGetTestDrive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockTestDrives(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetTestDrive(gomock.Any(), id).Return(models.TestDrive{}, nil)},
+		{desc: "not found", statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetTestDrive(gomock.Any(), id).
+				Return(models.TestDrive{}, testdrive.ErrTestDriveNotFound)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/test-drives/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.GetTestDrive(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestReschedule handler layer test function to test handler layer Reschedule function
+func TestReschedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockTestDrives(ctrl)
+	h := New(mockService)
+
+	d := models.TestDrive{Start: time.Date(2022, 3, 2, 15, 0, 0, 0, time.UTC)}
+	body := `{"Start":"2022-03-02T15:00:00Z"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Reschedule(gomock.Any(), id, d).Return(d, nil)},
+		{desc: "malformed body", body: `{"Start":`, statusCode: http.StatusBadRequest},
+		{desc: "cancelled", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Reschedule(gomock.Any(), id, d).
+				Return(models.TestDrive{}, testdrive.ErrTestDriveClosed)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPut, "/test-drives/"+id, strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.Reschedule(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestCancel handler layer test function to test handler layer Cancel function
+func TestCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockTestDrives(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Cancel(gomock.Any(), id).Return(models.TestDrive{}, nil)},
+		{desc: "cancelled", statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Cancel(gomock.Any(), id).Return(models.TestDrive{}, testdrive.ErrTestDriveClosed)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/test-drives/"+id+"/cancel", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.Cancel(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetSlots handler layer test function to test handler layer GetSlots function
+func TestGetSlots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockTestDrives(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		query      string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", query: "date=2022-03-02&duration=45&salesperson=sahil", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetSlots(gomock.Any(), id, "2022-03-02", 45*time.Minute, "sahil").
+				Return([]models.Slot{}, nil)},
+		{desc: "default duration", query: "date=2022-03-02", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetSlots(gomock.Any(), id, "2022-03-02", time.Duration(0), "").
+				Return([]models.Slot{}, nil)},
+		{desc: "invalid duration", query: "date=2022-03-02&duration=1h", statusCode: http.StatusBadRequest},
+		{desc: "invalid date", query: "date=tomorrow", statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().GetSlots(gomock.Any(), id, "tomorrow", time.Duration(0), "").
+				Return(nil, testdrive.ErrInvalidTestDrive)},
+		{desc: "car sold", query: "date=2022-03-02", statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().GetSlots(gomock.Any(), id, "2022-03-02", time.Duration(0), "").
+				Return(nil, testdrive.ErrCarNotAvailable)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/car/"+id+"/test-drives/slots?"+tc.query, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.GetSlots(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestCalendar handler layer test function to test handler layer Calendar function
+func TestCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockTestDrives(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc        string
+		statusCode  int
+		contentType string
+		mock        *gomock.Call
+	}{
+		{desc: "success", statusCode: http.StatusOK, contentType: "text/calendar; charset=utf-8",
+			mock: mockService.EXPECT().Calendar(gomock.Any(), "sahil").Return([]byte("BEGIN:VCALENDAR\r\n"), nil)},
+		{desc: "error", statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Calendar(gomock.Any(), "sahil").Return(nil, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/salespeople/sahil/test-drives.ics", nil)
+		req = mux.SetURLVars(req, map[string]string{"name": "sahil"})
+		res := httptest.NewRecorder()
+
+		h.Calendar(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+
+		if tc.contentType != "" && res.Header().Get("Content-Type") != tc.contentType {
+			t.Errorf("%v: Expected Content-Type: %v, Got: %v", tc.desc, tc.contentType, res.Header().Get("Content-Type"))
+		}
+	}
+}
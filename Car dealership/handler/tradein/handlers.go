@@ -0,0 +1,142 @@
+package tradein
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/tradein"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.TradeIns
+}
+
+func New(s service.TradeIns) handler { //nolint
+	return handler{service: s}
+}
+
+// Estimate handler layer function to estimate the value of the trade-in given in the body without recording it
+func (h handler) Estimate(w http.ResponseWriter, r *http.Request) {
+	var v models.TradeInVehicle
+
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Estimate(r.Context(), v)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Appraise handler layer function to value the trade-in of a customer given in the body and record the appraisal
+func (h handler) Appraise(w http.ResponseWriter, r *http.Request) {
+	var a models.Appraisal
+
+	if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Appraise(r.Context(), a)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetAppraisal handler layer function to get an appraisal by its id
+func (h handler) GetAppraisal(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetAppraisal(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetAppraisals handler layer function to get the appraisals of a customer
+func (h handler) GetAppraisals(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetAppraisals(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetCurves handler layer function to get every depreciation curve
+func (h handler) GetCurves(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetCurves(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// SetCurve handler layer function to set the depreciation curve given in the body
+func (h handler) SetCurve(w http.ResponseWriter, r *http.Request) {
+	var c models.DepreciationCurve
+
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.SetCurve(r.Context(), c)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, tradein.ErrAppraisalNotFound), errors.Is(err, tradein.ErrCustomerNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, tradein.ErrInvalidVehicle), errors.Is(err, tradein.ErrInvalidAppraisal),
+		errors.Is(err, tradein.ErrInvalidCurve), errors.Is(err, tradein.ErrNoCurve):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,180 @@
+package tradein
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/tradein"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// TestEstimate handler layer test function to test handler layer Estimate function
+func TestEstimate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockTradeIns(ctrl)
+	h := New(mockService)
+
+	v := models.TradeInVehicle{Brand: "BMW", Name: "320d", Year: 2018, FuelType: "diesel", Mileage: 75000,
+		Condition: "good"}
+	body := `{"Brand":"BMW","Name":"320d","Year":2018,"FuelType":"diesel","Mileage":75000,"Condition":"good"}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Estimate(gomock.Any(), v).Return(models.Valuation{}, nil)},
+		{desc: "malformed body", body: `{"Year":"2018"}`, statusCode: http.StatusBadRequest},
+		{desc: "invalid vehicle", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Estimate(gomock.Any(), v).Return(models.Valuation{}, tradein.ErrInvalidVehicle)},
+		{desc: "no curve", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Estimate(gomock.Any(), v).Return(models.Valuation{}, tradein.ErrNoCurve)},
+		{desc: "error", body: body, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Estimate(gomock.Any(), v).Return(models.Valuation{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/trade-ins/estimate", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		h.Estimate(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestAppraise handler layer test function to test handler layer Appraise function
+func TestAppraise(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockTradeIns(ctrl)
+	h := New(mockService)
+
+	a := models.Appraisal{CustomerID: uuid.MustParse(id), Appraiser: "sahil",
+		Vehicle: models.TradeInVehicle{Brand: "BMW", Name: "320d", Year: 2018, FuelType: "diesel", Condition: "good"}}
+	body := `{"CustomerID":"` + id + `","Appraiser":"sahil","Vehicle":{"Brand":"BMW","Name":"320d","Year":2018,` +
+		`"FuelType":"diesel","Condition":"good"}}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().Appraise(gomock.Any(), a).Return(a, nil)},
+		{desc: "malformed body", body: `{"Vehicle":`, statusCode: http.StatusBadRequest},
+		{desc: "customer not found", body: body, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Appraise(gomock.Any(), a).Return(models.Appraisal{}, tradein.ErrCustomerNotFound)},
+		{desc: "invalid appraisal", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Appraise(gomock.Any(), a).Return(models.Appraisal{}, tradein.ErrInvalidAppraisal)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/appraisals", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		h.Appraise(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGetAppraisals handler layer test function to test handler layer GetAppraisal and GetAppraisals functions
+func TestGetAppraisals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockTradeIns(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		handler    http.HandlerFunc
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "appraisal", handler: h.GetAppraisal, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetAppraisal(gomock.Any(), id).Return(models.Appraisal{}, nil)},
+		{desc: "appraisal not found", handler: h.GetAppraisal, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetAppraisal(gomock.Any(), id).
+				Return(models.Appraisal{}, tradein.ErrAppraisalNotFound)},
+		{desc: "appraisals of a customer", handler: h.GetAppraisals, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetAppraisals(gomock.Any(), id).Return([]models.Appraisal{}, nil)},
+		{desc: "customer not found", handler: h.GetAppraisals, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetAppraisals(gomock.Any(), id).Return(nil, tradein.ErrCustomerNotFound)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/appraisals/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		tc.handler(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestCurves handler layer test function to test handler layer GetCurves and SetCurve functions
+func TestCurves(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockTradeIns(ctrl)
+	h := New(mockService)
+
+	c := models.DepreciationCurve{Brand: "*", FuelType: "*", Currency: "USD", BaseValue: 2000000,
+		Retention: []int{8000}, MileagePerYear: 15000, MileageRate: 300}
+	body := `{"Brand":"*","FuelType":"*","Currency":"USD","BaseValue":"20000","Retention":[8000],` +
+		`"MileagePerYear":15000,"MileageRate":300}`
+
+	testCases := []struct {
+		desc       string
+		method     string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "get", method: http.MethodGet, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetCurves(gomock.Any()).Return([]models.DepreciationCurve{c}, nil)},
+		{desc: "set", method: http.MethodPut, body: body, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().SetCurve(gomock.Any(), c).Return(c, nil)},
+		{desc: "malformed body", method: http.MethodPut, body: `{"Retention":"fast"}`,
+			statusCode: http.StatusBadRequest},
+		{desc: "invalid curve", method: http.MethodPut, body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().SetCurve(gomock.Any(), c).Return(models.DepreciationCurve{}, tradein.ErrInvalidCurve)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(tc.method, "/trade-ins/curves", strings.NewReader(tc.body))
+		res := httptest.NewRecorder()
+
+		if tc.method == http.MethodGet {
+			h.GetCurves(res, req)
+		} else {
+			h.SetCurve(res, req)
+		}
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package vin
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/vin"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.VINs
+}
+
+func New(v service.VINs) handler { //nolint
+	return handler{service: v}
+}
+
+// GetCarByVIN handler layer function to get car record by giving its vin
+func (h handler) GetCarByVIN(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := h.service.GetCarByVIN(ctx, mux.Vars(r)["vin"])
+
+	switch {
+	case errors.Is(err, vin.ErrInvalidVIN):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	case errors.Is(err, vin.ErrCarNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case err != nil:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Decode handler layer function to decode the vin given in the vin query parameter
+func (h handler) Decode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	resp, err := h.service.Decode(ctx, r.URL.Query().Get("vin"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,84 @@
+package vin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/vin"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+// TestGetCarByVIN handler layer test function to test handler layer GetCarByVIN function
+func TestGetCarByVIN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockVINs(ctrl)
+	h := New(mockService)
+
+	const v = "5YJ3E1EA2JF000316"
+
+	testCases := []struct {
+		desc       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetCarByVIN(gomock.Any(), v).Return(models.Car{VIN: v}, nil)},
+		{desc: "invalid", statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().GetCarByVIN(gomock.Any(), v).Return(models.Car{}, vin.ErrInvalidVIN)},
+		{desc: "not found", statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetCarByVIN(gomock.Any(), v).Return(models.Car{}, vin.ErrCarNotFound)},
+		{desc: "error", statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().GetCarByVIN(gomock.Any(), v).Return(models.Car{}, errors.New("error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/cars/vin/"+v, nil)
+		req = mux.SetURLVars(req, map[string]string{"vin": v})
+		res := httptest.NewRecorder()
+
+		h.GetCarByVIN(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestDecode handler layer test function to test handler layer Decode function
+func TestDecode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockVINs(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		vin        string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", vin: "5YJ3E1EA2JF000316", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Decode(gomock.Any(), "5YJ3E1EA2JF000316").
+				Return(models.VINInfo{Manufacturer: "Tesla"}, nil)},
+		{desc: "malformed", vin: "ABC", statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Decode(gomock.Any(), "ABC").Return(models.VINInfo{}, vin.ErrInvalidVIN)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/vin/decode?vin="+tc.vin, nil)
+		res := httptest.NewRecorder()
+
+		h.Decode(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package warranty
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/warranty"
+
+	"github.com/gorilla/mux"
+)
+
+type handler struct {
+	service service.Warranties
+}
+
+func New(s service.Warranties) handler { //nolint
+	return handler{service: s}
+}
+
+// Attach handler layer function to attach the warranty given in the body to a car
+func (h handler) Attach(w http.ResponseWriter, r *http.Request) {
+	var plan models.Warranty
+
+	if err := json.NewDecoder(r.Body).Decode(&plan); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+
+		return
+	}
+
+	resp, err := h.service.Attach(r.Context(), mux.Vars(r)["id"], plan)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	writeJSON(w, resp)
+}
+
+// GetWarranty handler layer function to get a warranty by its id
+func (h handler) GetWarranty(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetWarranty(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetWarranties handler layer function to get the warranties of a car
+func (h handler) GetWarranties(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.GetWarranties(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Coverage handler layer function to get the warranty cover of a car on the date and odometer reading in km given
+// in the query, both are optional
+func (h handler) Coverage(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var odometer int
+
+	if km := query.Get("odometer"); km != "" {
+		var err error
+
+		if odometer, err = strconv.Atoi(km); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("odometer must be a number of km"))
+
+			return
+		}
+	}
+
+	resp, err := h.service.Coverage(r.Context(), mux.Vars(r)["id"], query.Get("date"), odometer)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// Expiring handler layer function to report the warranties expiring within the days given in the query
+func (h handler) Expiring(w http.ResponseWriter, r *http.Request) {
+	var days int
+
+	if d := r.URL.Query().Get("days"); d != "" {
+		var err error
+
+		if days, err = strconv.Atoi(d); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("days must be a number"))
+
+			return
+		}
+	}
+
+	resp, err := h.service.Expiring(r.Context(), days)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, warranty.ErrWarrantyNotFound), errors.Is(err, warranty.ErrCarNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, warranty.ErrInvalidWarranty):
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+	case errors.Is(err, warranty.ErrWarrantyExists):
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(err.Error()))
+	default:
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_, _ = w.Write(body)
+}
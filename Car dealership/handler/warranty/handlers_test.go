@@ -0,0 +1,171 @@
+package warranty
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/warranty"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// TestAttach handler layer test function to test handler layer Attach function
+func TestAttach(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockWarranties(ctrl)
+	h := New(mockService)
+
+	plan := models.Warranty{Kind: "manufacturer", Provider: "BMW", DurationMonths: 36,
+		StartDate: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), MileageLimit: 100000, Components: []string{"engine"}}
+	body := `{"Kind":"manufacturer","Provider":"BMW","StartDate":"2022-03-01T00:00:00Z","DurationMonths":36,` +
+		`"MileageLimit":100000,"Components":["engine"]}`
+
+	testCases := []struct {
+		desc       string
+		body       string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", body: body, statusCode: http.StatusCreated,
+			mock: mockService.EXPECT().Attach(gomock.Any(), id, plan).Return(plan, nil)},
+		{desc: "malformed body", body: `{"StartDate":"2022-03-01"}`, statusCode: http.StatusBadRequest},
+		{desc: "invalid", body: body, statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Attach(gomock.Any(), id, plan).
+				Return(models.Warranty{}, warranty.ErrInvalidWarranty)},
+		{desc: "exists", body: body, statusCode: http.StatusConflict,
+			mock: mockService.EXPECT().Attach(gomock.Any(), id, plan).
+				Return(models.Warranty{}, warranty.ErrWarrantyExists)},
+		{desc: "car not found", body: body, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().Attach(gomock.Any(), id, plan).
+				Return(models.Warranty{}, warranty.ErrCarNotFound)},
+		{desc: "error", body: body, statusCode: http.StatusInternalServerError,
+			mock: mockService.EXPECT().Attach(gomock.Any(), id, plan).
+				Return(models.Warranty{}, errors.New("db error"))},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/car/"+id+"/warranties", strings.NewReader(tc.body))
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.Attach(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestGet handler layer test function to test handler layer GetWarranty and GetWarranties functions
+func TestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockWarranties(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		handler    http.HandlerFunc
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "get", handler: h.GetWarranty, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetWarranty(gomock.Any(), id).Return(models.Warranty{}, nil)},
+		{desc: "not found", handler: h.GetWarranty, statusCode: http.StatusNotFound,
+			mock: mockService.EXPECT().GetWarranty(gomock.Any(), id).
+				Return(models.Warranty{}, warranty.ErrWarrantyNotFound)},
+		{desc: "of a car", handler: h.GetWarranties, statusCode: http.StatusOK,
+			mock: mockService.EXPECT().GetWarranties(gomock.Any(), id).Return([]models.Warranty{}, nil)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/warranties/"+id, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		tc.handler(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestCoverage handler layer test function to test handler layer Coverage function
+func TestCoverage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockWarranties(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		query      string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", query: "?date=2022-03-01&odometer=12000", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Coverage(gomock.Any(), id, "2022-03-01", 12000).
+				Return(models.Coverage{}, nil)},
+		{desc: "defaults", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Coverage(gomock.Any(), id, "", 0).Return(models.Coverage{}, nil)},
+		{desc: "bad odometer", query: "?odometer=twelve", statusCode: http.StatusBadRequest},
+		{desc: "bad date", query: "?date=yesterday", statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Coverage(gomock.Any(), id, "yesterday", 0).
+				Return(models.Coverage{}, warranty.ErrInvalidWarranty)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/car/"+id+"/warranties/coverage"+tc.query, nil)
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+		res := httptest.NewRecorder()
+
+		h.Coverage(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
+
+// TestExpiring handler layer test function to test handler layer Expiring function
+func TestExpiring(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := service.NewMockWarranties(ctrl)
+	h := New(mockService)
+
+	testCases := []struct {
+		desc       string
+		query      string
+		statusCode int
+		mock       *gomock.Call
+	}{
+		{desc: "success", query: "?days=7", statusCode: http.StatusOK,
+			mock: mockService.EXPECT().Expiring(gomock.Any(), 7).Return([]models.Warranty{}, nil)},
+		{desc: "bad days", query: "?days=week", statusCode: http.StatusBadRequest},
+		{desc: "too far", query: "?days=1000", statusCode: http.StatusBadRequest,
+			mock: mockService.EXPECT().Expiring(gomock.Any(), 1000).Return(nil, warranty.ErrInvalidWarranty)},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/warranties/expiring"+tc.query, nil)
+		res := httptest.NewRecorder()
+
+		h.Expiring(res, req)
+
+		if res.Code != tc.statusCode {
+			t.Errorf("%v: Expected Status Code: %v, Got: %v", tc.desc, tc.statusCode, res.Code)
+		}
+	}
+}
@@ -1,25 +1,182 @@
 package main
 
 import (
+	"context"
+	"expvar"
 	"github.com/gorilla/mux"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/blob"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/cache"
 	store "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/car"
+	cataloguestore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/catalogue"
+	customerstore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/customer"
 	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/engine"
+	leadstore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/lead"
+	lenderstore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/lender"
+	maintenancestore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/maintenance"
+	mediastore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/media"
+	orderstore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/order"
+	pricestore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/price"
+	reservationstore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/reservation"
+	statusstore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/status"
+	testdrivestore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/testdrive"
+	tradeinstore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/tradein"
+	warrantystore "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore/warranty"
 	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/driver"
 	handler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/car"
+	cataloguehandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/catalogue"
+	comparehandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/compare"
+	customerhandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/customer"
+	exporthandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/export"
+	financehandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/finance"
+	graphqlhandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/graphql"
+	importhandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/importer"
+	leadhandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/lead"
+	maintenancehandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/maintenance"
+	mediahandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/media"
+	orderhandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/order"
+	pricehandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/price"
+	quotahandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/quota"
+	recommendhandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/recommend"
+	reservationhandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/reservation"
+	searchhandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/search"
+	statushandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/status"
+	testdrivehandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/testdrive"
+	tradeinhandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/tradein"
+	vinhandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/vin"
+	warrantyhandler "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/handler/warranty"
 	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/middleware"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/rpc"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/rpc/pb"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/server"
+	services "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
 	service "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/car"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/catalogue"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/compare"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/customer"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/export"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/finance"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/importer"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/lead"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/listing"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/maintenance"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/media"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/order"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/powertrain"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/price"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/recommend"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/reservation"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/search"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/status"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/testdrive"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/tradein"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/vin"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/warranty"
 	"log"
 	"net/http"
+	"os"
+	"time"
 )
 
 func main() {
 
 	db := driver.ConnectToSQL()
-	st := store.New(db)
 
-	engin := engine.New(db)
-	svc := service.New(st, engin)
-	list := handler.New(svc)
+	index := search.NewIndex()
+	if err := index.Load(context.Background(), store.New(db)); err != nil {
+		log.Println("Cant build search index", err)
+	}
+
+	cached := cache.New(store.New(db), engine.New(db), cache.NewLRU(10000), 5*time.Minute)
+	expvar.Publish("cache", expvar.Func(func() interface{} { return cached.Metrics() }))
+
+	st := search.NewIndexedStore(cached, index)
+
+	engin := cached
+	mediaStore := mediastore.New(db)
+	catalogueStore := search.NewIndexedCatalogue(cache.NewCatalogue(cataloguestore.New(db), cached), index)
+	decorate := func(next services.Cars) services.Cars {
+		next = status.NewCarValidator(vin.NewCarValidator(powertrain.NewCarValidator(next)))
+		return catalogue.NewCarResolver(next, catalogueStore, engin)
+	}
+	svc := media.NewCarMedia(decorate(service.New(st, engin)), mediaStore)
+	listings := media.NewListingMedia(catalogue.NewListingResolver(listing.New(st), catalogueStore, engin), mediaStore)
+	list := handler.New(svc, listings)
+	imports := importhandler.New(importer.New(st, engin, decorate, 4))
+	exports := exporthandler.New(catalogue.NewExportResolver(export.New(st), catalogueStore, engin))
+	searches := searchhandler.New(index)
+	vinService := vin.New(st)
+	vins := vinhandler.New(vinService)
+	priceStore := search.NewIndexedPrices(cache.NewPrices(pricestore.New(db), cached), index)
+	prices := pricehandler.New(price.New(st, priceStore))
+	statusStore := search.NewIndexedStatus(cache.NewStatus(statusstore.New(db), cached), index)
+	statuses := statushandler.New(status.New(st, statusStore))
+
+	holdStore := search.NewIndexedReservations(cache.NewReservations(reservationstore.New(db), cached), index)
+	holds := reservation.New(st, holdStore)
+	go holds.Run(context.Background(), time.Minute)
+
+	reservations := reservationhandler.New(holds)
+
+	customerStore := customerstore.New(db)
+	customers := customerhandler.New(customer.New(customerStore))
+	leads := leadhandler.New(lead.New(st, customerStore, leadstore.New(db)))
+	orderStore := search.NewIndexedOrders(cache.NewOrders(orderstore.New(db), cached), index)
+	orders := orderhandler.New(order.New(st, customerStore, orderStore, "MAIN"))
+	testDrives := testdrivehandler.New(testdrive.New(st, customerStore, testdrivestore.New(db), time.Local))
+	loans := financehandler.New(finance.New(st, lenderstore.New(db)))
+	tradeIns := tradeinhandler.New(tradein.New(customerStore, tradeinstore.New(db)))
+	servicing := maintenancehandler.New(maintenance.New(st, engin, customerStore, maintenancestore.New(db)))
+	warranties := warrantyhandler.New(warranty.New(st, warrantystore.New(db)))
+	comparisons := comparehandler.New(compare.New(st, engin))
+	recommendations := recommendhandler.New(recommend.New(st, engin))
+	catalogues := cataloguehandler.New(catalogue.New(catalogueStore, engin))
+	photos := mediahandler.New(media.New(st, mediaStore, blob.NewLocal("media")))
+
+	schema, err := graphqlhandler.NewSchema(svc, listings, vinService)
+	if err != nil {
+		log.Fatal("Cant build the GraphQL schema ", err)
+	}
+
+	graph := graphqlhandler.New(schema, engin, graphqlhandler.Limits{MaxDepth: 8, MaxComplexity: 1000})
+
+	clients, err := middleware.ParseClients(os.Getenv("API_KEYS"))
+	if err != nil {
+		log.Fatal("Cant read API_KEYS ", err)
+	}
+
+	limiter, err := middleware.NewRateLimiter(middleware.RateLimits{
+		Clients: clients,
+		Rules: []middleware.Rule{
+			{Rate: 10, Burst: 20},
+			{Route: "/cars", Rate: 2, Burst: 10},
+			{Route: "/graphql", Rate: 2, Burst: 10},
+			{Route: pb.CarService_ListCars_FullMethodName, Rate: 2, Burst: 10},
+			{Role: "partner", Rate: 20, Burst: 40},
+			{Route: "/cars", Role: "partner", Rate: 5, Burst: 20},
+			{Route: "/graphql", Role: "partner", Rate: 5, Burst: 20},
+			{Route: pb.CarService_ListCars_FullMethodName, Role: "partner", Rate: 5, Burst: 20},
+			{Role: middleware.RoleAdmin, Rate: 50, Burst: 100},
+		},
+		Quotas: map[string]int{middleware.RoleAnonymous: 5000, "partner": 50000},
+	})
+	if err != nil {
+		log.Fatal("Cant build the rate limiter ", err)
+	}
+
+	quotas := quotahandler.New(limiter)
+
+	origins, err := middleware.ParseOrigins(os.Getenv("CORS_ORIGINS"))
+	if err != nil {
+		log.Fatal("Cant read CORS_ORIGINS ", err)
+	}
+
+	cors := middleware.CORS(middleware.CORSConfig{
+		Origins:        origins,
+		Methods:        []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodDelete},
+		Headers:        []string{"authorize", middleware.APIKeyHeader, "Content-Type"},
+		ExposedHeaders: []string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
+		MaxAge:         10 * time.Minute,
+	})
 
 	r := mux.NewRouter()
 
@@ -28,11 +185,131 @@ func main() {
 	r.HandleFunc("/car", list.CreateCar).Methods(http.MethodPost)
 	r.HandleFunc("/car/del/{id}", list.DeleteCar).Methods(http.MethodDelete)
 	r.HandleFunc("/car/upd/{id}", list.UpdateCar).Methods(http.MethodPut)
+	r.HandleFunc("/cars/import", imports.Import).Methods(http.MethodPost)
+	r.HandleFunc("/cars/import/{id}", imports.GetJob).Methods(http.MethodGet)
+	r.HandleFunc("/cars/export", exports.Export).Methods(http.MethodGet)
+	r.HandleFunc("/cars/search", searches.Search).Methods(http.MethodGet)
+	r.HandleFunc("/cars/vin/{vin}", vins.GetCarByVIN).Methods(http.MethodGet)
+	r.HandleFunc("/vin/decode", vins.Decode).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/price", prices.GetPrice).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/price", prices.SetPrice).Methods(http.MethodPut)
+	r.HandleFunc("/car/{id}/price/history", prices.GetPriceHistory).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/status", statuses.Transition).Methods(http.MethodPost)
+	r.HandleFunc("/car/{id}/status/history", statuses.GetStatusHistory).Methods(http.MethodGet)
+	r.HandleFunc("/reservations", reservations.Hold).Methods(http.MethodPost)
+	r.HandleFunc("/reservations/{id}", reservations.GetReservation).Methods(http.MethodGet)
+	r.HandleFunc("/reservations/{id}/extend", reservations.Extend).Methods(http.MethodPost)
+	r.HandleFunc("/reservations/{id}/release", reservations.Release).Methods(http.MethodPost)
+	r.HandleFunc("/customers", customers.CreateCustomer).Methods(http.MethodPost)
+	r.HandleFunc("/customers/{id}", customers.GetCustomer).Methods(http.MethodGet)
+	r.HandleFunc("/leads", leads.Capture).Methods(http.MethodPost)
+	r.HandleFunc("/leads", leads.GetLeads).Methods(http.MethodGet)
+	r.HandleFunc("/leads/{id}", leads.GetLead).Methods(http.MethodGet)
+	r.HandleFunc("/leads/{id}/status", leads.Transition).Methods(http.MethodPost)
+	r.HandleFunc("/leads/{id}/assignee", leads.Assign).Methods(http.MethodPut)
+	r.HandleFunc("/orders", orders.CreateOrder).Methods(http.MethodPost)
+	r.HandleFunc("/orders", orders.GetOrders).Methods(http.MethodGet)
+	r.HandleFunc("/orders/{id}", orders.GetOrder).Methods(http.MethodGet)
+	r.HandleFunc("/orders/{id}", orders.UpdateOrder).Methods(http.MethodPut)
+	r.HandleFunc("/orders/{id}", orders.CancelOrder).Methods(http.MethodDelete)
+	r.HandleFunc("/orders/{id}/payments", orders.AddPayment).Methods(http.MethodPost)
+	r.HandleFunc("/orders/{id}/invoice", orders.GetInvoice).Methods(http.MethodGet)
+	r.HandleFunc("/test-drives", testDrives.Book).Methods(http.MethodPost)
+	r.HandleFunc("/test-drives/{id}", testDrives.GetTestDrive).Methods(http.MethodGet)
+	r.HandleFunc("/test-drives/{id}", testDrives.Reschedule).Methods(http.MethodPut)
+	r.HandleFunc("/test-drives/{id}/cancel", testDrives.Cancel).Methods(http.MethodPost)
+	r.HandleFunc("/car/{id}/test-drives/slots", testDrives.GetSlots).Methods(http.MethodGet)
+	r.HandleFunc("/salespeople/{name}/test-drives.ics", testDrives.Calendar).Methods(http.MethodGet)
+	r.HandleFunc("/finance/quote", loans.Quote).Methods(http.MethodPost)
+	r.HandleFunc("/finance/offers", loans.Offers).Methods(http.MethodPost)
+	r.HandleFunc("/finance/rates", loans.GetRates).Methods(http.MethodGet)
+	r.HandleFunc("/finance/lenders/{lender}/rates", loans.SetRates).Methods(http.MethodPut)
+	r.HandleFunc("/trade-ins/estimate", tradeIns.Estimate).Methods(http.MethodPost)
+	r.HandleFunc("/trade-ins/curves", tradeIns.GetCurves).Methods(http.MethodGet)
+	r.HandleFunc("/trade-ins/curves", tradeIns.SetCurve).Methods(http.MethodPut)
+	r.HandleFunc("/appraisals", tradeIns.Appraise).Methods(http.MethodPost)
+	r.HandleFunc("/appraisals/{id}", tradeIns.GetAppraisal).Methods(http.MethodGet)
+	r.HandleFunc("/customers/{id}/appraisals", tradeIns.GetAppraisals).Methods(http.MethodGet)
+	r.HandleFunc("/service/appointments", servicing.Book).Methods(http.MethodPost)
+	r.HandleFunc("/service/appointments/{id}", servicing.GetAppointment).Methods(http.MethodGet)
+	r.HandleFunc("/service/appointments/{id}/cancel", servicing.CancelAppointment).Methods(http.MethodPost)
+	r.HandleFunc("/service/appointments/{id}/complete", servicing.Complete).Methods(http.MethodPost)
+	r.HandleFunc("/car/{id}/service/appointments", servicing.GetAppointments).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/service/reminder", servicing.GetReminder).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/maintenance", servicing.Record).Methods(http.MethodPost)
+	r.HandleFunc("/car/{id}/maintenance", servicing.GetHistory).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/warranties", warranties.Attach).Methods(http.MethodPost)
+	r.HandleFunc("/car/{id}/warranties", warranties.GetWarranties).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/warranties/coverage", warranties.Coverage).Methods(http.MethodGet)
+	r.HandleFunc("/warranties/expiring", warranties.Expiring).Methods(http.MethodGet)
+	r.HandleFunc("/warranties/{id}", warranties.GetWarranty).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/media", photos.Upload).Methods(http.MethodPost)
+	r.HandleFunc("/car/{id}/media", photos.GetMedia).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/media/order", photos.Reorder).Methods(http.MethodPut)
+	r.HandleFunc("/car/{id}/media/{mediaID}/primary", photos.SetPrimary).Methods(http.MethodPut)
+	r.HandleFunc("/car/{id}/media/{mediaID}", photos.Delete).Methods(http.MethodDelete)
+	r.HandleFunc("/media/{id}/{rendition}", photos.Serve).Methods(http.MethodGet)
+	r.HandleFunc("/cars/compare", comparisons.Compare).Methods(http.MethodGet)
+	r.HandleFunc("/car/{id}/similar", recommendations.Similar).Methods(http.MethodGet)
+	r.HandleFunc("/catalogue/brands", catalogues.CreateBrand).Methods(http.MethodPost)
+	r.HandleFunc("/catalogue/brands", catalogues.GetBrands).Methods(http.MethodGet)
+	r.HandleFunc("/catalogue/brands/{id}", catalogues.GetBrand).Methods(http.MethodGet)
+	r.HandleFunc("/catalogue/brands/{id}", catalogues.UpdateBrand).Methods(http.MethodPut)
+	r.HandleFunc("/catalogue/brands/{id}", catalogues.DeleteBrand).Methods(http.MethodDelete)
+	r.HandleFunc("/catalogue/brands/{id}/models", catalogues.CreateModel).Methods(http.MethodPost)
+	r.HandleFunc("/catalogue/brands/{id}/models", catalogues.GetModels).Methods(http.MethodGet)
+	r.HandleFunc("/catalogue/models/{id}", catalogues.GetModel).Methods(http.MethodGet)
+	r.HandleFunc("/catalogue/models/{id}", catalogues.UpdateModel).Methods(http.MethodPut)
+	r.HandleFunc("/catalogue/models/{id}", catalogues.DeleteModel).Methods(http.MethodDelete)
+	r.HandleFunc("/catalogue/models/{id}/trims", catalogues.CreateTrim).Methods(http.MethodPost)
+	r.HandleFunc("/catalogue/models/{id}/trims", catalogues.GetTrims).Methods(http.MethodGet)
+	r.HandleFunc("/catalogue/trims/{id}", catalogues.GetTrim).Methods(http.MethodGet)
+	r.HandleFunc("/catalogue/trims/{id}", catalogues.UpdateTrim).Methods(http.MethodPut)
+	r.HandleFunc("/catalogue/trims/{id}", catalogues.DeleteTrim).Methods(http.MethodDelete)
+	r.HandleFunc("/catalogue/migrations/brands", catalogues.MigrateBrands).Methods(http.MethodPost)
+	r.HandleFunc("/graphql", graph.Query).Methods(http.MethodGet, http.MethodPost)
+	r.Handle("/debug/vars", expvar.Handler()).Methods(http.MethodGet)
+	r.Handle("/admin/quotas", limiter.RequireRole(middleware.RoleAdmin, http.HandlerFunc(quotas.GetUsage))).
+		Methods(http.MethodGet)
+	r.Use(limiter.Limit)
 	r.Use(middleware.Auth)
 
-	err := http.ListenAndServe("localhost:2000", r)
+	tlsAddr := os.Getenv("TLS_ADDR")
+	if tlsAddr == "" {
+		tlsAddr = "localhost:2443"
+	}
+
+	// with a certificate the API is served over HTTPS and port 2000 only redirects to it
+	config := server.Config{
+		Addr:              "localhost:2000",
+		TLSAddr:           tlsAddr,
+		CertFile:          os.Getenv("TLS_CERT_FILE"),
+		KeyFile:           os.Getenv("TLS_KEY_FILE"),
+		ClientCAFile:      os.Getenv("TLS_CLIENT_CA_FILE"),
+		RequireClientCert: os.Getenv("TLS_CLIENT_CERT_REQUIRED") == "true",
+		ReloadEvery:       time.Minute,
+	}
+
+	tlsConfig, err := config.TLS(context.Background())
+	if err != nil {
+		log.Fatal("Cant load the TLS certificate ", err)
+	}
+
+	grpcAddr := os.Getenv("GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = "localhost:2001"
+	}
+
+	go func() {
+		if err := rpc.ListenAndServe(grpcAddr, rpc.NewServer(tlsConfig, limiter, svc, vinService, engin)); err != nil {
+			log.Println("Cant serve gRPC on", grpcAddr, err)
+		}
+	}()
+
+	// preflights are answered before the router, which does not route OPTIONS, and before authentication
+	err = server.ListenAndServe(config, tlsConfig, cors(r))
 	if err != nil {
-		log.Println("Cant Connect to port 2000", err)
+		log.Println("Cant serve the API", err)
 	}
 }
 
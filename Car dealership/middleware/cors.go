@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig is which cross-origin requests browsers may send. Origins are written as scheme://host[:port], a *
+// allows every origin. Methods default to GET, HEAD and POST, Headers are the request headers allowed on top of
+// those browsers always send and ExposedHeaders the response headers scripts may read. Credentials lets browsers
+// send cookies and MaxAge is how long they may keep a preflight response.
+type CORSConfig struct {
+	Origins        []string
+	Methods        []string
+	Headers        []string
+	ExposedHeaders []string
+	Credentials    bool
+	MaxAge         time.Duration
+}
+
+// ParseOrigins reads origins separated by commas, such as the CORS_ORIGINS environment variable
+func ParseOrigins(s string) ([]string, error) {
+	origins := []string{}
+
+	for _, o := range strings.Split(s, ",") {
+		o = strings.TrimSpace(o)
+		if o == "" {
+			continue
+		}
+
+		if o != "*" {
+			u, err := url.Parse(o)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" ||
+				u.RawQuery != "" || u.User != nil {
+				return nil, fmt.Errorf("origin %q is not written as scheme://host[:port]", o)
+			}
+		}
+
+		origins = append(origins, o)
+	}
+
+	return origins, nil
+}
+
+// CORS returns a middleware function answering preflight requests of the allowed origins and adding the CORS
+// headers to their other requests. Preflights are answered before h, so they need no authentication, a preflight
+// which is not allowed is answered 403.
+func CORS(c CORSConfig) func(h http.Handler) http.Handler {
+	methods := c.Methods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+	}
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			w.Header().Add("Vary", "Origin")
+
+			if preflight {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+			}
+
+			if origin == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			allowed, wildcard := c.origin(origin)
+
+			if preflight {
+				requested := r.Header.Get("Access-Control-Request-Headers")
+
+				if !allowed || !contains(methods, r.Header.Get("Access-Control-Request-Method")) ||
+					!c.headers(requested) {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+
+				c.allow(w, origin, wildcard)
+				w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+
+				if requested != "" {
+					w.Header().Set("Access-Control-Allow-Headers", requested)
+				}
+
+				if c.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(c.MaxAge.Seconds())))
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+
+				return
+			}
+
+			if allowed {
+				c.allow(w, origin, wildcard)
+
+				if len(c.ExposedHeaders) > 0 {
+					w.Header().Set("Access-Control-Expose-Headers", strings.Join(c.ExposedHeaders, ", "))
+				}
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// origin reports whether origin is allowed and whether it is only allowed because every origin is
+func (c CORSConfig) origin(origin string) (allowed, wildcard bool) {
+	for _, o := range c.Origins {
+		if strings.EqualFold(o, origin) {
+			return true, false
+		}
+
+		if o == "*" {
+			wildcard = true
+		}
+	}
+
+	return wildcard, wildcard
+}
+
+// headers reports whether every header of a comma separated list is allowed
+func (c CORSConfig) headers(list string) bool {
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !contains(c.Headers, name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// allow sets the origin a response is readable from, * is only sent when no credentials are allowed as browsers
+// refuse it with them
+func (c CORSConfig) allow(w http.ResponseWriter, origin string, wildcard bool) {
+	if wildcard && !c.Credentials {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
+
+	if c.Credentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+
+	return false
+}
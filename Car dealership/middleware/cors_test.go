@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCORS this function tests preflights are answered before authentication and other requests get CORS headers
+func TestCORS(t *testing.T) {
+	cors := CORS(CORSConfig{
+		Origins:        []string{"https://portal.example.com"},
+		Methods:        []string{http.MethodGet, http.MethodPut},
+		Headers:        []string{"authorize", "Content-Type"},
+		ExposedHeaders: []string{"Retry-After"},
+		Credentials:    true,
+		MaxAge:         10 * time.Minute,
+	})
+	h := cors(Auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+	testCases := []struct {
+		desc        string
+		method      string
+		origin      string
+		reqMethod   string
+		reqHeaders  string
+		auth        string
+		statusCode  int
+		allowOrigin string
+	}{
+		{"preflight", http.MethodOptions, "https://portal.example.com", http.MethodPut, "Authorize, content-type", "",
+			http.StatusNoContent, "https://portal.example.com"},
+		{"preflight of other origin", http.MethodOptions, "https://evil.example.com", http.MethodPut, "", "",
+			http.StatusForbidden, ""},
+		{"preflight of other method", http.MethodOptions, "https://portal.example.com", http.MethodDelete, "", "",
+			http.StatusForbidden, ""},
+		{"preflight of other header", http.MethodOptions, "https://portal.example.com", http.MethodGet, "X-Debug", "",
+			http.StatusForbidden, ""},
+		{"request", http.MethodGet, "https://portal.example.com", "", "", "0000", http.StatusOK,
+			"https://portal.example.com"},
+		{"request still authenticated", http.MethodGet, "https://portal.example.com", "", "", "",
+			http.StatusUnauthorized, "https://portal.example.com"},
+		{"request of other origin", http.MethodGet, "https://evil.example.com", "", "", "0000", http.StatusOK, ""},
+		{"same origin", http.MethodGet, "", "", "", "0000", http.StatusOK, ""},
+		{"options without preflight", http.MethodOptions, "https://portal.example.com", "", "", "",
+			http.StatusUnauthorized, "https://portal.example.com"},
+	}
+
+	for i, tc := range testCases {
+		req := httptest.NewRequest(tc.method, "/car", nil)
+		req.Header.Set("authorize", tc.auth)
+
+		if tc.origin != "" {
+			req.Header.Set("Origin", tc.origin)
+		}
+
+		if tc.reqMethod != "" {
+			req.Header.Set("Access-Control-Request-Method", tc.reqMethod)
+			req.Header.Set("Access-Control-Request-Headers", tc.reqHeaders)
+		}
+
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, req)
+
+		assert.Equal(t, tc.statusCode, res.Code, "Test Case %v Failed: %v", i, tc.desc)
+		assert.Equal(t, tc.allowOrigin, res.Header().Get("Access-Control-Allow-Origin"), "Test Case %v Failed: %v", i,
+			tc.desc)
+		assert.Contains(t, res.Header().Values("Vary"), "Origin", "Test Case %v Failed: %v", i, tc.desc)
+	}
+
+	req := httptest.NewRequest(http.MethodOptions, "/car", nil)
+	req.Header.Set("Origin", "https://portal.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+	req.Header.Set("Access-Control-Request-Headers", "authorize")
+
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	assert.Equal(t, "GET, PUT", res.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "authorize", res.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", res.Header().Get("Access-Control-Max-Age"))
+	assert.Equal(t, "true", res.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+// TestCORSAnyOrigin this function tests * allows every origin and is only echoed back when credentials are allowed
+func TestCORSAnyOrigin(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	for i, credentials := range []bool{false, true} {
+		h := CORS(CORSConfig{Origins: []string{"*"}, Credentials: credentials})(ok)
+
+		req := httptest.NewRequest(http.MethodGet, "/cars", nil)
+		req.Header.Set("Origin", "https://portal.example.com")
+
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, req)
+
+		expected := map[bool]string{false: "*", true: "https://portal.example.com"}[credentials]
+		assert.Equal(t, expected, res.Header().Get("Access-Control-Allow-Origin"), "Test Case %v Failed", i)
+	}
+}
+
+// TestParseOrigins this function tests origins are read from comma separated lists
+func TestParseOrigins(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		origins []string
+		err     bool
+	}{
+		{"empty", "", []string{}, false},
+		{"origins", "https://portal.example.com, http://localhost:3000,*", []string{"https://portal.example.com",
+			"http://localhost:3000", "*"}, false},
+		{"path", "https://portal.example.com/", nil, true},
+		{"no scheme", "portal.example.com", nil, true},
+	}
+
+	for i, tc := range testCases {
+		origins, err := ParseOrigins(tc.input)
+
+		assert.Equal(t, tc.err, err != nil, "Test Case %v Failed: %v", i, tc.desc)
+		assert.Equal(t, tc.origins, origins, "Test Case %v Failed: %v", i, tc.desc)
+	}
+}
@@ -1,10 +1,23 @@
 package middleware
 
-import "net/http"
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+)
 
-// Auth this is middleware function for authentication
+type principalKey struct{}
+
+// Auth this is middleware function for authentication. A request over mutual TLS is authenticated by its verified
+// client certificate, whose subject becomes the principal of the request, any other by the authorize header.
 func Auth(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subject := VerifiedSubject(r.TLS); subject != "" {
+			h.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), subject)))
+
+			return
+		}
+
 		if r.Header.Get("authorize") != "0000" {
 			w.WriteHeader(http.StatusUnauthorized)
 			return
@@ -13,3 +26,23 @@ func Auth(h http.Handler) http.Handler {
 		h.ServeHTTP(w, r)
 	})
 }
+
+// WithPrincipal returns ctx carrying the subject of the client certificate a request was authenticated by
+func WithPrincipal(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, principalKey{}, subject)
+}
+
+// Principal returns the subject of the client certificate a request was authenticated by, empty when it was not
+func Principal(ctx context.Context) string {
+	p, _ := ctx.Value(principalKey{}).(string)
+	return p
+}
+
+// VerifiedSubject returns the subject of the verified client certificate of a TLS connection, empty without one
+func VerifiedSubject(state *tls.ConnectionState) string {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ""
+	}
+
+	return state.VerifiedChains[0][0].Subject.String()
+}
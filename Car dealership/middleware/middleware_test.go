@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -32,3 +35,28 @@ func TestAuth(t *testing.T) {
 		assert.Equal(t, testCases[i].statusCode, w.Code, "Test Case Failed")
 	}
 }
+
+// TestAuthClientCertificate this function tests requests with a verified client certificate need no authorize header
+func TestAuthClientCertificate(t *testing.T) {
+	var principal string
+
+	a := Auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { principal = Principal(r.Context()) }))
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "partner", Organization: []string{"Acme"}}}
+
+	req := httptest.NewRequest(http.MethodGet, "/cars", nil)
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+
+	w := httptest.NewRecorder()
+	a.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "CN=partner,O=Acme", principal)
+
+	// an unverified certificate does not authenticate
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	w = httptest.NewRecorder()
+	a.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,462 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// APIKeyHeader carries the API key of a client
+	APIKeyHeader = "X-API-Key"
+
+	// RoleAnonymous is the role of callers without a known API key, they are told apart by IP address
+	RoleAnonymous = "anonymous"
+
+	// RoleAdmin is the role allowed to read the quota usage of every client
+	RoleAdmin = "admin"
+
+	// QuotaDays is how many days of quota usage are kept, today included
+	QuotaDays = 7
+
+	// maxBuckets is how many buckets are kept before the full ones are dropped, a full bucket is the same as none.
+	// When too few are full the least recently seen half is dropped as well.
+	maxBuckets = 10000
+
+	// maxClients is how many clients a day have their usage counted before the least recently seen half is dropped
+	maxClients = 10000
+
+	dayLayout = "2006-01-02"
+)
+
+// Client is a caller known by its API key
+type Client struct {
+	Name string
+	Role string
+}
+
+// Rule is a token bucket limit on the requests of each client of Role to Route, the bucket holds Burst requests
+// and refills at Rate requests a second. Route is a path template as registered on the router, such as
+// /car/{id}, and an empty Route or Role matches any.
+type Rule struct {
+	Route string
+	Role  string
+	Rate  float64
+	Burst int
+}
+
+// RateLimits configures a RateLimiter. The most specific rule matching a request applies, one for its route and
+// role before one for its route, one for its route before one for its role and one for its role before one for
+// neither. A request no rule matches is not rate limited. Quotas are the requests a day of each role, a role
+// without one has no quota.
+type RateLimits struct {
+	Clients map[string]Client
+	Rules   []Rule
+	Quotas  map[string]int
+}
+
+type bucketKey struct {
+	client string
+	rule   int
+}
+
+type bucket struct {
+	tokens float64
+	at     time.Time
+}
+
+// decision is the outcome of a request against its rule and quota, the times are in whole seconds
+type decision struct {
+	allowed   bool
+	limited   bool
+	limit     int
+	remaining int
+	reset     int
+	retry     int
+	reason    string
+}
+
+// usage is the quota usage of a client on a day and when the client was last seen
+type usage struct {
+	models.QuotaUsage
+	seen time.Time
+}
+
+// RateLimiter limits the requests of each client with token buckets and counts them against a daily quota.
+// Clients with a known API key are limited by their name and role, any other caller by its IP address. So that
+// callers from many addresses can not grow it without bound, the buckets and usage of the callers least
+// recently seen are dropped once there are too many, and those callers start over.
+type RateLimiter struct {
+	limits RateLimits
+	now    func() time.Time
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+	usage   map[string]map[string]*usage
+}
+
+// NewRateLimiter returns a RateLimiter for limits, a rule which does not refill or holds no request is an error
+func NewRateLimiter(limits RateLimits) (*RateLimiter, error) {
+	for i, r := range limits.Rules {
+		if !(r.Rate > 0) || math.IsInf(r.Rate, 1) {
+			return nil, fmt.Errorf("rate limit rule %d has a rate of %v, it must be above zero", i+1, r.Rate)
+		}
+
+		if r.Burst < 1 {
+			return nil, fmt.Errorf("rate limit rule %d has a burst of %d, it must be at least one", i+1, r.Burst)
+		}
+	}
+
+	for role, q := range limits.Quotas {
+		if q < 0 {
+			return nil, fmt.Errorf("quota of %s is negative", role)
+		}
+	}
+
+	return &RateLimiter{limits: limits, now: time.Now, buckets: map[bucketKey]*bucket{},
+		usage: map[string]map[string]*usage{}}, nil
+}
+
+// ParseClients reads API keys written as key=name:role separated by commas, such as the API_KEYS environment
+// variable
+func ParseClients(s string) (map[string]Client, error) {
+	clients := map[string]Client{}
+
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		eq := strings.Index(entry, "=")
+		colon := strings.LastIndex(entry, ":")
+
+		if eq < 1 || colon < eq+2 || colon == len(entry)-1 {
+			return nil, fmt.Errorf("API key %d is not written as key=name:role", len(clients)+1)
+		}
+
+		clients[entry[:eq]] = Client{Name: entry[eq+1 : colon], Role: entry[colon+1:]}
+	}
+
+	return clients, nil
+}
+
+// Limit is a middleware function answering 429 to requests over their rate limit or quota. The RateLimit-Limit,
+// RateLimit-Remaining and RateLimit-Reset headers describe the bucket of rate limited requests, Retry-After is
+// the seconds to wait once refused.
+func (l *RateLimiter) Limit(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, role := l.client(r)
+		d := l.take(client, role, route(r))
+
+		if d.limited {
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(d.limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(d.remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(d.reset))
+		}
+
+		if !d.allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(d.retry))
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(d.reason))
+
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole lets only clients of role through to h, any other caller is answered 403
+func (l *RateLimiter) RequireRole(role string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, got := l.client(r); got != role {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Usage returns the quota usage of every client on a day, today when day is zero, the busiest clients first
+func (l *RateLimiter) Usage(_ context.Context, day time.Time) ([]models.QuotaUsage, error) {
+	if day.IsZero() {
+		day = l.now()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	list := []models.QuotaUsage{}
+
+	for _, u := range l.usage[day.UTC().Format(dayLayout)] {
+		list = append(list, u.QuotaUsage)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Requests != list[j].Requests {
+			return list[i].Requests > list[j].Requests
+		}
+
+		return list[i].Client < list[j].Client
+	})
+
+	return list, nil
+}
+
+// Allow counts a call other than an HTTP request, such as a gRPC call, against the quota and rules of its caller
+// as Limit does. The caller is the client of a known apiKey, or else the principal of the call when it has one
+// and its address otherwise. A refused call gets the seconds to wait and the reason.
+func (l *RateLimiter) Allow(apiKey, principal, addr, route string) (allowed bool, retry int, reason string) {
+	client, role := l.caller(apiKey, principal, addr)
+	d := l.take(client, role, route)
+
+	return d.allowed, d.retry, d.reason
+}
+
+// client names the caller of r and its role, a client verified over mutual TLS is named by its certificate subject
+// as it is on a gRPC call
+func (l *RateLimiter) client(r *http.Request) (name, role string) {
+	return l.caller(r.Header.Get(APIKeyHeader), VerifiedSubject(r.TLS), r.RemoteAddr)
+}
+
+// caller names a caller and its role. An API key that is not known is ignored, so that made up keys do not get
+// buckets of their own.
+func (l *RateLimiter) caller(apiKey, principal, addr string) (name, role string) {
+	if apiKey != "" {
+		if c, ok := l.limits.Clients[apiKey]; ok {
+			return c.Name, c.Role
+		}
+	}
+
+	if principal != "" {
+		return principal, RoleAnonymous
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	return host, RoleAnonymous
+}
+
+// take counts a request of client against its quota and takes a token from the bucket of its rule
+func (l *RateLimiter) take(client, role, route string) decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	u := l.usageOf(now, client, role)
+
+	if u.Quota > 0 && u.Requests >= u.Quota {
+		u.Limited++
+		tomorrow := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+
+		return decision{retry: seconds(tomorrow.Sub(now).Seconds()),
+			reason: fmt.Sprintf("daily quota of %d requests used up", u.Quota)}
+	}
+
+	d := decision{allowed: true}
+
+	if i := l.rule(route, role); i >= 0 {
+		rule := l.limits.Rules[i]
+		b := l.bucket(bucketKey{client: client, rule: i}, rule, now)
+
+		d.limited, d.limit = true, rule.Burst
+
+		if b.tokens < 1 {
+			d.allowed = false
+			d.retry = seconds((1 - b.tokens) / rule.Rate)
+			d.reason = fmt.Sprintf("rate limit of %d requests exceeded", rule.Burst)
+		} else {
+			b.tokens--
+		}
+
+		d.remaining = int(b.tokens)
+		d.reset = seconds((float64(rule.Burst) - b.tokens) / rule.Rate)
+	}
+
+	if !d.allowed {
+		u.Limited++
+		return d
+	}
+
+	u.Requests++
+	u.Remaining = u.Quota - u.Requests
+
+	if u.Quota == 0 {
+		u.Remaining = 0
+	}
+
+	return d
+}
+
+// rule returns the index of the most specific rule for route and role, or -1 when no rule matches
+func (l *RateLimiter) rule(route, role string) int {
+	best, score := -1, -1
+
+	for i, r := range l.limits.Rules {
+		if (r.Route != "" && r.Route != route) || (r.Role != "" && r.Role != role) {
+			continue
+		}
+
+		s := 0
+		if r.Route != "" {
+			s += 2
+		}
+
+		if r.Role != "" {
+			s++
+		}
+
+		if s > score {
+			best, score = i, s
+		}
+	}
+
+	return best
+}
+
+// bucket returns the bucket under key refilled up to now, a new bucket starts full
+func (l *RateLimiter) bucket(key bucketKey, rule Rule, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxBuckets {
+			l.sweep(now)
+		}
+
+		b = &bucket{tokens: float64(rule.Burst), at: now}
+		l.buckets[key] = b
+
+		return b
+	}
+
+	b.tokens = math.Min(float64(rule.Burst), b.tokens+now.Sub(b.at).Seconds()*rule.Rate)
+	b.at = now
+
+	return b
+}
+
+// sweep drops the buckets which have refilled, they would start full again anyway. When too few have, the least
+// recently seen half is dropped as well.
+func (l *RateLimiter) sweep(now time.Time) {
+	seen := make([]time.Time, 0, len(l.buckets))
+
+	for key, b := range l.buckets {
+		rule := l.limits.Rules[key.rule]
+		if b.tokens+now.Sub(b.at).Seconds()*rule.Rate >= float64(rule.Burst) {
+			delete(l.buckets, key)
+			continue
+		}
+
+		seen = append(seen, b.at)
+	}
+
+	if len(l.buckets) < maxBuckets {
+		return
+	}
+
+	last := cutoff(seen, len(seen)/2)
+
+	for key, b := range l.buckets {
+		if !b.at.After(last) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// usageOf returns the usage of client today, usage older than QuotaDays is dropped as a new day starts
+func (l *RateLimiter) usageOf(now time.Time, client, role string) *models.QuotaUsage {
+	day := now.UTC().Format(dayLayout)
+
+	clients, ok := l.usage[day]
+	if !ok {
+		oldest := now.UTC().AddDate(0, 0, 1-QuotaDays).Format(dayLayout)
+
+		for d := range l.usage {
+			if d < oldest {
+				delete(l.usage, d)
+			}
+		}
+
+		clients = map[string]*usage{}
+		l.usage[day] = clients
+	}
+
+	u, ok := clients[client]
+	if !ok {
+		if len(clients) >= maxClients {
+			forget(clients)
+		}
+
+		quota := l.limits.Quotas[role]
+		u = &usage{QuotaUsage: models.QuotaUsage{Client: client, Role: role, Day: day, Quota: quota,
+			Remaining: quota}}
+		clients[client] = u
+	}
+
+	u.seen = now
+
+	return &u.QuotaUsage
+}
+
+// forget drops the usage of the least recently seen half of the clients of a day
+func forget(clients map[string]*usage) {
+	seen := make([]time.Time, 0, len(clients))
+
+	for _, u := range clients {
+		seen = append(seen, u.seen)
+	}
+
+	last := cutoff(seen, len(seen)/2)
+
+	for client, u := range clients {
+		if !u.seen.After(last) {
+			delete(clients, client)
+		}
+	}
+}
+
+// cutoff returns when the n-th least recently seen of seen was last seen, n is at least one
+func cutoff(seen []time.Time, n int) time.Time {
+	if n < 1 {
+		n = 1
+	}
+
+	sort.Slice(seen, func(i, j int) bool { return seen[i].Before(seen[j]) })
+
+	return seen[n-1]
+}
+
+// route is the path template of the route matching r, or its path when no route matched
+func route(r *http.Request) string {
+	if current := mux.CurrentRoute(r); current != nil {
+		if tpl, err := current.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+
+	return r.URL.Path
+}
+
+// seconds rounds up to whole seconds, at least one
+func seconds(s float64) int {
+	if s < 1 {
+		return 1
+	}
+
+	return int(math.Ceil(s))
+}
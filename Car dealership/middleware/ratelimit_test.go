@@ -0,0 +1,230 @@
+package middleware
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRouter(l *RateLimiter) *mux.Router {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	r := mux.NewRouter()
+	r.Handle("/cars", ok).Methods(http.MethodGet)
+	r.Handle("/car/{id}", ok).Methods(http.MethodGet)
+	r.Handle("/admin/quotas", l.RequireRole(RoleAdmin, ok)).Methods(http.MethodGet)
+	r.Use(l.Limit)
+
+	return r
+}
+
+func serve(r http.Handler, path, key, addr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = addr
+
+	if key != "" {
+		req.Header.Set(APIKeyHeader, key)
+	}
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	return res
+}
+
+// TestLimit this function tests the token buckets, the most specific rule applies and refused requests get 429
+func TestLimit(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	l, err := NewRateLimiter(RateLimits{
+		Clients: map[string]Client{"k1": {Name: "acme", Role: "partner"}},
+		Rules: []Rule{
+			{Rate: 10, Burst: 5},
+			{Route: "/cars", Rate: 1, Burst: 2},
+			{Route: "/cars", Role: "partner", Rate: 1, Burst: 3},
+		},
+	})
+	assert.Nil(t, err)
+
+	l.now = func() time.Time { return now }
+	r := newRouter(l)
+
+	testCases := []struct {
+		desc       string
+		path       string
+		key        string
+		addr       string
+		statusCode int
+		remaining  string
+	}{
+		{"first of two", "/cars", "", "10.0.0.1:1000", http.StatusOK, "1"},
+		{"second of two", "/cars", "", "10.0.0.1:2000", http.StatusOK, "0"},
+		{"over the route limit", "/cars", "", "10.0.0.1:1000", http.StatusTooManyRequests, "0"},
+		{"other route, default rule", "/car/1", "", "10.0.0.1:1000", http.StatusOK, "4"},
+		{"other address", "/cars", "", "10.0.0.2:1000", http.StatusOK, "1"},
+		{"unknown key is limited by address", "/cars", "made-up", "10.0.0.1:1000", http.StatusTooManyRequests, "0"},
+		{"partner rule", "/cars", "k1", "10.0.0.1:1000", http.StatusOK, "2"},
+	}
+
+	for i, tc := range testCases {
+		res := serve(r, tc.path, tc.key, tc.addr)
+
+		assert.Equal(t, tc.statusCode, res.Code, "Test Case %v Failed: %v", i, tc.desc)
+		assert.Equal(t, tc.remaining, res.Header().Get("RateLimit-Remaining"), "Test Case %v Failed: %v", i, tc.desc)
+	}
+
+	res := serve(r, "/cars", "", "10.0.0.1:1000")
+	assert.Equal(t, "2", res.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "2", res.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "1", res.Header().Get("Retry-After"))
+
+	// a token back a second later
+	now = now.Add(time.Second)
+
+	res = serve(r, "/cars", "", "10.0.0.1:1000")
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "", res.Header().Get("Retry-After"))
+}
+
+// TestQuota this function tests clients are refused once their daily quota is used up and counted in the usage
+func TestQuota(t *testing.T) {
+	now := time.Date(2022, 3, 1, 23, 0, 0, 0, time.UTC)
+	l, err := NewRateLimiter(RateLimits{
+		Clients: map[string]Client{"k1": {Name: "acme", Role: "partner"}, "k2": {Name: "ops", Role: RoleAdmin}},
+		Quotas:  map[string]int{"partner": 2},
+	})
+	assert.Nil(t, err)
+
+	l.now = func() time.Time { return now }
+	r := newRouter(l)
+
+	for i, code := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		res := serve(r, "/cars", "k1", "10.0.0.1:1000")
+
+		assert.Equal(t, code, res.Code, "Test Case %v Failed", i)
+		assert.Equal(t, "", res.Header().Get("RateLimit-Limit"), "Test Case %v Failed", i)
+	}
+
+	assert.Equal(t, "3600", serve(r, "/cars", "k1", "10.0.0.1:1000").Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusForbidden, serve(r, "/admin/quotas", "", "10.0.0.1:1000").Code)
+	assert.Equal(t, http.StatusOK, serve(r, "/admin/quotas", "k2", "10.0.0.1:1000").Code)
+
+	usage, _ := l.Usage(context.TODO(), time.Time{})
+	assert.Equal(t, []models.QuotaUsage{
+		{Client: "acme", Role: "partner", Day: "2022-03-01", Requests: 2, Limited: 2, Quota: 2},
+		{Client: "10.0.0.1", Role: RoleAnonymous, Day: "2022-03-01", Requests: 1},
+		{Client: "ops", Role: RoleAdmin, Day: "2022-03-01", Requests: 1},
+	}, usage)
+
+	// the quota starts again the next day, usage of past days can still be read
+	now = now.Add(time.Hour)
+
+	assert.Equal(t, http.StatusOK, serve(r, "/cars", "k1", "10.0.0.1:1000").Code)
+
+	usage, _ = l.Usage(context.TODO(), time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 3, len(usage))
+}
+
+// TestNewRateLimiter this function tests rules which never refill or hold no request are refused
+func TestNewRateLimiter(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		limits RateLimits
+		valid  bool
+	}{
+		{"valid", RateLimits{Rules: []Rule{{Rate: 0.5, Burst: 1}}, Quotas: map[string]int{"partner": 0}}, true},
+		{"zero rate", RateLimits{Rules: []Rule{{Rate: 0, Burst: 10}}}, false},
+		{"negative rate", RateLimits{Rules: []Rule{{Rate: -1, Burst: 10}}}, false},
+		{"infinite rate", RateLimits{Rules: []Rule{{Rate: math.Inf(1), Burst: 10}}}, false},
+		{"not a number", RateLimits{Rules: []Rule{{Rate: math.NaN(), Burst: 10}}}, false},
+		{"zero burst", RateLimits{Rules: []Rule{{Rate: 1, Burst: 0}}}, false},
+		{"negative quota", RateLimits{Quotas: map[string]int{"partner": -1}}, false},
+	}
+
+	for i, tc := range testCases {
+		_, err := NewRateLimiter(tc.limits)
+
+		assert.Equal(t, tc.valid, err == nil, "Test Case %v Failed: %v", i, tc.desc)
+	}
+}
+
+// TestForget this function tests the buckets and usage of the callers least recently seen are dropped once there
+// are too many
+func TestForget(t *testing.T) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	l, err := NewRateLimiter(RateLimits{Rules: []Rule{{Rate: 0.001, Burst: 1}}})
+	assert.Nil(t, err)
+
+	l.now = func() time.Time { return now }
+
+	for i := 0; i <= maxClients; i++ {
+		now = now.Add(time.Millisecond)
+		l.take("10.0."+strconv.Itoa(i/256)+"."+strconv.Itoa(i%256), RoleAnonymous, "/cars")
+	}
+
+	clients := l.usage["2022-03-01"]
+
+	assert.True(t, len(l.buckets) <= maxBuckets/2+1, "buckets are dropped")
+	assert.True(t, len(clients) <= maxClients/2+1, "usage is dropped")
+	assert.NotNil(t, clients["10.0.39.16"], "the caller seen last is kept")
+	assert.Nil(t, clients["10.0.0.0"], "the caller seen first is dropped")
+	assert.False(t, l.take("10.0.39.15", RoleAnonymous, "/cars").allowed, "a recent caller keeps its bucket")
+}
+
+// TestVerifiedClient this function tests a client verified over mutual TLS shares its bucket between HTTP and gRPC
+// calls from any address
+func TestVerifiedClient(t *testing.T) {
+	l, err := NewRateLimiter(RateLimits{Rules: []Rule{{Rate: 0.001, Burst: 2}}})
+	assert.Nil(t, err)
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "dealer-portal"}}
+
+	req := httptest.NewRequest(http.MethodGet, "/cars", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+
+	res := httptest.NewRecorder()
+	newRouter(l).ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	allowed, _, _ := l.Allow("", cert.Subject.String(), "10.0.0.2:1234", "/cars")
+	assert.True(t, allowed, "the second call of the burst")
+
+	allowed, _, _ = l.Allow("", cert.Subject.String(), "10.0.0.3:1234", "/cars")
+	assert.False(t, allowed, "the bucket of the subject is empty")
+}
+
+// TestParseClients this function tests API keys are read from key=name:role lists
+func TestParseClients(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		clients map[string]Client
+		err     bool
+	}{
+		{"empty", "", map[string]Client{}, false},
+		{"keys", "k1=acme:partner, k2=ops:admin,", map[string]Client{"k1": {Name: "acme", Role: "partner"},
+			"k2": {Name: "ops", Role: "admin"}}, false},
+		{"no role", "k1=acme", nil, true},
+		{"no name", "k1=:partner", nil, true},
+		{"no key", "=acme:partner", nil, true},
+	}
+
+	for i, tc := range testCases {
+		clients, err := ParseClients(tc.input)
+
+		assert.Equal(t, tc.err, err != nil, "Test Case %v Failed: %v", i, tc.desc)
+		assert.Equal(t, tc.clients, clients, "Test Case %v Failed: %v", i, tc.desc)
+	}
+}
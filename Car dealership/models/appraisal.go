@@ -0,0 +1,82 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// conditions of a trade-in, from best to worst
+const (
+	ConditionExcellent = "excellent"
+	ConditionGood      = "good"
+	ConditionFair      = "fair"
+	ConditionPoor      = "poor"
+)
+
+// AnyMatch is the brand or fuel type of a depreciation curve which applies to every brand or fuel type
+const AnyMatch = "*"
+
+var ErrUnknownCondition = errors.New("unknown condition")
+
+// ValidCondition reports whether c is one of the trade-in conditions
+func ValidCondition(c string) bool {
+	switch c {
+	case ConditionExcellent, ConditionGood, ConditionFair, ConditionPoor:
+		return true
+	}
+
+	return false
+}
+
+// TradeInVehicle is a car a customer offers in part exchange, Displacement is in cc and Mileage in km
+type TradeInVehicle struct {
+	Brand        string `json:"Brand"`
+	Name         string `json:"Name"`
+	Year         int    `json:"Year"`
+	FuelType     string `json:"FuelType"`
+	Displacement int64  `json:"Displacement"`
+	Mileage      int    `json:"Mileage"`
+	Condition    string `json:"Condition"`
+}
+
+// DepreciationCurve values the cars of a brand and fuel type, either may be AnyMatch. A new car is worth BaseValue
+// plus ValuePerLitre for every litre of displacement, and keeps Retention[i] basis points of that value at the end
+// of year i+1. Every 10000 km driven more, or less, than MileagePerYear a year takes MileageRate basis points off,
+// or adds them.
+type DepreciationCurve struct {
+	Brand          string `json:"Brand"`
+	FuelType       string `json:"FuelType"`
+	Currency       string `json:"Currency"`
+	BaseValue      Money  `json:"BaseValue"`
+	ValuePerLitre  Money  `json:"ValuePerLitre"`
+	Retention      []int  `json:"Retention"`
+	MileagePerYear int    `json:"MileagePerYear"`
+	MileageRate    int    `json:"MileageRate"`
+}
+
+// Valuation is an estimate of what a trade-in is worth, Value lies between Low and High. The adjustments are in
+// basis points of the value, 10000 leaves it as it is.
+type Valuation struct {
+	Currency            string `json:"Currency"`
+	NewValue            Money  `json:"NewValue"`
+	Age                 int    `json:"Age"`
+	Retention           int    `json:"Retention"`
+	MileageAdjustment   int    `json:"MileageAdjustment"`
+	ConditionAdjustment int    `json:"ConditionAdjustment"`
+	Value               Money  `json:"Value"`
+	Low                 Money  `json:"Low"`
+	High                Money  `json:"High"`
+}
+
+// Appraisal is a valuation of a customer's trade-in recorded by an appraiser
+type Appraisal struct {
+	ID         uuid.UUID      `json:"ID"`
+	CustomerID uuid.UUID      `json:"CustomerID"`
+	Vehicle    TradeInVehicle `json:"Vehicle"`
+	Valuation  Valuation      `json:"Valuation"`
+	Appraiser  string         `json:"Appraiser"`
+	Notes      string         `json:"Notes"`
+	CreatedAt  time.Time      `json:"CreatedAt"`
+}
@@ -2,11 +2,34 @@ package models
 
 import "github.com/google/uuid"
 
+// Car is a car of the dealership, the optional parts of a car and its engine are pointers so that both stay
+// comparable
 type Car struct {
 	ID       uuid.UUID `json:"ID"`
+	VIN      string    `json:"VIN"`
 	Name     string    `json:"Name"`
 	Year     int       `json:"Year"`
 	Brand    string    `json:"Brand"`
 	FuelType string    `json:"FuelType"`
 	Engine   Engine    `json:"Engine"`
+
+	// TrimID is the catalogue trim the car was created from, if any
+	TrimID *uuid.UUID `json:"TrimID,omitempty"`
+
+	// Status is read only here as well, it changes through the status transitions
+	Status string `json:"Status"`
+
+	// prices are read only here, they change through the price endpoints so that every change is recorded
+	MSRP      Money  `json:"MSRP"`
+	ListPrice Money  `json:"ListPrice"`
+	Cost      Money  `json:"Cost"`
+	Currency  string `json:"Currency"`
+
+	// Media is read only too, it is attached when a car is read and changes through the media endpoints
+	Media *CarMedia `json:"Media,omitempty"`
+}
+
+// Price returns the pricing of the car
+func (c Car) Price() Price {
+	return Price{MSRP: c.MSRP, ListPrice: c.ListPrice, Cost: c.Cost, Currency: c.Currency}
 }
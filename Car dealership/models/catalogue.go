@@ -0,0 +1,71 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Brand is a make of car in the catalogue. Aliases are other spellings of its name that cars are matched by,
+// such as VW for Volkswagen.
+type Brand struct {
+	ID      uuid.UUID `json:"ID"`
+	Name    string    `json:"Name"`
+	Aliases []string  `json:"Aliases"`
+}
+
+// CarModel is a model of a brand, such as the 3 Series of BMW
+type CarModel struct {
+	ID      uuid.UUID `json:"ID"`
+	BrandID uuid.UUID `json:"BrandID"`
+	Name    string    `json:"Name"`
+}
+
+// Trim is a variant of a model with its default fuel type and engine, cars created from a trim start with them
+// unless they give their own
+type Trim struct {
+	ID       uuid.UUID `json:"ID"`
+	ModelID  uuid.UUID `json:"ModelID"`
+	Name     string    `json:"Name"`
+	FuelType string    `json:"FuelType"`
+	Engine   Engine    `json:"Engine"`
+}
+
+// BrandSpelling is a way a brand is written on cars and how many cars are written so
+type BrandSpelling struct {
+	Brand string `json:"Brand"`
+	Cars  int    `json:"Cars"`
+}
+
+// BrandRename rewrites the brand of the cars written From to the catalogue name To
+type BrandRename struct {
+	From string `json:"From"`
+	To   string `json:"To"`
+	Cars int    `json:"Cars"`
+}
+
+// BrandMigration reports how the brands of existing cars were rewritten to the names in the catalogue. Nothing is
+// written on a dry run, Created are the brands added for spellings the catalogue did not have.
+type BrandMigration struct {
+	DryRun    bool            `json:"DryRun"`
+	Cars      int             `json:"Cars"`
+	Updated   int             `json:"Updated"`
+	Renames   []BrandRename   `json:"Renames"`
+	Created   []Brand         `json:"Created"`
+	Unmatched []BrandSpelling `json:"Unmatched"`
+}
+
+// CatalogueKey is the key names in the catalogue are matched by, the name in lower case without spaces or
+// punctuation so that BMW, bmw and B.M.W. are the same brand
+func CatalogueKey(name string) string {
+	var b strings.Builder
+
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package models
+
+import "github.com/google/uuid"
+
+// comparison markers, the best and the worst value of an attribute the cars can be ranked on
+const (
+	MarkerBest  = "best"
+	MarkerWorst = "worst"
+)
+
+// ComparedValue is the value of an attribute for one car. Value is null when the attribute does not apply to the
+// car, such as the range of a car without a battery.
+type ComparedValue struct {
+	Value  interface{} `json:"Value"`
+	Marker string      `json:"Marker,omitempty"`
+}
+
+// ComparedAttribute is a row of a comparison, its values are in the order of the compared cars. Ranked tells
+// whether the attribute has a better and a worse end, and Differs whether the cars do not all share a value.
+type ComparedAttribute struct {
+	Name    string          `json:"Name"`
+	Unit    string          `json:"Unit,omitempty"`
+	Ranked  bool            `json:"Ranked"`
+	Differs bool            `json:"Differs"`
+	Values  []ComparedValue `json:"Values"`
+}
+
+// ComparedCar is a column of a comparison
+type ComparedCar struct {
+	ID    uuid.UUID `json:"ID"`
+	Name  string    `json:"Name"`
+	Brand string    `json:"Brand"`
+}
+
+// Comparison is a side by side spec matrix of cars
+type Comparison struct {
+	Cars       []ComparedCar       `json:"Cars"`
+	Attributes []ComparedAttribute `json:"Attributes"`
+}
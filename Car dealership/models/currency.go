@@ -0,0 +1,50 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrUnknownCurrency = errors.New("currency is not a supported ISO 4217 code")
+
+// currencies are the supported ISO 4217 codes with their fraction digits. Money holds hundredths, so currencies
+// with three fraction digits such as BHD are left out, and amounts in a currency without any must be whole.
+var currencies = map[string]int{
+	"AED": 2, "AUD": 2, "BRL": 2, "CAD": 2, "CHF": 2, "CLP": 0, "CNY": 2, "CZK": 2, "DKK": 2, "EUR": 2, "GBP": 2,
+	"HKD": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2, "ISK": 0, "JPY": 0, "KRW": 0, "MXN": 2, "MYR": 2, "NOK": 2,
+	"NZD": 2, "PHP": 2, "PLN": 2, "SAR": 2, "SEK": 2, "SGD": 2, "THB": 2, "TRY": 2, "TWD": 2, "USD": 2, "VND": 0,
+	"ZAR": 2,
+}
+
+// ValidCurrency reports whether code is a supported currency, codes are upper case
+func ValidCurrency(code string) bool {
+	_, ok := currencies[code]
+	return ok
+}
+
+// MinorUnit is the smallest amount of a currency, 1 for a currency with two fraction digits and 100 for one
+// without any. Currencies which are not supported are taken to have two.
+func MinorUnit(currency string) Money {
+	if digits, ok := currencies[strings.ToUpper(currency)]; ok && digits == 0 {
+		return 100
+	}
+
+	return 1
+}
+
+// Fits reports whether the amount is a whole number of minor units of the currency
+func (m Money) Fits(currency string) bool {
+	return m%MinorUnit(currency) == 0
+}
+
+// Format formats the amount with the fraction digits of the currency, amounts which do not fit the currency and
+// currencies which are not supported are formatted by String
+func (m Money) Format(currency string) string {
+	if MinorUnit(currency) == 1 || !m.Fits(currency) {
+		return m.String()
+	}
+
+	s := m.String()
+
+	return s[:len(s)-3]
+}
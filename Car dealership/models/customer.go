@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Customer is a person the dealership is in contact with, customers are unique by Email
+type Customer struct {
+	ID        uuid.UUID `json:"ID"`
+	Name      string    `json:"Name"`
+	Email     string    `json:"Email"`
+	Phone     string    `json:"Phone"`
+	CreatedAt time.Time `json:"CreatedAt"`
+}
@@ -7,4 +7,7 @@ type Engine struct {
 	Displacement int64     `json:"Displacement"`
 	NoOfCylinder int64     `json:"NoOfCylinder"`
 	CarRange     int64     `json:"Range"`
+
+	// Powertrain is left out for engines recorded before powertrains were
+	Powertrain *Powertrain `json:"Powertrain,omitempty"`
 }
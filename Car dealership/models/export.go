@@ -0,0 +1,9 @@
+package models
+
+// ExportOptions selects the format, cars and columns of an inventory export
+type ExportOptions struct {
+	Format   string
+	Filter   CarFilter
+	IsEngine bool
+	Columns  []string
+}
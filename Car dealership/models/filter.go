@@ -0,0 +1,144 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultPageLimit and MaxPageLimit are the page sizes of listings when no limit is asked for and at most
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+var (
+	// ErrInvalidPageLimit is returned for a limit which is not a number of cars between 1 and MaxPageLimit
+	ErrInvalidPageLimit = fmt.Errorf("limit must be between 1 and %d", MaxPageLimit)
+
+	// ErrInvalidPageCursor is returned for an after cursor which is not the id of a car
+	ErrInvalidPageCursor = errors.New("after must be the next cursor of the page before")
+)
+
+// ErrPriceWithoutCurrency is returned for a price bound without a currency, amounts of different currencies are
+// not comparable
+var ErrPriceWithoutCurrency = errors.New("minPrice and maxPrice need a currency")
+
+// CarFilter holds the listing filters shared by /cars and the endpoints built on it, zero values match every car
+type CarFilter struct {
+	Brand string
+
+	// MinPrice and MaxPrice bound the list price, inclusive
+	MinPrice Money
+	MaxPrice Money
+	Currency string
+
+	// Status is a comma separated list of statuses, a car matches when it is in any of them
+	Status string
+}
+
+// ParseCarFilter reads the filters from the brand, minPrice, maxPrice, currency and status query parameters
+func ParseCarFilter(query url.Values) (CarFilter, error) {
+	f := CarFilter{Brand: query.Get("brand"), Currency: strings.ToUpper(strings.TrimSpace(query.Get("currency"))),
+		Status: query.Get("status")}
+
+	if f.Currency != "" && !ValidCurrency(f.Currency) {
+		return CarFilter{}, fmt.Errorf("%w: %s", ErrUnknownCurrency, f.Currency)
+	}
+
+	for _, s := range f.Statuses() {
+		if !ValidStatus(s) {
+			return CarFilter{}, fmt.Errorf("%w: %s", ErrUnknownStatus, s)
+		}
+	}
+
+	var err error
+
+	if v := query.Get("minPrice"); v != "" {
+		if f.MinPrice, err = ParseMoney(v); err != nil {
+			return CarFilter{}, err
+		}
+	}
+
+	if v := query.Get("maxPrice"); v != "" {
+		if f.MaxPrice, err = ParseMoney(v); err != nil {
+			return CarFilter{}, err
+		}
+	}
+
+	if (f.MinPrice != 0 || f.MaxPrice != 0) && f.Currency == "" {
+		return CarFilter{}, ErrPriceWithoutCurrency
+	}
+
+	if !f.MinPrice.Fits(f.Currency) || !f.MaxPrice.Fits(f.Currency) {
+		return CarFilter{}, fmt.Errorf("%w: %s has no fraction digits", ErrInvalidMoney, f.Currency)
+	}
+
+	return f, nil
+}
+
+// Statuses splits the Status filter into its statuses
+func (f CarFilter) Statuses() []string {
+	var statuses []string
+
+	for _, s := range strings.Split(f.Status, ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			statuses = append(statuses, s)
+		}
+	}
+
+	return statuses
+}
+
+// Page is a page of a listing ordered by car id, After is the id of the last car of the page before and a zero
+// Limit is no limit
+type Page struct {
+	After string
+	Limit int
+}
+
+// ParsePage reads a page from the limit and after query parameters, a page without a limit holds DefaultPageLimit
+// cars so that no listing is unbounded
+func ParsePage(query url.Values) (Page, error) {
+	p := Page{Limit: DefaultPageLimit}
+
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > MaxPageLimit {
+			return Page{}, ErrInvalidPageLimit
+		}
+
+		p.Limit = n
+	}
+
+	if v := query.Get("after"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return Page{}, ErrInvalidPageCursor
+		}
+
+		p.After = id.String()
+	}
+
+	return p, nil
+}
+
+// CarPage is a page of the cars which pass a filter, Total counts the cars of every page
+type CarPage struct {
+	Cars    []Car
+	Total   int
+	HasNext bool
+}
+
+// NextCursor returns the after cursor of the page following this one, or an empty string on the last page
+func (p CarPage) NextCursor() string {
+	if !p.HasNext || len(p.Cars) == 0 {
+		return ""
+	}
+
+	return p.Cars[len(p.Cars)-1].ID.String()
+}
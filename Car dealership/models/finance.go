@@ -0,0 +1,62 @@
+package models
+
+import "github.com/google/uuid"
+
+// LoanRequest asks for the financing of a car, or of Price when no car is given. The amount financed is the price
+// less the down payment and the trade-in credit. APR is in basis points, 699 is a rate of 6.99%. When a Lender is
+// given the APR is taken from the rate table of the lender for the credit Tier and TermMonths instead.
+type LoanRequest struct {
+	CarID       uuid.UUID `json:"CarID"`
+	Price       Money     `json:"Price"`
+	Currency    string    `json:"Currency"`
+	DownPayment Money     `json:"DownPayment"`
+	TradeIn     Money     `json:"TradeIn"`
+	APR         int       `json:"APR"`
+	TermMonths  int       `json:"TermMonths"`
+	Lender      string    `json:"Lender"`
+	Tier        string    `json:"Tier"`
+}
+
+// Installment is one monthly payment of a loan, Balance is what is left to pay after it
+type Installment struct {
+	Number    int   `json:"Number"`
+	Payment   Money `json:"Payment"`
+	Principal Money `json:"Principal"`
+	Interest  Money `json:"Interest"`
+	Balance   Money `json:"Balance"`
+}
+
+// LoanQuote is the repayment of a loan of Principal in equal monthly payments, the last payment settles the
+// cents left over by rounding and so may differ from the others
+type LoanQuote struct {
+	CarID          uuid.UUID     `json:"CarID"`
+	Lender         string        `json:"Lender"`
+	Tier           string        `json:"Tier"`
+	Currency       string        `json:"Currency"`
+	Price          Money         `json:"Price"`
+	DownPayment    Money         `json:"DownPayment"`
+	TradeIn        Money         `json:"TradeIn"`
+	Principal      Money         `json:"Principal"`
+	APR            int           `json:"APR"`
+	TermMonths     int           `json:"TermMonths"`
+	MonthlyPayment Money         `json:"MonthlyPayment"`
+	FinalPayment   Money         `json:"FinalPayment"`
+	TotalInterest  Money         `json:"TotalInterest"`
+	TotalPayments  Money         `json:"TotalPayments"`
+	Schedule       []Installment `json:"Schedule,omitempty"`
+}
+
+// LenderRate is the APR, in basis points, a lender offers customers of a credit tier for terms from MinTerm to
+// MaxTerm months
+type LenderRate struct {
+	Lender  string `json:"Lender"`
+	Tier    string `json:"Tier"`
+	MinTerm int    `json:"MinTerm"`
+	MaxTerm int    `json:"MaxTerm"`
+	APR     int    `json:"APR"`
+}
+
+// Covers reports whether the rate applies to a loan of the tier over term months
+func (r LenderRate) Covers(tier string, term int) bool {
+	return r.Tier == tier && r.MinTerm <= term && term <= r.MaxTerm
+}
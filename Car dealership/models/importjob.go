@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Import job states
+const (
+	ImportPending   = "pending"
+	ImportRunning   = "running"
+	ImportCompleted = "completed"
+	ImportFailed    = "failed"
+)
+
+// ImportOptions controls how a bulk import treats invalid rows and whether it writes anything
+type ImportOptions struct {
+	Format      string `json:"Format"`
+	DryRun      bool   `json:"DryRun"`
+	SkipInvalid bool   `json:"SkipInvalid"`
+	Atomic      bool   `json:"Atomic"`
+}
+
+// RowError is a validation or write error for a single row of an import file
+type RowError struct {
+	Row     int    `json:"Row"`
+	Message string `json:"Message"`
+}
+
+type ImportJob struct {
+	ID         uuid.UUID     `json:"ID"`
+	Status     string        `json:"Status"`
+	Options    ImportOptions `json:"Options"`
+	Total      int           `json:"Total"`
+	Processed  int           `json:"Processed"`
+	Succeeded  int           `json:"Succeeded"`
+	Failed     int           `json:"Failed"`
+	Errors     []RowError    `json:"Errors"`
+	Message    string        `json:"Message,omitempty"`
+	CreatedAt  time.Time     `json:"CreatedAt"`
+	FinishedAt *time.Time    `json:"FinishedAt,omitempty"`
+}
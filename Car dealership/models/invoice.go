@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceLine is a charge on an invoice
+type InvoiceLine struct {
+	Description string `json:"Description"`
+	Amount      Money  `json:"Amount"`
+}
+
+// InvoiceCar describes the car sold on an invoice, without the dealership's cost
+type InvoiceCar struct {
+	ID    uuid.UUID `json:"ID"`
+	VIN   string    `json:"VIN"`
+	Name  string    `json:"Name"`
+	Brand string    `json:"Brand"`
+	Year  int       `json:"Year"`
+}
+
+// Invoice is the bill of a sales order, Number is sequential per dealership and is issued with the order
+type Invoice struct {
+	Number      string        `json:"Number"`
+	Dealership  string        `json:"Dealership"`
+	OrderID     uuid.UUID     `json:"OrderID"`
+	Status      string        `json:"Status"`
+	IssuedAt    time.Time     `json:"IssuedAt"`
+	Customer    Customer      `json:"Customer"`
+	Car         InvoiceCar    `json:"Car"`
+	Salesperson string        `json:"Salesperson"`
+	Currency    string        `json:"Currency"`
+	Lines       []InvoiceLine `json:"Lines"`
+	Subtotal    Money         `json:"Subtotal"`
+	TaxRate     int           `json:"TaxRate"`
+	Tax         Money         `json:"Tax"`
+	Total       Money         `json:"Total"`
+	Paid        Money         `json:"Paid"`
+	Balance     Money         `json:"Balance"`
+	Payments    []Payment     `json:"Payments"`
+}
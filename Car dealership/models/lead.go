@@ -0,0 +1,92 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// lead statuses of the sales pipeline, a lead ends either won or lost
+const (
+	LeadNew       = "new"
+	LeadContacted = "contacted"
+	LeadQualified = "qualified"
+	LeadLost      = "lost"
+	LeadWon       = "won"
+)
+
+var ErrUnknownLeadStatus = errors.New("unknown lead status")
+
+// ValidLeadStatus reports whether s is one of the lead statuses
+func ValidLeadStatus(s string) bool {
+	switch s {
+	case LeadNew, LeadContacted, LeadQualified, LeadLost, LeadWon:
+		return true
+	}
+
+	return false
+}
+
+// Lead is the interest of a customer in a car, worked through the sales pipeline by Salesperson
+type Lead struct {
+	ID          uuid.UUID `json:"ID"`
+	CarID       uuid.UUID `json:"CarID"`
+	CustomerID  uuid.UUID `json:"CustomerID"`
+	Status      string    `json:"Status"`
+	Salesperson string    `json:"Salesperson"`
+	Source      string    `json:"Source"`
+	Notes       string    `json:"Notes"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt"`
+
+	// Customer carries the contact details of the customer when a lead is captured and when leads are read
+	Customer *Customer `json:"Customer,omitempty"`
+}
+
+// LeadFilter holds the filters of the lead listing, zero values match every lead
+type LeadFilter struct {
+	// Status is a comma separated list of statuses, a lead matches when it is in any of them
+	Status      string
+	Salesperson string
+	CarID       string
+	CustomerID  string
+
+	// Unassigned only matches leads without a salesperson
+	Unassigned bool
+}
+
+// ParseLeadFilter reads the filters from the status, salesperson, carId, customerId and unassigned query parameters
+func ParseLeadFilter(query url.Values) (LeadFilter, error) {
+	f := LeadFilter{
+		Status:      query.Get("status"),
+		Salesperson: strings.TrimSpace(query.Get("salesperson")),
+		CarID:       query.Get("carId"),
+		CustomerID:  query.Get("customerId"),
+		Unassigned:  query.Get("unassigned") == "true",
+	}
+
+	for _, s := range f.Statuses() {
+		if !ValidLeadStatus(s) {
+			return LeadFilter{}, fmt.Errorf("%w: %s", ErrUnknownLeadStatus, s)
+		}
+	}
+
+	return f, nil
+}
+
+// Statuses splits the Status filter into its statuses
+func (f LeadFilter) Statuses() []string {
+	var statuses []string
+
+	for _, s := range strings.Split(f.Status, ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			statuses = append(statuses, s)
+		}
+	}
+
+	return statuses
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// service appointment statuses, a booked appointment ends either completed or cancelled
+const (
+	AppointmentBooked    = "booked"
+	AppointmentCompleted = "completed"
+	AppointmentCancelled = "cancelled"
+)
+
+// ServiceAppointment is a car booked into the service bay at ScheduledAt for the work asked for
+type ServiceAppointment struct {
+	ID          uuid.UUID `json:"ID"`
+	CarID       uuid.UUID `json:"CarID"`
+	CustomerID  uuid.UUID `json:"CustomerID"`
+	ScheduledAt time.Time `json:"ScheduledAt"`
+	Work        string    `json:"Work"`
+	Status      string    `json:"Status"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt"`
+}
+
+// Part is a part fitted during maintenance
+type Part struct {
+	Number      string `json:"Number"`
+	Description string `json:"Description"`
+	Quantity    int    `json:"Quantity"`
+}
+
+// MaintenanceRecord is maintenance done on a car, either for an appointment or as a walk in. Odometer is the
+// reading in km when the car came in.
+type MaintenanceRecord struct {
+	ID            uuid.UUID  `json:"ID"`
+	CarID         uuid.UUID  `json:"CarID"`
+	AppointmentID *uuid.UUID `json:"AppointmentID,omitempty"`
+	Work          string     `json:"Work"`
+	Parts         []Part     `json:"Parts"`
+	LabourHours   float64    `json:"LabourHours"`
+	Odometer      int        `json:"Odometer"`
+	Technician    string     `json:"Technician"`
+	CompletedAt   time.Time  `json:"CompletedAt"`
+}
+
+// ServiceReminder is when a car is next due for a service, whichever of DueAt and DueOdometer comes first.
+// A car without a recorded service is due its first service at DueOdometer and has no DueAt.
+type ServiceReminder struct {
+	CarID          uuid.UUID  `json:"CarID"`
+	EngineType     string     `json:"EngineType"`
+	IntervalMonths int        `json:"IntervalMonths"`
+	IntervalKm     int        `json:"IntervalKm"`
+	LastServiceAt  *time.Time `json:"LastServiceAt,omitempty"`
+	LastOdometer   int        `json:"LastOdometer"`
+	DueAt          *time.Time `json:"DueAt,omitempty"`
+	DueOdometer    int        `json:"DueOdometer"`
+	Overdue        bool       `json:"Overdue"`
+}
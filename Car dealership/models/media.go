@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// media renditions, every upload is kept as sent and resized to fit a large and a thumbnail box
+const (
+	RenditionOriginal  = "original"
+	RenditionLarge     = "large"
+	RenditionThumbnail = "thumbnail"
+)
+
+// MediaURLs are where the renditions of an image are served from
+type MediaURLs struct {
+	Original  string `json:"Original"`
+	Large     string `json:"Large"`
+	Thumbnail string `json:"Thumbnail"`
+}
+
+// Media is an image of a car. Images are shown in Position order with the Primary one leading the listing.
+type Media struct {
+	ID          uuid.UUID `json:"ID"`
+	CarID       uuid.UUID `json:"CarID"`
+	Position    int       `json:"Position"`
+	Primary     bool      `json:"Primary"`
+	FileName    string    `json:"FileName"`
+	ContentType string    `json:"ContentType"`
+	Size        int64     `json:"Size"`
+	Width       int       `json:"Width"`
+	Height      int       `json:"Height"`
+	URLs        MediaURLs `json:"URLs"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+}
+
+// MediaOrder is the new order of the images of a car, it lists every image once
+type MediaOrder struct {
+	IDs []uuid.UUID `json:"IDs"`
+}
+
+// CarMedia is the media shown with a car, Images are in order and include the primary image
+type CarMedia struct {
+	Primary MediaURLs   `json:"Primary"`
+	Images  []MediaURLs `json:"Images"`
+}
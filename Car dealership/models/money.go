@@ -0,0 +1,107 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidMoney = errors.New("invalid amount, expected a decimal with at most two fraction digits")
+
+// Money is an exact amount in hundredths of the currency unit, so that prices are never rounded by floating point
+// arithmetic. It is written to JSON as a decimal string such as "24999.90", Format writes it with the fraction
+// digits of its currency.
+type Money int64
+
+// ParseMoney parses a decimal amount such as "24999.9" or "-12.05", rejecting more than two fraction digits
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+
+	if whole == "" || len(frac) > 2 || !digits(whole) || !digits(frac) {
+		return 0, ErrInvalidMoney
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/100-1 {
+		return 0, ErrInvalidMoney
+	}
+
+	cents, _ := strconv.ParseInt((frac + "00")[:2], 10, 64)
+
+	m := Money(units*100 + cents)
+	if neg {
+		m = -m
+	}
+
+	return m, nil
+}
+
+func digits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String formats the amount with exactly two fraction digits
+func (m Money) String() string {
+	sign := ""
+	v := int64(m)
+
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+
+	cents := strconv.FormatInt(v%100, 10)
+	if len(cents) == 1 {
+		cents = "0" + cents
+	}
+
+	return sign + strconv.FormatInt(v/100, 10) + "." + cents
+}
+
+// MarshalJSON writes the amount as a decimal string
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON reads the amount from a decimal string or a JSON number, the number is parsed from its text
+// and never converted to a float
+func (m *Money) UnmarshalJSON(b []byte) error {
+	s := string(b)
+
+	if s == "null" {
+		return nil
+	}
+
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	}
+
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+
+	*m = v
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseMoney test function to test decimal amounts are parsed exactly
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		input  string
+		output Money
+		err    error
+	}{
+		{"24999.90", 2499990, nil},
+		{"24999.9", 2499990, nil},
+		{"0.07", 7, nil},
+		{"-12.05", -1205, nil},
+		{"100", 10000, nil},
+		{"1.005", 0, ErrInvalidMoney},
+		{"1e3", 0, ErrInvalidMoney},
+		{".5", 0, ErrInvalidMoney},
+		{"", 0, ErrInvalidMoney},
+		{"99999999999999999999", 0, ErrInvalidMoney},
+	}
+
+	for i, tc := range testCases {
+		m, err := ParseMoney(tc.input)
+		if err != tc.err || m != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v, %v\n Expected %v, %v", i, tc.input, m, err, tc.output, tc.err)
+		}
+	}
+}
+
+// TestMoneyJSON test function to test amounts are written as decimal strings and read from strings or numbers
+func TestMoneyJSON(t *testing.T) {
+	b, err := json.Marshal(Price{MSRP: 2499990, ListPrice: -5, Currency: "EUR"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"MSRP":"24999.90","ListPrice":"-0.05","Cost":"0.00","Currency":"EUR"}`, string(b))
+
+	var p Price
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"MSRP":"0.30","ListPrice":0.1,"Cost":null}`), &p))
+	assert.Equal(t, Price{MSRP: 30, ListPrice: 10}, p)
+
+	assert.Equal(t, ErrInvalidMoney, json.Unmarshal([]byte(`{"MSRP":12.345}`), &p))
+}
+
+// TestMoneyFormat test function to test amounts are formatted with the fraction digits of their currency
+func TestMoneyFormat(t *testing.T) {
+	testCases := []struct {
+		amount   Money
+		currency string
+		output   string
+		fits     bool
+	}{
+		{2499990, "EUR", "24999.90", true},
+		{150000, "JPY", "1500", true},
+		{-150000, "jpy", "-1500", true},
+		{150050, "JPY", "1500.50", false},
+		{150050, "BHD", "1500.50", true},
+	}
+
+	for i, tc := range testCases {
+		if got := tc.amount.Format(tc.currency); got != tc.output || tc.amount.Fits(tc.currency) != tc.fits {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v, %v\n Expected %v, %v", i, tc.currency, got,
+				tc.amount.Fits(tc.currency), tc.output, tc.fits)
+		}
+	}
+
+	assert.True(t, ValidCurrency("JPY"))
+	assert.False(t, ValidCurrency("BHD"), "currencies of three fraction digits do not fit in hundredths")
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// sales order statuses, an order is open until it is paid in full or cancelled
+const (
+	OrderOpen      = "open"
+	OrderPaid      = "paid"
+	OrderCancelled = "cancelled"
+)
+
+var ErrUnknownOrderStatus = errors.New("unknown order status")
+
+// ValidOrderStatus reports whether s is one of the order statuses
+func ValidOrderStatus(s string) bool {
+	switch s {
+	case OrderOpen, OrderPaid, OrderCancelled:
+		return true
+	}
+
+	return false
+}
+
+// Fee is a charge added to the negotiated price of an order, such as registration or delivery
+type Fee struct {
+	Description string `json:"Description"`
+	Amount      Money  `json:"Amount"`
+}
+
+// Payment is an amount received against an order
+type Payment struct {
+	ID         uuid.UUID `json:"ID"`
+	OrderID    uuid.UUID `json:"OrderID"`
+	Amount     Money     `json:"Amount"`
+	Method     string    `json:"Method"`
+	Reference  string    `json:"Reference"`
+	ReceivedAt time.Time `json:"ReceivedAt"`
+}
+
+// SalesOrder is the sale of a car to a customer. Amounts are in Currency, tax is charged at TaxRate on the
+// price and fees together, and Tax, Total and Paid are kept up to date by the order service.
+type SalesOrder struct {
+	ID            uuid.UUID `json:"ID"`
+	Dealership    string    `json:"Dealership"`
+	InvoiceNumber string    `json:"InvoiceNumber"`
+	CarID         uuid.UUID `json:"CarID"`
+	CustomerID    uuid.UUID `json:"CustomerID"`
+	Salesperson   string    `json:"Salesperson"`
+	Price         Money     `json:"Price"`
+	Currency      string    `json:"Currency"`
+
+	// TaxRate is in basis points, 1850 is a rate of 18.50%
+	TaxRate   int       `json:"TaxRate"`
+	Fees      []Fee     `json:"Fees"`
+	Tax       Money     `json:"Tax"`
+	Total     Money     `json:"Total"`
+	Paid      Money     `json:"Paid"`
+	Status    string    `json:"Status"`
+	Notes     string    `json:"Notes"`
+	CreatedAt time.Time `json:"CreatedAt"`
+	UpdatedAt time.Time `json:"UpdatedAt"`
+
+	// Payments are only read with a single order
+	Payments []Payment `json:"Payments,omitempty"`
+}
+
+// Subtotal is the price and the fees before tax
+func (o SalesOrder) Subtotal() Money {
+	total := o.Price
+
+	for _, f := range o.Fees {
+		total += f.Amount
+	}
+
+	return total
+}
+
+// Balance is what is left to pay
+func (o SalesOrder) Balance() Money {
+	return o.Total - o.Paid
+}
+
+// Compute sets Tax and Total from the price, the fees and the tax rate, tax is rounded half up to the minor unit
+// of the currency
+func (o *SalesOrder) Compute() {
+	unit := MinorUnit(o.Currency)
+	subtotal := o.Subtotal() / unit
+	rate := Money(o.TaxRate)
+
+	o.Tax = (subtotal/10000*rate + (subtotal%10000*rate+5000)/10000) * unit
+	o.Total = subtotal*unit + o.Tax
+}
+
+// OrderFilter holds the filters of the order listing, zero values match every order
+type OrderFilter struct {
+	Status      string
+	Salesperson string
+	CustomerID  string
+	CarID       string
+}
+
+// ParseOrderFilter reads the filters from the status, salesperson, customerId and carId query parameters
+func ParseOrderFilter(query url.Values) (OrderFilter, error) {
+	f := OrderFilter{
+		Status:      strings.ToLower(strings.TrimSpace(query.Get("status"))),
+		Salesperson: strings.TrimSpace(query.Get("salesperson")),
+		CustomerID:  query.Get("customerId"),
+		CarID:       query.Get("carId"),
+	}
+
+	if f.Status != "" && !ValidOrderStatus(f.Status) {
+		return OrderFilter{}, fmt.Errorf("%w: %s", ErrUnknownOrderStatus, f.Status)
+	}
+
+	return f, nil
+}
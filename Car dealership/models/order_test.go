@@ -0,0 +1,31 @@
+package models
+
+import "testing"
+
+// TestCompute test function to test order tax is charged on the price and fees and rounded half up
+func TestCompute(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		order SalesOrder
+		tax   Money
+		total Money
+	}{
+		{"no tax", SalesOrder{Price: 2400000}, 0, 2400000},
+		{"tax on fees", SalesOrder{Price: 2400000, TaxRate: 1900, Fees: []Fee{{Amount: 15000}}}, 458850, 2873850},
+		{"rounded up", SalesOrder{Price: 1050, TaxRate: 1000}, 105, 1155},
+		{"half rounded up", SalesOrder{Price: 5, TaxRate: 1000}, 1, 6},
+		{"rounded down", SalesOrder{Price: 4, TaxRate: 1000}, 0, 4},
+		{"large amounts", SalesOrder{Price: 900000000000000000, TaxRate: 250}, 22500000000000000, 922500000000000000},
+		{"rounded to the yen", SalesOrder{Price: 1050000, Currency: "JPY", TaxRate: 1000, Fees: []Fee{{Amount: 500}}},
+			105100, 1155600},
+	}
+
+	for i, tc := range testCases {
+		tc.order.Compute()
+
+		if tc.order.Tax != tc.tax || tc.order.Total != tc.total {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v, %v\n Expected %v, %v", i, tc.desc, tc.order.Tax,
+				tc.order.Total, tc.tax, tc.total)
+		}
+	}
+}
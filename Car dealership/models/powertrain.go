@@ -0,0 +1,54 @@
+package models
+
+// powertrain types, from cars that only burn fuel to battery electric cars
+const (
+	PowertrainICE          = "ice"
+	PowertrainHybrid       = "hybrid"
+	PowertrainPlugInHybrid = "plug_in_hybrid"
+	PowertrainBEV          = "bev"
+)
+
+// ValidPowertrain reports whether t is one of the powertrain types
+func ValidPowertrain(t string) bool {
+	switch t {
+	case PowertrainICE, PowertrainHybrid, PowertrainPlugInHybrid, PowertrainBEV:
+		return true
+	}
+
+	return false
+}
+
+// charging standards a car may take, the plugs for AC and the DC fast charging standards
+const (
+	ChargingType1   = "type1"
+	ChargingType2   = "type2"
+	ChargingCCS1    = "ccs1"
+	ChargingCCS2    = "ccs2"
+	ChargingCHAdeMO = "chademo"
+	ChargingNACS    = "nacs"
+	ChargingGBT     = "gbt"
+)
+
+// ValidChargingStandard reports whether s is one of the charging standards
+func ValidChargingStandard(s string) bool {
+	switch s {
+	case ChargingType1, ChargingType2, ChargingCCS1, ChargingCCS2, ChargingCHAdeMO, ChargingNACS, ChargingGBT:
+		return true
+	}
+
+	return false
+}
+
+// Powertrain is what drives a car beyond its combustion engine. BatteryKWh is the usable battery capacity,
+// MaxChargeKW the fastest the car charges and MotorPowerKW the combined power of its electric motors.
+// FuelConsumption is in litres per 100 km for the cars that burn fuel and Efficiency in Wh per km for the cars
+// that charge. Which fields apply depends on the Type and is checked against the fuel type of the car.
+type Powertrain struct {
+	Type              string   `json:"Type"`
+	BatteryKWh        float64  `json:"BatteryKWh"`
+	ChargingStandards []string `json:"ChargingStandards"`
+	MaxChargeKW       float64  `json:"MaxChargeKW"`
+	MotorPowerKW      int64    `json:"MotorPowerKW"`
+	FuelConsumption   float64  `json:"FuelConsumption"`
+	Efficiency        int64    `json:"Efficiency"`
+}
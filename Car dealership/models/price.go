@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Price is the pricing of a car, amounts are in Currency, an ISO 4217 code
+type Price struct {
+	MSRP      Money  `json:"MSRP"`
+	ListPrice Money  `json:"ListPrice"`
+	Cost      Money  `json:"Cost"`
+	Currency  string `json:"Currency"`
+}
+
+// PriceChange is an entry of a car's price history, it records the price set by Actor and why
+type PriceChange struct {
+	ID        uuid.UUID `json:"ID"`
+	CarID     uuid.UUID `json:"CarID"`
+	Price     Price     `json:"Price"`
+	Actor     string    `json:"Actor"`
+	Reason    string    `json:"Reason"`
+	ChangedAt time.Time `json:"ChangedAt"`
+}
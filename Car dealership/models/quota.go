@@ -0,0 +1,13 @@
+package models
+
+// QuotaUsage is how many requests a client made on a day, Day is in YYYY-MM-DD form and in UTC. Limited counts
+// the requests refused by a rate limit or the quota, a Quota of zero is no quota.
+type QuotaUsage struct {
+	Client    string `json:"Client"`
+	Role      string `json:"Role"`
+	Day       string `json:"Day"`
+	Requests  int    `json:"Requests"`
+	Limited   int    `json:"Limited"`
+	Quota     int    `json:"Quota"`
+	Remaining int    `json:"Remaining"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// SimilarityWeights are how much each attribute counts towards the similarity of two cars, from 0 to 100
+type SimilarityWeights struct {
+	Brand        int `json:"Brand"`
+	FuelType     int `json:"FuelType"`
+	Year         int `json:"Year"`
+	Displacement int `json:"Displacement"`
+	Cylinders    int `json:"Cylinders"`
+	Range        int `json:"Range"`
+}
+
+// Recommendation is a car suggested in place of another, Score is its similarity from 0 to 100 and Reasons say
+// what the two cars have in common
+type Recommendation struct {
+	Car     Car      `json:"Car"`
+	Score   int      `json:"Score"`
+	Reasons []string `json:"Reasons"`
+}
+
+// Recommendations are the cars most similar to CarID, best first, with the weights they were ranked by
+type Recommendations struct {
+	CarID   string            `json:"CarID"`
+	Weights SimilarityWeights `json:"Weights"`
+	Cars    []Recommendation  `json:"Cars"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// reservation statuses, a hold is active until it is released, it expires or the car is sold to its holder
+const (
+	ReservationActive    = "active"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+	ReservationPurchased = "purchased"
+)
+
+// Reservation is a time limited hold on a car for Holder, while it is active the car is reserved. A hold placed
+// for a customer has the id of the customer as Holder so that the car can be sold to them.
+type Reservation struct {
+	ID        uuid.UUID  `json:"ID"`
+	CarID     uuid.UUID  `json:"CarID"`
+	Holder    string     `json:"Holder"`
+	Status    string     `json:"Status"`
+	CreatedAt time.Time  `json:"CreatedAt"`
+	ExpiresAt time.Time  `json:"ExpiresAt"`
+	EndedAt   *time.Time `json:"EndedAt,omitempty"`
+}
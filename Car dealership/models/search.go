@@ -0,0 +1,9 @@
+package models
+
+// SearchResult is a car matching a search query, Highlights holds the matched fields with the
+// matching words wrapped in <mark> tags
+type SearchResult struct {
+	Car        Car               `json:"Car"`
+	Score      float64           `json:"Score"`
+	Highlights map[string]string `json:"Highlights"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// inventory statuses of a car, the allowed transitions between them are enforced by the status service
+const (
+	StatusInTransit = "in_transit"
+	StatusInStock   = "in_stock"
+	StatusReserved  = "reserved"
+	StatusSold      = "sold"
+	StatusDelivered = "delivered"
+)
+
+var ErrUnknownStatus = errors.New("unknown status")
+
+// ValidStatus reports whether s is one of the inventory statuses
+func ValidStatus(s string) bool {
+	switch s {
+	case StatusInTransit, StatusInStock, StatusReserved, StatusSold, StatusDelivered:
+		return true
+	}
+
+	return false
+}
+
+// StatusChange is a transition of a car from one status to another, made by Actor at ChangedAt
+type StatusChange struct {
+	ID        uuid.UUID `json:"ID"`
+	CarID     uuid.UUID `json:"CarID"`
+	From      string    `json:"From"`
+	To        string    `json:"To"`
+	Actor     string    `json:"Actor"`
+	Reason    string    `json:"Reason"`
+	ChangedAt time.Time `json:"ChangedAt"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// test drive statuses, a test drive stays scheduled until it is cancelled
+const (
+	TestDriveScheduled = "scheduled"
+	TestDriveCancelled = "cancelled"
+)
+
+// TestDrive is an appointment of a customer to drive a car with a salesperson from Start until End.
+// Sequence counts the changes made to it since it was booked, calendar clients use it to pick the latest version.
+type TestDrive struct {
+	ID          uuid.UUID `json:"ID"`
+	CarID       uuid.UUID `json:"CarID"`
+	CustomerID  uuid.UUID `json:"CustomerID"`
+	Salesperson string    `json:"Salesperson"`
+	Start       time.Time `json:"Start"`
+	End         time.Time `json:"End"`
+	Status      string    `json:"Status"`
+	Notes       string    `json:"Notes"`
+	Sequence    int       `json:"Sequence"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt"`
+}
+
+// Overlaps reports whether the test drive takes place at some time between start and end
+func (d TestDrive) Overlaps(start, end time.Time) bool {
+	return d.Start.Before(end) && start.Before(d.End)
+}
+
+// Slot is a free period in which a test drive can be booked
+type Slot struct {
+	Start time.Time `json:"Start"`
+	End   time.Time `json:"End"`
+}
+
+// TestDriveFilter selects the test drives of a car or a salesperson taking place between From and To,
+// zero values match every test drive
+type TestDriveFilter struct {
+	CarID       string
+	Salesperson string
+	Status      string
+	From        time.Time
+	To          time.Time
+}
@@ -0,0 +1,15 @@
+package models
+
+// VINInfo is what can be read from a vehicle identification number without looking the car up
+type VINInfo struct {
+	VIN             string `json:"VIN"`
+	WMI             string `json:"WMI"`
+	VDS             string `json:"VDS"`
+	VIS             string `json:"VIS"`
+	Manufacturer    string `json:"Manufacturer"`
+	Country         string `json:"Country"`
+	Region          string `json:"Region"`
+	ModelYear       int    `json:"ModelYear"`
+	ModelYears      []int  `json:"ModelYears"`
+	CheckDigitValid bool   `json:"CheckDigitValid"`
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// warranty kinds, a car leaves the factory with a manufacturer warranty and may be sold extended cover on top
+const (
+	WarrantyManufacturer = "manufacturer"
+	WarrantyExtended     = "extended"
+)
+
+// components a warranty may cover, the battery is only covered on electric cars
+const (
+	ComponentEngine     = "engine"
+	ComponentPowertrain = "powertrain"
+	ComponentBattery    = "battery"
+)
+
+// Components are the components a warranty may cover in the order they are reported
+var Components = []string{ComponentEngine, ComponentPowertrain, ComponentBattery}
+
+// ValidComponent reports whether c is a component a warranty may cover
+func ValidComponent(c string) bool {
+	for _, component := range Components {
+		if c == component {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Warranty is a warranty plan on a car. It covers its components from StartDate until ExpiresAt, or until the car
+// runs past MileageLimit km if that comes first. A MileageLimit of 0 is unlimited mileage.
+type Warranty struct {
+	ID             uuid.UUID `json:"ID"`
+	CarID          uuid.UUID `json:"CarID"`
+	Kind           string    `json:"Kind"`
+	Provider       string    `json:"Provider"`
+	StartDate      time.Time `json:"StartDate"`
+	DurationMonths int       `json:"DurationMonths"`
+	MileageLimit   int       `json:"MileageLimit"`
+	Components     []string  `json:"Components"`
+	ExpiresAt      time.Time `json:"ExpiresAt"`
+	CreatedAt      time.Time `json:"CreatedAt"`
+}
+
+// warranty statuses on a given date and odometer reading
+const (
+	WarrantyActive          = "active"
+	WarrantyNotStarted      = "not_started"
+	WarrantyExpired         = "expired"
+	WarrantyMileageExceeded = "mileage_exceeded"
+)
+
+// WarrantyStatus is whether a warranty is in force on a given date and odometer reading
+type WarrantyStatus struct {
+	Warranty Warranty `json:"Warranty"`
+	Status   string   `json:"Status"`
+}
+
+// Coverage is the warranty cover of a car on Date at Odometer km. Covered lists the components at least one
+// active warranty covers.
+type Coverage struct {
+	CarID      uuid.UUID        `json:"CarID"`
+	Date       time.Time        `json:"Date"`
+	Odometer   int              `json:"Odometer"`
+	Covered    []string         `json:"Covered"`
+	Warranties []WarrantyStatus `json:"Warranties"`
+}
@@ -0,0 +1,150 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/rpc/pb"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+)
+
+// carServer serves cars through the same services as the car handlers
+type carServer struct {
+	pb.UnimplementedCarServiceServer
+
+	cars service.Cars
+	vins service.VINs
+}
+
+// GetCar gets a car by its id
+func (s carServer) GetCar(ctx context.Context, req *pb.GetCarRequest) (*pb.Car, error) {
+	car, err := s.cars.GetCarByID(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toCar(car), nil
+}
+
+// GetCarByVIN gets a car by its vehicle identification number
+func (s carServer) GetCarByVIN(ctx context.Context, req *pb.GetCarByVINRequest) (*pb.Car, error) {
+	car, err := s.vins.GetCarByVIN(ctx, req.Vin)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toCar(car), nil
+}
+
+// ListCars lists the cars of a brand, with their engines when asked for
+func (s carServer) ListCars(ctx context.Context, req *pb.ListCarsRequest) (*pb.ListCarsResponse, error) {
+	cars, err := s.cars.GetCarByBrand(ctx, req.Brand, req.IncludeEngine)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &pb.ListCarsResponse{Cars: make([]*pb.Car, len(cars))}
+
+	for i := range cars {
+		res.Cars[i] = toCar(cars[i])
+	}
+
+	return res, nil
+}
+
+// CreateCar creates the car in the request
+func (s carServer) CreateCar(ctx context.Context, req *pb.CreateCarRequest) (*pb.Car, error) {
+	car, err := fromCar(req.Car)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.cars.CreateCar(ctx, &car)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toCar(created), nil
+}
+
+// UpdateCar replaces the car with the id by the one in the request
+func (s carServer) UpdateCar(ctx context.Context, req *pb.UpdateCarRequest) (*pb.Car, error) {
+	car, err := fromCar(req.Car)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.cars.UpdateCar(ctx, req.Id, car)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toCar(updated), nil
+}
+
+// DeleteCar deletes a car by its id
+func (s carServer) DeleteCar(ctx context.Context, req *pb.DeleteCarRequest) (*pb.Car, error) {
+	car, err := s.cars.DeleteCar(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toCar(car), nil
+}
+
+// engineServer serves the engines of cars from the engine store, as there is no engine service
+type engineServer struct {
+	pb.UnimplementedEngineServiceServer
+
+	engines datastore.Engine
+}
+
+// GetEngine gets an engine by its id
+func (s engineServer) GetEngine(ctx context.Context, req *pb.GetEngineRequest) (*pb.Engine, error) {
+	engine, err := s.engines.EngineGetByID(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toEngine(engine), nil
+}
+
+// CreateEngine creates the engine in the request
+func (s engineServer) CreateEngine(ctx context.Context, req *pb.CreateEngineRequest) (*pb.Engine, error) {
+	engine, err := fromEngine(req.Engine)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.engines.EngineCreate(ctx, &engine)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toEngine(created), nil
+}
+
+// UpdateEngine replaces the engine with the id by the one in the request
+func (s engineServer) UpdateEngine(ctx context.Context, req *pb.UpdateEngineRequest) (*pb.Engine, error) {
+	engine, err := fromEngine(req.Engine)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.engines.EngineUpdate(ctx, req.Id, engine)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toEngine(updated), nil
+}
+
+// DeleteEngine deletes an engine by its id
+func (s engineServer) DeleteEngine(ctx context.Context, req *pb.DeleteEngineRequest) (*pb.Engine, error) {
+	engine, err := s.engines.EngineDelete(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return toEngine(engine), nil
+}
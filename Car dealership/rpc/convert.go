@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/rpc/pb"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toCar(c models.Car) *pb.Car {
+	car := &pb.Car{Id: c.ID.String(), Vin: c.VIN, Name: c.Name, Year: int32(c.Year), Brand: c.Brand,
+		FuelType: c.FuelType, Engine: toEngine(c.Engine), Status: c.Status, Msrp: int64(c.MSRP),
+		ListPrice: int64(c.ListPrice), Cost: int64(c.Cost), Currency: c.Currency}
+
+	if c.TrimID != nil {
+		car.TrimId = c.TrimID.String()
+	}
+
+	return car
+}
+
+// fromCar reads a car sent by a client, an id left empty is the nil id as it is when left out of JSON
+func fromCar(c *pb.Car) (models.Car, error) {
+	if c == nil {
+		return models.Car{}, status.Error(codes.InvalidArgument, "car is required")
+	}
+
+	id, err := parseID("car id", c.Id)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	engine, err := fromEngine(c.Engine)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	car := models.Car{ID: id, VIN: c.Vin, Name: c.Name, Year: int(c.Year), Brand: c.Brand, FuelType: c.FuelType,
+		Engine: engine, Status: c.Status, MSRP: models.Money(c.Msrp), ListPrice: models.Money(c.ListPrice),
+		Cost: models.Money(c.Cost), Currency: c.Currency}
+
+	if c.TrimId != "" {
+		trim, err := parseID("trim id", c.TrimId)
+		if err != nil {
+			return models.Car{}, err
+		}
+
+		car.TrimID = &trim
+	}
+
+	return car, nil
+}
+
+func toEngine(e models.Engine) *pb.Engine {
+	engine := &pb.Engine{Id: e.EngineID.String(), Displacement: e.Displacement, NoOfCylinder: e.NoOfCylinder,
+		Range: e.CarRange}
+
+	if p := e.Powertrain; p != nil {
+		engine.Powertrain = &pb.Powertrain{Type: p.Type, BatteryKwh: p.BatteryKWh,
+			ChargingStandards: p.ChargingStandards, MaxChargeKw: p.MaxChargeKW, MotorPowerKw: p.MotorPowerKW,
+			FuelConsumption: p.FuelConsumption, Efficiency: p.Efficiency}
+	}
+
+	return engine
+}
+
+func fromEngine(e *pb.Engine) (models.Engine, error) {
+	if e == nil {
+		return models.Engine{}, nil
+	}
+
+	id, err := parseID("engine id", e.Id)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	engine := models.Engine{EngineID: id, Displacement: e.Displacement, NoOfCylinder: e.NoOfCylinder,
+		CarRange: e.Range}
+
+	if p := e.Powertrain; p != nil {
+		engine.Powertrain = &models.Powertrain{Type: p.Type, BatteryKWh: p.BatteryKwh,
+			ChargingStandards: p.ChargingStandards, MaxChargeKW: p.MaxChargeKw, MotorPowerKW: p.MotorPowerKw,
+			FuelConsumption: p.FuelConsumption, Efficiency: p.Efficiency}
+	}
+
+	return engine, nil
+}
+
+func parseID(name, s string) (uuid.UUID, error) {
+	if s == "" {
+		return uuid.Nil, nil
+	}
+
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "%s %q is not a uuid", name, s)
+	}
+
+	return id, nil
+}
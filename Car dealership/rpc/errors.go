@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/catalogue"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/listing"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/powertrain"
+	statuses "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/status"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/vin"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes are the codes of the errors of the car and engine services, the first one an error is matches
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{sql.ErrNoRows, codes.NotFound},
+	{vin.ErrCarNotFound, codes.NotFound},
+	{vin.ErrInvalidVIN, codes.InvalidArgument},
+	{vin.ErrVINMismatch, codes.InvalidArgument},
+	{powertrain.ErrInvalidPowertrain, codes.InvalidArgument},
+	{catalogue.ErrTrimMismatch, codes.InvalidArgument},
+	{catalogue.ErrUnknownBrand, codes.InvalidArgument},
+	{catalogue.ErrInvalidCatalogue, codes.InvalidArgument},
+	{models.ErrUnknownStatus, codes.InvalidArgument},
+	{models.ErrInvalidMoney, codes.InvalidArgument},
+	{models.ErrPriceWithoutCurrency, codes.InvalidArgument},
+	{models.ErrUnknownCurrency, codes.InvalidArgument},
+	{listing.ErrInvalidPage, codes.InvalidArgument},
+	{statuses.ErrIllegalTransition, codes.FailedPrecondition},
+	{context.Canceled, codes.Canceled},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+}
+
+// Code returns the gRPC code of an error of the services, Internal for errors which are not the client's
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	if s, ok := status.FromError(err); ok {
+		return s.Code()
+	}
+
+	for _, e := range errorCodes {
+		if errors.Is(err, e.err) {
+			return e.code
+		}
+	}
+
+	return codes.Internal
+}
+
+// toStatus returns err as a gRPC status, the message of internal errors is logged rather than sent to clients
+func toStatus(err error) error {
+	code := Code(err)
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	if code == codes.Internal {
+		log.Println(err)
+		return status.Error(code, "internal error")
+	}
+
+	return status.Error(code, err.Error())
+}
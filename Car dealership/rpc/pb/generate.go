@@ -0,0 +1,4 @@
+// Package pb is the code generated from api/proto/car.proto
+package pb
+
+//go:generate protoc -I ../../api/proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative car.proto
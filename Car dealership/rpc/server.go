@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/middleware"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/rpc/pb"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer returns a gRPC server of the car and engine services with server reflection. Calls are logged, rate
+// limited by limiter and authenticated as HTTP requests are by middleware, they are served over TLS when tlsConfig
+// is set. The route of a call to the rules of the limiter is its full method, such as
+// /cardealership.v1.CarService/ListCars.
+func NewServer(tlsConfig *tls.Config, limiter *middleware.RateLimiter, cars service.Cars, vins service.VINs,
+	engines datastore.Engine) *grpc.Server {
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(logUnary, limitUnary(limiter), authUnary),
+		grpc.ChainStreamInterceptor(logStream, limitStream(limiter), authStream),
+	}
+
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	s := grpc.NewServer(opts...)
+	pb.RegisterCarServiceServer(s, carServer{cars: cars, vins: vins})
+	pb.RegisterEngineServiceServer(s, engineServer{engines: engines})
+	reflection.Register(s)
+
+	return s
+}
+
+// ListenAndServe serves s on addr until the listener fails
+func ListenAndServe(addr string, s *grpc.Server) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(lis)
+}
+
+// authenticate returns ctx with the principal of the call. A call over mutual TLS is authenticated by its verified
+// client certificate, any other by the authorize metadata.
+func authenticate(ctx context.Context) (context.Context, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if subject := middleware.VerifiedSubject(&info.State); subject != "" {
+				return middleware.WithPrincipal(ctx, subject), nil
+			}
+		}
+	}
+
+	if md, _ := metadata.FromIncomingContext(ctx); len(md.Get("authorize")) == 0 || md.Get("authorize")[0] != "0000" {
+		return nil, status.Error(codes.Unauthenticated, "authorize metadata or a client certificate is required")
+	}
+
+	return ctx, nil
+}
+
+// limit takes a call to method from the bucket and quota of its caller, which is known by the API key metadata,
+// the verified client certificate or the peer address as HTTP requests are. A refused call gets ResourceExhausted
+// and the seconds to wait in the retry-after header.
+func limit(ctx context.Context, limiter *middleware.RateLimiter, method string) (header metadata.MD, err error) {
+	var apiKey, principal, addr string
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(strings.ToLower(middleware.APIKeyHeader)); len(keys) > 0 {
+			apiKey = keys[0]
+		}
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			principal = middleware.VerifiedSubject(&info.State)
+		}
+	}
+
+	allowed, retry, reason := limiter.Allow(apiKey, principal, addr, method)
+	if allowed {
+		return nil, nil
+	}
+
+	return metadata.Pairs("retry-after", strconv.Itoa(retry)), status.Error(codes.ResourceExhausted, reason)
+}
+
+func limitUnary(limiter *middleware.RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		header, err := limit(ctx, limiter, info.FullMethod)
+		if err != nil {
+			_ = grpc.SetHeader(ctx, header)
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func limitStream(limiter *middleware.RateLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		header, err := limit(ss.Context(), limiter, info.FullMethod)
+		if err != nil {
+			_ = ss.SetHeader(header)
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func authUnary(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func authStream(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream is a stream whose context carries the principal of the call
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func logUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	res, err := handler(ctx, req)
+
+	log.Println("grpc", info.FullMethod, status.Code(err), time.Since(start))
+
+	return res, err
+}
+
+func logStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+
+	log.Println("grpc", info.FullMethod, status.Code(err), time.Since(start))
+
+	return err
+}
@@ -0,0 +1,305 @@
+package rpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/middleware"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/rpc/pb"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/vin"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+const id = "38ec1d7a-834f-11ec-a8a3-0242ac120002"
+
+// dial serves s in memory and returns a connection to it
+func dial(t *testing.T, s *grpc.Server) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+
+	go func() { _ = s.Serve(lis) }()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+
+	return conn
+}
+
+// unlimited returns a rate limiter without rules or quotas
+func unlimited(t *testing.T) *middleware.RateLimiter {
+	l, err := middleware.NewRateLimiter(middleware.RateLimits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return l
+}
+
+func authorized() context.Context {
+	return metadata.AppendToOutgoingContext(context.TODO(), "authorize", "0000")
+}
+
+// TestCarService test function to test cars are served through the car services with their errors translated
+func TestCarService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCars := service.NewMockCars(ctrl)
+	mockVINs := service.NewMockVINs(ctrl)
+	client := pb.NewCarServiceClient(dial(t, NewServer(nil, unlimited(t), mockCars, mockVINs,
+		datastore.NewMockEngine(ctrl))))
+
+	trimID := uuid.New()
+	car := models.Car{ID: uuid.MustParse(id), VIN: "WVWZZZ1KZAW000001", Name: "Golf", Year: 2010,
+		Brand: "Volkswagen", FuelType: "Petrol", Engine: models.Engine{EngineID: uuid.New(), Displacement: 1400,
+			NoOfCylinder: 4, CarRange: 700}, TrimID: &trimID, Status: "in_stock", ListPrice: 2399900, Currency: "EUR"}
+	created := car
+	created.ID = uuid.Nil
+
+	gomock.InOrder(
+		mockCars.EXPECT().GetCarByID(gomock.Any(), id).Return(car, nil),
+		mockCars.EXPECT().GetCarByID(gomock.Any(), id).Return(models.Car{}, sql.ErrNoRows),
+		mockVINs.EXPECT().GetCarByVIN(gomock.Any(), "bad").Return(models.Car{}, vin.ErrInvalidVIN),
+		mockCars.EXPECT().GetCarByBrand(gomock.Any(), "Volkswagen", true).Return([]models.Car{car, car}, nil),
+		mockCars.EXPECT().CreateCar(gomock.Any(), &created).Return(car, nil),
+		mockCars.EXPECT().UpdateCar(gomock.Any(), id, car).Return(car, nil),
+		mockCars.EXPECT().DeleteCar(gomock.Any(), id).Return(models.Car{}, errors.New("db password is hunter2")),
+	)
+
+	pbCar := toCar(car)
+	pbCreated := toCar(created)
+	pbCreated.Id = ""
+
+	testCases := []struct {
+		desc string
+		call func() (interface{}, error)
+		code codes.Code
+	}{
+		{"get", func() (interface{}, error) {
+			return client.GetCar(authorized(), &pb.GetCarRequest{Id: id})
+		}, codes.OK},
+		{"not found", func() (interface{}, error) {
+			return client.GetCar(authorized(), &pb.GetCarRequest{Id: id})
+		}, codes.NotFound},
+		{"invalid vin", func() (interface{}, error) {
+			return client.GetCarByVIN(authorized(), &pb.GetCarByVINRequest{Vin: "bad"})
+		}, codes.InvalidArgument},
+		{"list", func() (interface{}, error) {
+			return client.ListCars(authorized(), &pb.ListCarsRequest{Brand: "Volkswagen", IncludeEngine: true})
+		}, codes.OK},
+		{"create", func() (interface{}, error) {
+			return client.CreateCar(authorized(), &pb.CreateCarRequest{Car: pbCreated})
+		}, codes.OK},
+		{"create without car", func() (interface{}, error) {
+			return client.CreateCar(authorized(), &pb.CreateCarRequest{})
+		}, codes.InvalidArgument},
+		{"invalid trim id", func() (interface{}, error) {
+			return client.CreateCar(authorized(), &pb.CreateCarRequest{Car: &pb.Car{TrimId: "trim"}})
+		}, codes.InvalidArgument},
+		{"update", func() (interface{}, error) {
+			return client.UpdateCar(authorized(), &pb.UpdateCarRequest{Id: id, Car: pbCar})
+		}, codes.OK},
+		{"internal error", func() (interface{}, error) {
+			return client.DeleteCar(authorized(), &pb.DeleteCarRequest{Id: id})
+		}, codes.Internal},
+		{"unauthenticated", func() (interface{}, error) {
+			return client.GetCar(context.TODO(), &pb.GetCarRequest{Id: id})
+		}, codes.Unauthenticated},
+	}
+
+	for i, tc := range testCases {
+		res, err := tc.call()
+
+		if status.Code(err) != tc.code {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.code)
+		}
+
+		if r, ok := res.(*pb.Car); ok && err == nil && !proto.Equal(r, pbCar) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, r, pbCar)
+		}
+
+		if r, ok := res.(*pb.ListCarsResponse); ok && err == nil && len(r.Cars) != 2 {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, len(r.Cars), 2)
+		}
+
+		if tc.code == codes.Internal {
+			assert.Equal(t, "internal error", status.Convert(err).Message(), "internal errors are not sent to clients")
+		}
+	}
+}
+
+// TestEngineService test function to test engines and their powertrains are served from the engine store
+func TestEngineService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEngine := datastore.NewMockEngine(ctrl)
+	client := pb.NewEngineServiceClient(dial(t, NewServer(nil, unlimited(t), service.NewMockCars(ctrl),
+		service.NewMockVINs(ctrl), mockEngine)))
+
+	engine := models.Engine{EngineID: uuid.MustParse(id), CarRange: 450, Powertrain: &models.Powertrain{Type: "bev",
+		BatteryKWh: 77, ChargingStandards: []string{"ccs2"}, MaxChargeKW: 135, MotorPowerKW: 150, Efficiency: 170}}
+
+	gomock.InOrder(
+		mockEngine.EXPECT().EngineCreate(gomock.Any(), &engine).Return(engine, nil),
+		mockEngine.EXPECT().EngineGetByID(gomock.Any(), id).Return(engine, nil),
+		mockEngine.EXPECT().EngineUpdate(gomock.Any(), id, engine).Return(models.Engine{}, sql.ErrNoRows),
+		mockEngine.EXPECT().EngineDelete(gomock.Any(), id).Return(engine, nil),
+	)
+
+	res, err := client.CreateEngine(authorized(), &pb.CreateEngineRequest{Engine: toEngine(engine)})
+	if err != nil || res.Powertrain.GetChargingStandards()[0] != "ccs2" {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "create", err, engine)
+	}
+
+	if res, err = client.GetEngine(authorized(), &pb.GetEngineRequest{Id: id}); err != nil || res.Range != 450 {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "get", err, engine)
+	}
+
+	_, err = client.UpdateEngine(authorized(), &pb.UpdateEngineRequest{Id: id, Engine: toEngine(engine)})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "update missing", err, codes.NotFound)
+	}
+
+	if _, err = client.DeleteEngine(authorized(), &pb.DeleteEngineRequest{Id: id}); err != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "delete", err, nil)
+	}
+}
+
+// TestRateLimit test function to test calls are limited by the rules of the limiter for their method and caller
+func TestRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	limiter, err := middleware.NewRateLimiter(middleware.RateLimits{
+		Clients: map[string]middleware.Client{"k1": {Name: "acme", Role: "partner"}},
+		Rules: []middleware.Rule{
+			{Route: pb.EngineService_GetEngine_FullMethodName, Rate: 0.001, Burst: 1},
+			{Route: pb.EngineService_GetEngine_FullMethodName, Role: "partner", Rate: 0.001, Burst: 2},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockEngine := datastore.NewMockEngine(ctrl)
+	client := pb.NewEngineServiceClient(dial(t, NewServer(nil, limiter, service.NewMockCars(ctrl),
+		service.NewMockVINs(ctrl), mockEngine)))
+
+	mockEngine.EXPECT().EngineGetByID(gomock.Any(), id).Return(models.Engine{EngineID: uuid.MustParse(id)}, nil).
+		Times(3)
+
+	partner := metadata.AppendToOutgoingContext(authorized(), "x-api-key", "k1")
+
+	testCases := []struct {
+		desc string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{"first call of the peer", authorized(), codes.OK},
+		{"over the limit of the peer", authorized(), codes.ResourceExhausted},
+		{"limited before authentication", context.TODO(), codes.ResourceExhausted},
+		{"partner rule", partner, codes.OK},
+		{"second call of the partner", partner, codes.OK},
+		{"over the limit of the partner", partner, codes.ResourceExhausted},
+	}
+
+	for i, tc := range testCases {
+		var header metadata.MD
+
+		_, err := client.GetEngine(tc.ctx, &pb.GetEngineRequest{Id: id}, grpc.Header(&header))
+		if status.Code(err) != tc.code {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.code)
+		}
+
+		if tc.code == codes.ResourceExhausted {
+			assert.Equal(t, []string{"1000"}, header.Get("retry-after"), tc.desc)
+		}
+	}
+}
+
+// TestReflection test function to test the services can be listed through server reflection
+func TestReflection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := dial(t, NewServer(nil, unlimited(t), service.NewMockCars(ctrl), service.NewMockVINs(ctrl),
+		datastore.NewMockEngine(ctrl)))
+
+	for i, ctx := range []context.Context{context.TODO(), authorized()} {
+		stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_ = stream.Send(&rpb.ServerReflectionRequest{MessageRequest: &rpb.ServerReflectionRequest_ListServices{}})
+		res, err := stream.Recv()
+
+		if i == 0 {
+			assert.Equal(t, codes.Unauthenticated, status.Code(err), "reflection needs authentication")
+			continue
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var names []string
+
+		for _, s := range res.GetListServicesResponse().Service {
+			names = append(names, s.Name)
+		}
+
+		sort.Strings(names)
+		assert.Equal(t, []string{"cardealership.v1.CarService", "cardealership.v1.EngineService",
+			"grpc.reflection.v1alpha.ServerReflection"}, names)
+	}
+}
+
+// TestCode test function to test errors of the services are translated to gRPC codes
+func TestCode(t *testing.T) {
+	testCases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{nil, codes.OK},
+		{fmt.Errorf("reading car: %w", sql.ErrNoRows), codes.NotFound},
+		{fmt.Errorf("%w: bad check digit", vin.ErrInvalidVIN), codes.InvalidArgument},
+		{models.ErrUnknownStatus, codes.InvalidArgument},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		{status.Error(codes.PermissionDenied, "no"), codes.PermissionDenied},
+		{errors.New("db error"), codes.Internal},
+	}
+
+	for i, tc := range testCases {
+		if got := Code(tc.err); got != tc.code {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.err, got, tc.code)
+		}
+	}
+}
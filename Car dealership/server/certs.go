@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Certificate serves a certificate and key loaded from files, loading them again after either file changed so
+// that renewed certificates are served without a restart
+type Certificate struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	changed [2]time.Time
+}
+
+// LoadCertificate loads the PEM encoded certificate chain and key from certFile and keyFile
+func LoadCertificate(certFile, keyFile string) (*Certificate, error) {
+	c := &Certificate{certFile: certFile, keyFile: keyFile}
+
+	if _, err := c.Reload(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// GetCertificate returns the certificate to serve, it is meant for tls.Config.GetCertificate
+func (c *Certificate) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.cert, nil
+}
+
+// Reload loads the certificate and key again when either file changed since they were loaded, and reports whether
+// it did. The loaded certificate is kept when the files can not be read, such as while they are being replaced.
+func (c *Certificate) Reload() (bool, error) {
+	changed, err := modTimes(c.certFile, c.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.RLock()
+	same := c.cert != nil && changed == c.changed
+	c.mu.RUnlock()
+
+	if same {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	c.cert, c.changed = &cert, changed
+	c.mu.Unlock()
+
+	return true, nil
+}
+
+// Run checks the certificate and key files for changes every interval until ctx is done
+func (c *Certificate) Run(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := c.Reload()
+			if err != nil {
+				log.Println("Cant reload certificate", err)
+			}
+
+			if reloaded {
+				log.Println("Reloaded certificate", c.certFile)
+			}
+		}
+	}
+}
+
+func modTimes(certFile, keyFile string) ([2]time.Time, error) {
+	var times [2]time.Time
+
+	for i, name := range []string{certFile, keyFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return times, err
+		}
+
+		times[i] = info.ModTime()
+	}
+
+	return times, nil
+}
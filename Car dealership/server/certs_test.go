@@ -0,0 +1,125 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// issued is a certificate and its key, signed by a CA or by itself
+type issued struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// issue creates a certificate for name signed by ca, or a self signed CA when ca is nil
+func issue(t *testing.T, name string, ca *issued, serial int64) issued {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name, Organization: []string{"Car Dealership"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{name},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	parent, signer := tpl, key
+
+	if ca == nil {
+		tpl.IsCA, tpl.BasicConstraintsValid, tpl.KeyUsage = true, true, x509.KeyUsageCertSign
+	} else {
+		parent, signer = ca.cert, ca.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tpl, parent, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, _ := x509.ParseCertificate(der)
+
+	return issued{cert: cert, key: key, certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})}
+}
+
+// write writes the certificate and key of c to dir, dated at
+func write(t *testing.T, dir string, c issued, at time.Time) (certFile, keyFile string) {
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	for name, data := range map[string][]byte{certFile: c.certPEM, keyFile: c.keyPEM} {
+		if err := os.WriteFile(name, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.Chtimes(name, at, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return certFile, keyFile
+}
+
+// TestCertificate test function to test certificates are loaded again only after their files change
+func TestCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := issue(t, "ca", nil, 1)
+	first, second := issue(t, "localhost", &ca, 2), issue(t, "localhost", &ca, 3)
+	at := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	if _, err := LoadCertificate(filepath.Join(dir, "missing.pem"), filepath.Join(dir, "key.pem")); err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "missing files", err, "error")
+	}
+
+	c, err := LoadCertificate(write(t, dir, first, at))
+	if err != nil {
+		t.Fatalf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "load", err, nil)
+	}
+
+	steps := []struct {
+		desc     string
+		write    func()
+		reloaded bool
+		serial   int64
+	}{
+		{"unchanged", func() {}, false, 2},
+		{"renewed", func() { write(t, dir, second, at.Add(time.Hour)) }, true, 3},
+		{"broken key is not served", func() {
+			_ = os.WriteFile(filepath.Join(dir, "key.pem"), []byte("not a key"), 0600)
+			_ = os.Chtimes(filepath.Join(dir, "key.pem"), at.Add(2*time.Hour), at.Add(2*time.Hour))
+		}, false, 3},
+		{"fixed", func() { write(t, dir, first, at.Add(3*time.Hour)) }, true, 2},
+	}
+
+	for i, s := range steps {
+		s.write()
+
+		reloaded, _ := c.Reload()
+		cert, _ := c.GetCertificate(nil)
+		leaf, _ := x509.ParseCertificate(cert.Certificate[0])
+
+		if reloaded != s.reloaded || leaf.SerialNumber.Int64() != s.serial {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v %v\n Expected %v %v", i+2, s.desc, reloaded,
+				leaf.SerialNumber, s.reloaded, s.serial)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrNoClientCAs is returned when client certificates are required without a bundle of CAs to verify them with
+var ErrNoClientCAs = errors.New("client certificates required without a client CA bundle")
+
+// Config is how the API is served. Without a CertFile it is served over plain HTTP on Addr, with one it is served
+// over HTTPS on TLSAddr while Addr redirects to it. ClientCAFile is a PEM bundle of the CAs client certificates
+// are verified with, requests with a verified certificate are authenticated by it and RequireClientCert refuses
+// connections without one. The certificate and key files are checked for changes every ReloadEvery.
+type Config struct {
+	Addr              string
+	TLSAddr           string
+	CertFile          string
+	KeyFile           string
+	ClientCAFile      string
+	RequireClientCert bool
+	ReloadEvery       time.Duration
+}
+
+// TLS loads the certificate and returns the TLS configuration serving it, nil without a CertFile. The certificate
+// files are checked for changes until ctx is done.
+func (c Config) TLS(ctx context.Context) (*tls.Config, error) {
+	if c.CertFile == "" {
+		return nil, nil
+	}
+
+	cert, err := LoadCertificate(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := TLSConfig(cert, c.ClientCAFile, c.RequireClientCert)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ReloadEvery > 0 {
+		go cert.Run(ctx, c.ReloadEvery)
+	}
+
+	return tlsConfig, nil
+}
+
+// ListenAndServe serves h over HTTPS with tlsConfig on TLSAddr while Addr redirects to it, or over plain HTTP on
+// Addr when tlsConfig is nil, until the HTTPS listener, or the HTTP one without TLS, fails
+func ListenAndServe(c Config, tlsConfig *tls.Config, h http.Handler) error {
+	if tlsConfig == nil {
+		return newServer(c.Addr, h).ListenAndServe()
+	}
+
+	if c.Addr != "" {
+		go func() {
+			if err := newServer(c.Addr, Redirect(c.TLSAddr)).ListenAndServe(); err != nil {
+				log.Println("Cant serve HTTP redirects on", c.Addr, err)
+			}
+		}()
+	}
+
+	srv := newServer(c.TLSAddr, h)
+	srv.TLSConfig = tlsConfig
+
+	return srv.ListenAndServeTLS("", "")
+}
+
+// TLSConfig returns the TLS configuration serving cert. Client certificates are asked for and verified with the
+// CAs in clientCAFile when it is set, and required when require is.
+func TLSConfig(cert *Certificate, clientCAFile string, require bool) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: cert.GetCertificate}
+
+	if clientCAFile == "" {
+		if require {
+			return nil, ErrNoClientCAs
+		}
+
+		return config, nil
+	}
+
+	bundle, err := os.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("no certificates in client CA bundle %v", clientCAFile)
+	}
+
+	config.ClientCAs = pool
+	config.ClientAuth = tls.VerifyClientCertIfGiven
+
+	if require {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+// Redirect returns a handler redirecting requests to the same host and path over HTTPS on the port of tlsAddr
+func Redirect(tlsAddr string) http.Handler {
+	_, port, err := net.SplitHostPort(tlsAddr)
+	if err != nil {
+		port = "443"
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = strings.TrimSuffix(strings.TrimPrefix(r.Host, "["), "]")
+		}
+
+		if port != "443" {
+			host = net.JoinHostPort(host, port)
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
+
+func newServer(addr string, h http.Handler) *http.Server {
+	return &http.Server{Addr: addr, Handler: h, ReadHeaderTimeout: 10 * time.Second}
+}
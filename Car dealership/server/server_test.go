@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/middleware"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMutualTLS test function to test client certificates are verified and their subject becomes the principal
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, other := issue(t, "ca", nil, 1), issue(t, "other ca", nil, 2)
+	partner, stranger := issue(t, "partner", &ca, 3), issue(t, "partner", &other, 4)
+
+	cert, err := LoadCertificate(write(t, dir, issue(t, "localhost", &ca, 5), time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := filepath.Join(dir, "clients.pem")
+	_ = os.WriteFile(bundle, ca.certPEM, 0600)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	principal := middleware.Auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(middleware.Principal(r.Context())))
+	}))
+
+	testCases := []struct {
+		desc       string
+		require    bool
+		client     *issued
+		auth       string
+		statusCode int
+		principal  string
+	}{
+		{"client certificate", false, &partner, "", http.StatusOK, "CN=partner,O=Car Dealership"},
+		{"no client certificate", false, nil, "", http.StatusUnauthorized, ""},
+		{"no client certificate with header", false, nil, "0000", http.StatusOK, ""},
+		{"certificate of other CA", false, &stranger, "", 0, ""},
+		{"required client certificate", true, &partner, "", http.StatusOK, "CN=partner,O=Car Dealership"},
+		{"required without certificate", true, nil, "0000", 0, ""},
+	}
+
+	for i, tc := range testCases {
+		config, err := TLSConfig(cert, bundle, tc.require)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		srv := httptest.NewUnstartedServer(principal)
+		srv.TLS = config
+		srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+		srv.StartTLS()
+
+		// the server name makes the client send SNI, so the server answers with the loaded certificate
+		clientConfig := &tls.Config{RootCAs: roots, ServerName: "localhost", MinVersion: tls.VersionTLS12}
+
+		// the certificate is sent even when the server does not ask for its CA, as a stranger would
+		if tc.client != nil {
+			pair, _ := tls.X509KeyPair(tc.client.certPEM, tc.client.keyPEM)
+			clientConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				return &pair, nil
+			}
+		}
+
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientConfig}}
+
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/cars", nil)
+		req.Header.Set("authorize", tc.auth)
+
+		res, err := client.Do(req)
+
+		switch {
+		case tc.statusCode == 0 && err == nil:
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res.StatusCode,
+				"handshake error")
+		case tc.statusCode != 0 && err != nil:
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.statusCode)
+		case err == nil:
+			body, _ := io.ReadAll(res.Body)
+			res.Body.Close()
+
+			assert.Equal(t, tc.statusCode, res.StatusCode, "Test Case %v Failed: %v", i, tc.desc)
+			assert.Equal(t, tc.principal, string(body), "Test Case %v Failed: %v", i, tc.desc)
+		}
+
+		srv.Close()
+	}
+}
+
+// TestTLSConfig test function to test client certificates can only be required with a CA bundle to verify them
+func TestTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.pem")
+	_ = os.WriteFile(empty, []byte("no certificates"), 0600)
+
+	cert := &Certificate{}
+
+	config, err := TLSConfig(cert, "", false)
+	if err != nil || config.ClientAuth != tls.NoClientCert || config.MinVersion != tls.VersionTLS12 {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "no client certificates", err, nil)
+	}
+
+	if _, err := TLSConfig(cert, "", true); !errors.Is(err, ErrNoClientCAs) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "required without CAs", err, ErrNoClientCAs)
+	}
+
+	if _, err := TLSConfig(cert, filepath.Join(dir, "missing.pem"), false); err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "missing bundle", err, "error")
+	}
+
+	if _, err := TLSConfig(cert, empty, false); err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "empty bundle", err, "error")
+	}
+}
+
+// TestRedirect test function to test plain HTTP requests are redirected to the same host and path over HTTPS
+func TestRedirect(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		tlsAddr  string
+		host     string
+		target   string
+		location string
+	}{
+		{"port", "localhost:2443", "localhost:2000", "/cars?brand=BMW", "https://localhost:2443/cars?brand=BMW"},
+		{"default port", ":443", "cars.example.com", "/car/1", "https://cars.example.com/car/1"},
+		{"ipv6", ":443", "[::1]:2000", "/cars", "https://[::1]/cars"},
+		{"ipv6 with port", "localhost:2443", "[::1]", "/cars", "https://[::1]:2443/cars"},
+	}
+
+	for i, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, tc.target, nil)
+		req.Host = tc.host
+		res := httptest.NewRecorder()
+
+		Redirect(tc.tlsAddr).ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusPermanentRedirect, res.Code, "Test Case %v Failed: %v", i, tc.desc)
+		assert.Equal(t, tc.location, res.Header().Get("Location"), "Test Case %v Failed: %v", i, tc.desc)
+	}
+}
+
+// TestConfigTLS test function to test TLS is only configured with a certificate, which must load
+func TestConfigTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := issue(t, "ca", nil, 1)
+	certFile, keyFile := write(t, dir, issue(t, "localhost", &ca, 2), time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	testCases := []struct {
+		desc   string
+		config Config
+		tls    bool
+		err    bool
+	}{
+		{"plain HTTP", Config{Addr: "localhost:2000"}, false, false},
+		{"certificate", Config{CertFile: certFile, KeyFile: keyFile, ReloadEvery: time.Minute}, true, false},
+		{"missing key", Config{CertFile: certFile, KeyFile: filepath.Join(dir, "missing.pem")}, false, true},
+		{"required client certificates", Config{CertFile: certFile, KeyFile: keyFile, RequireClientCert: true}, false,
+			true},
+	}
+
+	for i, tc := range testCases {
+		config, err := tc.config.TLS(ctx)
+
+		if (config != nil) != tc.tls || (err != nil) != tc.err {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v %v\n Expected %v %v", i, tc.desc, config != nil, err,
+				tc.tls, tc.err)
+		}
+	}
+}
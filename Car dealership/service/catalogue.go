@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Catalogue interface {
+	CreateBrand(ctx context.Context, b models.Brand) (models.Brand, error)
+	GetBrand(ctx context.Context, id string) (models.Brand, error)
+	GetBrands(ctx context.Context) ([]models.Brand, error)
+	UpdateBrand(ctx context.Context, id string, b models.Brand) (models.Brand, error)
+	DeleteBrand(ctx context.Context, id string) error
+	CreateModel(ctx context.Context, brandID string, m models.CarModel) (models.CarModel, error)
+	GetModel(ctx context.Context, id string) (models.CarModel, error)
+	GetModels(ctx context.Context, brandID string) ([]models.CarModel, error)
+	UpdateModel(ctx context.Context, id string, m models.CarModel) (models.CarModel, error)
+	DeleteModel(ctx context.Context, id string) error
+	CreateTrim(ctx context.Context, modelID string, t models.Trim) (models.Trim, error)
+	GetTrim(ctx context.Context, id string) (models.Trim, error)
+	GetTrims(ctx context.Context, modelID string) ([]models.Trim, error)
+	UpdateTrim(ctx context.Context, id string, t models.Trim) (models.Trim, error)
+	DeleteTrim(ctx context.Context, id string) error
+	MigrateBrands(ctx context.Context, dryRun, createMissing bool) (models.BrandMigration, error)
+}
@@ -0,0 +1,225 @@
+package catalogue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/powertrain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTrimMismatch = errors.New("car does not match its trim")
+	ErrUnknownBrand = errors.New("brand is not in the catalogue")
+)
+
+// carResolver is a service.Cars which fills in cars from the catalogue, a car given a trim takes the brand, name,
+// fuel type and engine of the trim it leaves out and the brand of any other car is written as the catalogue has it.
+// A car of a brand the catalogue does not have is refused, the brand is added to the catalogue first.
+type carResolver struct {
+	service.Cars
+	catalogue catalogueService
+}
+
+// NewCarResolver wraps a car service so that cars are created and updated from the catalogue
+func NewCarResolver(next service.Cars, catalogue datastore.Catalogue, engine datastore.Engine) service.Cars {
+	return carResolver{Cars: next, catalogue: New(catalogue, engine)}
+}
+
+// GetCarByBrand looks the cars up by the catalogue name of the brand
+func (r carResolver) GetCarByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error) {
+	name, err := brandName(ctx, r.catalogue, brand)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Cars.GetCarByBrand(ctx, name, isEngine)
+}
+
+// listingResolver is a service.Listings which lists the cars of a brand by its catalogue name
+type listingResolver struct {
+	service.Listings
+	catalogue catalogueService
+}
+
+// NewListingResolver wraps a listing service so that brands are looked up as the catalogue has them
+func NewListingResolver(next service.Listings, catalogue datastore.Catalogue,
+	engine datastore.Engine) service.Listings {
+	return listingResolver{Listings: next, catalogue: New(catalogue, engine)}
+}
+
+// ListCars looks the cars up by the catalogue name of the brand
+func (l listingResolver) ListCars(ctx context.Context, filter models.CarFilter, page models.Page,
+	isEngine bool) (models.CarPage, error) {
+	name, err := brandName(ctx, l.catalogue, filter.Brand)
+	if err != nil {
+		return models.CarPage{}, err
+	}
+
+	filter.Brand = name
+
+	return l.Listings.ListCars(ctx, filter, page, isEngine)
+}
+
+// exportResolver is a service.Exporter which exports the cars of a brand by its catalogue name, as /cars lists them
+type exportResolver struct {
+	service.Exporter
+	catalogue catalogueService
+}
+
+// NewExportResolver wraps an export service so that brands are looked up as the catalogue has them
+func NewExportResolver(next service.Exporter, catalogue datastore.Catalogue,
+	engine datastore.Engine) service.Exporter {
+	return exportResolver{Exporter: next, catalogue: New(catalogue, engine)}
+}
+
+// Export looks the cars up by the catalogue name of the brand
+func (e exportResolver) Export(ctx context.Context, w io.Writer, opts models.ExportOptions) error {
+	name, err := brandName(ctx, e.catalogue, opts.Filter.Brand)
+	if err != nil {
+		return err
+	}
+
+	opts.Filter.Brand = name
+
+	return e.Exporter.Export(ctx, w, opts)
+}
+
+// CreateCar fills the car in from the catalogue before creating it
+func (r carResolver) CreateCar(ctx context.Context, car *models.Car) (models.Car, error) {
+	if car == nil {
+		return r.Cars.CreateCar(ctx, car)
+	}
+
+	if err := r.resolve(ctx, car); err != nil {
+		return models.Car{}, err
+	}
+
+	return r.Cars.CreateCar(ctx, car)
+}
+
+// UpdateCar fills the car in from the catalogue before updating it
+func (r carResolver) UpdateCar(ctx context.Context, id string, car models.Car) (models.Car, error) {
+	if err := r.resolve(ctx, &car); err != nil {
+		return models.Car{}, err
+	}
+
+	return r.Cars.UpdateCar(ctx, id, car)
+}
+
+// resolve fills a car in from its trim, a brand or fuel type given must be the one of the trim
+func (r carResolver) resolve(ctx context.Context, car *models.Car) error {
+	if car.TrimID == nil {
+		if models.CatalogueKey(car.Brand) == "" {
+			return nil
+		}
+
+		name, ok, err := lookupBrand(ctx, r.catalogue, car.Brand)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownBrand, car.Brand)
+		}
+
+		car.Brand = name
+
+		return nil
+	}
+
+	t, err := r.catalogue.GetTrim(ctx, car.TrimID.String())
+	if err != nil {
+		return err
+	}
+
+	m, err := r.catalogue.GetModel(ctx, t.ModelID.String())
+	if err != nil {
+		return err
+	}
+
+	b, err := r.catalogue.GetBrand(ctx, m.BrandID.String())
+	if err != nil {
+		return err
+	}
+
+	if car.Brand != "" && names([]models.Brand{b}, uuid.Nil)[models.CatalogueKey(car.Brand)] == "" {
+		return fmt.Errorf("%w: %s %s is a %s, not a %s", ErrTrimMismatch, m.Name, t.Name, b.Name, car.Brand)
+	}
+
+	fuel := car.FuelType
+	if fuel != "" && powertrain.NormalizeFuel(fuel) != powertrain.NormalizeFuel(t.FuelType) {
+		return fmt.Errorf("%w: %s %s runs on %s, not %s", ErrTrimMismatch, m.Name, t.Name, t.FuelType, fuel)
+	}
+
+	car.Brand = b.Name
+
+	if strings.TrimSpace(car.Name) == "" {
+		car.Name = m.Name + " " + t.Name
+	}
+
+	if fuel == "" {
+		car.FuelType = t.FuelType
+	}
+
+	car.Engine = inherit(car.Engine, t.Engine)
+
+	return nil
+}
+
+// brandName is the catalogue name of a brand, a brand the catalogue does not have is left as it is
+func brandName(ctx context.Context, catalogue catalogueService, brand string) (string, error) {
+	if models.CatalogueKey(brand) == "" {
+		return brand, nil
+	}
+
+	name, ok, err := lookupBrand(ctx, catalogue, brand)
+	if err != nil || !ok {
+		return brand, err
+	}
+
+	return name, nil
+}
+
+// lookupBrand finds the catalogue name of a brand by its name or an alias, ok is false when no brand matches
+func lookupBrand(ctx context.Context, catalogue catalogueService, brand string) (name string, ok bool, err error) {
+	brands, err := catalogue.GetBrands(ctx)
+	if err != nil {
+		return "", false, err
+	}
+
+	name, ok = names(brands, uuid.Nil)[models.CatalogueKey(brand)]
+
+	return name, ok, nil
+}
+
+// inherit fills the engine values a car leaves out with the defaults of its trim, a default powertrain is copied
+// so that the car does not share it with the trim
+func inherit(engine, defaults models.Engine) models.Engine {
+	if engine.Displacement == 0 {
+		engine.Displacement = defaults.Displacement
+	}
+
+	if engine.NoOfCylinder == 0 {
+		engine.NoOfCylinder = defaults.NoOfCylinder
+	}
+
+	if engine.CarRange == 0 {
+		engine.CarRange = defaults.CarRange
+	}
+
+	if engine.Powertrain == nil && defaults.Powertrain != nil {
+		p := *defaults.Powertrain
+		p.ChargingStandards = append([]string{}, p.ChargingStandards...)
+		engine.Powertrain = &p
+	}
+
+	return engine
+}
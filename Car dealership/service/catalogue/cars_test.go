@@ -0,0 +1,146 @@
+package catalogue
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCarResolver test function to test cars take their brand from the catalogue and fill in what they leave out
+// from their trim
+func TestCarResolver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCars(ctrl)
+	mockCatalogue := datastore.NewMockCatalogue(ctrl)
+	mockEngine := datastore.NewMockEngine(ctrl)
+	s := NewCarResolver(mockService, mockCatalogue, mockEngine)
+
+	brand := models.Brand{ID: uuid.New(), Name: "Volkswagen", Aliases: []string{"VW"}}
+	model := models.CarModel{ID: uuid.New(), BrandID: brand.ID, Name: "Golf"}
+	engine := models.Engine{EngineID: uuid.New(), Displacement: 1984, NoOfCylinder: 4, CarRange: 700}
+	trim := models.Trim{ID: uuid.New(), ModelID: model.ID, Name: "GTI", FuelType: "petrol",
+		Engine: models.Engine{EngineID: engine.EngineID}}
+	trimID := trim.ID
+
+	mockCatalogue.EXPECT().GetBrands(gomock.Any()).Return([]models.Brand{brand}, nil).Times(4)
+	mockCatalogue.EXPECT().GetTrim(gomock.Any(), trimID.String()).Return(trim, nil).Times(3)
+	mockEngine.EXPECT().EngineGetByID(gomock.Any(), engine.EngineID.String()).Return(engine, nil).Times(3)
+	mockCatalogue.EXPECT().GetModel(gomock.Any(), model.ID.String()).Return(model, nil).Times(3)
+	mockCatalogue.EXPECT().GetBrand(gomock.Any(), brand.ID.String()).Return(brand, nil).Times(3)
+
+	mockService.EXPECT().GetCarByBrand(gomock.Any(), "Volkswagen", true).Return([]models.Car{}, nil)
+	mockService.EXPECT().GetCarByBrand(gomock.Any(), "Lada", false).Return([]models.Car{}, nil)
+
+	if _, err := s.GetCarByBrand(context.TODO(), "vw", true); err != nil {
+		t.Errorf("get by alias: %v", err)
+	}
+
+	if _, err := s.GetCarByBrand(context.TODO(), "Lada", false); err != nil {
+		t.Errorf("get by unknown brand: %v", err)
+	}
+
+	plain := models.Car{Name: "Polo", Brand: "v.w.", FuelType: "petrol"}
+	expected := plain
+	expected.Brand = "Volkswagen"
+
+	mockService.EXPECT().CreateCar(gomock.Any(), &expected).Return(expected, nil)
+
+	if _, err := s.CreateCar(context.TODO(), &plain); err != nil {
+		t.Errorf("create without trim: %v", err)
+	}
+
+	if _, err := s.CreateCar(context.TODO(), &models.Car{Name: "Niva", Brand: "Lada"}); !errors.Is(err,
+		ErrUnknownBrand) {
+		t.Errorf("create with a brand the catalogue does not have: got %v", err)
+	}
+
+	fromTrim := models.Car{Year: 2022, TrimID: &trimID, Engine: models.Engine{CarRange: 650}}
+	expected = models.Car{Name: "Golf GTI", Year: 2022, Brand: "Volkswagen", FuelType: "petrol", TrimID: &trimID,
+		Engine: models.Engine{Displacement: 1984, NoOfCylinder: 4, CarRange: 650}}
+
+	mockService.EXPECT().CreateCar(gomock.Any(), &expected).Return(expected, nil)
+
+	if _, err := s.CreateCar(context.TODO(), &fromTrim); err != nil {
+		t.Errorf("create from trim: %v", err)
+	}
+
+	assert.Equal(t, expected, fromTrim, "the car is filled in from its trim")
+
+	other := models.Car{Brand: "BMW", TrimID: &trimID}
+	if _, err := s.UpdateCar(context.TODO(), uuid.New().String(), other); !errors.Is(err, ErrTrimMismatch) {
+		t.Errorf("update with the brand of another trim: got %v", err)
+	}
+
+	if _, err := s.CreateCar(context.TODO(), &models.Car{FuelType: "Diesel", TrimID: &trimID}); !errors.Is(err,
+		ErrTrimMismatch) {
+		t.Errorf("create with the fuel of another trim: got %v", err)
+	}
+
+	unknown := uuid.New()
+	mockCatalogue.EXPECT().GetTrim(gomock.Any(), unknown.String()).Return(models.Trim{}, errors.New("db error"))
+
+	if _, err := s.CreateCar(context.TODO(), &models.Car{TrimID: &unknown}); err == nil {
+		t.Errorf("create from unreadable trim: got %v", err)
+	}
+}
+
+// TestListingResolver test function to test cars are listed by the catalogue name of their brand
+func TestListingResolver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockListings := service.NewMockListings(ctrl)
+	mockCatalogue := datastore.NewMockCatalogue(ctrl)
+	s := NewListingResolver(mockListings, mockCatalogue, datastore.NewMockEngine(ctrl))
+
+	brand := models.Brand{ID: uuid.New(), Name: "Volkswagen", Aliases: []string{"VW"}}
+	page := models.Page{Limit: 10}
+
+	mockCatalogue.EXPECT().GetBrands(gomock.Any()).Return([]models.Brand{brand}, nil)
+	mockListings.EXPECT().ListCars(gomock.Any(), models.CarFilter{Brand: "Volkswagen", Status: "in_stock"}, page,
+		false).Return(models.CarPage{Total: 1}, nil)
+
+	res, err := s.ListCars(context.TODO(), models.CarFilter{Brand: "vw", Status: "in_stock"}, page, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Total)
+
+	mockCatalogue.EXPECT().GetBrands(gomock.Any()).Return(nil, errors.New("db error"))
+
+	_, err = s.ListCars(context.TODO(), models.CarFilter{Brand: "vw"}, page, false)
+	assert.Error(t, err)
+}
+
+// TestExportResolver test function to test cars are exported by the catalogue name of their brand
+func TestExportResolver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExporter := service.NewMockExporter(ctrl)
+	mockCatalogue := datastore.NewMockCatalogue(ctrl)
+	s := NewExportResolver(mockExporter, mockCatalogue, datastore.NewMockEngine(ctrl))
+
+	brand := models.Brand{ID: uuid.New(), Name: "Volkswagen", Aliases: []string{"VW"}}
+	opts := models.ExportOptions{Format: "csv", Filter: models.CarFilter{Brand: "vw", Status: "in_stock"}}
+	resolved := opts
+	resolved.Filter.Brand = "Volkswagen"
+
+	mockCatalogue.EXPECT().GetBrands(gomock.Any()).Return([]models.Brand{brand}, nil)
+	mockExporter.EXPECT().Export(gomock.Any(), gomock.Any(), resolved).Return(nil)
+
+	assert.NoError(t, s.Export(context.TODO(), io.Discard, opts))
+
+	mockCatalogue.EXPECT().GetBrands(gomock.Any()).Return(nil, errors.New("db error"))
+
+	assert.Error(t, s.Export(context.TODO(), io.Discard, opts))
+}
@@ -0,0 +1,109 @@
+package catalogue
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+// MigrateBrands service layer function to rewrite the brands of existing cars to the names in the catalogue,
+// matching them by name or alias whatever their case, spacing or punctuation. Spellings of brands the catalogue
+// does not have are reported, or added to it as new brands when createMissing is set, named after the spelling
+// most cars use. Nothing is written on a dry run.
+func (s catalogueService) MigrateBrands(ctx context.Context, dryRun, createMissing bool) (models.BrandMigration,
+	error) {
+	brands, err := s.catalogue.GetBrands(ctx)
+	if err != nil {
+		return models.BrandMigration{}, err
+	}
+
+	spellings, err := s.catalogue.GetBrandSpellings(ctx)
+	if err != nil {
+		return models.BrandMigration{}, err
+	}
+
+	known := names(brands, uuid.Nil)
+	m := models.BrandMigration{DryRun: dryRun, Renames: []models.BrandRename{}, Created: []models.Brand{},
+		Unmatched: []models.BrandSpelling{}}
+
+	// spellings of the same missing brand, in the order they are first seen
+	var missing []string
+
+	groups := map[string][]models.BrandSpelling{}
+
+	for _, sp := range spellings {
+		m.Cars += sp.Cars
+		key := models.CatalogueKey(sp.Brand)
+
+		switch name, ok := known[key]; {
+		case ok:
+			m.Renames = rename(m.Renames, sp, name)
+		case key == "" || !createMissing:
+			m.Unmatched = append(m.Unmatched, sp)
+		default:
+			if _, seen := groups[key]; !seen {
+				missing = append(missing, key)
+			}
+
+			groups[key] = append(groups[key], sp)
+		}
+	}
+
+	for _, key := range missing {
+		b, err := s.createMissing(ctx, groups[key], dryRun)
+		if err != nil {
+			return models.BrandMigration{}, err
+		}
+
+		m.Created = append(m.Created, b)
+
+		for _, sp := range groups[key] {
+			m.Renames = rename(m.Renames, sp, b.Name)
+		}
+	}
+
+	for _, r := range m.Renames {
+		m.Updated += r.Cars
+	}
+
+	if dryRun || len(m.Renames) == 0 {
+		return m, nil
+	}
+
+	if m.Updated, err = s.catalogue.RenameBrands(ctx, m.Renames); err != nil {
+		return models.BrandMigration{}, err
+	}
+
+	return m, nil
+}
+
+// createMissing adds a brand for spellings the catalogue does not have, named after the spelling most cars use
+func (s catalogueService) createMissing(ctx context.Context, spellings []models.BrandSpelling,
+	dryRun bool) (models.Brand, error) {
+	most := spellings[0]
+
+	for _, sp := range spellings[1:] {
+		if sp.Cars > most.Cars {
+			most = sp
+		}
+	}
+
+	b := models.Brand{ID: uuid.New(), Name: strings.TrimSpace(most.Brand), Aliases: []string{}}
+	if dryRun {
+		return b, nil
+	}
+
+	return s.catalogue.CreateBrand(ctx, b)
+}
+
+// rename adds the rename of a spelling to the catalogue name, spellings already written so are left as they are
+func rename(renames []models.BrandRename, sp models.BrandSpelling, name string) []models.BrandRename {
+	if sp.Brand == name {
+		return renames
+	}
+
+	return append(renames, models.BrandRename{From: sp.Brand, To: name, Cars: sp.Cars})
+}
@@ -0,0 +1,87 @@
+package catalogue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMigrateBrands service layer test function to test the brands of cars are rewritten to the catalogue names,
+// with missing brands reported or created and nothing written on a dry run
+func TestMigrateBrands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCatalogue := datastore.NewMockCatalogue(ctrl)
+	s := New(mockCatalogue, datastore.NewMockEngine(ctrl))
+
+	brands := []models.Brand{{ID: uuid.New(), Name: "BMW"},
+		{ID: uuid.New(), Name: "Volkswagen", Aliases: []string{"VW"}}}
+	spellings := []models.BrandSpelling{{Brand: "BMW", Cars: 5}, {Brand: "b.m.w.", Cars: 1}, {Brand: "kia", Cars: 1},
+		{Brand: "Kia", Cars: 3}, {Brand: "vw", Cars: 2}, {Brand: "?", Cars: 1}}
+	renames := []models.BrandRename{{From: "b.m.w.", To: "BMW", Cars: 1}, {From: "vw", To: "Volkswagen", Cars: 2}}
+	created := append(renames[:2:2], models.BrandRename{From: "kia", To: "Kia", Cars: 1})
+
+	testCases := []struct {
+		desc          string
+		dryRun        bool
+		createMissing bool
+		mock          func()
+		renames       []models.BrandRename
+		created       int
+		unmatched     int
+		updated       int
+		err           error
+	}{
+		{desc: "dry run", dryRun: true, renames: renames, unmatched: 3, updated: 3},
+		{desc: "migrate", mock: func() {
+			mockCatalogue.EXPECT().RenameBrands(gomock.Any(), renames).Return(3, nil)
+		}, renames: renames, unmatched: 3, updated: 3},
+		{desc: "dry run creating missing", dryRun: true, createMissing: true, renames: created, created: 1,
+			unmatched: 1, updated: 4},
+		{desc: "create missing", createMissing: true, mock: func() {
+			mockCatalogue.EXPECT().CreateBrand(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, b models.Brand) (models.Brand, error) { return b, nil })
+			mockCatalogue.EXPECT().RenameBrands(gomock.Any(), created).Return(4, nil)
+		}, renames: created, created: 1, unmatched: 1, updated: 4},
+		{desc: "rename error", mock: func() {
+			mockCatalogue.EXPECT().RenameBrands(gomock.Any(), renames).Return(0, errors.New("db error"))
+		}, err: errors.New("db error")},
+	}
+
+	for i, tc := range testCases {
+		mockCatalogue.EXPECT().GetBrands(gomock.Any()).Return(brands, nil)
+		mockCatalogue.EXPECT().GetBrandSpellings(gomock.Any()).Return(spellings, nil)
+
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.MigrateBrands(context.TODO(), tc.dryRun, tc.createMissing)
+		if (err == nil) != (tc.err == nil) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if tc.err != nil {
+			continue
+		}
+
+		assert.Equal(t, tc.renames, res.Renames, tc.desc)
+		assert.Equal(t, tc.dryRun, res.DryRun, tc.desc)
+		assert.Equal(t, 13, res.Cars, tc.desc)
+		assert.Equal(t, tc.updated, res.Updated, tc.desc)
+		assert.Len(t, res.Created, tc.created, tc.desc)
+		assert.Len(t, res.Unmatched, tc.unmatched, tc.desc)
+
+		if tc.created > 0 {
+			assert.Equal(t, "Kia", res.Created[0].Name, tc.desc)
+		}
+	}
+}
@@ -0,0 +1,503 @@
+package catalogue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/powertrain"
+
+	"github.com/google/uuid"
+)
+
+// MaxNameLength is the longest name of a brand, model or trim, as long as the brand and name of a car may be
+const MaxNameLength = 50
+
+var (
+	ErrBrandNotFound    = errors.New("brand not found")
+	ErrModelNotFound    = errors.New("model not found")
+	ErrTrimNotFound     = errors.New("trim not found")
+	ErrInvalidCatalogue = errors.New("invalid catalogue entry")
+	ErrDuplicate        = errors.New("already in the catalogue")
+	ErrInUse            = errors.New("still in use")
+)
+
+type catalogueService struct {
+	catalogue datastore.Catalogue
+	engine    datastore.Engine
+}
+
+func New(catalogue datastore.Catalogue, engine datastore.Engine) catalogueService { //nolint
+	return catalogueService{catalogue: catalogue, engine: engine}
+}
+
+// CreateBrand service layer function to add a brand, its name and aliases must not match those of another brand
+func (s catalogueService) CreateBrand(ctx context.Context, b models.Brand) (models.Brand, error) {
+	b, err := checkBrand(b)
+	if err != nil {
+		return models.Brand{}, err
+	}
+
+	if err = s.unique(ctx, b); err != nil {
+		return models.Brand{}, err
+	}
+
+	b.ID = uuid.New()
+
+	return s.catalogue.CreateBrand(ctx, b)
+}
+
+// GetBrand service layer function to get a brand by its id
+func (s catalogueService) GetBrand(ctx context.Context, id string) (models.Brand, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Brand{}, ErrBrandNotFound
+	}
+
+	b, err := s.catalogue.GetBrand(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Brand{}, ErrBrandNotFound
+	}
+
+	return b, err
+}
+
+// GetBrands service layer function to get every brand by name
+func (s catalogueService) GetBrands(ctx context.Context) ([]models.Brand, error) {
+	return s.catalogue.GetBrands(ctx)
+}
+
+// UpdateBrand service layer function to rename a brand and replace its aliases, the cars of a renamed brand are
+// renamed with it
+func (s catalogueService) UpdateBrand(ctx context.Context, id string, b models.Brand) (models.Brand, error) {
+	old, err := s.GetBrand(ctx, id)
+	if err != nil {
+		return models.Brand{}, err
+	}
+
+	if b, err = checkBrand(b); err != nil {
+		return models.Brand{}, err
+	}
+
+	b.ID = old.ID
+
+	if err = s.unique(ctx, b); err != nil {
+		return models.Brand{}, err
+	}
+
+	if err = s.catalogue.UpdateBrand(ctx, b); err != nil {
+		return models.Brand{}, err
+	}
+
+	if b.Name != old.Name {
+		_, err = s.catalogue.RenameBrands(ctx, []models.BrandRename{{From: old.Name, To: b.Name}})
+		if err != nil {
+			return models.Brand{}, err
+		}
+	}
+
+	return b, nil
+}
+
+// DeleteBrand service layer function to delete a brand, a brand is only deleted once it has no models
+func (s catalogueService) DeleteBrand(ctx context.Context, id string) error {
+	b, err := s.GetBrand(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	list, err := s.catalogue.GetModels(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(list) > 0 {
+		return fmt.Errorf("%w: %s still has %d models", ErrInUse, b.Name, len(list))
+	}
+
+	return s.catalogue.DeleteBrand(ctx, id)
+}
+
+// CreateModel service layer function to add a model to a brand, its name must not be taken by another model of
+// the brand
+func (s catalogueService) CreateModel(ctx context.Context, brandID string, m models.CarModel) (models.CarModel,
+	error) {
+	b, err := s.GetBrand(ctx, brandID)
+	if err != nil {
+		return models.CarModel{}, err
+	}
+
+	if m.Name, err = checkName("model", m.Name); err != nil {
+		return models.CarModel{}, err
+	}
+
+	m.ID, m.BrandID = uuid.New(), b.ID
+
+	if err = s.uniqueModel(ctx, m); err != nil {
+		return models.CarModel{}, err
+	}
+
+	return s.catalogue.CreateModel(ctx, m)
+}
+
+// GetModel service layer function to get a model by its id
+func (s catalogueService) GetModel(ctx context.Context, id string) (models.CarModel, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.CarModel{}, ErrModelNotFound
+	}
+
+	m, err := s.catalogue.GetModel(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.CarModel{}, ErrModelNotFound
+	}
+
+	return m, err
+}
+
+// GetModels service layer function to get the models of a brand by name
+func (s catalogueService) GetModels(ctx context.Context, brandID string) ([]models.CarModel, error) {
+	if _, err := s.GetBrand(ctx, brandID); err != nil {
+		return nil, err
+	}
+
+	return s.catalogue.GetModels(ctx, brandID)
+}
+
+// UpdateModel service layer function to rename a model
+func (s catalogueService) UpdateModel(ctx context.Context, id string, m models.CarModel) (models.CarModel, error) {
+	old, err := s.GetModel(ctx, id)
+	if err != nil {
+		return models.CarModel{}, err
+	}
+
+	if m.Name, err = checkName("model", m.Name); err != nil {
+		return models.CarModel{}, err
+	}
+
+	m.ID, m.BrandID = old.ID, old.BrandID
+
+	if err = s.uniqueModel(ctx, m); err != nil {
+		return models.CarModel{}, err
+	}
+
+	if err = s.catalogue.UpdateModel(ctx, m); err != nil {
+		return models.CarModel{}, err
+	}
+
+	return m, nil
+}
+
+// DeleteModel service layer function to delete a model, a model is only deleted once it has no trims
+func (s catalogueService) DeleteModel(ctx context.Context, id string) error {
+	m, err := s.GetModel(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	trims, err := s.catalogue.GetTrims(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(trims) > 0 {
+		return fmt.Errorf("%w: %s still has %d trims", ErrInUse, m.Name, len(trims))
+	}
+
+	return s.catalogue.DeleteModel(ctx, id)
+}
+
+// CreateTrim service layer function to add a trim to a model with its default engine, which must fit its fuel
+// type
+func (s catalogueService) CreateTrim(ctx context.Context, modelID string, t models.Trim) (models.Trim, error) {
+	m, err := s.GetModel(ctx, modelID)
+	if err != nil {
+		return models.Trim{}, err
+	}
+
+	if t, err = checkTrim(t); err != nil {
+		return models.Trim{}, err
+	}
+
+	t.ID, t.ModelID = uuid.New(), m.ID
+
+	if err = s.uniqueTrim(ctx, t); err != nil {
+		return models.Trim{}, err
+	}
+
+	engine, err := s.engine.EngineCreate(ctx, &t.Engine)
+	if err != nil {
+		return models.Trim{}, err
+	}
+
+	t.Engine = engine
+
+	if _, err = s.catalogue.CreateTrim(ctx, t); err != nil {
+		_, _ = s.engine.EngineDelete(ctx, engine.EngineID.String())
+		return models.Trim{}, err
+	}
+
+	return t, nil
+}
+
+// GetTrim service layer function to get a trim with its default engine
+func (s catalogueService) GetTrim(ctx context.Context, id string) (models.Trim, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Trim{}, ErrTrimNotFound
+	}
+
+	t, err := s.catalogue.GetTrim(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Trim{}, ErrTrimNotFound
+	}
+
+	if err != nil {
+		return models.Trim{}, err
+	}
+
+	return s.withEngine(ctx, t)
+}
+
+// GetTrims service layer function to get the trims of a model by name, each with its default engine
+func (s catalogueService) GetTrims(ctx context.Context, modelID string) ([]models.Trim, error) {
+	if _, err := s.GetModel(ctx, modelID); err != nil {
+		return nil, err
+	}
+
+	trims, err := s.catalogue.GetTrims(ctx, modelID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range trims {
+		if trims[i], err = s.withEngine(ctx, trims[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return trims, nil
+}
+
+// UpdateTrim service layer function to rename a trim and replace its fuel type and default engine, the cars
+// already created from it keep what they were created with
+func (s catalogueService) UpdateTrim(ctx context.Context, id string, t models.Trim) (models.Trim, error) {
+	old, err := s.GetTrim(ctx, id)
+	if err != nil {
+		return models.Trim{}, err
+	}
+
+	if t, err = checkTrim(t); err != nil {
+		return models.Trim{}, err
+	}
+
+	t.ID, t.ModelID = old.ID, old.ModelID
+
+	if err = s.uniqueTrim(ctx, t); err != nil {
+		return models.Trim{}, err
+	}
+
+	if t.Engine, err = s.engine.EngineUpdate(ctx, old.Engine.EngineID.String(), t.Engine); err != nil {
+		return models.Trim{}, err
+	}
+
+	if err = s.catalogue.UpdateTrim(ctx, t); err != nil {
+		return models.Trim{}, err
+	}
+
+	return t, nil
+}
+
+// DeleteTrim service layer function to delete a trim with its default engine, a trim is only deleted once no car
+// was created from it, which the store checks as it deletes the trim
+func (s catalogueService) DeleteTrim(ctx context.Context, id string) error {
+	t, err := s.GetTrim(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.catalogue.DeleteTrim(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return s.inUse(ctx, t)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = s.engine.EngineDelete(ctx, t.Engine.EngineID.String())
+
+	return err
+}
+
+// inUse explains why a trim which was found could not be deleted, it either still has cars or was deleted since
+func (s catalogueService) inUse(ctx context.Context, t models.Trim) error {
+	n, err := s.catalogue.CountTrimCars(ctx, t.ID.String())
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrTrimNotFound
+	}
+
+	return fmt.Errorf("%w: %d cars were created from %s", ErrInUse, n, t.Name)
+}
+
+// withEngine reads the default engine of a trim, a trim whose engine is missing is returned without one
+func (s catalogueService) withEngine(ctx context.Context, t models.Trim) (models.Trim, error) {
+	engine, err := s.engine.EngineGetByID(ctx, t.Engine.EngineID.String())
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return models.Trim{}, err
+	default:
+		t.Engine = engine
+	}
+
+	return t, nil
+}
+
+// unique checks no other brand is known by the name or aliases of b
+func (s catalogueService) unique(ctx context.Context, b models.Brand) error {
+	brands, err := s.catalogue.GetBrands(ctx)
+	if err != nil {
+		return err
+	}
+
+	taken := names(brands, b.ID)
+
+	for _, n := range append([]string{b.Name}, b.Aliases...) {
+		if other, ok := taken[models.CatalogueKey(n)]; ok {
+			return fmt.Errorf("%w: %s is already the brand %s", ErrDuplicate, n, other)
+		}
+	}
+
+	return nil
+}
+
+// uniqueModel checks no other model of the brand has the name of m
+func (s catalogueService) uniqueModel(ctx context.Context, m models.CarModel) error {
+	list, err := s.catalogue.GetModels(ctx, m.BrandID.String())
+	if err != nil {
+		return err
+	}
+
+	for _, other := range list {
+		if other.ID != m.ID && models.CatalogueKey(other.Name) == models.CatalogueKey(m.Name) {
+			return fmt.Errorf("%w: the brand already has the model %s", ErrDuplicate, other.Name)
+		}
+	}
+
+	return nil
+}
+
+// uniqueTrim checks no other trim of the model has the name of t
+func (s catalogueService) uniqueTrim(ctx context.Context, t models.Trim) error {
+	trims, err := s.catalogue.GetTrims(ctx, t.ModelID.String())
+	if err != nil {
+		return err
+	}
+
+	for _, other := range trims {
+		if other.ID != t.ID && models.CatalogueKey(other.Name) == models.CatalogueKey(t.Name) {
+			return fmt.Errorf("%w: the model already has the trim %s", ErrDuplicate, other.Name)
+		}
+	}
+
+	return nil
+}
+
+// names maps the keys of the names and aliases of the brands to the brand names, leaving out the brand with the
+// id except
+func names(brands []models.Brand, except uuid.UUID) map[string]string {
+	keys := map[string]string{}
+
+	for _, b := range brands {
+		if b.ID == except {
+			continue
+		}
+
+		keys[models.CatalogueKey(b.Name)] = b.Name
+
+		for _, a := range b.Aliases {
+			keys[models.CatalogueKey(a)] = b.Name
+		}
+	}
+
+	return keys
+}
+
+// checkName trims a name, which must have a letter or digit and fit MaxNameLength
+func checkName(kind, name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	switch {
+	case models.CatalogueKey(name) == "":
+		return "", fmt.Errorf("%w: a %s needs a name", ErrInvalidCatalogue, kind)
+	case len(name) > MaxNameLength:
+		return "", fmt.Errorf("%w: a %s name is at most %d characters", ErrInvalidCatalogue, kind, MaxNameLength)
+	}
+
+	return name, nil
+}
+
+// checkBrand checks the name and aliases of a brand, aliases are stored comma separated so may not have commas
+// and an alias that is the name again is dropped
+func checkBrand(b models.Brand) (models.Brand, error) {
+	name, err := checkName("brand", b.Name)
+	if err != nil {
+		return models.Brand{}, err
+	}
+
+	seen := map[string]bool{models.CatalogueKey(name): true}
+	aliases := []string{}
+
+	for _, a := range b.Aliases {
+		if a, err = checkName("brand alias", a); err != nil {
+			return models.Brand{}, err
+		}
+
+		if strings.Contains(a, ",") {
+			return models.Brand{}, fmt.Errorf("%w: alias %q has a comma", ErrInvalidCatalogue, a)
+		}
+
+		if key := models.CatalogueKey(a); !seen[key] {
+			seen[key] = true
+			aliases = append(aliases, a)
+		}
+	}
+
+	return models.Brand{Name: name, Aliases: aliases}, nil
+}
+
+// checkTrim checks the name, fuel type and default engine of a trim, a powertrain must fit the fuel type
+func checkTrim(t models.Trim) (models.Trim, error) {
+	name, err := checkName("trim", t.Name)
+	if err != nil {
+		return models.Trim{}, err
+	}
+
+	t.Name = name
+	t.FuelType = strings.ToLower(strings.TrimSpace(t.FuelType))
+
+	switch {
+	case t.FuelType == "":
+		return models.Trim{}, fmt.Errorf("%w: a trim needs a fuel type", ErrInvalidCatalogue)
+	case t.Engine.Displacement < 0 || t.Engine.NoOfCylinder < 0 || t.Engine.CarRange < 0:
+		return models.Trim{}, fmt.Errorf("%w: engine values can not be negative", ErrInvalidCatalogue)
+	}
+
+	car := models.Car{FuelType: t.FuelType, Engine: t.Engine}
+	if err = powertrain.CheckCar(car); err != nil {
+		return models.Trim{}, fmt.Errorf("%w: %v", ErrInvalidCatalogue, err)
+	}
+
+	powertrain.NormalizeCar(&car)
+	t.Engine = car.Engine
+	t.Engine.EngineID = uuid.Nil
+
+	return t, nil
+}
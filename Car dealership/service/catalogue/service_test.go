@@ -0,0 +1,204 @@
+package catalogue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBrands service layer test function to test brands are unique by name and alias and renamed with their cars
+func TestBrands(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCatalogue := datastore.NewMockCatalogue(ctrl)
+	s := New(mockCatalogue, datastore.NewMockEngine(ctrl))
+
+	bmw := models.Brand{ID: uuid.New(), Name: "BMW", Aliases: []string{"Bayerische Motoren Werke"}}
+	vw := models.Brand{ID: uuid.New(), Name: "Volkswagen", Aliases: []string{"VW"}}
+	id := bmw.ID.String()
+
+	testCases := []struct {
+		desc string
+		call func() (interface{}, error)
+		mock func()
+		res  interface{}
+		err  error
+	}{
+		{desc: "create", call: func() (interface{}, error) {
+			return s.CreateBrand(context.TODO(), models.Brand{Name: " Skoda ", Aliases: []string{"skoda", "Škoda"}})
+		}, mock: func() {
+			mockCatalogue.EXPECT().GetBrands(gomock.Any()).Return([]models.Brand{bmw, vw}, nil)
+			mockCatalogue.EXPECT().CreateBrand(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, b models.Brand) (models.Brand, error) { return b, nil })
+		}, res: []string{"Škoda"}},
+		{desc: "alias taken", call: func() (interface{}, error) {
+			return s.CreateBrand(context.TODO(), models.Brand{Name: "Volks", Aliases: []string{"v.w."}})
+		}, mock: func() {
+			mockCatalogue.EXPECT().GetBrands(gomock.Any()).Return([]models.Brand{bmw, vw}, nil)
+		}, err: ErrDuplicate},
+		{desc: "no name", call: func() (interface{}, error) {
+			return s.CreateBrand(context.TODO(), models.Brand{Name: " - "})
+		}, err: ErrInvalidCatalogue},
+		{desc: "alias with comma", call: func() (interface{}, error) {
+			return s.CreateBrand(context.TODO(), models.Brand{Name: "Rolls-Royce", Aliases: []string{"Rolls, Royce"}})
+		}, err: ErrInvalidCatalogue},
+		{desc: "bad id", call: func() (interface{}, error) { return s.GetBrand(context.TODO(), "abc") },
+			err: ErrBrandNotFound},
+		{desc: "not found", call: func() (interface{}, error) { return s.GetBrand(context.TODO(), id) },
+			mock: func() { mockCatalogue.EXPECT().GetBrand(gomock.Any(), id).Return(models.Brand{}, sql.ErrNoRows) },
+			err:  ErrBrandNotFound},
+		{desc: "rename", call: func() (interface{}, error) {
+			return s.UpdateBrand(context.TODO(), id, models.Brand{Name: "B.M.W", Aliases: []string{"bmw"}})
+		}, mock: func() {
+			mockCatalogue.EXPECT().GetBrand(gomock.Any(), id).Return(bmw, nil)
+			mockCatalogue.EXPECT().GetBrands(gomock.Any()).Return([]models.Brand{bmw, vw}, nil)
+			renamed := models.Brand{ID: bmw.ID, Name: "B.M.W", Aliases: []string{}}
+			mockCatalogue.EXPECT().UpdateBrand(gomock.Any(), renamed).Return(nil)
+			mockCatalogue.EXPECT().RenameBrands(gomock.Any(), []models.BrandRename{{From: "BMW", To: "B.M.W"}}).
+				Return(4, nil)
+		}, res: []string{}},
+		{desc: "delete with models", call: func() (interface{}, error) {
+			return nil, s.DeleteBrand(context.TODO(), id)
+		}, mock: func() {
+			mockCatalogue.EXPECT().GetBrand(gomock.Any(), id).Return(bmw, nil)
+			mockCatalogue.EXPECT().GetModels(gomock.Any(), id).Return([]models.CarModel{{Name: "X5"}}, nil)
+		}, err: ErrInUse},
+		{desc: "delete", call: func() (interface{}, error) { return nil, s.DeleteBrand(context.TODO(), id) },
+			mock: func() {
+				mockCatalogue.EXPECT().GetBrand(gomock.Any(), id).Return(bmw, nil)
+				mockCatalogue.EXPECT().GetModels(gomock.Any(), id).Return([]models.CarModel{}, nil)
+				mockCatalogue.EXPECT().DeleteBrand(gomock.Any(), id).Return(nil)
+			}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := tc.call()
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if b, ok := res.(models.Brand); ok && tc.res != nil {
+			assert.Equal(t, tc.res, b.Aliases, tc.desc)
+		}
+	}
+}
+
+// TestModels service layer test function to test models belong to a brand, are unique within it and are only
+// deleted without trims
+func TestModels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCatalogue := datastore.NewMockCatalogue(ctrl)
+	s := New(mockCatalogue, datastore.NewMockEngine(ctrl))
+
+	brand := models.Brand{ID: uuid.New(), Name: "BMW"}
+	x5 := models.CarModel{ID: uuid.New(), BrandID: brand.ID, Name: "X5"}
+	brandID, id := brand.ID.String(), x5.ID.String()
+
+	mockCatalogue.EXPECT().GetBrand(gomock.Any(), brandID).Return(brand, nil).Times(2)
+	mockCatalogue.EXPECT().GetModels(gomock.Any(), brandID).Return([]models.CarModel{x5}, nil).Times(3)
+	mockCatalogue.EXPECT().CreateModel(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, m models.CarModel) (models.CarModel, error) { return m, nil })
+	mockCatalogue.EXPECT().GetModel(gomock.Any(), id).Return(x5, nil).Times(2)
+	mockCatalogue.EXPECT().UpdateModel(gomock.Any(), models.CarModel{ID: x5.ID, BrandID: brand.ID, Name: "X5 M"}).
+		Return(nil)
+	mockCatalogue.EXPECT().GetTrims(gomock.Any(), id).Return([]models.Trim{{Name: "xDrive40i"}}, nil)
+
+	m, err := s.CreateModel(context.TODO(), brandID, models.CarModel{Name: " 3 Series "})
+	if err != nil || m.BrandID != brand.ID || m.Name != "3 Series" || m.ID == uuid.Nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "create", m, "3 Series of BMW")
+	}
+
+	if _, err = s.CreateModel(context.TODO(), brandID, models.CarModel{Name: "x-5"}); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "duplicate", err, ErrDuplicate)
+	}
+
+	if m, err = s.UpdateModel(context.TODO(), id, models.CarModel{Name: "X5 M", BrandID: uuid.New()}); err != nil ||
+		m.BrandID != brand.ID {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "rename keeps brand", m, brand.ID)
+	}
+
+	if err = s.DeleteModel(context.TODO(), id); !errors.Is(err, ErrInUse) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "delete with trims", err, ErrInUse)
+	}
+
+	if _, err = s.GetModels(context.TODO(), "abc"); !errors.Is(err, ErrBrandNotFound) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 4, "of unknown brand", err, ErrBrandNotFound)
+	}
+}
+
+// TestTrims service layer test function to test trims are stored with their default engine, which must fit their
+// fuel type, and are only deleted once no car was created from them
+func TestTrims(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCatalogue := datastore.NewMockCatalogue(ctrl)
+	mockEngine := datastore.NewMockEngine(ctrl)
+	s := New(mockCatalogue, mockEngine)
+
+	model := models.CarModel{ID: uuid.New(), Name: "Model 3"}
+	engine := models.Engine{EngineID: uuid.New(), CarRange: 500,
+		Powertrain: &models.Powertrain{Type: "bev", BatteryKWh: 75}}
+	trim := models.Trim{ID: uuid.New(), ModelID: model.ID, Name: "Long Range", FuelType: "electric",
+		Engine: models.Engine{EngineID: engine.EngineID}}
+	modelID, id := model.ID.String(), trim.ID.String()
+
+	mockCatalogue.EXPECT().GetModel(gomock.Any(), modelID).Return(model, nil).Times(3)
+	mockCatalogue.EXPECT().GetTrims(gomock.Any(), modelID).Return([]models.Trim{trim}, nil).Times(2)
+	mockEngine.EXPECT().EngineCreate(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, e *models.Engine) (models.Engine, error) {
+			e.EngineID = uuid.New()
+			return *e, nil
+		})
+	mockCatalogue.EXPECT().CreateTrim(gomock.Any(), gomock.Any()).Return(models.Trim{}, errors.New("db error"))
+	mockEngine.EXPECT().EngineDelete(gomock.Any(), gomock.Any()).Return(models.Engine{}, nil)
+	mockCatalogue.EXPECT().GetTrim(gomock.Any(), id).Return(trim, nil).Times(2)
+	mockEngine.EXPECT().EngineGetByID(gomock.Any(), engine.EngineID.String()).Return(engine, nil).Times(2)
+	mockCatalogue.EXPECT().DeleteTrim(gomock.Any(), id).Return(sql.ErrNoRows)
+	mockCatalogue.EXPECT().CountTrimCars(gomock.Any(), id).Return(2, nil)
+
+	valid := models.Trim{Name: "Performance", FuelType: " Electric",
+		Engine: models.Engine{CarRange: 480, Powertrain: &models.Powertrain{Type: "BEV", BatteryKWh: 82}}}
+
+	if _, err := s.CreateTrim(context.TODO(), modelID, valid); err == nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "engine removed on error", err, "db error")
+	}
+
+	invalid := models.Trim{Name: "Performance", FuelType: "petrol", Engine: valid.Engine}
+	if _, err := s.CreateTrim(context.TODO(), modelID, invalid); !errors.Is(err, ErrInvalidCatalogue) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "fuel mismatch", err, ErrInvalidCatalogue)
+	}
+
+	duplicate := models.Trim{Name: "long range", FuelType: "electric", Engine: valid.Engine}
+	if _, err := s.CreateTrim(context.TODO(), modelID, duplicate); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "duplicate", err, ErrDuplicate)
+	}
+
+	res, err := s.GetTrim(context.TODO(), id)
+	if err != nil || res.Engine.CarRange != 500 {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 3, "with engine", res, engine)
+	}
+
+	if err = s.DeleteTrim(context.TODO(), id); !errors.Is(err, ErrInUse) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 4, "delete with cars", err, ErrInUse)
+	}
+
+	if _, err = s.GetTrim(context.TODO(), "abc"); !errors.Is(err, ErrTrimNotFound) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 5, "bad id", err, ErrTrimNotFound)
+	}
+}
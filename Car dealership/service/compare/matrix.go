@@ -0,0 +1,158 @@
+package compare
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// direction is which end of an attribute is better
+type direction int
+
+const (
+	unranked direction = iota
+	higher
+	lower
+)
+
+// attribute is a row of the spec matrix. Numeric attributes read 0 when they do not apply to a car, and so do not
+// take part in the ranking. Engine size is left unranked as a bigger engine is not better for every buyer.
+type attribute struct {
+	name   string
+	unit   string
+	better direction
+	money  bool
+	number func(c models.Car) int64
+	text   func(c models.Car) string
+}
+
+var attributes = []attribute{
+	{name: "Year", better: higher, number: func(c models.Car) int64 { return int64(c.Year) }},
+	{name: "FuelType", text: func(c models.Car) string { return c.FuelType }},
+	{name: "Displacement", unit: "cc", number: func(c models.Car) int64 { return c.Engine.Displacement }},
+	{name: "Cylinders", number: func(c models.Car) int64 { return c.Engine.NoOfCylinder }},
+	{name: "Range", unit: "km", better: higher, number: func(c models.Car) int64 { return c.Engine.CarRange }},
+	{name: "Price", better: lower, money: true, number: func(c models.Car) int64 { return int64(c.ListPrice) }},
+	{name: "Currency", text: func(c models.Car) string { return strings.ToUpper(c.Currency) }},
+}
+
+// matrix lays the cars out side by side. Prices are only ranked when every priced car is in the same currency.
+func matrix(cars []models.Car) models.Comparison {
+	c := models.Comparison{Cars: make([]models.ComparedCar, len(cars)), Attributes: []models.ComparedAttribute{}}
+
+	for i, car := range cars {
+		c.Cars[i] = models.ComparedCar{ID: car.ID, Name: car.Name, Brand: car.Brand}
+	}
+
+	currency, mixed := currencyOf(cars)
+
+	for _, a := range attributes {
+		row := models.ComparedAttribute{Name: a.name, Unit: a.unit, Ranked: a.better != unranked,
+			Values: make([]models.ComparedValue, len(cars))}
+
+		if a.money {
+			row.Unit, row.Ranked = currency, !mixed
+		}
+
+		keys := make([]string, len(cars))
+		numbers := make([]int64, len(cars))
+
+		for i, car := range cars {
+			switch {
+			case a.text != nil:
+				if s := strings.TrimSpace(a.text(car)); s != "" {
+					row.Values[i].Value = s
+					keys[i] = strings.ToLower(s)
+				}
+			case a.number(car) != 0:
+				numbers[i] = a.number(car)
+				keys[i] = fmt.Sprint(numbers[i])
+				row.Values[i].Value = numbers[i]
+
+				if a.money {
+					row.Values[i].Value = models.Money(numbers[i])
+				}
+			}
+		}
+
+		for i := range keys {
+			if keys[i] != keys[0] {
+				row.Differs = true
+			}
+		}
+
+		if row.Ranked {
+			mark(row.Values, numbers, a.better)
+		}
+
+		c.Attributes = append(c.Attributes, row)
+	}
+
+	return c
+}
+
+// mark marks the best and the worst of the values that apply, every car sharing the best or the worst value is
+// marked. Nothing is marked unless at least two values apply and they differ.
+func mark(values []models.ComparedValue, numbers []int64, better direction) {
+	beats := func(a, b int64) bool {
+		if better == higher {
+			return a > b
+		}
+
+		return a < b
+	}
+
+	var best, worst int64
+
+	found := false
+
+	for i, n := range numbers {
+		switch {
+		case values[i].Value == nil:
+			continue
+		case !found:
+			best, worst, found = n, n, true
+		case beats(n, best):
+			best = n
+		case beats(worst, n):
+			worst = n
+		}
+	}
+
+	if best == worst {
+		return
+	}
+
+	for i, n := range numbers {
+		switch {
+		case values[i].Value == nil:
+		case n == best:
+			values[i].Marker = models.MarkerBest
+		case n == worst:
+			values[i].Marker = models.MarkerWorst
+		}
+	}
+}
+
+// currencyOf is the currency the priced cars share, mixed is true when they are in more than one
+func currencyOf(cars []models.Car) (currency string, mixed bool) {
+	priced := false
+
+	for _, car := range cars {
+		if car.ListPrice == 0 {
+			continue
+		}
+
+		c := strings.ToUpper(strings.TrimSpace(car.Currency))
+
+		switch {
+		case !priced:
+			currency, priced = c, true
+		case c != currency:
+			return "", true
+		}
+	}
+
+	return currency, false
+}
@@ -0,0 +1,109 @@
+package compare
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// TestMatrix test function to test the spec matrix marks the best and worst values and highlights differences
+func TestMatrix(t *testing.T) {
+	petrol := models.Car{Name: "3 Series", Brand: "BMW", Year: 2021, FuelType: "Petrol", ListPrice: 4500000,
+		Currency: "EUR", Engine: models.Engine{Displacement: 1998, NoOfCylinder: 4}}
+	electric := models.Car{Name: "Model 3", Brand: "Tesla", Year: 2022, FuelType: "Electric", ListPrice: 4200000,
+		Currency: "eur", Engine: models.Engine{CarRange: 500}}
+	hybrid := models.Car{Name: "Prius", Brand: "Toyota", Year: 2022, FuelType: "Hybrid", ListPrice: 3000000,
+		Currency: "EUR", Engine: models.Engine{Displacement: 1798, NoOfCylinder: 4, CarRange: 60}}
+
+	c := matrix([]models.Car{petrol, electric, hybrid})
+
+	testCases := []struct {
+		name    string
+		unit    string
+		ranked  bool
+		differs bool
+		values  []interface{}
+		markers []string
+	}{
+		{name: "Year", ranked: true, differs: true, values: []interface{}{int64(2021), int64(2022), int64(2022)},
+			markers: []string{"worst", "best", "best"}},
+		{name: "FuelType", differs: true, values: []interface{}{"Petrol", "Electric", "Hybrid"},
+			markers: []string{"", "", ""}},
+		{name: "Displacement", unit: "cc", differs: true, values: []interface{}{int64(1998), nil, int64(1798)},
+			markers: []string{"", "", ""}},
+		{name: "Cylinders", differs: true, values: []interface{}{int64(4), nil, int64(4)},
+			markers: []string{"", "", ""}},
+		{name: "Range", unit: "km", ranked: true, differs: true, values: []interface{}{nil, int64(500), int64(60)},
+			markers: []string{"", "best", "worst"}},
+		{name: "Price", unit: "EUR", ranked: true, differs: true,
+			values:  []interface{}{models.Money(4500000), models.Money(4200000), models.Money(3000000)},
+			markers: []string{"worst", "", "best"}},
+		{name: "Currency", values: []interface{}{"EUR", "EUR", "EUR"}, markers: []string{"", "", ""}},
+	}
+
+	if len(c.Attributes) != len(testCases) || len(c.Cars) != 3 || c.Cars[1].Name != "Model 3" {
+		t.Fatalf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "shape", c, len(testCases))
+	}
+
+	for i, tc := range testCases {
+		a := c.Attributes[i]
+		values, markers := []interface{}{}, []string{}
+
+		for _, v := range a.Values {
+			values = append(values, v.Value)
+			markers = append(markers, v.Marker)
+		}
+
+		if a.Name != tc.name || a.Unit != tc.unit || a.Ranked != tc.ranked || a.Differs != tc.differs ||
+			!reflect.DeepEqual(values, tc.values) || !reflect.DeepEqual(markers, tc.markers) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %+v\n Expected %+v", i, tc.name, a, tc)
+		}
+	}
+}
+
+// TestMixedCurrencies test function to test prices in different currencies are not ranked
+func TestMixedCurrencies(t *testing.T) {
+	c := matrix([]models.Car{{ListPrice: 100, Currency: "EUR"}, {ListPrice: 200, Currency: "USD"}, {}})
+
+	price := c.Attributes[5]
+	if price.Ranked || price.Unit != "" || price.Values[0].Marker != "" || price.Values[2].Value != nil {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %+v\n Expected %v", 0, "mixed currencies", price, "unranked")
+	}
+}
+
+// TestMark test function to test markers need two differing values
+func TestMark(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		values  []interface{}
+		numbers []int64
+		better  direction
+		markers []string
+	}{
+		{desc: "all equal", values: []interface{}{1, 1}, numbers: []int64{1, 1}, better: higher,
+			markers: []string{"", ""}},
+		{desc: "single value", values: []interface{}{nil, 5}, numbers: []int64{0, 5}, better: higher,
+			markers: []string{"", ""}},
+		{desc: "lower is better", values: []interface{}{3, 1, 2}, numbers: []int64{3, 1, 2}, better: lower,
+			markers: []string{"worst", "best", ""}},
+	}
+
+	for i, tc := range testCases {
+		values := make([]models.ComparedValue, len(tc.values))
+		for j := range values {
+			values[j].Value = tc.values[j]
+		}
+
+		mark(values, tc.numbers, tc.better)
+
+		markers := []string{}
+		for _, v := range values {
+			markers = append(markers, v.Marker)
+		}
+
+		if !reflect.DeepEqual(markers, tc.markers) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, markers, tc.markers)
+		}
+	}
+}
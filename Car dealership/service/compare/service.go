@@ -0,0 +1,106 @@
+package compare
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MinCars is the fewest cars a comparison takes
+	MinCars = 2
+	// MaxCars is the most cars a comparison takes
+	MaxCars = 4
+)
+
+var (
+	ErrCarNotFound       = errors.New("car not found")
+	ErrInvalidComparison = errors.New("invalid comparison")
+)
+
+type service struct {
+	car    datastore.Car
+	engine datastore.Engine
+}
+
+func New(car datastore.Car, engine datastore.Engine) service { //nolint
+	return service{car: car, engine: engine}
+}
+
+// Compare service layer function to lay the cars with the ids side by side, in the order of the ids, each with its
+// full engine
+func (s service) Compare(ctx context.Context, ids []string) (models.Comparison, error) {
+	wanted := []string{}
+	seen := map[string]bool{}
+
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+
+		switch {
+		case id == "":
+			continue
+		case seen[id]:
+			return models.Comparison{}, fmt.Errorf("%w: car %s is listed twice", ErrInvalidComparison, id)
+		}
+
+		seen[id] = true
+		wanted = append(wanted, id)
+	}
+
+	if len(wanted) < MinCars || len(wanted) > MaxCars {
+		return models.Comparison{}, fmt.Errorf("%w: compare between %d and %d cars", ErrInvalidComparison, MinCars,
+			MaxCars)
+	}
+
+	cars := make([]models.Car, len(wanted))
+
+	for i, id := range wanted {
+		car, err := s.load(ctx, id)
+		if err != nil {
+			return models.Comparison{}, err
+		}
+
+		cars[i] = car
+	}
+
+	return matrix(cars), nil
+}
+
+// load reads a car with its engine, a car whose engine is missing is compared without one
+func (s service) load(ctx context.Context, id string) (models.Car, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Car{}, fmt.Errorf("%w: %s", ErrCarNotFound, id)
+	}
+
+	car, err := s.car.GetCarByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Car{}, fmt.Errorf("%w: %s", ErrCarNotFound, id)
+	}
+
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	if car.Engine.EngineID == uuid.Nil {
+		return car, nil
+	}
+
+	engine, err := s.engine.EngineGetByID(ctx, car.Engine.EngineID.String())
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return models.Car{}, err
+	default:
+		car.Engine = engine
+	}
+
+	return car, nil
+}
@@ -0,0 +1,80 @@
+package compare
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCompare service layer test function to test cars are loaded with their engines and compared in order
+func TestCompare(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := datastore.NewMockCar(ctrl)
+	mockEngine := datastore.NewMockEngine(ctrl)
+	s := New(mockCar, mockEngine)
+
+	first, second := uuid.New(), uuid.New()
+	engineID := uuid.New()
+	engine := models.Engine{EngineID: engineID, Displacement: 1998, NoOfCylinder: 4}
+
+	testCases := []struct {
+		desc string
+		ids  []string
+		mock func()
+		err  error
+	}{
+		{desc: "success", ids: []string{second.String(), " " + first.String(), ""},
+			mock: func() {
+				mockCar.EXPECT().GetCarByID(gomock.Any(), second.String()).
+					Return(models.Car{ID: second, Name: "Model 3"}, nil)
+				mockCar.EXPECT().GetCarByID(gomock.Any(), first.String()).
+					Return(models.Car{ID: first, Name: "3 Series", Engine: models.Engine{EngineID: engineID}}, nil)
+				mockEngine.EXPECT().EngineGetByID(gomock.Any(), engineID.String()).Return(engine, nil)
+			}},
+		{desc: "engine missing", ids: []string{first.String(), second.String()},
+			mock: func() {
+				mockCar.EXPECT().GetCarByID(gomock.Any(), first.String()).
+					Return(models.Car{ID: first, Engine: models.Engine{EngineID: engineID}}, nil)
+				mockEngine.EXPECT().EngineGetByID(gomock.Any(), engineID.String()).
+					Return(models.Engine{}, sql.ErrNoRows)
+				mockCar.EXPECT().GetCarByID(gomock.Any(), second.String()).Return(models.Car{ID: second}, nil)
+			}},
+		{desc: "one car", ids: []string{first.String()}, err: ErrInvalidComparison},
+		{desc: "too many cars", ids: []string{"a", "b", "c", "d", "e"}, err: ErrInvalidComparison},
+		{desc: "listed twice", ids: []string{first.String(), first.String()}, err: ErrInvalidComparison},
+		{desc: "bad id", ids: []string{first.String(), "abc"}, err: ErrCarNotFound,
+			mock: func() {
+				mockCar.EXPECT().GetCarByID(gomock.Any(), first.String()).Return(models.Car{ID: first}, nil)
+			}},
+		{desc: "car not found", ids: []string{first.String(), second.String()}, err: ErrCarNotFound,
+			mock: func() {
+				mockCar.EXPECT().GetCarByID(gomock.Any(), first.String()).Return(models.Car{}, sql.ErrNoRows)
+			}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Compare(context.TODO(), tc.ids)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if i == 0 {
+			assert.Equal(t, []models.ComparedCar{{ID: second, Name: "Model 3"}, {ID: first, Name: "3 Series"}}, res.Cars)
+			assert.Equal(t, int64(1998), res.Attributes[2].Values[1].Value)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Comparer interface {
+	Compare(ctx context.Context, ids []string) (models.Comparison, error)
+}
@@ -0,0 +1,106 @@
+package customer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrInvalidCustomer  = errors.New("invalid customer")
+	ErrDuplicateEmail   = errors.New("a customer with this email already exists")
+)
+
+type service struct {
+	customer datastore.Customer
+	now      func() time.Time
+}
+
+func New(customer datastore.Customer) service { //nolint
+	return service{customer: customer, now: time.Now}
+}
+
+// CreateCustomer service layer function to create a customer, the email must not belong to another customer
+func (s service) CreateCustomer(ctx context.Context, c models.Customer) (models.Customer, error) {
+	c, err := Check(c)
+	if err != nil {
+		return models.Customer{}, err
+	}
+
+	_, err = s.customer.GetCustomerByEmail(ctx, c.Email)
+	if err == nil {
+		return models.Customer{}, ErrDuplicateEmail
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return models.Customer{}, err
+	}
+
+	c.ID = uuid.New()
+	c.CreatedAt = s.now().UTC().Truncate(time.Second)
+
+	return s.customer.CreateCustomer(ctx, c)
+}
+
+// GetCustomer service layer function to get a customer by its id
+func (s service) GetCustomer(ctx context.Context, id string) (models.Customer, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Customer{}, ErrCustomerNotFound
+	}
+
+	c, err := s.customer.GetCustomerByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Customer{}, ErrCustomerNotFound
+	}
+
+	return c, err
+}
+
+// Check trims the contact details of a customer and validates them, the email is lower cased so that it
+// identifies the customer however it is typed
+func Check(c models.Customer) (models.Customer, error) {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Email = strings.ToLower(strings.TrimSpace(c.Email))
+	c.Phone = strings.TrimSpace(c.Phone)
+
+	if c.Name == "" {
+		return models.Customer{}, fmt.Errorf("%w: name is required", ErrInvalidCustomer)
+	}
+
+	if addr, err := mail.ParseAddress(c.Email); err != nil || addr.Address != c.Email {
+		return models.Customer{}, fmt.Errorf("%w: invalid email %q", ErrInvalidCustomer, c.Email)
+	}
+
+	if !validPhone(c.Phone) {
+		return models.Customer{}, fmt.Errorf("%w: invalid phone %q", ErrInvalidCustomer, c.Phone)
+	}
+
+	return c, nil
+}
+
+// validPhone accepts an empty phone or digits with an optional leading + and spaces or dashes between them
+func validPhone(phone string) bool {
+	digits := 0
+
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0, r == ' ', r == '-':
+		default:
+			return false
+		}
+	}
+
+	return phone == "" || digits >= 6 && digits <= 15
+}
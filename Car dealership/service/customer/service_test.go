@@ -0,0 +1,89 @@
+package customer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCheck service layer test function to test contact details are validated and normalised
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		input  models.Customer
+		output models.Customer
+		err    error
+	}{
+		{desc: "trimmed and lower cased", input: models.Customer{Name: " Sahil ", Email: " Sahil@Example.com",
+			Phone: "+91 98765-43210"}, output: models.Customer{Name: "Sahil", Email: "sahil@example.com",
+			Phone: "+91 98765-43210"}},
+		{desc: "no phone", input: models.Customer{Name: "Sahil", Email: "sahil@example.com"},
+			output: models.Customer{Name: "Sahil", Email: "sahil@example.com"}},
+		{desc: "missing name", input: models.Customer{Email: "sahil@example.com"}, err: ErrInvalidCustomer},
+		{desc: "bad email", input: models.Customer{Name: "Sahil", Email: "sahil"}, err: ErrInvalidCustomer},
+		{desc: "display name", input: models.Customer{Name: "Sahil", Email: "Sahil <sahil@example.com>"},
+			err: ErrInvalidCustomer},
+		{desc: "bad phone", input: models.Customer{Name: "Sahil", Email: "sahil@example.com", Phone: "call me"},
+			err: ErrInvalidCustomer},
+		{desc: "short phone", input: models.Customer{Name: "Sahil", Email: "sahil@example.com", Phone: "123"},
+			err: ErrInvalidCustomer},
+	}
+
+	for i, tc := range testCases {
+		res, err := Check(tc.input)
+		if !errors.Is(err, tc.err) || res != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
+
+// TestCreateCustomer service layer test function to test customers are unique by email
+func TestCreateCustomer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockCustomer(ctrl)
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	s := New(mockStore)
+	s.now = func() time.Time { return now }
+
+	c := models.Customer{Name: "Sahil", Email: "sahil@example.com"}
+
+	testCases := []struct {
+		desc string
+		mock func()
+		err  error
+	}{
+		{desc: "success", mock: func() {
+			mockStore.EXPECT().GetCustomerByEmail(gomock.Any(), c.Email).Return(models.Customer{}, sql.ErrNoRows)
+			mockStore.EXPECT().CreateCustomer(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, c models.Customer) (models.Customer, error) {
+					assert.Equal(t, now, c.CreatedAt)
+					return c, nil
+				})
+		}},
+		{desc: "duplicate email", err: ErrDuplicateEmail, mock: func() {
+			mockStore.EXPECT().GetCustomerByEmail(gomock.Any(), c.Email).Return(c, nil)
+		}},
+	}
+
+	for i, tc := range testCases {
+		tc.mock()
+
+		_, err := s.CreateCustomer(context.TODO(), c)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+
+	_, err := s.GetCustomer(context.TODO(), "abc")
+	assert.Equal(t, ErrCustomerNotFound, err)
+}
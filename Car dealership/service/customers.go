@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Customers interface {
+	CreateCustomer(ctx context.Context, c models.Customer) (models.Customer, error)
+	GetCustomer(ctx context.Context, id string) (models.Customer, error)
+}
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// column is a single exportable attribute of a car, value returns a string or an integer
+type column struct {
+	name   string
+	engine bool
+	value  func(c models.Car) interface{}
+}
+
+var columns = []column{
+	{name: "id", value: func(c models.Car) interface{} { return c.ID.String() }},
+	{name: "vin", value: func(c models.Car) interface{} { return c.VIN }},
+	{name: "name", value: func(c models.Car) interface{} { return c.Name }},
+	{name: "year", value: func(c models.Car) interface{} { return int64(c.Year) }},
+	{name: "brand", value: func(c models.Car) interface{} { return c.Brand }},
+	{name: "fuelType", value: func(c models.Car) interface{} { return c.FuelType }},
+	{name: "status", value: func(c models.Car) interface{} { return c.Status }},
+	{name: "msrp", value: func(c models.Car) interface{} { return c.MSRP.Format(c.Currency) }},
+	{name: "listPrice", value: func(c models.Car) interface{} { return c.ListPrice.Format(c.Currency) }},
+	{name: "cost", value: func(c models.Car) interface{} { return c.Cost.Format(c.Currency) }},
+	{name: "currency", value: func(c models.Car) interface{} { return c.Currency }},
+	{name: "engine.id", value: func(c models.Car) interface{} { return c.Engine.EngineID.String() }},
+	{name: "engine.displacement", engine: true,
+		value: func(c models.Car) interface{} { return c.Engine.Displacement }},
+	{name: "engine.cylinders", engine: true,
+		value: func(c models.Car) interface{} { return c.Engine.NoOfCylinder }},
+	{name: "engine.range", engine: true, value: func(c models.Car) interface{} { return c.Engine.CarRange }},
+}
+
+// selectColumns resolves the requested column names, by default every car column and,
+// when isEngine is set, every engine column is exported
+func selectColumns(names []string, isEngine bool) ([]column, error) {
+	if len(names) == 0 {
+		var selected []column
+
+		for _, c := range columns {
+			if !c.engine || isEngine {
+				selected = append(selected, c)
+			}
+		}
+
+		return selected, nil
+	}
+
+	selected := make([]column, 0, len(names))
+
+	for _, name := range names {
+		c, ok := lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, name)
+		}
+
+		selected = append(selected, c)
+	}
+
+	return selected, nil
+}
+
+func lookup(name string) (column, bool) {
+	for _, c := range columns {
+		if strings.EqualFold(c.name, strings.TrimSpace(name)) {
+			return c, true
+		}
+	}
+
+	return column{}, false
+}
+
+// needsEngine reports whether any of the columns is read from the engine table
+func needsEngine(cols []column) bool {
+	for _, c := range cols {
+		if c.engine {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,113 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// Supported export formats
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+// flushEvery is the number of rows buffered before they are pushed to the client
+const flushEvery = 100
+
+var (
+	ErrUnknownFormat = errors.New("unknown export format")
+	ErrUnknownColumn = errors.New("unknown export column")
+)
+
+type service struct {
+	car datastore.Car
+}
+
+func New(car datastore.Car) service { //nolint
+	return service{car: car}
+}
+
+// Export service layer function to stream every car matching the options to w in the requested format.
+// Invalid options are reported before anything is written to w.
+func (s service) Export(ctx context.Context, w io.Writer, opts models.ExportOptions) error {
+	cols, err := selectColumns(opts.Columns, opts.IsEngine)
+	if err != nil {
+		return err
+	}
+
+	if !validFormat(opts.Format) {
+		return ErrUnknownFormat
+	}
+
+	rw, err := newWriter(w, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(cols))
+	for i := range cols {
+		names[i] = cols[i].name
+	}
+
+	if err = rw.Header(names); err != nil {
+		return err
+	}
+
+	n := 0
+
+	err = s.car.StreamCars(ctx, opts.Filter, needsEngine(cols), func(c models.Car) error {
+		values := make([]interface{}, len(cols))
+		for i := range cols {
+			values[i] = cols[i].value(c)
+		}
+
+		if err := rw.Row(values); err != nil {
+			return err
+		}
+
+		if n++; n%flushEvery == 0 {
+			return flush(w, rw)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return rw.Close()
+}
+
+func validFormat(format string) bool {
+	return format == FormatCSV || format == FormatNDJSON || format == FormatXLSX
+}
+
+func newWriter(w io.Writer, format string) (rowWriter, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatNDJSON:
+		return newNDJSONWriter(w), nil
+	default:
+		return newXLSXWriter(w)
+	}
+}
+
+// flush pushes buffered rows through to w and on to the client when w is a http response
+func flush(w io.Writer, rw rowWriter) error {
+	if err := rw.Flush(); err != nil {
+		return err
+	}
+
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func stream(cars ...models.Car) func(context.Context, models.CarFilter, bool, func(models.Car) error) error {
+	return func(_ context.Context, _ models.CarFilter, _ bool, fn func(models.Car) error) error {
+		for _, c := range cars {
+			if err := fn(c); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// TestExport service layer test function to test the export formats
+func TestExport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockCar(ctrl)
+	s := New(mockStore)
+
+	id := uuid.MustParse("38ec1d7a-834f-11ec-a8a3-0242ac120002")
+	car := models.Car{ID: id, VIN: "ZFF67NFA1A0123456", Name: "GenX, V8", Year: 2015, Brand: "Ferrari",
+		FuelType: "petrol", Engine: models.Engine{EngineID: id, Displacement: 300, NoOfCylinder: 8},
+		MSRP: 21500000, ListPrice: 19999999, Currency: "EUR", Status: models.StatusInStock}
+	filter := models.CarFilter{Brand: "Ferrari"}
+
+	testCases := []struct {
+		desc   string
+		opts   models.ExportOptions
+		mock   *gomock.Call
+		output string
+		err    error
+	}{
+		{desc: "csv", opts: models.ExportOptions{Format: FormatCSV, Filter: filter},
+			mock: mockStore.EXPECT().StreamCars(gomock.Any(), filter, false, gomock.Any()).DoAndReturn(stream(car)),
+			output: "id,vin,name,year,brand,fuelType,status,msrp,listPrice,cost,currency,engine.id\n" + id.String() +
+				`,ZFF67NFA1A0123456,"GenX, V8",2015,Ferrari,petrol,in_stock,215000.00,199999.99,0.00,EUR,` + id.String() + "\n"},
+		{desc: "ndjson with selected engine column",
+			opts: models.ExportOptions{Format: FormatNDJSON, Columns: []string{"name", "engine.cylinders"}},
+			mock: mockStore.EXPECT().StreamCars(gomock.Any(), models.CarFilter{}, true, gomock.Any()).
+				DoAndReturn(stream(car)),
+			output: `{"name":"GenX, V8","engine.cylinders":8}` + "\n"},
+		{desc: "unknown column", opts: models.ExportOptions{Format: FormatCSV, Columns: []string{"price"}},
+			err: ErrUnknownColumn},
+		{desc: "unknown format", opts: models.ExportOptions{Format: "pdf"}, err: ErrUnknownFormat},
+		{desc: "store error", opts: models.ExportOptions{Format: FormatCSV},
+			mock: mockStore.EXPECT().StreamCars(gomock.Any(), models.CarFilter{}, false, gomock.Any()).
+				Return(errors.New("db error")),
+			output: "", err: errors.New("db error")},
+	}
+
+	for i, tc := range testCases {
+		var b bytes.Buffer
+
+		err := s.Export(context.TODO(), &b, tc.opts)
+		if tc.err != nil && (err == nil || !strings.Contains(err.Error(), tc.err.Error())) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if tc.err == nil {
+			assert.Nil(t, err, tc.desc)
+			assert.Equal(t, tc.output, b.String(), tc.desc)
+		}
+	}
+}
+
+// TestExportXLSX service layer test function to test the spreadsheet is a readable zip with every row
+func TestExportXLSX(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockCar(ctrl)
+	s := New(mockStore)
+
+	car := models.Car{ID: uuid.New(), Name: "Q2 <S>", Year: 2009, Brand: "BMW", FuelType: "petrol"}
+
+	mockStore.EXPECT().StreamCars(gomock.Any(), models.CarFilter{}, false, gomock.Any()).DoAndReturn(stream(car, car))
+
+	var b bytes.Buffer
+
+	err := s.Export(context.TODO(), &b, models.ExportOptions{Format: FormatXLSX, Columns: []string{"name", "year"}})
+	assert.Nil(t, err)
+
+	z, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 5, len(z.File))
+
+	f, err := z.File[4].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sheet, _ := io.ReadAll(f)
+
+	assert.Contains(t, string(sheet), `<c r="A3" t="inlineStr"><is><t>Q2 &lt;S&gt;</t></is></c><c r="B3"><v>2009</v></c>`)
+	assert.True(t, strings.HasSuffix(string(sheet), "</sheetData></worksheet>"))
+}
+
+// TestCellName test function to test spreadsheet column letters
+func TestCellName(t *testing.T) {
+	for i, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, name, cellName(i))
+	}
+}
@@ -0,0 +1,99 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// rowWriter encodes exported rows in one output format
+type rowWriter interface {
+	Header(names []string) error
+	Row(values []interface{}) error
+	Flush() error
+	Close() error
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Header(names []string) error {
+	return c.w.Write(names)
+}
+
+func (c *csvWriter) Row(values []interface{}) error {
+	record := make([]string, len(values))
+
+	for i, v := range values {
+		record[i] = fmt.Sprint(v)
+	}
+
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	return c.Flush()
+}
+
+// ndjsonWriter writes one JSON object per line keyed by column name, objects are built by hand
+// so that keys keep the requested column order
+type ndjsonWriter struct {
+	w     *bufio.Writer
+	names []string
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	return &ndjsonWriter{w: bufio.NewWriter(w)}
+}
+
+func (n *ndjsonWriter) Header(names []string) error {
+	n.names = names
+	return nil
+}
+
+func (n *ndjsonWriter) Row(values []interface{}) error {
+	if _, err := n.w.WriteString("{"); err != nil {
+		return err
+	}
+
+	for i, v := range values {
+		key, _ := json.Marshal(n.names[i])
+		val, err := json.Marshal(v)
+
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			_ = n.w.WriteByte(',')
+		}
+
+		_, _ = n.w.Write(key)
+		_ = n.w.WriteByte(':')
+		_, _ = n.w.Write(val)
+	}
+
+	_, err := n.w.WriteString("}\n")
+
+	return err
+}
+
+func (n *ndjsonWriter) Flush() error {
+	return n.w.Flush()
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.Flush()
+}
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+// static parts of a single sheet workbook, the sheet itself is streamed as the last zip entry
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" ` +
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" ` +
+		`Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Cars" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" ` +
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" ` +
+		`Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// xlsxWriter streams rows into a minimal Office Open XML workbook using inline strings,
+// so nothing but the current row is held in memory
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	row   int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	z := zip.NewWriter(w)
+
+	for _, p := range xlsxParts {
+		f, err := z.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = io.WriteString(f, p.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := z.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := bufio.NewWriter(f)
+	_, _ = sheet.WriteString(xml.Header +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	return &xlsxWriter{zip: z, sheet: sheet}, nil
+}
+
+func (x *xlsxWriter) Header(names []string) error {
+	values := make([]interface{}, len(names))
+
+	for i := range names {
+		values[i] = names[i]
+	}
+
+	return x.Row(values)
+}
+
+func (x *xlsxWriter) Row(values []interface{}) error {
+	x.row++
+	rowRef := strconv.Itoa(x.row)
+
+	_, _ = x.sheet.WriteString(`<row r="` + rowRef + `">`)
+
+	for i, v := range values {
+		ref := cellName(i) + rowRef
+
+		switch val := v.(type) {
+		case int64:
+			_, _ = x.sheet.WriteString(`<c r="` + ref + `"><v>` + strconv.FormatInt(val, 10) + `</v></c>`)
+		default:
+			_, _ = x.sheet.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t>`)
+
+			if s, ok := val.(string); ok {
+				_ = xml.EscapeText(x.sheet, []byte(s))
+			}
+
+			_, _ = x.sheet.WriteString(`</t></is></c>`)
+		}
+	}
+
+	_, err := x.sheet.WriteString(`</row>`)
+
+	return err
+}
+
+func (x *xlsxWriter) Flush() error {
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return x.zip.Flush()
+}
+
+func (x *xlsxWriter) Close() error {
+	_, _ = x.sheet.WriteString(`</sheetData></worksheet>`)
+
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return x.zip.Close()
+}
+
+// cellName converts a zero based column index to its spreadsheet letters, 0 is A and 26 is AA
+func cellName(i int) string {
+	name := ""
+
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+
+	return name
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Exporter interface {
+	Export(ctx context.Context, w io.Writer, opts models.ExportOptions) error
+}
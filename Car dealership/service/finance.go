@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Finance interface {
+	Quote(ctx context.Context, req models.LoanRequest) (models.LoanQuote, error)
+	Offers(ctx context.Context, req models.LoanRequest) ([]models.LoanQuote, error)
+	GetRates(ctx context.Context, lender string) ([]models.LenderRate, error)
+	SetRates(ctx context.Context, lender string, rates []models.LenderRate) ([]models.LenderRate, error)
+}
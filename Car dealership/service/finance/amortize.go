@@ -0,0 +1,72 @@
+package finance
+
+import (
+	"math/big"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// monthlyRate is the interest rate of one month for an APR in basis points, apr / 10000 / 12
+func monthlyRate(apr int) *big.Rat {
+	return big.NewRat(int64(apr), 120000)
+}
+
+// payment is the level monthly payment which repays principal with interest over n months,
+// principal * r / (1 - (1 + r)^-n), rounded half up to the cent. It is computed on exact fractions so that
+// the only rounding is the final one.
+func payment(principal models.Money, apr, n int) models.Money {
+	p := new(big.Rat).SetInt64(int64(principal))
+
+	if apr == 0 {
+		return round(p.Quo(p, big.NewRat(int64(n), 1)))
+	}
+
+	r := monthlyRate(apr)
+
+	growth := new(big.Rat).SetInt64(1)
+	step := new(big.Rat).Add(growth, r)
+
+	for i := 0; i < n; i++ {
+		growth.Mul(growth, step)
+	}
+
+	// principal * r * (1 + r)^n / ((1 + r)^n - 1)
+	p.Mul(p, r)
+	p.Mul(p, growth)
+
+	return round(p.Quo(p, growth.Sub(growth, big.NewRat(1, 1))))
+}
+
+// schedule splits the payments of a loan into interest and principal month by month. Interest is charged on the
+// balance and rounded half up to the cent, and the last payment is whatever settles the balance.
+func schedule(principal models.Money, apr, n int) []models.Installment {
+	level := payment(principal, apr, n)
+	r := monthlyRate(apr)
+	balance := principal
+	installments := make([]models.Installment, 0, n)
+
+	for i := 1; i <= n && balance > 0; i++ {
+		interest := round(new(big.Rat).Mul(new(big.Rat).SetInt64(int64(balance)), r))
+
+		repaid := level - interest
+		if i == n || repaid > balance {
+			repaid = balance
+		}
+
+		balance -= repaid
+		installments = append(installments, models.Installment{Number: i, Payment: repaid + interest,
+			Principal: repaid, Interest: interest, Balance: balance})
+	}
+
+	return installments
+}
+
+// round rounds a non negative amount of cents half up to a whole cent
+func round(x *big.Rat) models.Money {
+	q, m := new(big.Int).QuoRem(x.Num(), x.Denom(), new(big.Int))
+	if m.Lsh(m, 1).Cmp(x.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	return models.Money(q.Int64())
+}
@@ -0,0 +1,76 @@
+package finance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSchedule test function to test payments are rounded half up and the last payment settles the balance
+func TestSchedule(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		principal models.Money
+		apr, n    int
+		payment   models.Money
+		first     models.Installment
+		last      models.Installment
+		interest  models.Money
+	}{
+		{desc: "6% over 5 years", principal: 2000000, apr: 600, n: 60, payment: 38666,
+			first:    models.Installment{Number: 1, Payment: 38666, Principal: 28666, Interest: 10000, Balance: 1971334},
+			last:     models.Installment{Number: 60, Payment: 38641, Principal: 38449, Interest: 192},
+			interest: 319935},
+		{desc: "6.99% over 6 years", principal: 2500000, apr: 699, n: 72, payment: 42611,
+			first:    models.Installment{Number: 1, Payment: 42611, Principal: 28048, Interest: 14563, Balance: 2471952},
+			last:     models.Installment{Number: 72, Payment: 42566, Principal: 42319, Interest: 247},
+			interest: 567947},
+		{desc: "interest free", principal: 100000, apr: 0, n: 3, payment: 33333,
+			first: models.Installment{Number: 1, Payment: 33333, Principal: 33333, Balance: 66667},
+			last:  models.Installment{Number: 3, Payment: 33334, Principal: 33334}},
+	}
+
+	for i, tc := range testCases {
+		if got := payment(tc.principal, tc.apr, tc.n); got != tc.payment {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, got, tc.payment)
+		}
+
+		installments := schedule(tc.principal, tc.apr, tc.n)
+		assert.Len(t, installments, tc.n, tc.desc)
+		assert.Equal(t, tc.first, installments[0], tc.desc)
+		assert.Equal(t, tc.last, installments[tc.n-1], tc.desc)
+
+		var interest, repaid models.Money
+
+		for _, in := range installments {
+			interest += in.Interest
+			repaid += in.Principal
+		}
+
+		assert.Equal(t, tc.interest, interest, tc.desc)
+		assert.Equal(t, tc.principal, repaid, tc.desc)
+	}
+}
+
+// TestRound test function to test amounts are rounded half up to the cent
+func TestRound(t *testing.T) {
+	testCases := []struct {
+		input  *big.Rat
+		output models.Money
+	}{
+		{big.NewRat(5, 2), 3},
+		{big.NewRat(7, 3), 2},
+		{big.NewRat(1, 2), 1},
+		{big.NewRat(49, 100), 0},
+		{big.NewRat(12, 1), 12},
+	}
+
+	for i, tc := range testCases {
+		if got := round(tc.input); got != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.input, got, tc.output)
+		}
+	}
+}
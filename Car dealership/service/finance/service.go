@@ -0,0 +1,255 @@
+package finance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxTerm is the longest loan in months
+	MaxTerm = 120
+	// MaxAPR is the highest rate in basis points
+	MaxAPR = 5000
+)
+
+var (
+	ErrCarNotFound = errors.New("car not found")
+	ErrInvalidLoan = errors.New("invalid loan")
+	ErrInvalidRate = errors.New("invalid rate table")
+	ErrNoRate      = errors.New("no rate for the loan")
+)
+
+type service struct {
+	car    datastore.Car
+	lender datastore.Lender
+}
+
+func New(car datastore.Car, lender datastore.Lender) service { //nolint
+	return service{car: car, lender: lender}
+}
+
+// Quote service layer function to work out the monthly payment, the total interest and the amortization
+// schedule of a loan. The price and currency default to those of the car, and with a lender the APR comes from
+// the lender's rate table.
+func (s service) Quote(ctx context.Context, req models.LoanRequest) (models.LoanQuote, error) {
+	req.Lender = strings.TrimSpace(req.Lender)
+
+	if err := s.checkLoan(ctx, &req); err != nil {
+		return models.LoanQuote{}, err
+	}
+
+	if req.Lender != "" {
+		rates, err := s.lender.GetRates(ctx, req.Lender)
+		if err != nil {
+			return models.LoanQuote{}, err
+		}
+
+		rate, ok := best(rates, req.Tier, req.TermMonths)
+		if !ok {
+			return models.LoanQuote{}, fmt.Errorf("%w: %s has no rate for %s credit over %d months", ErrNoRate,
+				req.Lender, req.Tier, req.TermMonths)
+		}
+
+		req.APR = rate.APR
+	}
+
+	if req.APR < 0 || req.APR > MaxAPR {
+		return models.LoanQuote{}, fmt.Errorf("%w: APR must be between 0 and %d basis points", ErrInvalidLoan, MaxAPR)
+	}
+
+	return quote(req), nil
+}
+
+// Offers service layer function to quote the loan with every lender which has a rate for the credit tier and
+// term, cheapest first. Offers leave out the amortization schedule.
+func (s service) Offers(ctx context.Context, req models.LoanRequest) ([]models.LoanQuote, error) {
+	if err := s.checkLoan(ctx, &req); err != nil {
+		return nil, err
+	}
+
+	if req.Tier == "" {
+		return nil, fmt.Errorf("%w: tier is required", ErrInvalidLoan)
+	}
+
+	rates, err := s.lender.GetRates(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	byLender := map[string][]models.LenderRate{}
+	for _, r := range rates {
+		byLender[r.Lender] = append(byLender[r.Lender], r)
+	}
+
+	offers := []models.LoanQuote{}
+
+	for lender, rates := range byLender {
+		rate, ok := best(rates, req.Tier, req.TermMonths)
+		if !ok {
+			continue
+		}
+
+		req.Lender, req.APR = lender, rate.APR
+
+		offer := quote(req)
+		offer.Schedule = nil
+		offers = append(offers, offer)
+	}
+
+	sort.Slice(offers, func(i, j int) bool {
+		if offers[i].APR != offers[j].APR {
+			return offers[i].APR < offers[j].APR
+		}
+
+		return offers[i].Lender < offers[j].Lender
+	})
+
+	return offers, nil
+}
+
+// GetRates service layer function to get the rate table of a lender, or of every lender when lender is empty
+func (s service) GetRates(ctx context.Context, lender string) ([]models.LenderRate, error) {
+	return s.lender.GetRates(ctx, strings.TrimSpace(lender))
+}
+
+// SetRates service layer function to replace the rate table of a lender. The term ranges of a tier must not
+// overlap so that a loan gets a single rate, and an empty table removes the lender.
+func (s service) SetRates(ctx context.Context, lender string, rates []models.LenderRate) ([]models.LenderRate, error) {
+	lender = strings.TrimSpace(lender)
+	if lender == "" {
+		return nil, fmt.Errorf("%w: lender is required", ErrInvalidRate)
+	}
+
+	table := make([]models.LenderRate, len(rates))
+
+	for i, r := range rates {
+		r.Lender = lender
+		r.Tier = strings.ToLower(strings.TrimSpace(r.Tier))
+
+		switch {
+		case r.Tier == "":
+			return nil, fmt.Errorf("%w: tier is required", ErrInvalidRate)
+		case r.MinTerm < 1 || r.MinTerm > r.MaxTerm || r.MaxTerm > MaxTerm:
+			return nil, fmt.Errorf("%w: terms must be between 1 and %d months", ErrInvalidRate, MaxTerm)
+		case r.APR < 0 || r.APR > MaxAPR:
+			return nil, fmt.Errorf("%w: APR must be between 0 and %d basis points", ErrInvalidRate, MaxAPR)
+		}
+
+		for _, prev := range table[:i] {
+			if prev.Tier == r.Tier && prev.MinTerm <= r.MaxTerm && r.MinTerm <= prev.MaxTerm {
+				return nil, fmt.Errorf("%w: terms %d-%d and %d-%d of %s overlap", ErrInvalidRate, prev.MinTerm,
+					prev.MaxTerm, r.MinTerm, r.MaxTerm, r.Tier)
+			}
+		}
+
+		table[i] = r
+	}
+
+	sort.Slice(table, func(i, j int) bool {
+		if table[i].Tier != table[j].Tier {
+			return table[i].Tier < table[j].Tier
+		}
+
+		return table[i].MinTerm < table[j].MinTerm
+	})
+
+	if err := s.lender.ReplaceRates(ctx, lender, table); err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// checkLoan fills in the price and currency of the car and checks the amounts and term of the loan
+func (s service) checkLoan(ctx context.Context, req *models.LoanRequest) error {
+	req.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+	req.Tier = strings.ToLower(strings.TrimSpace(req.Tier))
+
+	if req.CarID != uuid.Nil {
+		car, err := s.car.GetCarByID(ctx, req.CarID.String())
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCarNotFound
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if req.Price == 0 {
+			req.Price = car.ListPrice
+		}
+
+		if req.Currency == "" {
+			req.Currency = car.Currency
+		}
+	}
+
+	switch {
+	case req.Price <= 0:
+		return fmt.Errorf("%w: price must be positive", ErrInvalidLoan)
+	case !models.ValidCurrency(req.Currency):
+		return fmt.Errorf("%w: currency must be a supported ISO 4217 code", ErrInvalidLoan)
+	case req.DownPayment < 0 || req.TradeIn < 0:
+		return fmt.Errorf("%w: down payment and trade-in must not be negative", ErrInvalidLoan)
+	case !req.Price.Fits(req.Currency) || !req.DownPayment.Fits(req.Currency) || !req.TradeIn.Fits(req.Currency):
+		return fmt.Errorf("%w: amounts have more fraction digits than %s", ErrInvalidLoan, req.Currency)
+	case req.DownPayment+req.TradeIn >= req.Price:
+		return fmt.Errorf("%w: down payment and trade-in cover the price, there is nothing to finance",
+			ErrInvalidLoan)
+	case req.TermMonths < 1 || req.TermMonths > MaxTerm:
+		return fmt.Errorf("%w: term must be between 1 and %d months", ErrInvalidLoan, MaxTerm)
+	}
+
+	return nil
+}
+
+func quote(req models.LoanRequest) models.LoanQuote {
+	q := models.LoanQuote{CarID: req.CarID, Lender: req.Lender, Tier: req.Tier, Currency: req.Currency,
+		Price: req.Price, DownPayment: req.DownPayment, TradeIn: req.TradeIn,
+		Principal: req.Price - req.DownPayment - req.TradeIn, APR: req.APR, TermMonths: req.TermMonths}
+
+	// the schedule is worked out in minor units of the currency so that every payment is a whole one of them
+	unit := models.MinorUnit(q.Currency)
+	q.Schedule = schedule(q.Principal/unit, q.APR, q.TermMonths)
+
+	for i := range q.Schedule {
+		s := &q.Schedule[i]
+		s.Payment, s.Principal, s.Interest, s.Balance = s.Payment*unit, s.Principal*unit, s.Interest*unit,
+			s.Balance*unit
+	}
+
+	q.MonthlyPayment = q.Schedule[0].Payment
+	q.FinalPayment = q.Schedule[len(q.Schedule)-1].Payment
+
+	for _, i := range q.Schedule {
+		q.TotalInterest += i.Interest
+		q.TotalPayments += i.Payment
+	}
+
+	return q
+}
+
+// best returns the lowest rate covering a loan of the tier over term months
+func best(rates []models.LenderRate, tier string, term int) (models.LenderRate, bool) {
+	var (
+		rate  models.LenderRate
+		found bool
+	)
+
+	for _, r := range rates {
+		if r.Covers(tier, term) && (!found || r.APR < rate.APR) {
+			rate, found = r, true
+		}
+	}
+
+	return rate, found
+}
@@ -0,0 +1,161 @@
+package finance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQuote service layer test function to test loans are priced from the car and the lender's rate table
+func TestQuote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	lenderStore := datastore.NewMockLender(ctrl)
+	s := New(carStore, lenderStore)
+
+	carID := uuid.New()
+	car := models.Car{ID: carID, ListPrice: 2600000, Currency: "USD"}
+	rates := []models.LenderRate{{Lender: "acme", Tier: "prime", MinTerm: 12, MaxTerm: 48, APR: 499},
+		{Lender: "acme", Tier: "prime", MinTerm: 49, MaxTerm: 72, APR: 600}}
+
+	testCases := []struct {
+		desc    string
+		input   models.LoanRequest
+		mock    func()
+		apr     int
+		payment models.Money
+		err     error
+	}{
+		{desc: "price of the car", input: models.LoanRequest{CarID: carID, DownPayment: 400000, TradeIn: 200000,
+			APR: 600, TermMonths: 60}, apr: 600, payment: 38666,
+			mock: func() { carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(car, nil) }},
+		{desc: "rate of the lender", input: models.LoanRequest{Price: 2000000, Currency: "usd", TermMonths: 60,
+			Lender: " acme ", Tier: "Prime"}, apr: 600, payment: 38666,
+			mock: func() { lenderStore.EXPECT().GetRates(gomock.Any(), "acme").Return(rates, nil) }},
+		{desc: "no rate for the term", input: models.LoanRequest{Price: 2000000, Currency: "USD", TermMonths: 84,
+			Lender: "acme", Tier: "prime"}, err: ErrNoRate,
+			mock: func() { lenderStore.EXPECT().GetRates(gomock.Any(), "acme").Return(rates, nil) }},
+		{desc: "no rate for the tier", input: models.LoanRequest{Price: 2000000, Currency: "USD", TermMonths: 60,
+			Lender: "acme", Tier: "subprime"}, err: ErrNoRate,
+			mock: func() { lenderStore.EXPECT().GetRates(gomock.Any(), "acme").Return(rates, nil) }},
+		{desc: "car not found", input: models.LoanRequest{CarID: carID, TermMonths: 60}, err: ErrCarNotFound,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{}, sql.ErrNoRows)
+			}},
+		{desc: "nothing to finance", input: models.LoanRequest{Price: 100000, Currency: "USD", DownPayment: 60000,
+			TradeIn: 40000, TermMonths: 12}, err: ErrInvalidLoan},
+		{desc: "missing currency", input: models.LoanRequest{Price: 100000, TermMonths: 12}, err: ErrInvalidLoan},
+		{desc: "fraction of a yen", input: models.LoanRequest{Price: 100000, Currency: "JPY", DownPayment: 50,
+			TermMonths: 12}, err: ErrInvalidLoan},
+		{desc: "rate too high", input: models.LoanRequest{Price: 100000, Currency: "USD", APR: 9900,
+			TermMonths: 12}, err: ErrInvalidLoan},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Quote(context.TODO(), tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, models.Money(2000000), res.Principal, tc.desc)
+		assert.Equal(t, "USD", res.Currency, tc.desc)
+		assert.Equal(t, tc.apr, res.APR, tc.desc)
+		assert.Equal(t, tc.payment, res.MonthlyPayment, tc.desc)
+		assert.Equal(t, models.Money(38641), res.FinalPayment, tc.desc)
+		assert.Equal(t, models.Money(319935), res.TotalInterest, tc.desc)
+		assert.Equal(t, models.Money(2319935), res.TotalPayments, tc.desc)
+		assert.Len(t, res.Schedule, 60, tc.desc)
+	}
+}
+
+// TestOffers service layer test function to test every lender with a rate is quoted, cheapest first
+func TestOffers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lenderStore := datastore.NewMockLender(ctrl)
+	s := New(datastore.NewMockCar(ctrl), lenderStore)
+
+	lenderStore.EXPECT().GetRates(gomock.Any(), "").Return([]models.LenderRate{
+		{Lender: "acme", Tier: "prime", MinTerm: 12, MaxTerm: 72, APR: 650},
+		{Lender: "bolt", Tier: "prime", MinTerm: 12, MaxTerm: 36, APR: 399},
+		{Lender: "bolt", Tier: "prime", MinTerm: 37, MaxTerm: 72, APR: 599},
+		{Lender: "coin", Tier: "near-prime", MinTerm: 12, MaxTerm: 72, APR: 899},
+	}, nil)
+
+	offers, err := s.Offers(context.TODO(), models.LoanRequest{Price: 2000000, Currency: "USD", TermMonths: 60,
+		Tier: "prime"})
+	assert.Nil(t, err)
+	assert.Len(t, offers, 2)
+	assert.Equal(t, "bolt", offers[0].Lender)
+	assert.Equal(t, 599, offers[0].APR)
+	assert.Equal(t, "acme", offers[1].Lender)
+	assert.Nil(t, offers[0].Schedule)
+
+	_, err = s.Offers(context.TODO(), models.LoanRequest{Price: 2000000, Currency: "USD", TermMonths: 60})
+	assert.True(t, errors.Is(err, ErrInvalidLoan))
+}
+
+// TestSetRates service layer test function to test rate tables are validated before they are replaced
+func TestSetRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lenderStore := datastore.NewMockLender(ctrl)
+	s := New(datastore.NewMockCar(ctrl), lenderStore)
+
+	table := []models.LenderRate{{Lender: "acme", Tier: "prime", MinTerm: 12, MaxTerm: 36, APR: 499},
+		{Lender: "acme", Tier: "prime", MinTerm: 37, MaxTerm: 72, APR: 599}}
+
+	testCases := []struct {
+		desc   string
+		lender string
+		input  []models.LenderRate
+		mock   func()
+		err    error
+	}{
+		{desc: "success", lender: "acme", input: []models.LenderRate{{Tier: " Prime", MinTerm: 37, MaxTerm: 72,
+			APR: 599}, {Tier: "prime", MinTerm: 12, MaxTerm: 36, APR: 499}},
+			mock: func() { lenderStore.EXPECT().ReplaceRates(gomock.Any(), "acme", table).Return(nil) }},
+		{desc: "missing lender", input: table, err: ErrInvalidRate},
+		{desc: "missing tier", lender: "acme", input: []models.LenderRate{{MinTerm: 12, MaxTerm: 36}},
+			err: ErrInvalidRate},
+		{desc: "term too long", lender: "acme", input: []models.LenderRate{{Tier: "prime", MinTerm: 12,
+			MaxTerm: 240}}, err: ErrInvalidRate},
+		{desc: "overlapping terms", lender: "acme", input: []models.LenderRate{{Tier: "prime", MinTerm: 12,
+			MaxTerm: 48}, {Tier: "prime", MinTerm: 36, MaxTerm: 72}}, err: ErrInvalidRate},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.SetRates(context.TODO(), tc.lender, tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err == nil {
+			assert.Equal(t, table, res, tc.desc)
+		}
+	}
+}
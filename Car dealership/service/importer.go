@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Importer interface {
+	Import(ctx context.Context, r io.Reader, opts models.ImportOptions) (models.ImportJob, error)
+	GetJob(ctx context.Context, id string) (models.ImportJob, error)
+}
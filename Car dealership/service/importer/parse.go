@@ -0,0 +1,168 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// Supported import formats
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown import format")
+	ErrMissingColumn = errors.New("missing required column")
+)
+
+// requiredColumns must be present in a CSV header, the vin, status, displacement, cylinders and range columns
+// are optional
+var requiredColumns = []string{"name", "year", "brand", "fueltype"}
+
+// row is a single parsed line of an import file, err is set when the line could not be decoded
+type row struct {
+	line int
+	car  models.Car
+	err  error
+}
+
+func parse(r io.Reader, format string) ([]row, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatNDJSON:
+		return parseNDJSON(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func parseCSV(r io.Reader) ([]row, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int)
+
+	for i, h := range header {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	for _, c := range requiredColumns {
+		if _, ok := index[c]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrMissingColumn, c)
+		}
+	}
+
+	var rows []row
+
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				rows = append(rows, row{line: line, err: err})
+				continue
+			}
+
+			return nil, err
+		}
+
+		rows = append(rows, csvRow(line, record, index))
+	}
+
+	return rows, nil
+}
+
+func csvRow(line int, record []string, index map[string]int) row {
+	field := func(name string) string {
+		i, ok := index[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	var (
+		car models.Car
+		err error
+	)
+
+	car.VIN = field("vin")
+	car.Status = strings.ToLower(field("status"))
+	car.Name = field("name")
+	car.Brand = field("brand")
+	car.FuelType = field("fueltype")
+
+	if car.Year, err = strconv.Atoi(field("year")); err != nil {
+		return row{line: line, err: fmt.Errorf("invalid year %q", field("year"))}
+	}
+
+	for _, c := range []struct {
+		name string
+		dst  *int64
+	}{
+		{"displacement", &car.Engine.Displacement},
+		{"cylinders", &car.Engine.NoOfCylinder},
+		{"range", &car.Engine.CarRange},
+	} {
+		v := field(c.name)
+		if v == "" {
+			continue
+		}
+
+		if *c.dst, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return row{line: line, err: fmt.Errorf("invalid %s %q", c.name, v)}
+		}
+	}
+
+	return row{line: line, car: car}
+}
+
+func parseNDJSON(r io.Reader) ([]row, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []row
+
+	for line := 1; sc.Scan(); line++ {
+		b := sc.Bytes()
+		if len(strings.TrimSpace(string(b))) == 0 {
+			continue
+		}
+
+		var car models.Car
+
+		if err := json.Unmarshal(b, &car); err != nil {
+			rows = append(rows, row{line: line, err: err})
+			continue
+		}
+
+		rows = append(rows, row{line: line, car: car})
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,292 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	svc "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/powertrain"
+
+	"github.com/google/uuid"
+)
+
+// jobRetention is how long finished jobs are kept around for polling
+const jobRetention = 24 * time.Hour
+
+var (
+	ErrConflictingModes = errors.New("skip invalid and atomic imports can not be combined")
+	ErrJobNotFound      = errors.New("import job not found")
+)
+
+type service struct {
+	car     datastore.Car
+	engine  datastore.Engine
+	cars    svc.Cars
+	workers int
+
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*models.ImportJob
+	wg   sync.WaitGroup
+}
+
+// New returns an import service which writes rows with at most workers concurrent inserts. Rows are first passed
+// through the car decorators of decorate, which resolve and check them as they do cars created one at a time.
+func New(car datastore.Car, engine datastore.Engine, decorate func(next svc.Cars) svc.Cars,
+	workers int) *service { //nolint
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &service{car: car, engine: engine, cars: decorate(prepared{}), workers: workers,
+		jobs: make(map[uuid.UUID]*models.ImportJob)}
+}
+
+// Import service layer function to parse an import file and process its rows in the background
+func (s *service) Import(ctx context.Context, r io.Reader, opts models.ImportOptions) (models.ImportJob, error) {
+	if opts.Atomic && opts.SkipInvalid {
+		return models.ImportJob{}, ErrConflictingModes
+	}
+
+	rows, err := parse(r, opts.Format)
+	if err != nil {
+		return models.ImportJob{}, err
+	}
+
+	job := &models.ImportJob{
+		ID:        uuid.New(),
+		Status:    models.ImportPending,
+		Options:   opts,
+		Total:     len(rows),
+		Errors:    []models.RowError{},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	s.mu.Lock()
+	s.prune()
+	s.jobs[job.ID] = job
+	snapshot := *job
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+		s.run(context.Background(), job, rows)
+	}()
+
+	return snapshot, nil
+}
+
+// GetJob service layer function to get the current progress of an import job
+func (s *service) GetJob(ctx context.Context, id string) (models.ImportJob, error) {
+	jobID, err := uuid.Parse(id)
+	if err != nil {
+		return models.ImportJob{}, ErrJobNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[jobID]
+	if !ok {
+		return models.ImportJob{}, ErrJobNotFound
+	}
+
+	snapshot := *job
+	snapshot.Errors = append([]models.RowError{}, job.Errors...)
+
+	return snapshot, nil
+}
+
+// prune drops finished jobs older than jobRetention, callers must hold s.mu
+func (s *service) prune() {
+	for id, job := range s.jobs {
+		if job.FinishedAt != nil && time.Since(*job.FinishedAt) > jobRetention {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func (s *service) run(ctx context.Context, job *models.ImportJob, rows []row) {
+	s.update(job, func(j *models.ImportJob) { j.Status = models.ImportRunning })
+
+	valid := make([]row, 0, len(rows))
+
+	for _, r := range rows {
+		if r.err == nil {
+			r.car, r.err = s.prepare(ctx, r.car)
+		}
+
+		if r.err != nil {
+			s.update(job, func(j *models.ImportJob) {
+				j.Processed++
+				j.Failed++
+				j.Errors = append(j.Errors, models.RowError{Row: r.line, Message: r.err.Error()})
+			})
+
+			continue
+		}
+
+		valid = append(valid, r)
+	}
+
+	invalid := len(rows) - len(valid)
+
+	switch {
+	case invalid > 0 && !job.Options.SkipInvalid:
+		s.update(job, func(j *models.ImportJob) { j.Processed += len(valid) })
+		s.finish(job, models.ImportFailed, fmt.Sprintf("%d invalid rows, nothing imported", invalid))
+	case job.Options.DryRun:
+		s.update(job, func(j *models.ImportJob) {
+			j.Processed += len(valid)
+			j.Succeeded += len(valid)
+		})
+		s.finish(job, models.ImportCompleted, "dry run, nothing imported")
+	case job.Options.Atomic:
+		s.runAtomic(ctx, job, valid)
+	default:
+		s.runPool(ctx, job, valid)
+	}
+}
+
+// runAtomic writes all rows in a single transaction so either every row is imported or none is
+func (s *service) runAtomic(ctx context.Context, job *models.ImportJob, rows []row) {
+	cars := make([]models.Car, len(rows))
+
+	for i := range rows {
+		cars[i] = rows[i].car
+		cars[i].ID = uuid.New()
+		cars[i].Engine.EngineID = uuid.New()
+	}
+
+	if err := s.car.CreateCars(ctx, cars); err != nil {
+		s.update(job, func(j *models.ImportJob) {
+			j.Processed += len(rows)
+			j.Failed += len(rows)
+		})
+		s.finish(job, models.ImportFailed, err.Error())
+
+		return
+	}
+
+	s.update(job, func(j *models.ImportJob) {
+		j.Processed += len(rows)
+		j.Succeeded += len(rows)
+	})
+	s.finish(job, models.ImportCompleted, "")
+}
+
+// runPool writes rows one by one using a bounded number of workers
+func (s *service) runPool(ctx context.Context, job *models.ImportJob, rows []row) {
+	queue := make(chan row)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for r := range queue {
+				err := s.create(ctx, r.car)
+
+				s.update(job, func(j *models.ImportJob) {
+					j.Processed++
+
+					if err != nil {
+						j.Failed++
+						j.Errors = append(j.Errors, models.RowError{Row: r.line, Message: err.Error()})
+
+						return
+					}
+
+					j.Succeeded++
+				})
+			}
+		}()
+	}
+
+	for _, r := range rows {
+		queue <- r
+	}
+
+	close(queue)
+	wg.Wait()
+
+	s.finish(job, models.ImportCompleted, "")
+}
+
+func (s *service) create(ctx context.Context, car models.Car) error {
+	engine, err := s.engine.EngineCreate(ctx, &car.Engine)
+	if err != nil {
+		return err
+	}
+
+	car.ID = uuid.New()
+	car.Engine = engine
+
+	_, err = s.car.CreateCar(ctx, &car)
+
+	return err
+}
+
+func (s *service) update(job *models.ImportJob, f func(j *models.ImportJob)) {
+	s.mu.Lock()
+	f(job)
+	s.mu.Unlock()
+}
+
+func (s *service) finish(job *models.ImportJob, status, message string) {
+	now := time.Now().UTC()
+
+	s.update(job, func(j *models.ImportJob) {
+		j.Status = status
+		j.Message = message
+		j.FinishedAt = &now
+	})
+}
+
+// prepared ends the car decorators of an import, it hands back the car the decorators resolved and checked
+// rather than writing it so that the rows of an atomic import can be written in one transaction
+type prepared struct {
+	svc.Cars
+}
+
+func (prepared) CreateCar(_ context.Context, car *models.Car) (models.Car, error) {
+	return *car, nil
+}
+
+// prepare passes a single car row through the car decorators and checks it before it is written
+func (s *service) prepare(ctx context.Context, car models.Car) (models.Car, error) {
+	car, err := s.cars.CreateCar(ctx, &car)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	return car, validate(car)
+}
+
+// validate checks a single car row once it is filled in from the catalogue
+func validate(car models.Car) error {
+	switch {
+	case car.Name == "":
+		return errors.New("name is required")
+	case car.Brand == "":
+		return errors.New("brand is required")
+	case car.Year < 1886 || car.Year > time.Now().Year()+1:
+		return fmt.Errorf("year %d out of range", car.Year)
+	case !powertrain.ValidFuel(car.FuelType):
+		return fmt.Errorf("unknown fuel type %q", car.FuelType)
+	case car.Engine.Displacement < 0 || car.Engine.NoOfCylinder < 0 || car.Engine.CarRange < 0:
+		return errors.New("engine values can not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	svc "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/catalogue"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/powertrain"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/status"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/vin"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const csvFile = `name,year,brand,fuelType,displacement,cylinders,range
+Model 3,2018,Tesla,electric,0,0,500
+GenX,20x5,Ferrari,petrol,300,8,
+X4,2019,BMW,hydrogen,200,4,
+`
+
+// validators are the car decorators of main which check a car, without the catalogue
+func validators(next svc.Cars) svc.Cars {
+	return status.NewCarValidator(vin.NewCarValidator(powertrain.NewCarValidator(next)))
+}
+
+// TestImport service layer test function to test the import modes
+func TestImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	engineStore := datastore.NewMockEngine(ctrl)
+	s := New(carStore, engineStore, validators, 2)
+
+	testCases := []struct {
+		desc      string
+		body      string
+		opts      models.ImportOptions
+		mock      func()
+		status    string
+		succeeded int
+		failed    int
+	}{
+		{desc: "strict mode rejects file with invalid rows", body: csvFile,
+			opts: models.ImportOptions{Format: FormatCSV}, status: models.ImportFailed, failed: 2},
+		{desc: "dry run validates only", body: csvFile,
+			opts:   models.ImportOptions{Format: FormatCSV, DryRun: true, SkipInvalid: true},
+			status: models.ImportCompleted, succeeded: 1, failed: 2},
+		{desc: "skip invalid imports valid rows", body: csvFile,
+			opts: models.ImportOptions{Format: FormatCSV, SkipInvalid: true},
+			mock: func() {
+				engineStore.EXPECT().EngineCreate(gomock.Any(), gomock.Any()).
+					Return(models.Engine{EngineID: uuid.New()}, nil)
+				carStore.EXPECT().CreateCar(gomock.Any(), gomock.Any()).Return(models.Car{}, nil)
+			},
+			status: models.ImportCompleted, succeeded: 1, failed: 2},
+		{desc: "row write error is reported", body: csvFile,
+			opts: models.ImportOptions{Format: FormatCSV, SkipInvalid: true},
+			mock: func() {
+				engineStore.EXPECT().EngineCreate(gomock.Any(), gomock.Any()).
+					Return(models.Engine{}, errors.New("db error"))
+			},
+			status: models.ImportCompleted, failed: 3},
+		{desc: "atomic import in one transaction",
+			body: `{"Name":"Model 3","Year":2018,"Brand":"Tesla","FuelType":"electric","Engine":{"Range":500}}` + "\n" +
+				`{"Name":"GenX","Year":2015,"Brand":"Ferrari","FuelType":"petrol",` +
+				`"Engine":{"Displacement":3900,"NoOfCylinder":8}}` + "\n",
+			opts: models.ImportOptions{Format: FormatNDJSON, Atomic: true},
+			mock: func() {
+				carStore.EXPECT().CreateCars(gomock.Any(), gomock.Len(2)).Return(nil)
+			},
+			status: models.ImportCompleted, succeeded: 2},
+		{desc: "fuel types are matched in any case",
+			body: `{"Name":"Prius","Year":2020,"Brand":"Toyota","FuelType":"Hybrid",` +
+				`"Engine":{"Displacement":1798,"NoOfCylinder":4}}` + "\n" +
+				`{"Name":"Panda","Year":2019,"Brand":"Fiat","FuelType":" CNG ",` +
+				`"Engine":{"Displacement":1242,"NoOfCylinder":4}}` + "\n",
+			opts: models.ImportOptions{Format: FormatNDJSON, Atomic: true},
+			mock: func() {
+				carStore.EXPECT().CreateCars(gomock.Any(), gomock.Len(2)).Return(nil)
+			},
+			status: models.ImportCompleted, succeeded: 2},
+		{desc: "vin not matching the car is a row error",
+			body: `{"VIN":"5YJ3E1EA2JF000316","Name":"X5","Year":2018,"Brand":"BMW","FuelType":"petrol"}` + "\n",
+			opts: models.ImportOptions{Format: FormatNDJSON}, status: models.ImportFailed, failed: 1},
+		{desc: "powertrain not fitting the fuel type is a row error",
+			body: `{"Name":"Model 3","Year":2018,"Brand":"Tesla","FuelType":"electric",` +
+				`"Engine":{"Range":500,"Powertrain":{"Type":"ice"}}}` + "\n",
+			opts: models.ImportOptions{Format: FormatNDJSON}, status: models.ImportFailed, failed: 1},
+		{desc: "cars can not be imported as sold",
+			body: `{"Name":"Model 3","Year":2018,"Brand":"Tesla","FuelType":"electric","Status":"sold"}` + "\n",
+			opts: models.ImportOptions{Format: FormatNDJSON}, status: models.ImportFailed, failed: 1},
+		{desc: "engine not fitting the fuel type is a row error without a powertrain",
+			body: `{"Name":"GenX","Year":2015,"Brand":"Ferrari","FuelType":"petrol","Engine":{"Range":500}}` + "\n",
+			opts: models.ImportOptions{Format: FormatNDJSON}, status: models.ImportFailed, failed: 1},
+		{desc: "atomic import rolled back",
+			body: `{"Name":"Model 3","Year":2018,"Brand":"Tesla","FuelType":"electric","Engine":{"Range":500}}` + "\n",
+			opts: models.ImportOptions{Format: FormatNDJSON, Atomic: true},
+			mock: func() {
+				carStore.EXPECT().CreateCars(gomock.Any(), gomock.Len(1)).Return(errors.New("db error"))
+			},
+			status: models.ImportFailed, failed: 1},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		job, err := s.Import(context.TODO(), strings.NewReader(tc.body), tc.opts)
+		if err != nil {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v", i, tc.desc, err)
+			continue
+		}
+
+		s.wg.Wait()
+
+		job, err = s.GetJob(context.TODO(), job.ID.String())
+
+		assert.Nil(t, err, tc.desc)
+		assert.Equal(t, tc.status, job.Status, tc.desc)
+		assert.Equal(t, tc.succeeded, job.Succeeded, tc.desc)
+		assert.Equal(t, tc.failed, job.Failed, tc.desc)
+		assert.Equal(t, job.Total, job.Processed, tc.desc)
+	}
+}
+
+// TestImportErrors service layer test function to test requests rejected before a job starts
+func TestImportErrors(t *testing.T) {
+	s := New(nil, nil, validators, 1)
+
+	testCases := []struct {
+		desc string
+		body string
+		opts models.ImportOptions
+		err  error
+	}{
+		{"unknown format", "", models.ImportOptions{Format: "xml"}, ErrUnknownFormat},
+		{"conflicting modes", "", models.ImportOptions{Format: FormatCSV, Atomic: true, SkipInvalid: true},
+			ErrConflictingModes},
+		{"missing column", "name,year,brand\n", models.ImportOptions{Format: FormatCSV}, ErrMissingColumn},
+	}
+
+	for i, tc := range testCases {
+		_, err := s.Import(context.TODO(), strings.NewReader(tc.body), tc.opts)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+
+	_, err := s.GetJob(context.TODO(), uuid.NewString())
+	assert.Equal(t, ErrJobNotFound, err)
+}
+
+// TestImportFromTrim service layer test function to test rows are filled in from their trim before they are checked
+// and written
+func TestImportFromTrim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	catalogueStore := datastore.NewMockCatalogue(ctrl)
+	engineStore := datastore.NewMockEngine(ctrl)
+	s := New(carStore, engineStore, func(next svc.Cars) svc.Cars {
+		return catalogue.NewCarResolver(validators(next), catalogueStore, engineStore)
+	}, 1)
+
+	brand := models.Brand{ID: uuid.New(), Name: "Volkswagen"}
+	model := models.CarModel{ID: uuid.New(), BrandID: brand.ID, Name: "Golf"}
+	engine := models.Engine{EngineID: uuid.New(), Displacement: 1984, NoOfCylinder: 4}
+	trim := models.Trim{ID: uuid.New(), ModelID: model.ID, Name: "GTI", FuelType: "petrol",
+		Engine: models.Engine{EngineID: engine.EngineID}}
+
+	catalogueStore.EXPECT().GetTrim(gomock.Any(), trim.ID.String()).Return(trim, nil)
+	engineStore.EXPECT().EngineGetByID(gomock.Any(), engine.EngineID.String()).Return(engine, nil)
+	catalogueStore.EXPECT().GetModel(gomock.Any(), model.ID.String()).Return(model, nil)
+	catalogueStore.EXPECT().GetBrand(gomock.Any(), brand.ID.String()).Return(brand, nil)
+	carStore.EXPECT().CreateCars(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context,
+		cars []models.Car) error {
+		assert.Equal(t, "Golf GTI", cars[0].Name)
+		assert.Equal(t, "Volkswagen", cars[0].Brand)
+		assert.Equal(t, "petrol", cars[0].FuelType)
+		assert.Equal(t, int64(1984), cars[0].Engine.Displacement)
+
+		return nil
+	})
+
+	job, err := s.Import(context.TODO(), strings.NewReader(`{"TrimID":"`+trim.ID.String()+`","Year":2022}`+"\n"),
+		models.ImportOptions{Format: FormatNDJSON, Atomic: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.wg.Wait()
+
+	job, err = s.GetJob(context.TODO(), job.ID.String())
+
+	assert.Nil(t, err)
+	assert.Equal(t, models.ImportCompleted, job.Status)
+	assert.Equal(t, 1, job.Succeeded)
+}
@@ -0,0 +1,37 @@
+package lead
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+var (
+	ErrIllegalTransition = errors.New("lead status transition not allowed")
+	ErrLeadClosed        = errors.New("lead is closed")
+)
+
+// pipeline lists the statuses a lead may move to from each status, a lead can be lost at any stage
+// but only a qualified lead can be won. Won and lost leads are closed.
+var pipeline = map[string][]string{
+	models.LeadNew:       {models.LeadContacted, models.LeadLost},
+	models.LeadContacted: {models.LeadQualified, models.LeadLost},
+	models.LeadQualified: {models.LeadWon, models.LeadLost},
+}
+
+// checkMove returns ErrIllegalTransition unless the pipeline allows a lead to move from one status to another
+func checkMove(from, to string) error {
+	for _, next := range pipeline[from] {
+		if next == to {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s to %s", ErrIllegalTransition, from, to)
+}
+
+// closed reports whether the lead has left the pipeline
+func closed(status string) bool {
+	return status == models.LeadWon || status == models.LeadLost
+}
@@ -0,0 +1,178 @@
+package lead
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/customer"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCarNotFound  = errors.New("car not found")
+	ErrLeadNotFound = errors.New("lead not found")
+	ErrInvalidLead  = errors.New("invalid lead")
+	ErrLeadConflict = errors.New("the lead was changed by another request")
+)
+
+type service struct {
+	car      datastore.Car
+	customer datastore.Customer
+	lead     datastore.Lead
+	now      func() time.Time
+}
+
+func New(car datastore.Car, customer datastore.Customer, lead datastore.Lead) service { //nolint
+	return service{car: car, customer: customer, lead: lead, now: time.Now}
+}
+
+// Capture service layer function to record the interest of l.Customer in the car l.CarID as a new lead. The
+// customer is looked up by email and only created when it is not known yet.
+func (s service) Capture(ctx context.Context, l models.Lead) (models.Lead, error) {
+	if l.Customer == nil {
+		return models.Lead{}, fmt.Errorf("%w: customer is required", ErrInvalidLead)
+	}
+
+	c, err := customer.Check(*l.Customer)
+	if err != nil {
+		return models.Lead{}, err
+	}
+
+	_, err = s.car.GetCarByID(ctx, l.CarID.String())
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Lead{}, ErrCarNotFound
+	}
+
+	if err != nil {
+		return models.Lead{}, err
+	}
+
+	now := s.now().UTC().Truncate(time.Second)
+
+	c, err = s.findOrCreate(ctx, c, now)
+	if err != nil {
+		return models.Lead{}, err
+	}
+
+	l.ID = uuid.New()
+	l.CustomerID = c.ID
+	l.Customer = &c
+	l.Status = models.LeadNew
+	l.Salesperson = strings.TrimSpace(l.Salesperson)
+	l.Source = strings.TrimSpace(l.Source)
+	l.Notes = strings.TrimSpace(l.Notes)
+	l.CreatedAt = now
+	l.UpdatedAt = now
+
+	return s.lead.CreateLead(ctx, l)
+}
+
+// GetLead service layer function to get a lead by its id
+func (s service) GetLead(ctx context.Context, id string) (models.Lead, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Lead{}, ErrLeadNotFound
+	}
+
+	l, err := s.lead.GetLeadByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Lead{}, ErrLeadNotFound
+	}
+
+	return l, err
+}
+
+// GetLeads service layer function to list the leads passing the filter, most recently updated first
+func (s service) GetLeads(ctx context.Context, filter models.LeadFilter) ([]models.Lead, error) {
+	return s.lead.GetLeads(ctx, filter)
+}
+
+// Transition service layer function to move a lead to status if the pipeline allows it from its current status
+func (s service) Transition(ctx context.Context, id, status string) (models.Lead, error) {
+	status = strings.ToLower(strings.TrimSpace(status))
+
+	if !models.ValidLeadStatus(status) {
+		return models.Lead{}, fmt.Errorf("%w: %s", models.ErrUnknownLeadStatus, status)
+	}
+
+	l, err := s.GetLead(ctx, id)
+	if err != nil {
+		return models.Lead{}, err
+	}
+
+	if err = checkMove(l.Status, status); err != nil {
+		return models.Lead{}, err
+	}
+
+	now := s.now().UTC().Truncate(time.Second)
+
+	err = s.lead.SetLeadStatus(ctx, id, l.Status, status, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Lead{}, ErrLeadConflict
+	}
+
+	if err != nil {
+		return models.Lead{}, err
+	}
+
+	l.Status = status
+	l.UpdatedAt = now
+
+	return l, nil
+}
+
+// Assign service layer function to hand a lead which is still in the pipeline to a salesperson
+func (s service) Assign(ctx context.Context, id, salesperson string) (models.Lead, error) {
+	salesperson = strings.TrimSpace(salesperson)
+	if salesperson == "" {
+		return models.Lead{}, fmt.Errorf("%w: salesperson is required", ErrInvalidLead)
+	}
+
+	l, err := s.GetLead(ctx, id)
+	if err != nil {
+		return models.Lead{}, err
+	}
+
+	if closed(l.Status) {
+		return models.Lead{}, fmt.Errorf("%w: the lead is %s", ErrLeadClosed, l.Status)
+	}
+
+	now := s.now().UTC().Truncate(time.Second)
+
+	err = s.lead.AssignLead(ctx, id, salesperson, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Lead{}, ErrLeadNotFound
+	}
+
+	if err != nil {
+		return models.Lead{}, err
+	}
+
+	l.Salesperson = salesperson
+	l.UpdatedAt = now
+
+	return l, nil
+}
+
+// findOrCreate returns the customer with the email of c, creating it from c when there is none
+func (s service) findOrCreate(ctx context.Context, c models.Customer, now time.Time) (models.Customer, error) {
+	found, err := s.customer.GetCustomerByEmail(ctx, c.Email)
+	if err == nil {
+		return found, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return models.Customer{}, err
+	}
+
+	c.ID = uuid.New()
+	c.CreatedAt = now
+
+	return s.customer.CreateCustomer(ctx, c)
+}
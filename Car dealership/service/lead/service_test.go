@@ -0,0 +1,195 @@
+package lead
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/customer"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// TestCapture service layer test function to test leads are captured for known cars and customers are reused
+func TestCapture(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	customerStore := datastore.NewMockCustomer(ctrl)
+	leadStore := datastore.NewMockLead(ctrl)
+	s := New(carStore, customerStore, leadStore)
+	s.now = func() time.Time { return now }
+
+	carID := uuid.New()
+	known := models.Customer{ID: uuid.New(), Name: "Sahil Gupta", Email: "sahil@example.com"}
+	lead := models.Lead{CarID: carID, Source: " website ",
+		Customer: &models.Customer{Name: "Sahil", Email: "Sahil@example.com"}}
+
+	created := func(_ context.Context, l models.Lead) (models.Lead, error) { return l, nil }
+
+	testCases := []struct {
+		desc     string
+		input    models.Lead
+		mock     func()
+		customer string
+		err      error
+	}{
+		{desc: "known customer", input: lead, customer: "Sahil Gupta", mock: func() {
+			carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+			customerStore.EXPECT().GetCustomerByEmail(gomock.Any(), "sahil@example.com").Return(known, nil)
+			leadStore.EXPECT().CreateLead(gomock.Any(), gomock.Any()).DoAndReturn(created)
+		}},
+		{desc: "new customer", input: lead, customer: "Sahil", mock: func() {
+			carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+			customerStore.EXPECT().GetCustomerByEmail(gomock.Any(), "sahil@example.com").
+				Return(models.Customer{}, sql.ErrNoRows)
+			customerStore.EXPECT().CreateCustomer(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, c models.Customer) (models.Customer, error) { return c, nil })
+			leadStore.EXPECT().CreateLead(gomock.Any(), gomock.Any()).DoAndReturn(created)
+		}},
+		{desc: "missing customer", input: models.Lead{CarID: carID}, err: ErrInvalidLead},
+		{desc: "invalid customer", input: models.Lead{CarID: carID, Customer: &models.Customer{Name: "Sahil"}},
+			err: customer.ErrInvalidCustomer},
+		{desc: "car not found", input: lead, err: ErrCarNotFound, mock: func() {
+			carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{}, sql.ErrNoRows)
+		}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Capture(context.TODO(), tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, models.LeadNew, res.Status, tc.desc)
+		assert.Equal(t, "website", res.Source, tc.desc)
+		assert.Equal(t, tc.customer, res.Customer.Name, tc.desc)
+		assert.Equal(t, res.Customer.ID, res.CustomerID, tc.desc)
+		assert.Equal(t, now, res.CreatedAt, tc.desc)
+	}
+}
+
+// TestTransition service layer test function to test leads only move along the pipeline
+func TestTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	leadStore := datastore.NewMockLead(ctrl)
+	s := New(datastore.NewMockCar(ctrl), datastore.NewMockCustomer(ctrl), leadStore)
+	s.now = func() time.Time { return now }
+
+	id := uuid.NewString()
+	get := func(status string) {
+		leadStore.EXPECT().GetLeadByID(gomock.Any(), id).Return(models.Lead{Status: status}, nil)
+	}
+
+	testCases := []struct {
+		desc string
+		to   string
+		mock func()
+		err  error
+	}{
+		{desc: "contacted", to: " Contacted", mock: func() {
+			get(models.LeadNew)
+			leadStore.EXPECT().SetLeadStatus(gomock.Any(), id, "new", "contacted", now).Return(nil)
+		}},
+		{desc: "won", to: "won", mock: func() {
+			get(models.LeadQualified)
+			leadStore.EXPECT().SetLeadStatus(gomock.Any(), id, "qualified", "won", now).Return(nil)
+		}},
+		{desc: "lost from any stage", to: "lost", mock: func() {
+			get(models.LeadContacted)
+			leadStore.EXPECT().SetLeadStatus(gomock.Any(), id, "contacted", "lost", now).Return(nil)
+		}},
+		{desc: "skip qualification", to: "won", err: ErrIllegalTransition, mock: func() { get(models.LeadNew) }},
+		{desc: "reopen", to: "contacted", err: ErrIllegalTransition, mock: func() { get(models.LeadLost) }},
+		{desc: "same status", to: "new", err: ErrIllegalTransition, mock: func() { get(models.LeadNew) }},
+		{desc: "unknown status", to: "hot", err: models.ErrUnknownLeadStatus},
+		{desc: "not found", to: "lost", err: ErrLeadNotFound, mock: func() {
+			leadStore.EXPECT().GetLeadByID(gomock.Any(), id).Return(models.Lead{}, sql.ErrNoRows)
+		}},
+		{desc: "moved concurrently", to: "contacted", err: ErrLeadConflict, mock: func() {
+			get(models.LeadNew)
+			leadStore.EXPECT().SetLeadStatus(gomock.Any(), id, "new", "contacted", now).Return(sql.ErrNoRows)
+		}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Transition(context.TODO(), id, tc.to)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err == nil && res.UpdatedAt != now {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res.UpdatedAt, now)
+		}
+	}
+}
+
+// TestAssign service layer test function to test open leads are assigned to a salesperson
+func TestAssign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	leadStore := datastore.NewMockLead(ctrl)
+	s := New(datastore.NewMockCar(ctrl), datastore.NewMockCustomer(ctrl), leadStore)
+	s.now = func() time.Time { return now }
+
+	id := uuid.NewString()
+
+	testCases := []struct {
+		desc        string
+		salesperson string
+		mock        func()
+		err         error
+	}{
+		{desc: "success", salesperson: " ravi ", mock: func() {
+			leadStore.EXPECT().GetLeadByID(gomock.Any(), id).Return(models.Lead{Status: models.LeadContacted}, nil)
+			leadStore.EXPECT().AssignLead(gomock.Any(), id, "ravi", now).Return(nil)
+		}},
+		{desc: "missing salesperson", salesperson: " ", err: ErrInvalidLead},
+		{desc: "closed lead", salesperson: "ravi", err: ErrLeadClosed, mock: func() {
+			leadStore.EXPECT().GetLeadByID(gomock.Any(), id).Return(models.Lead{Status: models.LeadWon}, nil)
+		}},
+		{desc: "deleted concurrently", salesperson: "ravi", err: ErrLeadNotFound, mock: func() {
+			leadStore.EXPECT().GetLeadByID(gomock.Any(), id).Return(models.Lead{Status: models.LeadNew}, nil)
+			leadStore.EXPECT().AssignLead(gomock.Any(), id, "ravi", now).Return(sql.ErrNoRows)
+		}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Assign(context.TODO(), id, tc.salesperson)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err == nil && res.Salesperson != "ravi" {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res.Salesperson, "ravi")
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Leads interface {
+	Capture(ctx context.Context, l models.Lead) (models.Lead, error)
+	GetLead(ctx context.Context, id string) (models.Lead, error)
+	GetLeads(ctx context.Context, filter models.LeadFilter) ([]models.Lead, error)
+	Transition(ctx context.Context, id, status string) (models.Lead, error)
+	Assign(ctx context.Context, id, salesperson string) (models.Lead, error)
+}
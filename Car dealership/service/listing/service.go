@@ -0,0 +1,38 @@
+package listing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidPage = errors.New("page must start after a car id and hold zero or more cars")
+
+type listingService struct {
+	car datastore.Car
+}
+
+func New(car datastore.Car) listingService { //nolint
+	return listingService{car: car}
+}
+
+// ListCars service layer function to list a page of the cars which pass the filter, the store filters and pages
+// them in its query
+func (s listingService) ListCars(ctx context.Context, filter models.CarFilter, page models.Page,
+	isEngine bool) (models.CarPage, error) {
+	if page.Limit < 0 {
+		return models.CarPage{}, ErrInvalidPage
+	}
+
+	if page.After != "" {
+		if _, err := uuid.Parse(page.After); err != nil {
+			return models.CarPage{}, ErrInvalidPage
+		}
+	}
+
+	return s.car.GetCars(ctx, filter, page, isEngine)
+}
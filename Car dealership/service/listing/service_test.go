@@ -0,0 +1,58 @@
+package listing
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+// TestListCars service layer test function to test ListCars function
+func TestListCars(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockCar(ctrl)
+	s := New(mockStore)
+
+	car := models.Car{ID: uuid.New(), Name: "Model 3", Brand: "Tesla", ListPrice: 4499000, Currency: "USD"}
+	filter := models.CarFilter{Brand: "Tesla", MaxPrice: 5000000, Currency: "USD"}
+	dbErr := errors.New("db error")
+
+	testCases := []struct {
+		desc   string
+		page   models.Page
+		output models.CarPage
+		err    error
+	}{
+		{desc: "success", page: models.Page{After: uuid.Nil.String(), Limit: 10},
+			output: models.CarPage{Cars: []models.Car{car}, Total: 1}},
+		{desc: "db error", err: dbErr},
+		{desc: "after is not an id", page: models.Page{After: "first", Limit: 10}, err: ErrInvalidPage},
+		{desc: "negative limit", page: models.Page{Limit: -1}, err: ErrInvalidPage},
+	}
+
+	gomock.InOrder(
+		mockStore.EXPECT().GetCars(gomock.Any(), filter, models.Page{After: uuid.Nil.String(), Limit: 10}, true).
+			Return(models.CarPage{Cars: []models.Car{car}, Total: 1}, nil),
+		mockStore.EXPECT().GetCars(gomock.Any(), filter, models.Page{}, true).Return(models.CarPage{}, dbErr),
+	)
+
+	for i, tc := range testCases {
+		res, err := s.ListCars(context.TODO(), filter, tc.page, true)
+
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if !reflect.DeepEqual(res, tc.output) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Listings interface {
+	ListCars(ctx context.Context, filter models.CarFilter, page models.Page, isEngine bool) (models.CarPage, error)
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Maintenance interface {
+	Book(ctx context.Context, a models.ServiceAppointment) (models.ServiceAppointment, error)
+	GetAppointment(ctx context.Context, id string) (models.ServiceAppointment, error)
+	GetAppointments(ctx context.Context, carID string) ([]models.ServiceAppointment, error)
+	CancelAppointment(ctx context.Context, id string) (models.ServiceAppointment, error)
+	Complete(ctx context.Context, appointmentID string, r models.MaintenanceRecord) (models.MaintenanceRecord, error)
+	Record(ctx context.Context, carID string, r models.MaintenanceRecord) (models.MaintenanceRecord, error)
+	GetHistory(ctx context.Context, carID string) ([]models.MaintenanceRecord, error)
+	GetReminder(ctx context.Context, carID string) (models.ServiceReminder, error)
+}
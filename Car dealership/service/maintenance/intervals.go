@@ -0,0 +1,72 @@
+package maintenance
+
+import (
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+// engine types, each has its own service interval
+const (
+	EnginePetrol      = "petrol"
+	EngineDiesel      = "diesel"
+	EngineHybrid      = "hybrid"
+	EngineElectric    = "electric"
+	EnginePerformance = "performance"
+)
+
+// interval is how long a car runs between services, whichever of months and km comes first
+type interval struct {
+	months int
+	km     int
+}
+
+var intervals = map[string]interval{
+	EnginePetrol:      {months: 12, km: 10000},
+	EngineDiesel:      {months: 12, km: 15000},
+	EngineHybrid:      {months: 12, km: 15000},
+	EngineElectric:    {months: 24, km: 30000},
+	EnginePerformance: {months: 6, km: 8000},
+}
+
+// engineType classifies a car by its fuel and engine. An engine without displacement but with a range is
+// electric, and engines of eight or more cylinders or over four litres are serviced as performance engines.
+func engineType(car models.Car, engine models.Engine) string {
+	fuel := strings.ToLower(car.FuelType)
+
+	switch {
+	case fuel == EngineElectric || engine.Displacement == 0 && engine.CarRange > 0:
+		return EngineElectric
+	case engine.NoOfCylinder >= 8 || engine.Displacement > 4000:
+		return EnginePerformance
+	case fuel == EngineDiesel || fuel == EngineHybrid:
+		return fuel
+	}
+
+	return EnginePetrol
+}
+
+// reminder works out when a car of an engine type is next due for a service after its last one, last is nil for
+// a car never serviced
+func reminder(carID uuid.UUID, kind string, last *models.MaintenanceRecord, now time.Time) models.ServiceReminder {
+	iv := intervals[kind]
+	r := models.ServiceReminder{CarID: carID, EngineType: kind, IntervalMonths: iv.months, IntervalKm: iv.km,
+		DueOdometer: iv.km}
+
+	if last == nil {
+		return r
+	}
+
+	at, due := last.CompletedAt, last.CompletedAt.AddDate(0, iv.months, 0)
+
+	r.LastServiceAt = &at
+	r.LastOdometer = last.Odometer
+	r.DueAt = &due
+	r.DueOdometer = last.Odometer + iv.km
+	r.Overdue = !now.Before(due)
+
+	return r
+}
@@ -0,0 +1,55 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEngineType test function to test cars are classified by fuel and engine
+func TestEngineType(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		car    models.Car
+		engine models.Engine
+		output string
+	}{
+		{"petrol", models.Car{FuelType: "Petrol"}, models.Engine{Displacement: 1500, NoOfCylinder: 4}, EnginePetrol},
+		{"diesel", models.Car{FuelType: "diesel"}, models.Engine{Displacement: 2000, NoOfCylinder: 4}, EngineDiesel},
+		{"hybrid", models.Car{FuelType: "hybrid"}, models.Engine{Displacement: 1800, NoOfCylinder: 4}, EngineHybrid},
+		{"electric", models.Car{FuelType: "electric"}, models.Engine{CarRange: 400}, EngineElectric},
+		{"range without displacement", models.Car{}, models.Engine{CarRange: 350}, EngineElectric},
+		{"eight cylinders", models.Car{FuelType: "petrol"}, models.Engine{Displacement: 3900, NoOfCylinder: 8},
+			EnginePerformance},
+		{"large diesel", models.Car{FuelType: "diesel"}, models.Engine{Displacement: 4400, NoOfCylinder: 6},
+			EnginePerformance},
+	}
+
+	for i, tc := range testCases {
+		if got := engineType(tc.car, tc.engine); got != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, got, tc.output)
+		}
+	}
+}
+
+// TestReminder test function to test the next service is due an interval after the last one
+func TestReminder(t *testing.T) {
+	carID := uuid.New()
+	last := models.MaintenanceRecord{Odometer: 42000, CompletedAt: time.Date(2021, 3, 15, 9, 0, 0, 0, time.UTC)}
+	due := time.Date(2022, 3, 15, 9, 0, 0, 0, time.UTC)
+
+	r := reminder(carID, EngineDiesel, &last, time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, models.ServiceReminder{CarID: carID, EngineType: EngineDiesel, IntervalMonths: 12,
+		IntervalKm: 15000, LastServiceAt: &last.CompletedAt, LastOdometer: 42000, DueAt: &due, DueOdometer: 57000}, r)
+
+	r = reminder(carID, EngineDiesel, &last, due)
+	assert.True(t, r.Overdue)
+
+	r = reminder(carID, EngineElectric, nil, due)
+	assert.Equal(t, models.ServiceReminder{CarID: carID, EngineType: EngineElectric, IntervalMonths: 24,
+		IntervalKm: 30000, DueOdometer: 30000}, r)
+}
@@ -0,0 +1,291 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+// MaxLabourHours is the most labour a single maintenance record may book
+const MaxLabourHours = 100
+
+var (
+	ErrAppointmentNotFound = errors.New("service appointment not found")
+	ErrCarNotFound         = errors.New("car not found")
+	ErrCustomerNotFound    = errors.New("customer not found")
+	ErrInvalidAppointment  = errors.New("invalid service appointment")
+	ErrInvalidRecord       = errors.New("invalid maintenance record")
+	ErrAppointmentClosed   = errors.New("service appointment is no longer booked")
+)
+
+type service struct {
+	car         datastore.Car
+	engine      datastore.Engine
+	customer    datastore.Customer
+	maintenance datastore.Maintenance
+	now         func() time.Time
+}
+
+func New(car datastore.Car, engine datastore.Engine, customer datastore.Customer, maintenance datastore.Maintenance) service { //nolint
+	return service{car: car, engine: engine, customer: customer, maintenance: maintenance, now: time.Now}
+}
+
+// Book service layer function to book a car of a customer into the service bay
+func (s service) Book(ctx context.Context, a models.ServiceAppointment) (models.ServiceAppointment, error) {
+	now := s.clock()
+
+	a.Work = strings.TrimSpace(a.Work)
+	a.ScheduledAt = a.ScheduledAt.UTC().Truncate(time.Second)
+
+	switch {
+	case a.Work == "":
+		return models.ServiceAppointment{}, fmt.Errorf("%w: work is required", ErrInvalidAppointment)
+	case a.ScheduledAt.Before(now):
+		return models.ServiceAppointment{}, fmt.Errorf("%w: scheduled time must be in the future",
+			ErrInvalidAppointment)
+	}
+
+	if _, err := s.getCar(ctx, a.CarID.String()); err != nil {
+		return models.ServiceAppointment{}, err
+	}
+
+	_, err := s.customer.GetCustomerByID(ctx, a.CustomerID.String())
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ServiceAppointment{}, ErrCustomerNotFound
+	}
+
+	if err != nil {
+		return models.ServiceAppointment{}, err
+	}
+
+	a.ID = uuid.New()
+	a.Status = models.AppointmentBooked
+	a.CreatedAt = now
+	a.UpdatedAt = now
+
+	return s.maintenance.CreateAppointment(ctx, a)
+}
+
+// GetAppointment service layer function to get a service appointment by its id
+func (s service) GetAppointment(ctx context.Context, id string) (models.ServiceAppointment, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.ServiceAppointment{}, ErrAppointmentNotFound
+	}
+
+	a, err := s.maintenance.GetAppointment(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ServiceAppointment{}, ErrAppointmentNotFound
+	}
+
+	return a, err
+}
+
+// GetAppointments service layer function to get the service appointments of a car, latest first
+func (s service) GetAppointments(ctx context.Context, carID string) ([]models.ServiceAppointment, error) {
+	if _, err := s.getCar(ctx, carID); err != nil {
+		return nil, err
+	}
+
+	return s.maintenance.GetAppointments(ctx, carID)
+}
+
+// CancelAppointment service layer function to cancel a booked service appointment
+func (s service) CancelAppointment(ctx context.Context, id string) (models.ServiceAppointment, error) {
+	a, err := s.booked(ctx, id)
+	if err != nil {
+		return models.ServiceAppointment{}, err
+	}
+
+	a.Status = models.AppointmentCancelled
+	a.UpdatedAt = s.clock()
+
+	err = s.maintenance.CancelAppointment(ctx, id, a.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ServiceAppointment{}, ErrAppointmentClosed
+	}
+
+	if err != nil {
+		return models.ServiceAppointment{}, err
+	}
+
+	return a, nil
+}
+
+// Complete service layer function to record the maintenance done for a booked appointment and complete it.
+// The work defaults to the work the appointment was booked for.
+func (s service) Complete(ctx context.Context, appointmentID string,
+	r models.MaintenanceRecord) (models.MaintenanceRecord, error) {
+	a, err := s.booked(ctx, appointmentID)
+	if err != nil {
+		return models.MaintenanceRecord{}, err
+	}
+
+	if strings.TrimSpace(r.Work) == "" {
+		r.Work = a.Work
+	}
+
+	r.CarID = a.CarID
+	r.AppointmentID = &a.ID
+
+	r, err = s.record(ctx, r)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.MaintenanceRecord{}, ErrAppointmentClosed
+	}
+
+	return r, err
+}
+
+// Record service layer function to record maintenance done on a car without an appointment
+func (s service) Record(ctx context.Context, carID string, r models.MaintenanceRecord) (models.MaintenanceRecord,
+	error) {
+	car, err := s.getCar(ctx, carID)
+	if err != nil {
+		return models.MaintenanceRecord{}, err
+	}
+
+	r.CarID = car.ID
+	r.AppointmentID = nil
+
+	return s.record(ctx, r)
+}
+
+// GetHistory service layer function to get the maintenance history of a car, latest first
+func (s service) GetHistory(ctx context.Context, carID string) ([]models.MaintenanceRecord, error) {
+	if _, err := s.getCar(ctx, carID); err != nil {
+		return nil, err
+	}
+
+	return s.maintenance.GetRecords(ctx, carID)
+}
+
+// GetReminder service layer function to work out when a car is next due for a service from its engine type and
+// its last service
+func (s service) GetReminder(ctx context.Context, carID string) (models.ServiceReminder, error) {
+	car, err := s.getCar(ctx, carID)
+	if err != nil {
+		return models.ServiceReminder{}, err
+	}
+
+	engine, err := s.engine.EngineGetByID(ctx, car.Engine.EngineID.String())
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return models.ServiceReminder{}, err
+	}
+
+	records, err := s.maintenance.GetRecords(ctx, carID)
+	if err != nil {
+		return models.ServiceReminder{}, err
+	}
+
+	var last *models.MaintenanceRecord
+	if len(records) > 0 {
+		last = &records[0]
+	}
+
+	return reminder(car.ID, engineType(car, engine), last, s.clock()), nil
+}
+
+// record checks a maintenance record against the history of its car and inserts it. The odometer may not read
+// less than it did at an earlier service.
+func (s service) record(ctx context.Context, r models.MaintenanceRecord) (models.MaintenanceRecord, error) {
+	now := s.clock()
+
+	if err := checkRecord(&r, now); err != nil {
+		return models.MaintenanceRecord{}, err
+	}
+
+	history, err := s.maintenance.GetRecords(ctx, r.CarID.String())
+	if err != nil {
+		return models.MaintenanceRecord{}, err
+	}
+
+	for _, h := range history {
+		if !h.CompletedAt.After(r.CompletedAt) && h.Odometer > r.Odometer {
+			return models.MaintenanceRecord{}, fmt.Errorf("%w: odometer read %d km at the service on %s",
+				ErrInvalidRecord, h.Odometer, h.CompletedAt.Format("2006-01-02"))
+		}
+	}
+
+	r.ID = uuid.New()
+
+	return s.maintenance.CreateRecord(ctx, r)
+}
+
+// booked returns the appointment with the id if it is still booked
+func (s service) booked(ctx context.Context, id string) (models.ServiceAppointment, error) {
+	a, err := s.GetAppointment(ctx, id)
+	if err != nil {
+		return models.ServiceAppointment{}, err
+	}
+
+	if a.Status != models.AppointmentBooked {
+		return models.ServiceAppointment{}, ErrAppointmentClosed
+	}
+
+	return a, nil
+}
+
+func (s service) getCar(ctx context.Context, id string) (models.Car, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Car{}, ErrCarNotFound
+	}
+
+	car, err := s.car.GetCarByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Car{}, ErrCarNotFound
+	}
+
+	return car, err
+}
+
+func (s service) clock() time.Time {
+	return s.now().UTC().Truncate(time.Second)
+}
+
+// checkRecord normalises a maintenance record, labour is kept to the hundredth of an hour and the completion
+// time defaults to now
+func checkRecord(r *models.MaintenanceRecord, now time.Time) error {
+	r.Work = strings.TrimSpace(r.Work)
+	r.Technician = strings.TrimSpace(r.Technician)
+	r.LabourHours = math.Round(r.LabourHours*100) / 100
+
+	if r.CompletedAt.IsZero() {
+		r.CompletedAt = now
+	}
+
+	r.CompletedAt = r.CompletedAt.UTC().Truncate(time.Second)
+
+	if r.Parts == nil {
+		r.Parts = []models.Part{}
+	}
+
+	switch {
+	case r.Work == "" || r.Technician == "":
+		return fmt.Errorf("%w: work and technician are required", ErrInvalidRecord)
+	case r.LabourHours < 0 || r.LabourHours > MaxLabourHours:
+		return fmt.Errorf("%w: labour must be between 0 and %d hours", ErrInvalidRecord, MaxLabourHours)
+	case r.Odometer < 0:
+		return fmt.Errorf("%w: odometer must not be negative", ErrInvalidRecord)
+	case r.CompletedAt.After(now):
+		return fmt.Errorf("%w: completion time must not be in the future", ErrInvalidRecord)
+	}
+
+	for i := range r.Parts {
+		r.Parts[i].Number = strings.TrimSpace(r.Parts[i].Number)
+		r.Parts[i].Description = strings.TrimSpace(r.Parts[i].Description)
+
+		if r.Parts[i].Description == "" || r.Parts[i].Quantity < 1 {
+			return fmt.Errorf("%w: parts need a description and a quantity of at least 1", ErrInvalidRecord)
+		}
+	}
+
+	return nil
+}
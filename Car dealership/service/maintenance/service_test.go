@@ -0,0 +1,242 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// TestBook service layer test function to test appointments are only booked for known cars and customers
+func TestBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	customerStore := datastore.NewMockCustomer(ctrl)
+	maintenanceStore := datastore.NewMockMaintenance(ctrl)
+	s := New(carStore, datastore.NewMockEngine(ctrl), customerStore, maintenanceStore)
+	s.now = func() time.Time { return now }
+
+	carID, customerID := uuid.New(), uuid.New()
+	appointment := models.ServiceAppointment{CarID: carID, CustomerID: customerID, Work: " annual service ",
+		ScheduledAt: now.Add(24 * time.Hour)}
+	stored := func(_ context.Context, a models.ServiceAppointment) (models.ServiceAppointment, error) {
+		return a, nil
+	}
+
+	testCases := []struct {
+		desc  string
+		input models.ServiceAppointment
+		mock  func()
+		err   error
+	}{
+		{desc: "success", input: appointment,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+				customerStore.EXPECT().GetCustomerByID(gomock.Any(), customerID.String()).
+					Return(models.Customer{ID: customerID}, nil)
+				maintenanceStore.EXPECT().CreateAppointment(gomock.Any(), gomock.Any()).DoAndReturn(stored)
+			}},
+		{desc: "missing work", input: models.ServiceAppointment{CarID: carID, ScheduledAt: now.Add(time.Hour)},
+			err: ErrInvalidAppointment},
+		{desc: "in the past", input: models.ServiceAppointment{CarID: carID, Work: "brakes",
+			ScheduledAt: now.Add(-time.Hour)}, err: ErrInvalidAppointment},
+		{desc: "car not found", input: appointment, err: ErrCarNotFound,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{}, sql.ErrNoRows)
+			}},
+		{desc: "customer not found", input: appointment, err: ErrCustomerNotFound,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+				customerStore.EXPECT().GetCustomerByID(gomock.Any(), customerID.String()).
+					Return(models.Customer{}, sql.ErrNoRows)
+			}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Book(context.TODO(), tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, "annual service", res.Work, tc.desc)
+		assert.Equal(t, models.AppointmentBooked, res.Status, tc.desc)
+		assert.Equal(t, now, res.CreatedAt, tc.desc)
+	}
+}
+
+// TestCompleteCancel service layer test function to test only booked appointments are completed or cancelled
+func TestCompleteCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	maintenanceStore := datastore.NewMockMaintenance(ctrl)
+	s := New(datastore.NewMockCar(ctrl), datastore.NewMockEngine(ctrl), datastore.NewMockCustomer(ctrl),
+		maintenanceStore)
+	s.now = func() time.Time { return now }
+
+	booked := models.ServiceAppointment{ID: uuid.New(), CarID: uuid.New(), Work: "annual service",
+		Status: models.AppointmentBooked}
+	cancelled := booked
+	cancelled.Status = models.AppointmentCancelled
+	id := booked.ID.String()
+	record := models.MaintenanceRecord{Technician: "ravi", LabourHours: 1.333, Odometer: 15200,
+		Parts: []models.Part{{Description: "oil filter", Quantity: 1}}}
+	history := []models.MaintenanceRecord{{Odometer: 5100, CompletedAt: now.AddDate(-1, 0, 0)}}
+
+	maintenanceStore.EXPECT().GetAppointment(gomock.Any(), id).Return(booked, nil).Times(3)
+	maintenanceStore.EXPECT().GetRecords(gomock.Any(), booked.CarID.String()).Return(history, nil).Times(2)
+	maintenanceStore.EXPECT().CreateRecord(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r models.MaintenanceRecord) (models.MaintenanceRecord, error) {
+			assert.Equal(t, "annual service", r.Work)
+			assert.Equal(t, booked.ID, *r.AppointmentID)
+			assert.Equal(t, 1.33, r.LabourHours)
+			assert.Equal(t, now, r.CompletedAt)
+
+			return r, nil
+		})
+	maintenanceStore.EXPECT().CreateRecord(gomock.Any(), gomock.Any()).Return(models.MaintenanceRecord{}, sql.ErrNoRows)
+	maintenanceStore.EXPECT().CancelAppointment(gomock.Any(), id, now).Return(nil)
+
+	_, err := s.Complete(context.TODO(), id, record)
+	assert.Nil(t, err)
+
+	_, err = s.Complete(context.TODO(), id, record)
+	assert.Equal(t, ErrAppointmentClosed, err)
+
+	res, err := s.CancelAppointment(context.TODO(), id)
+	assert.Nil(t, err)
+	assert.Equal(t, models.AppointmentCancelled, res.Status)
+
+	maintenanceStore.EXPECT().GetAppointment(gomock.Any(), id).Return(cancelled, nil).Times(2)
+
+	_, err = s.CancelAppointment(context.TODO(), id)
+	assert.Equal(t, ErrAppointmentClosed, err)
+
+	_, err = s.Complete(context.TODO(), id, record)
+	assert.Equal(t, ErrAppointmentClosed, err)
+
+	_, err = s.GetAppointment(context.TODO(), "abc")
+	assert.Equal(t, ErrAppointmentNotFound, err)
+}
+
+// TestRecord service layer test function to test walk in records are checked against the history of the car
+func TestRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	maintenanceStore := datastore.NewMockMaintenance(ctrl)
+	s := New(carStore, datastore.NewMockEngine(ctrl), datastore.NewMockCustomer(ctrl), maintenanceStore)
+	s.now = func() time.Time { return now }
+
+	carID := uuid.New()
+	history := []models.MaintenanceRecord{{Odometer: 30100, CompletedAt: now.AddDate(0, -2, 0)}}
+	stored := func(_ context.Context, r models.MaintenanceRecord) (models.MaintenanceRecord, error) { return r, nil }
+
+	testCases := []struct {
+		desc  string
+		input models.MaintenanceRecord
+		mock  func()
+		err   error
+	}{
+		{desc: "success", input: models.MaintenanceRecord{Work: "tyres", Technician: "ravi", Odometer: 31000},
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+				maintenanceStore.EXPECT().GetRecords(gomock.Any(), carID.String()).Return(history, nil)
+				maintenanceStore.EXPECT().CreateRecord(gomock.Any(), gomock.Any()).DoAndReturn(stored)
+			}},
+		{desc: "odometer went back", input: models.MaintenanceRecord{Work: "tyres", Technician: "ravi",
+			Odometer: 29000}, err: ErrInvalidRecord,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+				maintenanceStore.EXPECT().GetRecords(gomock.Any(), carID.String()).Return(history, nil)
+			}},
+		{desc: "earlier service", input: models.MaintenanceRecord{Work: "tyres", Technician: "ravi",
+			Odometer: 29000, CompletedAt: now.AddDate(0, -3, 0)},
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+				maintenanceStore.EXPECT().GetRecords(gomock.Any(), carID.String()).Return(history, nil)
+				maintenanceStore.EXPECT().CreateRecord(gomock.Any(), gomock.Any()).DoAndReturn(stored)
+			}},
+		{desc: "missing technician", input: models.MaintenanceRecord{Work: "tyres"}, err: ErrInvalidRecord,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+			}},
+		{desc: "part without quantity", input: models.MaintenanceRecord{Work: "tyres", Technician: "ravi",
+			Parts: []models.Part{{Description: "tyre"}}}, err: ErrInvalidRecord,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+			}},
+		{desc: "car not found", input: models.MaintenanceRecord{Work: "tyres"}, err: ErrCarNotFound,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{}, sql.ErrNoRows)
+			}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Record(context.TODO(), carID.String(), tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err == nil {
+			assert.Equal(t, carID, res.CarID, tc.desc)
+			assert.Nil(t, res.AppointmentID, tc.desc)
+		}
+	}
+}
+
+// TestGetReminder service layer test function to test reminders follow the engine and the last service
+func TestGetReminder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	engineStore := datastore.NewMockEngine(ctrl)
+	maintenanceStore := datastore.NewMockMaintenance(ctrl)
+	s := New(carStore, engineStore, datastore.NewMockCustomer(ctrl), maintenanceStore)
+	s.now = func() time.Time { return now }
+
+	engineID := uuid.New()
+	car := models.Car{ID: uuid.New(), FuelType: "petrol", Engine: models.Engine{EngineID: engineID}}
+	records := []models.MaintenanceRecord{{Odometer: 21000, CompletedAt: now.AddDate(0, -13, 0)},
+		{Odometer: 11000, CompletedAt: now.AddDate(0, -25, 0)}}
+
+	carStore.EXPECT().GetCarByID(gomock.Any(), car.ID.String()).Return(car, nil)
+	engineStore.EXPECT().EngineGetByID(gomock.Any(), engineID.String()).
+		Return(models.Engine{EngineID: engineID, Displacement: 5000, NoOfCylinder: 8}, nil)
+	maintenanceStore.EXPECT().GetRecords(gomock.Any(), car.ID.String()).Return(records, nil)
+
+	res, err := s.GetReminder(context.TODO(), car.ID.String())
+	assert.Nil(t, err)
+	assert.Equal(t, EnginePerformance, res.EngineType)
+	assert.Equal(t, 29000, res.DueOdometer)
+	assert.True(t, res.Overdue)
+
+	_, err = s.GetReminder(context.TODO(), "abc")
+	assert.Equal(t, ErrCarNotFound, err)
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+type Media interface {
+	Upload(ctx context.Context, carID, fileName, contentType string, body io.Reader) (models.Media, error)
+	GetMedia(ctx context.Context, carID string) ([]models.Media, error)
+	Reorder(ctx context.Context, carID string, ids []uuid.UUID) ([]models.Media, error)
+	SetPrimary(ctx context.Context, carID, id string) ([]models.Media, error)
+	Delete(ctx context.Context, carID, id string) error
+	Open(ctx context.Context, id, rendition string) (io.ReadCloser, string, error)
+}
@@ -0,0 +1,115 @@
+package media
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+)
+
+// carMedia is a service.Cars which attaches the urls of their images to the cars it reads
+type carMedia struct {
+	service.Cars
+	media datastore.Media
+}
+
+// NewCarMedia wraps a car service so that cars are read with their images
+func NewCarMedia(next service.Cars, media datastore.Media) service.Cars {
+	return carMedia{Cars: next, media: media}
+}
+
+// GetCarByID attaches the images of the car
+func (c carMedia) GetCarByID(ctx context.Context, id string) (models.Car, error) {
+	car, err := c.Cars.GetCarByID(ctx, id)
+	if err != nil {
+		return car, err
+	}
+
+	cars := []models.Car{car}
+	if err = attach(ctx, c.media, cars); err != nil {
+		return models.Car{}, err
+	}
+
+	return cars[0], nil
+}
+
+// GetCarByBrand attaches the images of the cars, all of them are read in one go
+func (c carMedia) GetCarByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error) {
+	cars, err := c.Cars.GetCarByBrand(ctx, brand, isEngine)
+	if err != nil {
+		return cars, err
+	}
+
+	if err = attach(ctx, c.media, cars); err != nil {
+		return nil, err
+	}
+
+	return cars, nil
+}
+
+// listingMedia is a service.Listings which attaches the urls of their images to the cars it lists
+type listingMedia struct {
+	service.Listings
+	media datastore.Media
+}
+
+// NewListingMedia wraps a listing service so that cars are listed with their images
+func NewListingMedia(next service.Listings, media datastore.Media) service.Listings {
+	return listingMedia{Listings: next, media: media}
+}
+
+// ListCars attaches the images of the cars of the page, all of them are read in one go
+func (l listingMedia) ListCars(ctx context.Context, filter models.CarFilter, page models.Page,
+	isEngine bool) (models.CarPage, error) {
+	res, err := l.Listings.ListCars(ctx, filter, page, isEngine)
+	if err != nil {
+		return res, err
+	}
+
+	if err = attach(ctx, l.media, res.Cars); err != nil {
+		return models.CarPage{}, err
+	}
+
+	return res, nil
+}
+
+// attach sets the media of the cars that have images, a car without images is left without media
+func attach(ctx context.Context, store datastore.Media, cars []models.Car) error {
+	if len(cars) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(cars))
+	for i := range cars {
+		ids[i] = cars[i].ID.String()
+	}
+
+	media, err := store.GetCarMedia(ctx, ids...)
+	if err != nil {
+		return err
+	}
+
+	byCar := map[string]*models.CarMedia{}
+
+	for _, m := range media {
+		cm, ok := byCar[m.CarID.String()]
+		if !ok {
+			cm = &models.CarMedia{Images: []models.MediaURLs{}}
+			byCar[m.CarID.String()] = cm
+		}
+
+		urls := URLs(m.ID)
+		if m.Primary || len(cm.Images) == 0 {
+			cm.Primary = urls
+		}
+
+		cm.Images = append(cm.Images, urls)
+	}
+
+	for i := range cars {
+		cars[i].Media = byCar[cars[i].ID.String()]
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCarMedia test function to test cars are read with the urls of their images
+func TestCarMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCars(ctrl)
+	mockMedia := datastore.NewMockMedia(ctrl)
+	s := NewCarMedia(mockService, mockMedia)
+
+	withPhotos, without := models.Car{ID: uuid.New(), Brand: "BMW"}, models.Car{ID: uuid.New(), Brand: "BMW"}
+	front, side := uuid.New(), uuid.New()
+	media := []models.Media{{ID: front, CarID: withPhotos.ID}, {ID: side, CarID: withPhotos.ID, Primary: true}}
+
+	mockService.EXPECT().GetCarByID(gomock.Any(), withPhotos.ID.String()).Return(withPhotos, nil)
+	mockMedia.EXPECT().GetCarMedia(gomock.Any(), withPhotos.ID.String()).Return(media, nil)
+
+	car, err := s.GetCarByID(context.TODO(), withPhotos.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, &models.CarMedia{Primary: URLs(side), Images: []models.MediaURLs{URLs(front), URLs(side)}},
+		car.Media)
+
+	mockService.EXPECT().GetCarByBrand(gomock.Any(), "BMW", false).Return([]models.Car{without, withPhotos}, nil)
+	mockMedia.EXPECT().GetCarMedia(gomock.Any(), without.ID.String(), withPhotos.ID.String()).
+		Return(media[:1], nil)
+
+	cars, err := s.GetCarByBrand(context.TODO(), "BMW", false)
+	assert.NoError(t, err)
+	assert.Nil(t, cars[0].Media)
+	assert.Equal(t, URLs(front), cars[1].Media.Primary)
+
+	mockService.EXPECT().GetCarByID(gomock.Any(), without.ID.String()).Return(without, nil)
+	mockMedia.EXPECT().GetCarMedia(gomock.Any(), without.ID.String()).Return(nil, errors.New("db error"))
+
+	_, err = s.GetCarByID(context.TODO(), without.ID.String())
+	assert.Error(t, err)
+}
+
+// TestListingMedia test function to test listed cars are read with the urls of their images
+func TestListingMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockListings := service.NewMockListings(ctrl)
+	mockMedia := datastore.NewMockMedia(ctrl)
+	s := NewListingMedia(mockListings, mockMedia)
+
+	withPhotos, without := models.Car{ID: uuid.New(), Brand: "BMW"}, models.Car{ID: uuid.New(), Brand: "BMW"}
+	front := uuid.New()
+	filter := models.CarFilter{Brand: "BMW"}
+
+	mockListings.EXPECT().ListCars(gomock.Any(), filter, models.Page{Limit: 2}, false).
+		Return(models.CarPage{Cars: []models.Car{without, withPhotos}, Total: 3, HasNext: true}, nil)
+	mockMedia.EXPECT().GetCarMedia(gomock.Any(), without.ID.String(), withPhotos.ID.String()).
+		Return([]models.Media{{ID: front, CarID: withPhotos.ID}}, nil)
+
+	res, err := s.ListCars(context.TODO(), filter, models.Page{Limit: 2}, false)
+	assert.NoError(t, err)
+	assert.Nil(t, res.Cars[0].Media)
+	assert.Equal(t, URLs(front), res.Cars[1].Media.Primary)
+	assert.True(t, res.HasNext)
+
+	mockListings.EXPECT().ListCars(gomock.Any(), filter, models.Page{}, false).
+		Return(models.CarPage{Cars: []models.Car{without}, Total: 1}, nil)
+	mockMedia.EXPECT().GetCarMedia(gomock.Any(), without.ID.String()).Return(nil, errors.New("db error"))
+
+	_, err = s.ListCars(context.TODO(), filter, models.Page{}, false)
+	assert.Error(t, err)
+}
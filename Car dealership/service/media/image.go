@@ -0,0 +1,188 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"mime"
+	"net/http"
+)
+
+// image types that can be uploaded, the extension is used for the keys of their blobs
+var types = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// sniff works out the type of an image from its first bytes and checks it against the type it was sent as. A
+// part sent without a type or as application/octet-stream takes the sniffed type.
+func sniff(data []byte, declared string) (string, error) {
+	kind := http.DetectContentType(data)
+	if _, ok := types[kind]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedMedia, kind)
+	}
+
+	if declared == "" {
+		return kind, nil
+	}
+
+	declared, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		return "", fmt.Errorf("%w: content type %v", ErrInvalidMedia, err)
+	}
+
+	if declared != "application/octet-stream" && declared != kind {
+		return "", fmt.Errorf("%w: sent as %s but the image is %s", ErrInvalidMedia, declared, kind)
+	}
+
+	return kind, nil
+}
+
+// decode decodes an image, its size is checked from the header first so that a small file claiming a huge
+// image is turned away before any pixels are allocated. Only the first frame of an animated gif is kept.
+func decode(data []byte, kind string) (image.Image, error) {
+	cfg, err := decodeConfig(data, kind)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMedia, err)
+	}
+
+	if cfg.Width < 1 || cfg.Height < 1 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, fmt.Errorf("%w: an image may have at most %d pixels", ErrInvalidMedia, MaxPixels)
+	}
+
+	var img image.Image
+
+	switch kind {
+	case "image/jpeg":
+		img, err = jpeg.Decode(bytes.NewReader(data))
+	case "image/png":
+		img, err = png.Decode(bytes.NewReader(data))
+	default:
+		img, err = gif.Decode(bytes.NewReader(data))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMedia, err)
+	}
+
+	return img, nil
+}
+
+func decodeConfig(data []byte, kind string) (image.Config, error) {
+	switch kind {
+	case "image/jpeg":
+		return jpeg.DecodeConfig(bytes.NewReader(data))
+	case "image/png":
+		return png.DecodeConfig(bytes.NewReader(data))
+	}
+
+	return gif.DecodeConfig(bytes.NewReader(data))
+}
+
+// renditionType is the type resized renditions of an image are encoded as. Photos stay jpeg, png and gif become
+// png so that transparency survives.
+func renditionType(kind string) string {
+	if kind == "image/jpeg" {
+		return kind
+	}
+
+	return "image/png"
+}
+
+// encode encodes a rendition as kind
+func encode(img image.Image, kind string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var err error
+
+	if kind == "image/jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+
+	return buf.Bytes(), err
+}
+
+// fit scales w x h down to fit a box of side box keeping its aspect ratio, an image that already fits is kept at
+// its size
+func fit(w, h, box int) (int, int) {
+	if w <= box && h <= box {
+		return w, h
+	}
+
+	if w >= h {
+		return box, max(1, (h*box+w/2)/w)
+	}
+
+	return max(1, (w*box+h/2)/h), box
+}
+
+// resize scales src to w x h, each pixel of the result is the average of the block of source pixels it covers.
+// Averaging is done on premultiplied colour so that transparent pixels do not darken their neighbours.
+func resize(src image.Image, w, h int) *image.RGBA {
+	b := src.Bounds()
+
+	rgba, ok := src.(*image.RGBA)
+	if !ok || b.Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	}
+
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		y0, y1 := span(y, h, sh)
+
+		for x := 0; x < w; x++ {
+			x0, x1 := span(x, w, sw)
+
+			var r, g, bl, a, n uint64
+
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride+x0*4 : sy*rgba.Stride+x1*4]
+
+				for i := 0; i < len(row); i += 4 {
+					r += uint64(row[i])
+					g += uint64(row[i+1])
+					bl += uint64(row[i+2])
+					a += uint64(row[i+3])
+				}
+
+				n += uint64(x1 - x0)
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8((r + n/2) / n)
+			dst.Pix[i+1] = uint8((g + n/2) / n)
+			dst.Pix[i+2] = uint8((bl + n/2) / n)
+			dst.Pix[i+3] = uint8((a + n/2) / n)
+		}
+	}
+
+	return dst
+}
+
+// span is the block of source pixels out of size that pixel i of a result n pixels wide covers, at least one
+func span(i, n, size int) (int, int) {
+	from, to := i*size/n, (i+1)*size/n
+	if to <= from {
+		to = from + 1
+	}
+
+	return from, to
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,120 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// TestFit test function to test images are scaled down into their box keeping the aspect ratio
+func TestFit(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		w, h, box  int
+		outW, outH int
+	}{
+		{desc: "landscape", w: 4000, h: 3000, box: 1280, outW: 1280, outH: 960},
+		{desc: "portrait", w: 3000, h: 4000, box: 320, outW: 240, outH: 320},
+		{desc: "already fits", w: 800, h: 600, box: 1280, outW: 800, outH: 600},
+		{desc: "panorama keeps a pixel", w: 10000, h: 2, box: 320, outW: 320, outH: 1},
+	}
+
+	for i, tc := range testCases {
+		w, h := fit(tc.w, tc.h, tc.box)
+		if w != tc.outW || h != tc.outH {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %vx%v\n Expected %vx%v", i, tc.desc, w, h, tc.outW, tc.outH)
+		}
+	}
+}
+
+// TestResize test function to test each pixel of a resized image averages the block it covers
+func TestResize(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(10, 10, 14, 12))
+
+	for y := 10; y < 12; y++ {
+		for x := 10; x < 14; x++ {
+			if x < 12 {
+				src.Set(x, y, color.NRGBA{R: 200, A: 255})
+			} else {
+				src.Set(x, y, color.NRGBA{G: 100, A: 255})
+			}
+		}
+	}
+
+	dst := resize(src, 2, 1)
+
+	red, green := color.RGBA{R: 200, A: 255}, color.RGBA{G: 100, A: 255}
+
+	if got := dst.RGBAAt(0, 0); got != red {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "left block", got, red)
+	}
+
+	if got := dst.RGBAAt(1, 0); got != green {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 1, "right block", got, green)
+	}
+
+	half := resize(src, 1, 1).RGBAAt(0, 0)
+	if half != (color.RGBA{R: 100, G: 50, A: 255}) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 2, "whole image", half, "100,50,0,255")
+	}
+}
+
+// TestSniff test function to test the type of an upload comes from its bytes and must match the type it was sent as
+func TestSniff(t *testing.T) {
+	pngData := encodePNG(t, 2, 2)
+
+	testCases := []struct {
+		desc     string
+		data     []byte
+		declared string
+		kind     string
+		err      error
+	}{
+		{desc: "declared", data: pngData, declared: "image/png", kind: "image/png"},
+		{desc: "not declared", data: pngData, kind: "image/png"},
+		{desc: "octet stream", data: pngData, declared: "application/octet-stream", kind: "image/png"},
+		{desc: "mismatch", data: pngData, declared: "image/jpeg", err: ErrInvalidMedia},
+		{desc: "not an image", data: []byte("%PDF-1.4"), declared: "image/png", err: ErrUnsupportedMedia},
+	}
+
+	for i, tc := range testCases {
+		kind, err := sniff(tc.data, tc.declared)
+		if !errors.Is(err, tc.err) || kind != tc.kind {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v %v\n Expected %v %v", i, tc.desc, kind, err, tc.kind, tc.err)
+		}
+	}
+}
+
+// TestDecodeTooManyPixels test function to test an image claiming too many pixels is refused before it is decoded
+func TestDecodeTooManyPixels(t *testing.T) {
+	data := encodePNG(t, 1, 1)
+
+	// IHDR width is at offset 16, a 1x1 image now claims 100000x1000 pixels
+	copy(data[16:20], []byte{0x00, 0x01, 0x86, 0xa0})
+	copy(data[20:24], []byte{0x00, 0x00, 0x03, 0xe8})
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := decodeConfig(data, "image/png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = decode(data, "image/png"); !errors.Is(err, ErrInvalidMedia) {
+		t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", 0, "too many pixels", err, ErrInvalidMedia)
+	}
+}
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,352 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxSize is the largest image in bytes that may be uploaded
+	MaxSize = 10 << 20
+	// MaxPixels is the most pixels an uploaded image may have
+	MaxPixels = 40000000
+	// LargeBox is the side of the box the large rendition is resized to fit
+	LargeBox = 1280
+	// ThumbnailBox is the side of the box the thumbnail is resized to fit
+	ThumbnailBox = 320
+
+	jpegQuality = 85
+)
+
+var (
+	ErrMediaNotFound    = errors.New("media not found")
+	ErrCarNotFound      = errors.New("car not found")
+	ErrInvalidMedia     = errors.New("invalid media")
+	ErrUnsupportedMedia = errors.New("unsupported media type, upload a jpeg, png or gif image")
+	ErrMediaTooLarge    = errors.New("media too large")
+)
+
+var renditions = []string{models.RenditionOriginal, models.RenditionLarge, models.RenditionThumbnail}
+
+type mediaService struct {
+	car   datastore.Car
+	media datastore.Media
+	blob  datastore.Blob
+	now   func() time.Time
+}
+
+func New(car datastore.Car, media datastore.Media, blob datastore.Blob) mediaService { //nolint
+	return mediaService{car: car, media: media, blob: blob, now: time.Now}
+}
+
+// Upload service layer function to add an image to a car. The image is kept as sent along with a large and a
+// thumbnail rendition, and the first image of a car becomes its primary image.
+func (s mediaService) Upload(ctx context.Context, carID, fileName, contentType string, body io.Reader) (models.Media,
+	error) {
+	car, err := s.getCar(ctx, carID)
+	if err != nil {
+		return models.Media{}, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, MaxSize+1))
+
+	switch {
+	case err != nil:
+		return models.Media{}, err
+	case len(data) > MaxSize:
+		return models.Media{}, fmt.Errorf("%w: an image may be at most %d MB", ErrMediaTooLarge, MaxSize>>20)
+	case len(data) == 0:
+		return models.Media{}, fmt.Errorf("%w: the image is empty", ErrInvalidMedia)
+	}
+
+	kind, err := sniff(data, contentType)
+	if err != nil {
+		return models.Media{}, err
+	}
+
+	img, err := decode(data, kind)
+	if err != nil {
+		return models.Media{}, err
+	}
+
+	existing, err := s.media.GetCarMedia(ctx, carID)
+	if err != nil {
+		return models.Media{}, err
+	}
+
+	m := models.Media{ID: uuid.New(), CarID: car.ID, Primary: len(existing) == 0, FileName: baseName(fileName),
+		ContentType: kind, Size: int64(len(data)), Width: img.Bounds().Dx(), Height: img.Bounds().Dy(),
+		CreatedAt: s.now().UTC().Truncate(time.Second)}
+
+	for _, e := range existing {
+		if e.Position >= m.Position {
+			m.Position = e.Position + 1
+		}
+	}
+
+	blobs := map[string][]byte{models.RenditionOriginal: data}
+
+	boxes := map[string]int{models.RenditionLarge: LargeBox, models.RenditionThumbnail: ThumbnailBox}
+
+	for rendition, box := range boxes {
+		w, h := fit(m.Width, m.Height, box)
+
+		if blobs[rendition], err = encode(resize(img, w, h), renditionType(kind)); err != nil {
+			return models.Media{}, err
+		}
+	}
+
+	for _, rendition := range renditions {
+		err = s.blob.Put(ctx, key(m, rendition), typeOf(m, rendition), bytes.NewReader(blobs[rendition]))
+		if err != nil {
+			s.deleteBlobs(m)
+			return models.Media{}, err
+		}
+	}
+
+	created, err := s.media.CreateMedia(ctx, m)
+	if err != nil {
+		s.deleteBlobs(m)
+		return models.Media{}, err
+	}
+
+	created.URLs = URLs(created.ID)
+
+	return created, nil
+}
+
+// GetMedia service layer function to get the images of a car in order
+func (s mediaService) GetMedia(ctx context.Context, carID string) ([]models.Media, error) {
+	if _, err := s.getCar(ctx, carID); err != nil {
+		return nil, err
+	}
+
+	media, err := s.media.GetCarMedia(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range media {
+		media[i].URLs = URLs(media[i].ID)
+	}
+
+	return media, nil
+}
+
+// Reorder service layer function to put the images of a car in the order given, which must list every image of
+// the car once
+func (s mediaService) Reorder(ctx context.Context, carID string, ids []uuid.UUID) ([]models.Media, error) {
+	media, err := s.GetMedia(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	position := map[uuid.UUID]int{}
+
+	for i, id := range ids {
+		if _, ok := position[id]; ok {
+			return nil, fmt.Errorf("%w: image %v is listed twice", ErrInvalidMedia, id)
+		}
+
+		position[id] = i
+	}
+
+	order := make([]string, len(ids))
+
+	for i := range media {
+		p, ok := position[media[i].ID]
+		if !ok {
+			return nil, fmt.Errorf("%w: image %v is missing from the order", ErrInvalidMedia, media[i].ID)
+		}
+
+		media[i].Position = p
+		order[p] = media[i].ID.String()
+	}
+
+	if len(ids) != len(media) {
+		return nil, fmt.Errorf("%w: the order lists images the car does not have", ErrInvalidMedia)
+	}
+
+	if err = s.media.Reorder(ctx, carID, order); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(media, func(i, j int) bool { return media[i].Position < media[j].Position })
+
+	return media, nil
+}
+
+// SetPrimary service layer function to make an image the primary image of its car
+func (s mediaService) SetPrimary(ctx context.Context, carID, id string) ([]models.Media, error) {
+	media, err := s.GetMedia(ctx, carID)
+	if err != nil {
+		return nil, err
+	}
+
+	if find(media, id) < 0 {
+		return nil, ErrMediaNotFound
+	}
+
+	if err = s.media.SetPrimary(ctx, carID, id); err != nil {
+		return nil, err
+	}
+
+	for i := range media {
+		media[i].Primary = media[i].ID.String() == id
+	}
+
+	return media, nil
+}
+
+// Delete service layer function to delete an image of a car with its renditions. When the primary image is
+// deleted the next image in order takes its place.
+func (s mediaService) Delete(ctx context.Context, carID, id string) error {
+	media, err := s.GetMedia(ctx, carID)
+	if err != nil {
+		return err
+	}
+
+	i := find(media, id)
+	if i < 0 {
+		return ErrMediaNotFound
+	}
+
+	err = s.media.DeleteMedia(ctx, carID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrMediaNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.deleteBlobs(media[i])
+
+	remaining := append(media[:i:i], media[i+1:]...)
+	if media[i].Primary && len(remaining) > 0 {
+		return s.media.SetPrimary(ctx, carID, remaining[0].ID.String())
+	}
+
+	return nil
+}
+
+// Open service layer function to open a rendition of an image, the content type of the rendition is returned
+// with it
+func (s mediaService) Open(ctx context.Context, id, rendition string) (io.ReadCloser, string, error) {
+	if _, err := uuid.Parse(id); err != nil || !validRendition(rendition) {
+		return nil, "", ErrMediaNotFound
+	}
+
+	m, err := s.media.GetMedia(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, "", ErrMediaNotFound
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	r, err := s.blob.Get(ctx, key(m, rendition))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", ErrMediaNotFound
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return r, typeOf(m, rendition), nil
+}
+
+// URLs are where the renditions of the image with the id are served from
+func URLs(id uuid.UUID) models.MediaURLs {
+	base := "/media/" + id.String() + "/"
+
+	return models.MediaURLs{Original: base + models.RenditionOriginal, Large: base + models.RenditionLarge,
+		Thumbnail: base + models.RenditionThumbnail}
+}
+
+// deleteBlobs deletes the renditions of an image, a blob left behind is only logged as nothing points at it
+func (s mediaService) deleteBlobs(m models.Media) {
+	for _, rendition := range renditions {
+		if err := s.blob.Delete(context.Background(), key(m, rendition)); err != nil {
+			log.Println("Cant delete media blob", key(m, rendition), err)
+		}
+	}
+}
+
+func (s mediaService) getCar(ctx context.Context, id string) (models.Car, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Car{}, ErrCarNotFound
+	}
+
+	car, err := s.car.GetCarByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Car{}, ErrCarNotFound
+	}
+
+	return car, err
+}
+
+// key is the key of the blob of a rendition of an image
+func key(m models.Media, rendition string) string {
+	return "cars/" + m.CarID.String() + "/" + m.ID.String() + "/" + rendition + types[typeOf(m, rendition)]
+}
+
+// typeOf is the content type of a rendition of an image
+func typeOf(m models.Media, rendition string) string {
+	if rendition == models.RenditionOriginal {
+		return m.ContentType
+	}
+
+	return renditionType(m.ContentType)
+}
+
+func validRendition(rendition string) bool {
+	for _, r := range renditions {
+		if r == rendition {
+			return true
+		}
+	}
+
+	return false
+}
+
+func find(media []models.Media, id string) int {
+	for i := range media {
+		if media[i].ID.String() == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// baseName keeps the last element of an uploaded file name, whichever separator the client used
+func baseName(name string) string {
+	name = path.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[:255])
+	}
+
+	return name
+}
@@ -0,0 +1,247 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/jpeg"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func encodeJPEG(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// TestUpload service layer test function to test an upload is stored with its renditions
+func TestUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	mediaStore := datastore.NewMockMedia(ctrl)
+	blobStore := datastore.NewMockBlob(ctrl)
+	s := New(carStore, mediaStore, blobStore)
+	s.now = func() time.Time { return now }
+
+	carID := uuid.New()
+	photo := encodeJPEG(t, 1600, 1200)
+	sizes := map[string]image.Point{}
+
+	carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+	mediaStore.EXPECT().GetCarMedia(gomock.Any(), carID.String()).Return([]models.Media{{Position: 4}}, nil)
+	blobStore.EXPECT().Put(gomock.Any(), gomock.Any(), "image/jpeg", gomock.Any()).Times(3).
+		DoAndReturn(func(_ context.Context, key, _ string, body io.Reader) error {
+			cfg, err := jpeg.DecodeConfig(body)
+			sizes[key[strings.LastIndex(key, "/")+1:]] = image.Pt(cfg.Width, cfg.Height)
+
+			return err
+		})
+	mediaStore.EXPECT().CreateMedia(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, m models.Media) (models.Media, error) { return m, nil })
+
+	res, err := s.Upload(context.TODO(), carID.String(), `C:\photos\front.jpg`, "image/jpeg", bytes.NewReader(photo))
+
+	assert.NoError(t, err)
+	assert.Equal(t, carID, res.CarID)
+	assert.Equal(t, 5, res.Position)
+	assert.False(t, res.Primary)
+	assert.Equal(t, "front.jpg", res.FileName)
+	assert.Equal(t, int64(len(photo)), res.Size)
+	assert.Equal(t, 1600, res.Width)
+	assert.Equal(t, now, res.CreatedAt)
+	assert.Equal(t, "/media/"+res.ID.String()+"/thumbnail", res.URLs.Thumbnail)
+	assert.Equal(t, map[string]image.Point{"original.jpg": image.Pt(1600, 1200), "large.jpg": image.Pt(1280, 960),
+		"thumbnail.jpg": image.Pt(320, 240)}, sizes)
+}
+
+// TestUploadRejected service layer test function to test uploads which are not accepted
+func TestUploadRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	mediaStore := datastore.NewMockMedia(ctrl)
+	blobStore := datastore.NewMockBlob(ctrl)
+	s := New(carStore, mediaStore, blobStore)
+	s.now = func() time.Time { return now }
+
+	carID := uuid.New()
+	photo := encodeJPEG(t, 8, 8)
+	errDisk := errors.New("disk full")
+	found := func() {
+		carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+	}
+
+	testCases := []struct {
+		desc        string
+		body        []byte
+		contentType string
+		mock        func()
+		err         error
+	}{
+		{desc: "too large", body: make([]byte, MaxSize+1), mock: found, err: ErrMediaTooLarge},
+		{desc: "empty", body: []byte{}, mock: found, err: ErrInvalidMedia},
+		{desc: "not an image", body: []byte("hello"), mock: found, err: ErrUnsupportedMedia},
+		{desc: "wrong type", body: photo, contentType: "image/png", mock: found, err: ErrInvalidMedia},
+		{desc: "truncated", body: photo[:len(photo)/2], mock: found, err: ErrInvalidMedia},
+		{desc: "car not found", body: photo, err: ErrCarNotFound,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{}, sql.ErrNoRows)
+			}},
+		{desc: "blob store down", body: photo, err: errDisk,
+			mock: func() {
+				found()
+				mediaStore.EXPECT().GetCarMedia(gomock.Any(), carID.String()).Return([]models.Media{}, nil)
+				blobStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				blobStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errDisk)
+				blobStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(3).Return(nil)
+			}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		_, err := s.Upload(context.TODO(), carID.String(), "photo.jpg", tc.contentType, bytes.NewReader(tc.body))
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
+
+// TestArrange service layer test function to test images are reordered, made primary and deleted
+func TestArrange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	mediaStore := datastore.NewMockMedia(ctrl)
+	blobStore := datastore.NewMockBlob(ctrl)
+	s := New(carStore, mediaStore, blobStore)
+	s.now = func() time.Time { return now }
+
+	carID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	media := func() []models.Media {
+		return []models.Media{{ID: first, CarID: carID, Position: 0, Primary: true, ContentType: "image/jpeg"},
+			{ID: second, CarID: carID, Position: 1, ContentType: "image/jpeg"}}
+	}
+	listed := func() {
+		carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+		mediaStore.EXPECT().GetCarMedia(gomock.Any(), carID.String()).Return(media(), nil)
+	}
+
+	listed()
+	mediaStore.EXPECT().Reorder(gomock.Any(), carID.String(), []string{second.String(), first.String()}).Return(nil)
+
+	res, err := s.Reorder(context.TODO(), carID.String(), []uuid.UUID{second, first})
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{second, first}, []uuid.UUID{res[0].ID, res[1].ID})
+	assert.Equal(t, 0, res[0].Position)
+
+	for _, ids := range [][]uuid.UUID{{first}, {first, first}, {first, second, uuid.New()}} {
+		listed()
+
+		_, err = s.Reorder(context.TODO(), carID.String(), ids)
+		assert.ErrorIs(t, err, ErrInvalidMedia)
+	}
+
+	listed()
+	mediaStore.EXPECT().SetPrimary(gomock.Any(), carID.String(), second.String()).Return(nil)
+
+	res, err = s.SetPrimary(context.TODO(), carID.String(), second.String())
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false, true}, []bool{res[0].Primary, res[1].Primary})
+
+	listed()
+
+	_, err = s.SetPrimary(context.TODO(), carID.String(), uuid.New().String())
+	assert.ErrorIs(t, err, ErrMediaNotFound)
+
+	listed()
+	mediaStore.EXPECT().DeleteMedia(gomock.Any(), carID.String(), first.String()).Return(nil)
+	blobStore.EXPECT().Delete(gomock.Any(), "cars/"+carID.String()+"/"+first.String()+"/original.jpg").Return(nil)
+	blobStore.EXPECT().Delete(gomock.Any(), "cars/"+carID.String()+"/"+first.String()+"/large.jpg").Return(nil)
+	blobStore.EXPECT().Delete(gomock.Any(), "cars/"+carID.String()+"/"+first.String()+"/thumbnail.jpg").Return(nil)
+	mediaStore.EXPECT().SetPrimary(gomock.Any(), carID.String(), second.String()).Return(nil)
+
+	assert.NoError(t, s.Delete(context.TODO(), carID.String(), first.String()))
+}
+
+// TestOpen service layer test function to test renditions are opened from the blob store
+func TestOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mediaStore := datastore.NewMockMedia(ctrl)
+	blobStore := datastore.NewMockBlob(ctrl)
+	s := New(datastore.NewMockCar(ctrl), mediaStore, blobStore)
+	s.now = func() time.Time { return now }
+
+	id, carID := uuid.New(), uuid.New()
+	photo := models.Media{ID: id, CarID: carID, ContentType: "image/png"}
+	body := io.NopCloser(strings.NewReader("png"))
+
+	testCases := []struct {
+		desc        string
+		id          string
+		rendition   string
+		mock        func()
+		contentType string
+		err         error
+	}{
+		{desc: "thumbnail", id: id.String(), rendition: "thumbnail", contentType: "image/png",
+			mock: func() {
+				mediaStore.EXPECT().GetMedia(gomock.Any(), id.String()).Return(photo, nil)
+				blobStore.EXPECT().Get(gomock.Any(), "cars/"+carID.String()+"/"+id.String()+"/thumbnail.png").
+					Return(body, nil)
+			}},
+		{desc: "unknown rendition", id: id.String(), rendition: "huge", err: ErrMediaNotFound},
+		{desc: "bad id", id: "abc", rendition: "large", err: ErrMediaNotFound},
+		{desc: "no record", id: id.String(), rendition: "large", err: ErrMediaNotFound,
+			mock: func() {
+				mediaStore.EXPECT().GetMedia(gomock.Any(), id.String()).Return(models.Media{}, sql.ErrNoRows)
+			}},
+		{desc: "blob missing", id: id.String(), rendition: "original", err: ErrMediaNotFound,
+			mock: func() {
+				mediaStore.EXPECT().GetMedia(gomock.Any(), id.String()).Return(photo, nil)
+				blobStore.EXPECT().Get(gomock.Any(), "cars/"+carID.String()+"/"+id.String()+"/original.png").
+					Return(nil, fs.ErrNotExist)
+			}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		_, contentType, err := s.Open(context.TODO(), tc.id, tc.rendition)
+		if !errors.Is(err, tc.err) || contentType != tc.contentType {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v %v\n Expected %v %v", i, tc.desc, contentType, err,
+				tc.contentType, tc.err)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: catalogue.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockCatalogue is a mock of Catalogue interface.
+type MockCatalogue struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogueMockRecorder
+}
+
+// MockCatalogueMockRecorder is the mock recorder for MockCatalogue.
+type MockCatalogueMockRecorder struct {
+	mock *MockCatalogue
+}
+
+// NewMockCatalogue creates a new mock instance.
+func NewMockCatalogue(ctrl *gomock.Controller) *MockCatalogue {
+	mock := &MockCatalogue{ctrl: ctrl}
+	mock.recorder = &MockCatalogueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogue) EXPECT() *MockCatalogueMockRecorder {
+	return m.recorder
+}
+
+// CreateBrand mocks base method.
+func (m *MockCatalogue) CreateBrand(ctx context.Context, b models.Brand) (models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBrand", ctx, b)
+	ret0, _ := ret[0].(models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBrand indicates an expected call of CreateBrand.
+func (mr *MockCatalogueMockRecorder) CreateBrand(ctx, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBrand", reflect.TypeOf((*MockCatalogue)(nil).CreateBrand), ctx, b)
+}
+
+// CreateModel mocks base method.
+func (m_2 *MockCatalogue) CreateModel(ctx context.Context, brandID string, m models.CarModel) (models.CarModel, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "CreateModel", ctx, brandID, m)
+	ret0, _ := ret[0].(models.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateModel indicates an expected call of CreateModel.
+func (mr *MockCatalogueMockRecorder) CreateModel(ctx, brandID, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModel", reflect.TypeOf((*MockCatalogue)(nil).CreateModel), ctx, brandID, m)
+}
+
+// CreateTrim mocks base method.
+func (m *MockCatalogue) CreateTrim(ctx context.Context, modelID string, t models.Trim) (models.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrim", ctx, modelID, t)
+	ret0, _ := ret[0].(models.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTrim indicates an expected call of CreateTrim.
+func (mr *MockCatalogueMockRecorder) CreateTrim(ctx, modelID, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrim", reflect.TypeOf((*MockCatalogue)(nil).CreateTrim), ctx, modelID, t)
+}
+
+// DeleteBrand mocks base method.
+func (m *MockCatalogue) DeleteBrand(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBrand", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBrand indicates an expected call of DeleteBrand.
+func (mr *MockCatalogueMockRecorder) DeleteBrand(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBrand", reflect.TypeOf((*MockCatalogue)(nil).DeleteBrand), ctx, id)
+}
+
+// DeleteModel mocks base method.
+func (m *MockCatalogue) DeleteModel(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteModel", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteModel indicates an expected call of DeleteModel.
+func (mr *MockCatalogueMockRecorder) DeleteModel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteModel", reflect.TypeOf((*MockCatalogue)(nil).DeleteModel), ctx, id)
+}
+
+// DeleteTrim mocks base method.
+func (m *MockCatalogue) DeleteTrim(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrim", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrim indicates an expected call of DeleteTrim.
+func (mr *MockCatalogueMockRecorder) DeleteTrim(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrim", reflect.TypeOf((*MockCatalogue)(nil).DeleteTrim), ctx, id)
+}
+
+// GetBrand mocks base method.
+func (m *MockCatalogue) GetBrand(ctx context.Context, id string) (models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrand", ctx, id)
+	ret0, _ := ret[0].(models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrand indicates an expected call of GetBrand.
+func (mr *MockCatalogueMockRecorder) GetBrand(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrand", reflect.TypeOf((*MockCatalogue)(nil).GetBrand), ctx, id)
+}
+
+// GetBrands mocks base method.
+func (m *MockCatalogue) GetBrands(ctx context.Context) ([]models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrands", ctx)
+	ret0, _ := ret[0].([]models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrands indicates an expected call of GetBrands.
+func (mr *MockCatalogueMockRecorder) GetBrands(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrands", reflect.TypeOf((*MockCatalogue)(nil).GetBrands), ctx)
+}
+
+// GetModel mocks base method.
+func (m *MockCatalogue) GetModel(ctx context.Context, id string) (models.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModel", ctx, id)
+	ret0, _ := ret[0].(models.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModel indicates an expected call of GetModel.
+func (mr *MockCatalogueMockRecorder) GetModel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModel", reflect.TypeOf((*MockCatalogue)(nil).GetModel), ctx, id)
+}
+
+// GetModels mocks base method.
+func (m *MockCatalogue) GetModels(ctx context.Context, brandID string) ([]models.CarModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModels", ctx, brandID)
+	ret0, _ := ret[0].([]models.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModels indicates an expected call of GetModels.
+func (mr *MockCatalogueMockRecorder) GetModels(ctx, brandID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModels", reflect.TypeOf((*MockCatalogue)(nil).GetModels), ctx, brandID)
+}
+
+// GetTrim mocks base method.
+func (m *MockCatalogue) GetTrim(ctx context.Context, id string) (models.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrim", ctx, id)
+	ret0, _ := ret[0].(models.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrim indicates an expected call of GetTrim.
+func (mr *MockCatalogueMockRecorder) GetTrim(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrim", reflect.TypeOf((*MockCatalogue)(nil).GetTrim), ctx, id)
+}
+
+// GetTrims mocks base method.
+func (m *MockCatalogue) GetTrims(ctx context.Context, modelID string) ([]models.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrims", ctx, modelID)
+	ret0, _ := ret[0].([]models.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrims indicates an expected call of GetTrims.
+func (mr *MockCatalogueMockRecorder) GetTrims(ctx, modelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrims", reflect.TypeOf((*MockCatalogue)(nil).GetTrims), ctx, modelID)
+}
+
+// MigrateBrands mocks base method.
+func (m *MockCatalogue) MigrateBrands(ctx context.Context, dryRun, createMissing bool) (models.BrandMigration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrateBrands", ctx, dryRun, createMissing)
+	ret0, _ := ret[0].(models.BrandMigration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MigrateBrands indicates an expected call of MigrateBrands.
+func (mr *MockCatalogueMockRecorder) MigrateBrands(ctx, dryRun, createMissing interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateBrands", reflect.TypeOf((*MockCatalogue)(nil).MigrateBrands), ctx, dryRun, createMissing)
+}
+
+// UpdateBrand mocks base method.
+func (m *MockCatalogue) UpdateBrand(ctx context.Context, id string, b models.Brand) (models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBrand", ctx, id, b)
+	ret0, _ := ret[0].(models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBrand indicates an expected call of UpdateBrand.
+func (mr *MockCatalogueMockRecorder) UpdateBrand(ctx, id, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBrand", reflect.TypeOf((*MockCatalogue)(nil).UpdateBrand), ctx, id, b)
+}
+
+// UpdateModel mocks base method.
+func (m_2 *MockCatalogue) UpdateModel(ctx context.Context, id string, m models.CarModel) (models.CarModel, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "UpdateModel", ctx, id, m)
+	ret0, _ := ret[0].(models.CarModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateModel indicates an expected call of UpdateModel.
+func (mr *MockCatalogueMockRecorder) UpdateModel(ctx, id, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateModel", reflect.TypeOf((*MockCatalogue)(nil).UpdateModel), ctx, id, m)
+}
+
+// UpdateTrim mocks base method.
+func (m *MockCatalogue) UpdateTrim(ctx context.Context, id string, t models.Trim) (models.Trim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrim", ctx, id, t)
+	ret0, _ := ret[0].(models.Trim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTrim indicates an expected call of UpdateTrim.
+func (mr *MockCatalogueMockRecorder) UpdateTrim(ctx, id, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrim", reflect.TypeOf((*MockCatalogue)(nil).UpdateTrim), ctx, id, t)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comparer.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockComparer is a mock of Comparer interface.
+type MockComparer struct {
+	ctrl     *gomock.Controller
+	recorder *MockComparerMockRecorder
+}
+
+// MockComparerMockRecorder is the mock recorder for MockComparer.
+type MockComparerMockRecorder struct {
+	mock *MockComparer
+}
+
+// NewMockComparer creates a new mock instance.
+func NewMockComparer(ctrl *gomock.Controller) *MockComparer {
+	mock := &MockComparer{ctrl: ctrl}
+	mock.recorder = &MockComparerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComparer) EXPECT() *MockComparerMockRecorder {
+	return m.recorder
+}
+
+// Compare mocks base method.
+func (m *MockComparer) Compare(ctx context.Context, ids []string) (models.Comparison, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compare", ctx, ids)
+	ret0, _ := ret[0].(models.Comparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compare indicates an expected call of Compare.
+func (mr *MockComparerMockRecorder) Compare(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockComparer)(nil).Compare), ctx, ids)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: customers.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockCustomers is a mock of Customers interface.
+type MockCustomers struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomersMockRecorder
+}
+
+// MockCustomersMockRecorder is the mock recorder for MockCustomers.
+type MockCustomersMockRecorder struct {
+	mock *MockCustomers
+}
+
+// NewMockCustomers creates a new mock instance.
+func NewMockCustomers(ctrl *gomock.Controller) *MockCustomers {
+	mock := &MockCustomers{ctrl: ctrl}
+	mock.recorder = &MockCustomersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomers) EXPECT() *MockCustomersMockRecorder {
+	return m.recorder
+}
+
+// CreateCustomer mocks base method.
+func (m *MockCustomers) CreateCustomer(ctx context.Context, c models.Customer) (models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomer", ctx, c)
+	ret0, _ := ret[0].(models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomer indicates an expected call of CreateCustomer.
+func (mr *MockCustomersMockRecorder) CreateCustomer(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomer", reflect.TypeOf((*MockCustomers)(nil).CreateCustomer), ctx, c)
+}
+
+// GetCustomer mocks base method.
+func (m *MockCustomers) GetCustomer(ctx context.Context, id string) (models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomer", ctx, id)
+	ret0, _ := ret[0].(models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomer indicates an expected call of GetCustomer.
+func (mr *MockCustomersMockRecorder) GetCustomer(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomer", reflect.TypeOf((*MockCustomers)(nil).GetCustomer), ctx, id)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: finance.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockFinance is a mock of Finance interface.
+type MockFinance struct {
+	ctrl     *gomock.Controller
+	recorder *MockFinanceMockRecorder
+}
+
+// MockFinanceMockRecorder is the mock recorder for MockFinance.
+type MockFinanceMockRecorder struct {
+	mock *MockFinance
+}
+
+// NewMockFinance creates a new mock instance.
+func NewMockFinance(ctrl *gomock.Controller) *MockFinance {
+	mock := &MockFinance{ctrl: ctrl}
+	mock.recorder = &MockFinanceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFinance) EXPECT() *MockFinanceMockRecorder {
+	return m.recorder
+}
+
+// GetRates mocks base method.
+func (m *MockFinance) GetRates(ctx context.Context, lender string) ([]models.LenderRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates", ctx, lender)
+	ret0, _ := ret[0].([]models.LenderRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates.
+func (mr *MockFinanceMockRecorder) GetRates(ctx, lender interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockFinance)(nil).GetRates), ctx, lender)
+}
+
+// Offers mocks base method.
+func (m *MockFinance) Offers(ctx context.Context, req models.LoanRequest) ([]models.LoanQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Offers", ctx, req)
+	ret0, _ := ret[0].([]models.LoanQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Offers indicates an expected call of Offers.
+func (mr *MockFinanceMockRecorder) Offers(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Offers", reflect.TypeOf((*MockFinance)(nil).Offers), ctx, req)
+}
+
+// Quote mocks base method.
+func (m *MockFinance) Quote(ctx context.Context, req models.LoanRequest) (models.LoanQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, req)
+	ret0, _ := ret[0].(models.LoanQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockFinanceMockRecorder) Quote(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockFinance)(nil).Quote), ctx, req)
+}
+
+// SetRates mocks base method.
+func (m *MockFinance) SetRates(ctx context.Context, lender string, rates []models.LenderRate) ([]models.LenderRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRates", ctx, lender, rates)
+	ret0, _ := ret[0].([]models.LenderRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRates indicates an expected call of SetRates.
+func (mr *MockFinanceMockRecorder) SetRates(ctx, lender, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRates", reflect.TypeOf((*MockFinance)(nil).SetRates), ctx, lender, rates)
+}
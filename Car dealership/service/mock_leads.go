@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: leads.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockLeads is a mock of Leads interface.
+type MockLeads struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeadsMockRecorder
+}
+
+// MockLeadsMockRecorder is the mock recorder for MockLeads.
+type MockLeadsMockRecorder struct {
+	mock *MockLeads
+}
+
+// NewMockLeads creates a new mock instance.
+func NewMockLeads(ctrl *gomock.Controller) *MockLeads {
+	mock := &MockLeads{ctrl: ctrl}
+	mock.recorder = &MockLeadsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLeads) EXPECT() *MockLeadsMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockLeads) Assign(ctx context.Context, id, salesperson string) (models.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, id, salesperson)
+	ret0, _ := ret[0].(models.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockLeadsMockRecorder) Assign(ctx, id, salesperson interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockLeads)(nil).Assign), ctx, id, salesperson)
+}
+
+// Capture mocks base method.
+func (m *MockLeads) Capture(ctx context.Context, l models.Lead) (models.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", ctx, l)
+	ret0, _ := ret[0].(models.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockLeadsMockRecorder) Capture(ctx, l interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockLeads)(nil).Capture), ctx, l)
+}
+
+// GetLead mocks base method.
+func (m *MockLeads) GetLead(ctx context.Context, id string) (models.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLead", ctx, id)
+	ret0, _ := ret[0].(models.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLead indicates an expected call of GetLead.
+func (mr *MockLeadsMockRecorder) GetLead(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLead", reflect.TypeOf((*MockLeads)(nil).GetLead), ctx, id)
+}
+
+// GetLeads mocks base method.
+func (m *MockLeads) GetLeads(ctx context.Context, filter models.LeadFilter) ([]models.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeads", ctx, filter)
+	ret0, _ := ret[0].([]models.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLeads indicates an expected call of GetLeads.
+func (mr *MockLeadsMockRecorder) GetLeads(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeads", reflect.TypeOf((*MockLeads)(nil).GetLeads), ctx, filter)
+}
+
+// Transition mocks base method.
+func (m *MockLeads) Transition(ctx context.Context, id, status string) (models.Lead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", ctx, id, status)
+	ret0, _ := ret[0].(models.Lead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockLeadsMockRecorder) Transition(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockLeads)(nil).Transition), ctx, id, status)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: listings.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockListings is a mock of Listings interface.
+type MockListings struct {
+	ctrl     *gomock.Controller
+	recorder *MockListingsMockRecorder
+}
+
+// MockListingsMockRecorder is the mock recorder for MockListings.
+type MockListingsMockRecorder struct {
+	mock *MockListings
+}
+
+// NewMockListings creates a new mock instance.
+func NewMockListings(ctrl *gomock.Controller) *MockListings {
+	mock := &MockListings{ctrl: ctrl}
+	mock.recorder = &MockListingsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListings) EXPECT() *MockListingsMockRecorder {
+	return m.recorder
+}
+
+// ListCars mocks base method.
+func (m *MockListings) ListCars(ctx context.Context, filter models.CarFilter, page models.Page, isEngine bool) (models.CarPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCars", ctx, filter, page, isEngine)
+	ret0, _ := ret[0].(models.CarPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCars indicates an expected call of ListCars.
+func (mr *MockListingsMockRecorder) ListCars(ctx, filter, page, isEngine interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCars", reflect.TypeOf((*MockListings)(nil).ListCars), ctx, filter, page, isEngine)
+}
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: maintenance.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockMaintenance is a mock of Maintenance interface.
+type MockMaintenance struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceMockRecorder
+}
+
+// MockMaintenanceMockRecorder is the mock recorder for MockMaintenance.
+type MockMaintenanceMockRecorder struct {
+	mock *MockMaintenance
+}
+
+// NewMockMaintenance creates a new mock instance.
+func NewMockMaintenance(ctrl *gomock.Controller) *MockMaintenance {
+	mock := &MockMaintenance{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenance) EXPECT() *MockMaintenanceMockRecorder {
+	return m.recorder
+}
+
+// Book mocks base method.
+func (m *MockMaintenance) Book(ctx context.Context, a models.ServiceAppointment) (models.ServiceAppointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Book", ctx, a)
+	ret0, _ := ret[0].(models.ServiceAppointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Book indicates an expected call of Book.
+func (mr *MockMaintenanceMockRecorder) Book(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Book", reflect.TypeOf((*MockMaintenance)(nil).Book), ctx, a)
+}
+
+// CancelAppointment mocks base method.
+func (m *MockMaintenance) CancelAppointment(ctx context.Context, id string) (models.ServiceAppointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelAppointment", ctx, id)
+	ret0, _ := ret[0].(models.ServiceAppointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelAppointment indicates an expected call of CancelAppointment.
+func (mr *MockMaintenanceMockRecorder) CancelAppointment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAppointment", reflect.TypeOf((*MockMaintenance)(nil).CancelAppointment), ctx, id)
+}
+
+// Complete mocks base method.
+func (m *MockMaintenance) Complete(ctx context.Context, appointmentID string, r models.MaintenanceRecord) (models.MaintenanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, appointmentID, r)
+	ret0, _ := ret[0].(models.MaintenanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockMaintenanceMockRecorder) Complete(ctx, appointmentID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockMaintenance)(nil).Complete), ctx, appointmentID, r)
+}
+
+// GetAppointment mocks base method.
+func (m *MockMaintenance) GetAppointment(ctx context.Context, id string) (models.ServiceAppointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppointment", ctx, id)
+	ret0, _ := ret[0].(models.ServiceAppointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppointment indicates an expected call of GetAppointment.
+func (mr *MockMaintenanceMockRecorder) GetAppointment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppointment", reflect.TypeOf((*MockMaintenance)(nil).GetAppointment), ctx, id)
+}
+
+// GetAppointments mocks base method.
+func (m *MockMaintenance) GetAppointments(ctx context.Context, carID string) ([]models.ServiceAppointment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppointments", ctx, carID)
+	ret0, _ := ret[0].([]models.ServiceAppointment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppointments indicates an expected call of GetAppointments.
+func (mr *MockMaintenanceMockRecorder) GetAppointments(ctx, carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppointments", reflect.TypeOf((*MockMaintenance)(nil).GetAppointments), ctx, carID)
+}
+
+// GetHistory mocks base method.
+func (m *MockMaintenance) GetHistory(ctx context.Context, carID string) ([]models.MaintenanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, carID)
+	ret0, _ := ret[0].([]models.MaintenanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockMaintenanceMockRecorder) GetHistory(ctx, carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockMaintenance)(nil).GetHistory), ctx, carID)
+}
+
+// GetReminder mocks base method.
+func (m *MockMaintenance) GetReminder(ctx context.Context, carID string) (models.ServiceReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReminder", ctx, carID)
+	ret0, _ := ret[0].(models.ServiceReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReminder indicates an expected call of GetReminder.
+func (mr *MockMaintenanceMockRecorder) GetReminder(ctx, carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReminder", reflect.TypeOf((*MockMaintenance)(nil).GetReminder), ctx, carID)
+}
+
+// Record mocks base method.
+func (m *MockMaintenance) Record(ctx context.Context, carID string, r models.MaintenanceRecord) (models.MaintenanceRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, carID, r)
+	ret0, _ := ret[0].(models.MaintenanceRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockMaintenanceMockRecorder) Record(ctx, carID, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockMaintenance)(nil).Record), ctx, carID, r)
+}
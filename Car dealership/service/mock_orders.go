@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: orders.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockOrders is a mock of Orders interface.
+type MockOrders struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrdersMockRecorder
+}
+
+// MockOrdersMockRecorder is the mock recorder for MockOrders.
+type MockOrdersMockRecorder struct {
+	mock *MockOrders
+}
+
+// NewMockOrders creates a new mock instance.
+func NewMockOrders(ctrl *gomock.Controller) *MockOrders {
+	mock := &MockOrders{ctrl: ctrl}
+	mock.recorder = &MockOrdersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrders) EXPECT() *MockOrdersMockRecorder {
+	return m.recorder
+}
+
+// AddPayment mocks base method.
+func (m *MockOrders) AddPayment(ctx context.Context, id string, p models.Payment) (models.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPayment", ctx, id, p)
+	ret0, _ := ret[0].(models.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPayment indicates an expected call of AddPayment.
+func (mr *MockOrdersMockRecorder) AddPayment(ctx, id, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPayment", reflect.TypeOf((*MockOrders)(nil).AddPayment), ctx, id, p)
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrders) CancelOrder(ctx context.Context, id string) (models.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, id)
+	ret0, _ := ret[0].(models.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrdersMockRecorder) CancelOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrders)(nil).CancelOrder), ctx, id)
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrders) CreateOrder(ctx context.Context, o models.SalesOrder) (models.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, o)
+	ret0, _ := ret[0].(models.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrdersMockRecorder) CreateOrder(ctx, o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrders)(nil).CreateOrder), ctx, o)
+}
+
+// GetInvoice mocks base method.
+func (m *MockOrders) GetInvoice(ctx context.Context, id string) (models.Invoice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvoice", ctx, id)
+	ret0, _ := ret[0].(models.Invoice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvoice indicates an expected call of GetInvoice.
+func (mr *MockOrdersMockRecorder) GetInvoice(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvoice", reflect.TypeOf((*MockOrders)(nil).GetInvoice), ctx, id)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrders) GetOrder(ctx context.Context, id string) (models.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id)
+	ret0, _ := ret[0].(models.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrdersMockRecorder) GetOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrders)(nil).GetOrder), ctx, id)
+}
+
+// GetOrders mocks base method.
+func (m *MockOrders) GetOrders(ctx context.Context, filter models.OrderFilter) ([]models.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", ctx, filter)
+	ret0, _ := ret[0].([]models.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockOrdersMockRecorder) GetOrders(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockOrders)(nil).GetOrders), ctx, filter)
+}
+
+// UpdateOrder mocks base method.
+func (m *MockOrders) UpdateOrder(ctx context.Context, id string, o models.SalesOrder) (models.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrder", ctx, id, o)
+	ret0, _ := ret[0].(models.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrder indicates an expected call of UpdateOrder.
+func (mr *MockOrdersMockRecorder) UpdateOrder(ctx, id, o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrder", reflect.TypeOf((*MockOrders)(nil).UpdateOrder), ctx, id, o)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: prices.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockPrices is a mock of Prices interface.
+type MockPrices struct {
+	ctrl     *gomock.Controller
+	recorder *MockPricesMockRecorder
+}
+
+// MockPricesMockRecorder is the mock recorder for MockPrices.
+type MockPricesMockRecorder struct {
+	mock *MockPrices
+}
+
+// NewMockPrices creates a new mock instance.
+func NewMockPrices(ctrl *gomock.Controller) *MockPrices {
+	mock := &MockPrices{ctrl: ctrl}
+	mock.recorder = &MockPricesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrices) EXPECT() *MockPricesMockRecorder {
+	return m.recorder
+}
+
+// GetPrice mocks base method.
+func (m *MockPrices) GetPrice(ctx context.Context, id string) (models.Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrice", ctx, id)
+	ret0, _ := ret[0].(models.Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrice indicates an expected call of GetPrice.
+func (mr *MockPricesMockRecorder) GetPrice(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrice", reflect.TypeOf((*MockPrices)(nil).GetPrice), ctx, id)
+}
+
+// GetPriceHistory mocks base method.
+func (m *MockPrices) GetPriceHistory(ctx context.Context, id string) ([]models.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceHistory", ctx, id)
+	ret0, _ := ret[0].([]models.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceHistory indicates an expected call of GetPriceHistory.
+func (mr *MockPricesMockRecorder) GetPriceHistory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceHistory", reflect.TypeOf((*MockPrices)(nil).GetPriceHistory), ctx, id)
+}
+
+// SetPrice mocks base method.
+func (m *MockPrices) SetPrice(ctx context.Context, id string, change models.PriceChange) (models.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrice", ctx, id, change)
+	ret0, _ := ret[0].(models.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPrice indicates an expected call of SetPrice.
+func (mr *MockPricesMockRecorder) SetPrice(ctx, id, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrice", reflect.TypeOf((*MockPrices)(nil).SetPrice), ctx, id, change)
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: quotas.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockQuotas is a mock of Quotas interface.
+type MockQuotas struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuotasMockRecorder
+}
+
+// MockQuotasMockRecorder is the mock recorder for MockQuotas.
+type MockQuotasMockRecorder struct {
+	mock *MockQuotas
+}
+
+// NewMockQuotas creates a new mock instance.
+func NewMockQuotas(ctrl *gomock.Controller) *MockQuotas {
+	mock := &MockQuotas{ctrl: ctrl}
+	mock.recorder = &MockQuotasMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuotas) EXPECT() *MockQuotasMockRecorder {
+	return m.recorder
+}
+
+// Usage mocks base method.
+func (m *MockQuotas) Usage(ctx context.Context, day time.Time) ([]models.QuotaUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", ctx, day)
+	ret0, _ := ret[0].([]models.QuotaUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockQuotasMockRecorder) Usage(ctx, day interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockQuotas)(nil).Usage), ctx, day)
+}
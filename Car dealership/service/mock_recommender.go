@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: recommender.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockRecommender is a mock of Recommender interface.
+type MockRecommender struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommenderMockRecorder
+}
+
+// MockRecommenderMockRecorder is the mock recorder for MockRecommender.
+type MockRecommenderMockRecorder struct {
+	mock *MockRecommender
+}
+
+// NewMockRecommender creates a new mock instance.
+func NewMockRecommender(ctrl *gomock.Controller) *MockRecommender {
+	mock := &MockRecommender{ctrl: ctrl}
+	mock.recorder = &MockRecommenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommender) EXPECT() *MockRecommenderMockRecorder {
+	return m.recorder
+}
+
+// Similar mocks base method.
+func (m *MockRecommender) Similar(ctx context.Context, carID, weights string, limit int) (models.Recommendations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Similar", ctx, carID, weights, limit)
+	ret0, _ := ret[0].(models.Recommendations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Similar indicates an expected call of Similar.
+func (mr *MockRecommenderMockRecorder) Similar(ctx, carID, weights, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Similar", reflect.TypeOf((*MockRecommender)(nil).Similar), ctx, carID, weights, limit)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reservations.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockReservations is a mock of Reservations interface.
+type MockReservations struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationsMockRecorder
+}
+
+// MockReservationsMockRecorder is the mock recorder for MockReservations.
+type MockReservationsMockRecorder struct {
+	mock *MockReservations
+}
+
+// NewMockReservations creates a new mock instance.
+func NewMockReservations(ctrl *gomock.Controller) *MockReservations {
+	mock := &MockReservations{ctrl: ctrl}
+	mock.recorder = &MockReservationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservations) EXPECT() *MockReservationsMockRecorder {
+	return m.recorder
+}
+
+// Extend mocks base method.
+func (m *MockReservations) Extend(ctx context.Context, id string, expiresAt time.Time) (models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extend", ctx, id, expiresAt)
+	ret0, _ := ret[0].(models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Extend indicates an expected call of Extend.
+func (mr *MockReservationsMockRecorder) Extend(ctx, id, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockReservations)(nil).Extend), ctx, id, expiresAt)
+}
+
+// GetReservation mocks base method.
+func (m *MockReservations) GetReservation(ctx context.Context, id string) (models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservation", ctx, id)
+	ret0, _ := ret[0].(models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservation indicates an expected call of GetReservation.
+func (mr *MockReservationsMockRecorder) GetReservation(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservation", reflect.TypeOf((*MockReservations)(nil).GetReservation), ctx, id)
+}
+
+// Hold mocks base method.
+func (m *MockReservations) Hold(ctx context.Context, r models.Reservation) (models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hold", ctx, r)
+	ret0, _ := ret[0].(models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockReservationsMockRecorder) Hold(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockReservations)(nil).Hold), ctx, r)
+}
+
+// Release mocks base method.
+func (m *MockReservations) Release(ctx context.Context, id string) (models.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, id)
+	ret0, _ := ret[0].(models.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockReservationsMockRecorder) Release(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockReservations)(nil).Release), ctx, id)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statuses.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockStatuses is a mock of Statuses interface.
+type MockStatuses struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusesMockRecorder
+}
+
+// MockStatusesMockRecorder is the mock recorder for MockStatuses.
+type MockStatusesMockRecorder struct {
+	mock *MockStatuses
+}
+
+// NewMockStatuses creates a new mock instance.
+func NewMockStatuses(ctrl *gomock.Controller) *MockStatuses {
+	mock := &MockStatuses{ctrl: ctrl}
+	mock.recorder = &MockStatusesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatuses) EXPECT() *MockStatusesMockRecorder {
+	return m.recorder
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockStatuses) GetStatusHistory(ctx context.Context, id string) ([]models.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", ctx, id)
+	ret0, _ := ret[0].([]models.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockStatusesMockRecorder) GetStatusHistory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockStatuses)(nil).GetStatusHistory), ctx, id)
+}
+
+// Transition mocks base method.
+func (m *MockStatuses) Transition(ctx context.Context, id string, change models.StatusChange) (models.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", ctx, id, change)
+	ret0, _ := ret[0].(models.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockStatusesMockRecorder) Transition(ctx, id, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*MockStatuses)(nil).Transition), ctx, id, change)
+}
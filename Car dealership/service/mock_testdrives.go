@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: testdrives.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockTestDrives is a mock of TestDrives interface.
+type MockTestDrives struct {
+	ctrl     *gomock.Controller
+	recorder *MockTestDrivesMockRecorder
+}
+
+// MockTestDrivesMockRecorder is the mock recorder for MockTestDrives.
+type MockTestDrivesMockRecorder struct {
+	mock *MockTestDrives
+}
+
+// NewMockTestDrives creates a new mock instance.
+func NewMockTestDrives(ctrl *gomock.Controller) *MockTestDrives {
+	mock := &MockTestDrives{ctrl: ctrl}
+	mock.recorder = &MockTestDrivesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTestDrives) EXPECT() *MockTestDrivesMockRecorder {
+	return m.recorder
+}
+
+// Book mocks base method.
+func (m *MockTestDrives) Book(ctx context.Context, d models.TestDrive) (models.TestDrive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Book", ctx, d)
+	ret0, _ := ret[0].(models.TestDrive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Book indicates an expected call of Book.
+func (mr *MockTestDrivesMockRecorder) Book(ctx, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Book", reflect.TypeOf((*MockTestDrives)(nil).Book), ctx, d)
+}
+
+// Calendar mocks base method.
+func (m *MockTestDrives) Calendar(ctx context.Context, salesperson string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Calendar", ctx, salesperson)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Calendar indicates an expected call of Calendar.
+func (mr *MockTestDrivesMockRecorder) Calendar(ctx, salesperson interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Calendar", reflect.TypeOf((*MockTestDrives)(nil).Calendar), ctx, salesperson)
+}
+
+// Cancel mocks base method.
+func (m *MockTestDrives) Cancel(ctx context.Context, id string) (models.TestDrive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, id)
+	ret0, _ := ret[0].(models.TestDrive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockTestDrivesMockRecorder) Cancel(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockTestDrives)(nil).Cancel), ctx, id)
+}
+
+// GetSlots mocks base method.
+func (m *MockTestDrives) GetSlots(ctx context.Context, carID, date string, length time.Duration, salesperson string) ([]models.Slot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlots", ctx, carID, date, length, salesperson)
+	ret0, _ := ret[0].([]models.Slot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlots indicates an expected call of GetSlots.
+func (mr *MockTestDrivesMockRecorder) GetSlots(ctx, carID, date, length, salesperson interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlots", reflect.TypeOf((*MockTestDrives)(nil).GetSlots), ctx, carID, date, length, salesperson)
+}
+
+// GetTestDrive mocks base method.
+func (m *MockTestDrives) GetTestDrive(ctx context.Context, id string) (models.TestDrive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTestDrive", ctx, id)
+	ret0, _ := ret[0].(models.TestDrive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTestDrive indicates an expected call of GetTestDrive.
+func (mr *MockTestDrivesMockRecorder) GetTestDrive(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestDrive", reflect.TypeOf((*MockTestDrives)(nil).GetTestDrive), ctx, id)
+}
+
+// Reschedule mocks base method.
+func (m *MockTestDrives) Reschedule(ctx context.Context, id string, d models.TestDrive) (models.TestDrive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", ctx, id, d)
+	ret0, _ := ret[0].(models.TestDrive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockTestDrivesMockRecorder) Reschedule(ctx, id, d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockTestDrives)(nil).Reschedule), ctx, id, d)
+}
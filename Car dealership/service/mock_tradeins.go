@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: tradeins.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockTradeIns is a mock of TradeIns interface.
+type MockTradeIns struct {
+	ctrl     *gomock.Controller
+	recorder *MockTradeInsMockRecorder
+}
+
+// MockTradeInsMockRecorder is the mock recorder for MockTradeIns.
+type MockTradeInsMockRecorder struct {
+	mock *MockTradeIns
+}
+
+// NewMockTradeIns creates a new mock instance.
+func NewMockTradeIns(ctrl *gomock.Controller) *MockTradeIns {
+	mock := &MockTradeIns{ctrl: ctrl}
+	mock.recorder = &MockTradeInsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTradeIns) EXPECT() *MockTradeInsMockRecorder {
+	return m.recorder
+}
+
+// Appraise mocks base method.
+func (m *MockTradeIns) Appraise(ctx context.Context, a models.Appraisal) (models.Appraisal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Appraise", ctx, a)
+	ret0, _ := ret[0].(models.Appraisal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Appraise indicates an expected call of Appraise.
+func (mr *MockTradeInsMockRecorder) Appraise(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Appraise", reflect.TypeOf((*MockTradeIns)(nil).Appraise), ctx, a)
+}
+
+// Estimate mocks base method.
+func (m *MockTradeIns) Estimate(ctx context.Context, v models.TradeInVehicle) (models.Valuation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Estimate", ctx, v)
+	ret0, _ := ret[0].(models.Valuation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Estimate indicates an expected call of Estimate.
+func (mr *MockTradeInsMockRecorder) Estimate(ctx, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Estimate", reflect.TypeOf((*MockTradeIns)(nil).Estimate), ctx, v)
+}
+
+// GetAppraisal mocks base method.
+func (m *MockTradeIns) GetAppraisal(ctx context.Context, id string) (models.Appraisal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppraisal", ctx, id)
+	ret0, _ := ret[0].(models.Appraisal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppraisal indicates an expected call of GetAppraisal.
+func (mr *MockTradeInsMockRecorder) GetAppraisal(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppraisal", reflect.TypeOf((*MockTradeIns)(nil).GetAppraisal), ctx, id)
+}
+
+// GetAppraisals mocks base method.
+func (m *MockTradeIns) GetAppraisals(ctx context.Context, customerID string) ([]models.Appraisal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppraisals", ctx, customerID)
+	ret0, _ := ret[0].([]models.Appraisal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppraisals indicates an expected call of GetAppraisals.
+func (mr *MockTradeInsMockRecorder) GetAppraisals(ctx, customerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppraisals", reflect.TypeOf((*MockTradeIns)(nil).GetAppraisals), ctx, customerID)
+}
+
+// GetCurves mocks base method.
+func (m *MockTradeIns) GetCurves(ctx context.Context) ([]models.DepreciationCurve, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurves", ctx)
+	ret0, _ := ret[0].([]models.DepreciationCurve)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurves indicates an expected call of GetCurves.
+func (mr *MockTradeInsMockRecorder) GetCurves(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurves", reflect.TypeOf((*MockTradeIns)(nil).GetCurves), ctx)
+}
+
+// SetCurve mocks base method.
+func (m *MockTradeIns) SetCurve(ctx context.Context, c models.DepreciationCurve) (models.DepreciationCurve, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCurve", ctx, c)
+	ret0, _ := ret[0].(models.DepreciationCurve)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCurve indicates an expected call of SetCurve.
+func (mr *MockTradeInsMockRecorder) SetCurve(ctx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurve", reflect.TypeOf((*MockTradeIns)(nil).SetCurve), ctx, c)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vins.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockVINs is a mock of VINs interface.
+type MockVINs struct {
+	ctrl     *gomock.Controller
+	recorder *MockVINsMockRecorder
+}
+
+// MockVINsMockRecorder is the mock recorder for MockVINs.
+type MockVINsMockRecorder struct {
+	mock *MockVINs
+}
+
+// NewMockVINs creates a new mock instance.
+func NewMockVINs(ctrl *gomock.Controller) *MockVINs {
+	mock := &MockVINs{ctrl: ctrl}
+	mock.recorder = &MockVINsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVINs) EXPECT() *MockVINsMockRecorder {
+	return m.recorder
+}
+
+// Decode mocks base method.
+func (m *MockVINs) Decode(ctx context.Context, vin string) (models.VINInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decode", ctx, vin)
+	ret0, _ := ret[0].(models.VINInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decode indicates an expected call of Decode.
+func (mr *MockVINsMockRecorder) Decode(ctx, vin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decode", reflect.TypeOf((*MockVINs)(nil).Decode), ctx, vin)
+}
+
+// GetCarByVIN mocks base method.
+func (m *MockVINs) GetCarByVIN(ctx context.Context, vin string) (models.Car, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarByVIN", ctx, vin)
+	ret0, _ := ret[0].(models.Car)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarByVIN indicates an expected call of GetCarByVIN.
+func (mr *MockVINsMockRecorder) GetCarByVIN(ctx, vin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarByVIN", reflect.TypeOf((*MockVINs)(nil).GetCarByVIN), ctx, vin)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: warranties.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// MockWarranties is a mock of Warranties interface.
+type MockWarranties struct {
+	ctrl     *gomock.Controller
+	recorder *MockWarrantiesMockRecorder
+}
+
+// MockWarrantiesMockRecorder is the mock recorder for MockWarranties.
+type MockWarrantiesMockRecorder struct {
+	mock *MockWarranties
+}
+
+// NewMockWarranties creates a new mock instance.
+func NewMockWarranties(ctrl *gomock.Controller) *MockWarranties {
+	mock := &MockWarranties{ctrl: ctrl}
+	mock.recorder = &MockWarrantiesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWarranties) EXPECT() *MockWarrantiesMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockWarranties) Attach(ctx context.Context, carID string, w models.Warranty) (models.Warranty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", ctx, carID, w)
+	ret0, _ := ret[0].(models.Warranty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockWarrantiesMockRecorder) Attach(ctx, carID, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockWarranties)(nil).Attach), ctx, carID, w)
+}
+
+// Coverage mocks base method.
+func (m *MockWarranties) Coverage(ctx context.Context, carID, date string, odometer int) (models.Coverage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Coverage", ctx, carID, date, odometer)
+	ret0, _ := ret[0].(models.Coverage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Coverage indicates an expected call of Coverage.
+func (mr *MockWarrantiesMockRecorder) Coverage(ctx, carID, date, odometer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Coverage", reflect.TypeOf((*MockWarranties)(nil).Coverage), ctx, carID, date, odometer)
+}
+
+// Expiring mocks base method.
+func (m *MockWarranties) Expiring(ctx context.Context, days int) ([]models.Warranty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expiring", ctx, days)
+	ret0, _ := ret[0].([]models.Warranty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expiring indicates an expected call of Expiring.
+func (mr *MockWarrantiesMockRecorder) Expiring(ctx, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expiring", reflect.TypeOf((*MockWarranties)(nil).Expiring), ctx, days)
+}
+
+// GetWarranties mocks base method.
+func (m *MockWarranties) GetWarranties(ctx context.Context, carID string) ([]models.Warranty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWarranties", ctx, carID)
+	ret0, _ := ret[0].([]models.Warranty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWarranties indicates an expected call of GetWarranties.
+func (mr *MockWarrantiesMockRecorder) GetWarranties(ctx, carID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarranties", reflect.TypeOf((*MockWarranties)(nil).GetWarranties), ctx, carID)
+}
+
+// GetWarranty mocks base method.
+func (m *MockWarranties) GetWarranty(ctx context.Context, id string) (models.Warranty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWarranty", ctx, id)
+	ret0, _ := ret[0].(models.Warranty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWarranty indicates an expected call of GetWarranty.
+func (mr *MockWarrantiesMockRecorder) GetWarranty(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarranty", reflect.TypeOf((*MockWarranties)(nil).GetWarranty), ctx, id)
+}
@@ -0,0 +1,352 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/status"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOrderNotFound    = errors.New("order not found")
+	ErrCarNotFound      = errors.New("car not found")
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrCarNotAvailable  = errors.New("car can not be sold")
+	ErrInvalidOrder     = errors.New("invalid order")
+	ErrInvalidPayment   = errors.New("invalid payment")
+	ErrOrderClosed      = errors.New("order is closed")
+	ErrOrderHasPayments = errors.New("order has payments")
+	ErrOrderConflict    = errors.New("the order was changed by another request")
+)
+
+type service struct {
+	car        datastore.Car
+	customer   datastore.Customer
+	order      datastore.Order
+	dealership string
+	now        func() time.Time
+}
+
+// New returns the order service, orders which do not name a dealership are invoiced by dealership
+func New(car datastore.Car, customer datastore.Customer, order datastore.Order, dealership string) service { //nolint
+	return service{car: car, customer: customer, order: order, dealership: dealership, now: time.Now}
+}
+
+// CreateOrder service layer function to sell a car in stock to a customer, the car is marked sold and the
+// order gets the next invoice number of its dealership. The currency defaults to the currency of the car.
+func (s service) CreateOrder(ctx context.Context, o models.SalesOrder) (models.SalesOrder, error) {
+	o.Dealership = strings.ToUpper(strings.TrimSpace(o.Dealership))
+	if o.Dealership == "" {
+		o.Dealership = s.dealership
+	}
+
+	if !validDealership(o.Dealership) {
+		return models.SalesOrder{}, fmt.Errorf("%w: dealership must be 2 to 10 letters or digits", ErrInvalidOrder)
+	}
+
+	_, err := s.customer.GetCustomerByID(ctx, o.CustomerID.String())
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.SalesOrder{}, ErrCustomerNotFound
+	}
+
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	car, err := s.car.GetCarByID(ctx, o.CarID.String())
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.SalesOrder{}, ErrCarNotFound
+	}
+
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	if err = status.CheckSale(car); err != nil {
+		return models.SalesOrder{}, fmt.Errorf("%w: %v", ErrCarNotAvailable, err)
+	}
+
+	if strings.TrimSpace(o.Currency) == "" {
+		o.Currency = car.Currency
+	}
+
+	if err = checkTerms(&o); err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	now := s.clock()
+
+	o.ID = uuid.New()
+	o.Status = models.OrderOpen
+	o.Paid = 0
+	o.Payments = nil
+	o.CreatedAt = now
+	o.UpdatedAt = now
+	o.Compute()
+
+	o, err = s.order.CreateOrder(ctx, o)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.SalesOrder{}, fmt.Errorf("%w: the car is no longer in stock or is held for another customer",
+			ErrCarNotAvailable)
+	}
+
+	return o, err
+}
+
+// GetOrder service layer function to get an order by its id with its fees and payments
+func (s service) GetOrder(ctx context.Context, id string) (models.SalesOrder, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.SalesOrder{}, ErrOrderNotFound
+	}
+
+	o, err := s.order.GetOrder(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.SalesOrder{}, ErrOrderNotFound
+	}
+
+	return o, err
+}
+
+// GetOrders service layer function to list the orders passing the filter, newest first
+func (s service) GetOrders(ctx context.Context, filter models.OrderFilter) ([]models.SalesOrder, error) {
+	return s.order.GetOrders(ctx, filter)
+}
+
+// UpdateOrder service layer function to replace the salesperson, price, currency, tax rate, fees and notes of an
+// open order, the new total may not be less than what has been paid
+func (s service) UpdateOrder(ctx context.Context, id string, o models.SalesOrder) (models.SalesOrder, error) {
+	current, err := s.open(ctx, id)
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	if strings.TrimSpace(o.Currency) == "" {
+		o.Currency = current.Currency
+	}
+
+	if err = checkTerms(&o); err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	current.Salesperson = o.Salesperson
+	current.Price = o.Price
+	current.Currency = o.Currency
+	current.TaxRate = o.TaxRate
+	current.Fees = o.Fees
+	current.Notes = o.Notes
+	current.UpdatedAt = s.clock()
+	current.Compute()
+
+	if current.Total < current.Paid {
+		return models.SalesOrder{}, fmt.Errorf("%w: the total %v is less than the %v paid", ErrInvalidOrder,
+			current.Total, current.Paid)
+	}
+
+	err = s.order.UpdateOrder(ctx, current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.SalesOrder{}, ErrOrderConflict
+	}
+
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	return current, nil
+}
+
+// CancelOrder service layer function to cancel an open order without payments, the car goes back in stock
+func (s service) CancelOrder(ctx context.Context, id string) (models.SalesOrder, error) {
+	o, err := s.open(ctx, id)
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	if o.Paid > 0 {
+		return models.SalesOrder{}, fmt.Errorf("%w: %v has been paid", ErrOrderHasPayments, o.Paid)
+	}
+
+	o.Status = models.OrderCancelled
+	o.UpdatedAt = s.clock()
+
+	err = s.order.CancelOrder(ctx, o)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.SalesOrder{}, ErrOrderConflict
+	}
+
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	return o, nil
+}
+
+// AddPayment service layer function to record a payment against an open order, the order is paid once nothing
+// is left to pay. A payment may not exceed the balance.
+func (s service) AddPayment(ctx context.Context, id string, p models.Payment) (models.SalesOrder, error) {
+	p.Method = strings.TrimSpace(p.Method)
+	p.Reference = strings.TrimSpace(p.Reference)
+
+	if p.Amount <= 0 {
+		return models.SalesOrder{}, fmt.Errorf("%w: amount must be positive", ErrInvalidPayment)
+	}
+
+	if p.Method == "" {
+		return models.SalesOrder{}, fmt.Errorf("%w: method is required", ErrInvalidPayment)
+	}
+
+	o, err := s.open(ctx, id)
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	if p.Amount > o.Balance() {
+		return models.SalesOrder{}, fmt.Errorf("%w: the balance is %v", ErrInvalidPayment, o.Balance())
+	}
+
+	now := s.clock()
+
+	p.ID = uuid.New()
+	p.OrderID = o.ID
+
+	if p.ReceivedAt.IsZero() {
+		p.ReceivedAt = now
+	}
+
+	o.Paid += p.Amount
+	o.UpdatedAt = now
+
+	if o.Balance() == 0 {
+		o.Status = models.OrderPaid
+	}
+
+	err = s.order.AddPayment(ctx, o, p)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.SalesOrder{}, ErrOrderConflict
+	}
+
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	o.Payments = append(o.Payments, p)
+
+	return o, nil
+}
+
+// GetInvoice service layer function to get the invoice of an order, it describes the car and the customer
+// as they are now
+func (s service) GetInvoice(ctx context.Context, id string) (models.Invoice, error) {
+	o, err := s.GetOrder(ctx, id)
+	if err != nil {
+		return models.Invoice{}, err
+	}
+
+	c, err := s.customer.GetCustomerByID(ctx, o.CustomerID.String())
+	if err != nil {
+		return models.Invoice{}, err
+	}
+
+	car, err := s.car.GetCarByID(ctx, o.CarID.String())
+	if err != nil {
+		return models.Invoice{}, err
+	}
+
+	lines := []models.InvoiceLine{{Description: fmt.Sprintf("%d %s %s, VIN %s", car.Year, car.Brand, car.Name,
+		car.VIN), Amount: o.Price}}
+
+	for _, f := range o.Fees {
+		lines = append(lines, models.InvoiceLine(f))
+	}
+
+	return models.Invoice{
+		Number:      o.InvoiceNumber,
+		Dealership:  o.Dealership,
+		OrderID:     o.ID,
+		Status:      o.Status,
+		IssuedAt:    o.CreatedAt,
+		Customer:    c,
+		Car:         models.InvoiceCar{ID: car.ID, VIN: car.VIN, Name: car.Name, Brand: car.Brand, Year: car.Year},
+		Salesperson: o.Salesperson,
+		Currency:    o.Currency,
+		Lines:       lines,
+		Subtotal:    o.Subtotal(),
+		TaxRate:     o.TaxRate,
+		Tax:         o.Tax,
+		Total:       o.Total,
+		Paid:        o.Paid,
+		Balance:     o.Balance(),
+		Payments:    o.Payments,
+	}, nil
+}
+
+// open returns the order with the id if it is still open
+func (s service) open(ctx context.Context, id string) (models.SalesOrder, error) {
+	o, err := s.GetOrder(ctx, id)
+	if err != nil {
+		return models.SalesOrder{}, err
+	}
+
+	if o.Status != models.OrderOpen {
+		return models.SalesOrder{}, fmt.Errorf("%w: the order is %s", ErrOrderClosed, o.Status)
+	}
+
+	return o, nil
+}
+
+func (s service) clock() time.Time {
+	return s.now().UTC().Truncate(time.Second)
+}
+
+// checkTerms trims the terms of an order and validates them
+func checkTerms(o *models.SalesOrder) error {
+	o.Salesperson = strings.TrimSpace(o.Salesperson)
+	o.Currency = strings.ToUpper(strings.TrimSpace(o.Currency))
+	o.Notes = strings.TrimSpace(o.Notes)
+
+	if o.Fees == nil {
+		o.Fees = []models.Fee{}
+	}
+
+	switch {
+	case o.Salesperson == "":
+		return fmt.Errorf("%w: salesperson is required", ErrInvalidOrder)
+	case o.Price <= 0:
+		return fmt.Errorf("%w: price must be positive", ErrInvalidOrder)
+	case !models.ValidCurrency(o.Currency):
+		return fmt.Errorf("%w: currency must be a supported ISO 4217 code", ErrInvalidOrder)
+	case !o.Price.Fits(o.Currency):
+		return fmt.Errorf("%w: price has more fraction digits than %s", ErrInvalidOrder, o.Currency)
+	case o.TaxRate < 0 || o.TaxRate > 10000:
+		return fmt.Errorf("%w: tax rate must be between 0 and 10000 basis points", ErrInvalidOrder)
+	}
+
+	for i := range o.Fees {
+		o.Fees[i].Description = strings.TrimSpace(o.Fees[i].Description)
+
+		if o.Fees[i].Description == "" || o.Fees[i].Amount < 0 {
+			return fmt.Errorf("%w: fees need a description and an amount which is not negative", ErrInvalidOrder)
+		}
+
+		if !o.Fees[i].Amount.Fits(o.Currency) {
+			return fmt.Errorf("%w: fees have more fraction digits than %s", ErrInvalidOrder, o.Currency)
+		}
+	}
+
+	return nil
+}
+
+func validDealership(code string) bool {
+	if len(code) < 2 || len(code) > 10 {
+		return false
+	}
+
+	return strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789") == ""
+}
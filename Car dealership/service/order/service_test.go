@@ -0,0 +1,327 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func openOrder() models.SalesOrder {
+	o := models.SalesOrder{ID: uuid.New(), Dealership: "MAIN", InvoiceNumber: "MAIN-000001", CarID: uuid.New(),
+		CustomerID: uuid.New(), Salesperson: "ravi", Price: 2400000, Currency: "EUR", TaxRate: 1900,
+		Fees: []models.Fee{{Description: "registration", Amount: 15000}}, Status: models.OrderOpen}
+	o.Compute()
+
+	return o
+}
+
+// TestCreateOrder service layer test function to test only cars in stock are sold to known customers
+func TestCreateOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	customerStore := datastore.NewMockCustomer(ctrl)
+	orderStore := datastore.NewMockOrder(ctrl)
+	s := New(carStore, customerStore, orderStore, "MAIN")
+	s.now = func() time.Time { return now }
+
+	car := models.Car{ID: uuid.New(), Status: models.StatusInStock, ListPrice: 2499900, Currency: "EUR"}
+	held := car
+	held.Status = models.StatusReserved
+	customerID := uuid.New()
+	input := models.SalesOrder{CarID: car.ID, CustomerID: customerID, Salesperson: " ravi ", Price: 2400000,
+		TaxRate: 1900, Fees: []models.Fee{{Description: "registration", Amount: 15000}}}
+
+	found := func() {
+		customerStore.EXPECT().GetCustomerByID(gomock.Any(), customerID.String()).Return(models.Customer{}, nil)
+	}
+	created := func(_ context.Context, o models.SalesOrder) (models.SalesOrder, error) {
+		o.InvoiceNumber = o.Dealership + "-000001"
+		return o, nil
+	}
+
+	testCases := []struct {
+		desc  string
+		input models.SalesOrder
+		mock  func()
+		err   error
+	}{
+		{desc: "success", input: input, mock: func() {
+			found()
+			carStore.EXPECT().GetCarByID(gomock.Any(), car.ID.String()).Return(car, nil)
+			orderStore.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).DoAndReturn(created)
+		}},
+		{desc: "bad dealership", input: models.SalesOrder{Dealership: "main-1"}, err: ErrInvalidOrder},
+		{desc: "customer not found", input: input, err: ErrCustomerNotFound, mock: func() {
+			customerStore.EXPECT().GetCustomerByID(gomock.Any(), customerID.String()).
+				Return(models.Customer{}, sql.ErrNoRows)
+		}},
+		{desc: "car not found", input: input, err: ErrCarNotFound, mock: func() {
+			found()
+			carStore.EXPECT().GetCarByID(gomock.Any(), car.ID.String()).Return(models.Car{}, sql.ErrNoRows)
+		}},
+		{desc: "car held for the customer", input: input, mock: func() {
+			found()
+			carStore.EXPECT().GetCarByID(gomock.Any(), car.ID.String()).Return(held, nil)
+			orderStore.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).DoAndReturn(created)
+		}},
+		{desc: "car held for another customer", input: input, err: ErrCarNotAvailable, mock: func() {
+			found()
+			carStore.EXPECT().GetCarByID(gomock.Any(), car.ID.String()).Return(held, nil)
+			orderStore.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(models.SalesOrder{}, sql.ErrNoRows)
+		}},
+		{desc: "car held without a list price", input: input, err: ErrCarNotAvailable, mock: func() {
+			found()
+			carStore.EXPECT().GetCarByID(gomock.Any(), car.ID.String()).
+				Return(models.Car{ID: car.ID, Status: models.StatusReserved}, nil)
+		}},
+		{desc: "missing price", input: models.SalesOrder{CarID: car.ID, CustomerID: customerID, Salesperson: "ravi"},
+			err: ErrInvalidOrder, mock: func() {
+				found()
+				carStore.EXPECT().GetCarByID(gomock.Any(), car.ID.String()).Return(car, nil)
+			}},
+		{desc: "sold concurrently", input: input, err: ErrCarNotAvailable, mock: func() {
+			found()
+			carStore.EXPECT().GetCarByID(gomock.Any(), car.ID.String()).Return(car, nil)
+			orderStore.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(models.SalesOrder{}, sql.ErrNoRows)
+		}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.CreateOrder(context.TODO(), tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, "MAIN-000001", res.InvoiceNumber)
+		assert.Equal(t, "EUR", res.Currency)
+		assert.Equal(t, "ravi", res.Salesperson)
+		assert.Equal(t, models.OrderOpen, res.Status)
+		assert.Equal(t, models.Money(458850), res.Tax)
+		assert.Equal(t, models.Money(2873850), res.Total)
+		assert.Equal(t, now, res.CreatedAt)
+	}
+}
+
+// TestUpdateOrder service layer test function to test only open orders are updated
+func TestUpdateOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderStore := datastore.NewMockOrder(ctrl)
+	s := New(datastore.NewMockCar(ctrl), datastore.NewMockCustomer(ctrl), orderStore, "MAIN")
+	s.now = func() time.Time { return now }
+
+	o := openOrder()
+	id := o.ID.String()
+	paid := o
+	paid.Paid = 1000000
+	update := models.SalesOrder{Salesperson: "asha", Price: 2300000, TaxRate: 1900}
+
+	testCases := []struct {
+		desc  string
+		input models.SalesOrder
+		mock  func()
+		err   error
+	}{
+		{desc: "success", input: update, mock: func() {
+			orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(o, nil)
+			orderStore.EXPECT().UpdateOrder(gomock.Any(), gomock.Any()).Return(nil)
+		}},
+		{desc: "less than paid", input: models.SalesOrder{Salesperson: "asha", Price: 500000}, err: ErrInvalidOrder,
+			mock: func() { orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(paid, nil) }},
+		{desc: "paid order", input: update, err: ErrOrderClosed, mock: func() {
+			orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(models.SalesOrder{Status: models.OrderPaid}, nil)
+		}},
+		{desc: "paid concurrently", input: update, err: ErrOrderConflict, mock: func() {
+			orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(o, nil)
+			orderStore.EXPECT().UpdateOrder(gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+		}},
+		{desc: "not found", input: update, err: ErrOrderNotFound, mock: func() {
+			orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(models.SalesOrder{}, sql.ErrNoRows)
+		}},
+	}
+
+	for i, tc := range testCases {
+		tc.mock()
+
+		res, err := s.UpdateOrder(context.TODO(), id, tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err == nil {
+			assert.Equal(t, "asha", res.Salesperson)
+			assert.Equal(t, "EUR", res.Currency)
+			assert.Equal(t, models.Money(2737000), res.Total)
+			assert.Equal(t, []models.Fee{}, res.Fees)
+		}
+	}
+}
+
+// TestAddPayment service layer test function to test payments settle the order and never exceed the balance
+func TestAddPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderStore := datastore.NewMockOrder(ctrl)
+	s := New(datastore.NewMockCar(ctrl), datastore.NewMockCustomer(ctrl), orderStore, "MAIN")
+	s.now = func() time.Time { return now }
+
+	o := openOrder()
+	id := o.ID.String()
+	card := models.Payment{Amount: 1000000, Method: "card"}
+
+	testCases := []struct {
+		desc   string
+		input  models.Payment
+		mock   func()
+		status string
+		err    error
+	}{
+		{desc: "part payment", input: card, status: models.OrderOpen, mock: func() {
+			orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(o, nil)
+			orderStore.EXPECT().AddPayment(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		}},
+		{desc: "paid in full", input: models.Payment{Amount: o.Total, Method: "transfer"}, status: models.OrderPaid,
+			mock: func() {
+				orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(o, nil)
+				orderStore.EXPECT().AddPayment(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, o models.SalesOrder, p models.Payment) error {
+						assert.Equal(t, o.Total, o.Paid)
+						assert.Equal(t, now, p.ReceivedAt)
+
+						return nil
+					})
+			}},
+		{desc: "more than the balance", input: models.Payment{Amount: o.Total + 1, Method: "card"},
+			err: ErrInvalidPayment, mock: func() { orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(o, nil) }},
+		{desc: "no amount", input: models.Payment{Method: "card"}, err: ErrInvalidPayment},
+		{desc: "no method", input: models.Payment{Amount: 100}, err: ErrInvalidPayment},
+		{desc: "cancelled order", input: card, err: ErrOrderClosed, mock: func() {
+			orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(models.SalesOrder{Status: models.OrderCancelled}, nil)
+		}},
+		{desc: "paid concurrently", input: card, err: ErrOrderConflict, mock: func() {
+			orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(o, nil)
+			orderStore.EXPECT().AddPayment(gomock.Any(), gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+		}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.AddPayment(context.TODO(), id, tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err == nil {
+			assert.Equal(t, tc.status, res.Status, tc.desc)
+			assert.Len(t, res.Payments, 1, tc.desc)
+		}
+	}
+}
+
+// TestCancelOrder service layer test function to test only open orders without payments are cancelled
+func TestCancelOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderStore := datastore.NewMockOrder(ctrl)
+	s := New(datastore.NewMockCar(ctrl), datastore.NewMockCustomer(ctrl), orderStore, "MAIN")
+	s.now = func() time.Time { return now }
+
+	o := openOrder()
+	id := o.ID.String()
+	paid := o
+	paid.Paid = 100
+
+	testCases := []struct {
+		desc string
+		mock func()
+		err  error
+	}{
+		{desc: "success", mock: func() {
+			orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(o, nil)
+			orderStore.EXPECT().CancelOrder(gomock.Any(), gomock.Any()).Return(nil)
+		}},
+		{desc: "has payments", err: ErrOrderHasPayments, mock: func() {
+			orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(paid, nil)
+		}},
+		{desc: "car delivered", err: ErrOrderConflict, mock: func() {
+			orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(o, nil)
+			orderStore.EXPECT().CancelOrder(gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+		}},
+	}
+
+	for i, tc := range testCases {
+		tc.mock()
+
+		res, err := s.CancelOrder(context.TODO(), id)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err == nil {
+			assert.Equal(t, models.OrderCancelled, res.Status)
+		}
+	}
+
+	_, err := s.CancelOrder(context.TODO(), "abc")
+	assert.Equal(t, ErrOrderNotFound, err)
+}
+
+// TestGetInvoice service layer test function to test invoices carry the car, the customer and the totals
+func TestGetInvoice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	customerStore := datastore.NewMockCustomer(ctrl)
+	orderStore := datastore.NewMockOrder(ctrl)
+	s := New(carStore, customerStore, orderStore, "MAIN")
+	s.now = func() time.Time { return now }
+
+	o := openOrder()
+	o.Paid = 1000000
+	id := o.ID.String()
+	car := models.Car{ID: o.CarID, VIN: "1HGCM82633A004352", Name: "Civic", Brand: "Honda", Year: 2021, Cost: 1900000}
+	c := models.Customer{ID: o.CustomerID, Name: "Sahil"}
+
+	orderStore.EXPECT().GetOrder(gomock.Any(), id).Return(o, nil)
+	customerStore.EXPECT().GetCustomerByID(gomock.Any(), o.CustomerID.String()).Return(c, nil)
+	carStore.EXPECT().GetCarByID(gomock.Any(), o.CarID.String()).Return(car, nil)
+
+	res, err := s.GetInvoice(context.TODO(), id)
+	assert.Nil(t, err)
+	assert.Equal(t, "MAIN-000001", res.Number)
+	assert.Equal(t, []models.InvoiceLine{
+		{Description: "2021 Honda Civic, VIN 1HGCM82633A004352", Amount: 2400000},
+		{Description: "registration", Amount: 15000},
+	}, res.Lines)
+	assert.Equal(t, models.Money(2415000), res.Subtotal)
+	assert.Equal(t, o.Total-1000000, res.Balance)
+	assert.Equal(t, "Sahil", res.Customer.Name)
+}
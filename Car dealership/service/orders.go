@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Orders interface {
+	CreateOrder(ctx context.Context, o models.SalesOrder) (models.SalesOrder, error)
+	GetOrder(ctx context.Context, id string) (models.SalesOrder, error)
+	GetOrders(ctx context.Context, filter models.OrderFilter) ([]models.SalesOrder, error)
+	UpdateOrder(ctx context.Context, id string, o models.SalesOrder) (models.SalesOrder, error)
+	CancelOrder(ctx context.Context, id string) (models.SalesOrder, error)
+	AddPayment(ctx context.Context, id string, p models.Payment) (models.SalesOrder, error)
+	GetInvoice(ctx context.Context, id string) (models.Invoice, error)
+}
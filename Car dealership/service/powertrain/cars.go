@@ -0,0 +1,52 @@
+package powertrain
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+)
+
+// carValidator is a service.Cars which rejects cars whose powertrain does not fit their fuel type and engine
+type carValidator struct {
+	service.Cars
+}
+
+// NewCarValidator wraps a car service so that cars are only created or updated with a valid powertrain
+func NewCarValidator(next service.Cars) service.Cars {
+	return carValidator{Cars: next}
+}
+
+// CreateCar validates and normalises the powertrain before creating the car
+func (v carValidator) CreateCar(ctx context.Context, car *models.Car) (models.Car, error) {
+	if car == nil {
+		return v.Cars.CreateCar(ctx, car)
+	}
+
+	if err := CheckCar(*car); err != nil {
+		return models.Car{}, err
+	}
+
+	NormalizeCar(car)
+
+	return v.Cars.CreateCar(ctx, car)
+}
+
+// UpdateCar validates and normalises the powertrain before updating the car
+func (v carValidator) UpdateCar(ctx context.Context, id string, car models.Car) (models.Car, error) {
+	if err := CheckCar(car); err != nil {
+		return models.Car{}, err
+	}
+
+	NormalizeCar(&car)
+
+	return v.Cars.UpdateCar(ctx, id, car)
+}
+
+// NormalizeCar replaces the powertrain of a car with its normalised copy, so the caller's powertrain is not changed
+func NormalizeCar(car *models.Car) {
+	if car.Engine.Powertrain != nil {
+		p := Normalize(*car.Engine.Powertrain)
+		car.Engine.Powertrain = &p
+	}
+}
@@ -0,0 +1,60 @@
+package powertrain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCarValidator test function to test cars are only created or updated with a valid powertrain
+func TestCarValidator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCars(ctrl)
+	s := NewCarValidator(mockService)
+
+	id := uuid.New().String()
+	given := &models.Powertrain{Type: " BEV", BatteryKWh: 75, ChargingStandards: []string{"CCS2"}, MaxChargeKW: 250}
+	lower := models.Car{Name: "Model 3", Brand: "Tesla", FuelType: "Electric",
+		Engine: models.Engine{CarRange: 500, Powertrain: given}}
+	valid := lower
+	valid.Engine.Powertrain = &models.Powertrain{Type: "bev", BatteryKWh: 75, ChargingStandards: []string{"ccs2"},
+		MaxChargeKW: 250}
+	invalid := models.Car{FuelType: "Electric", Engine: models.Engine{Displacement: 1998,
+		Powertrain: &models.Powertrain{Type: "bev"}}}
+
+	mockService.EXPECT().CreateCar(gomock.Any(), &valid).Return(valid, nil)
+	mockService.EXPECT().UpdateCar(gomock.Any(), id, valid).Return(valid, nil)
+	mockService.EXPECT().CreateCar(gomock.Any(), nil).Return(models.Car{}, nil)
+
+	create := lower
+	if _, err := s.CreateCar(context.TODO(), &create); err != nil {
+		t.Errorf("create with valid powertrain: %v", err)
+	}
+
+	if _, err := s.UpdateCar(context.TODO(), id, lower); err != nil {
+		t.Errorf("update with valid powertrain: %v", err)
+	}
+
+	if _, err := s.CreateCar(context.TODO(), nil); err != nil {
+		t.Errorf("create without car: %v", err)
+	}
+
+	if _, err := s.CreateCar(context.TODO(), &invalid); !errors.Is(err, ErrInvalidPowertrain) {
+		t.Errorf("create with invalid powertrain: got %v", err)
+	}
+
+	if _, err := s.UpdateCar(context.TODO(), id, invalid); !errors.Is(err, ErrInvalidPowertrain) {
+		t.Errorf("update with invalid powertrain: got %v", err)
+	}
+
+	assert.Equal(t, " BEV", given.Type, "the caller's powertrain is left as it is")
+}
@@ -0,0 +1,188 @@
+package powertrain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// upper bounds of the powertrain figures, well past any production car, to catch figures given in the wrong unit
+const (
+	MaxBatteryKWh      = 250
+	MaxChargeKW        = 1000
+	MaxMotorPowerKW    = 2000
+	MaxFuelConsumption = 50
+	MaxEfficiency      = 1000
+)
+
+var ErrInvalidPowertrain = errors.New("invalid powertrain")
+
+// fuels are the powertrains a car of each fuel type may have, a car burning petrol or diesel may be a hybrid of
+// either kind while an electric car only runs on its battery
+var fuels = map[string][]string{
+	"petrol":         {models.PowertrainICE, models.PowertrainHybrid, models.PowertrainPlugInHybrid},
+	"diesel":         {models.PowertrainICE, models.PowertrainHybrid, models.PowertrainPlugInHybrid},
+	"cng":            {models.PowertrainICE},
+	"lpg":            {models.PowertrainICE},
+	"hybrid":         {models.PowertrainHybrid, models.PowertrainPlugInHybrid},
+	"plug_in_hybrid": {models.PowertrainPlugInHybrid},
+	"electric":       {models.PowertrainBEV},
+}
+
+// spec is which fields a powertrain type has. Combustion engines need a displacement and cylinders, a battery
+// is required when there is one, and only cars that plug in charge or have an efficiency in Wh per km.
+type spec struct {
+	combustion bool
+	battery    bool
+	plugIn     bool
+	motor      bool
+}
+
+var specs = map[string]spec{
+	models.PowertrainICE:          {combustion: true},
+	models.PowertrainHybrid:       {combustion: true, battery: true, motor: true},
+	models.PowertrainPlugInHybrid: {combustion: true, battery: true, plugIn: true, motor: true},
+	models.PowertrainBEV:          {battery: true, plugIn: true, motor: true},
+}
+
+// NormalizeFuel lower cases a fuel type and joins its words with underscores, so Plug-in Hybrid is plug_in_hybrid
+func NormalizeFuel(fuel string) string {
+	fuel = strings.ToLower(strings.TrimSpace(fuel))
+
+	return strings.NewReplacer("-", "_", " ", "_").Replace(fuel)
+}
+
+// ValidFuel reports whether a fuel type is one of the fuels table once normalised, so Diesel and plug-in hybrid are
+func ValidFuel(fuel string) bool {
+	_, ok := fuels[NormalizeFuel(fuel)]
+
+	return ok
+}
+
+// Normalize lower cases the type and charging standards of a powertrain, the powertrain given is left as it is
+func Normalize(p models.Powertrain) models.Powertrain {
+	p.Type = strings.ToLower(strings.TrimSpace(p.Type))
+
+	standards := make([]string, len(p.ChargingStandards))
+	for i, s := range p.ChargingStandards {
+		standards[i] = strings.ToLower(strings.TrimSpace(s))
+	}
+
+	p.ChargingStandards = standards
+
+	return p
+}
+
+// CheckCar validates the engine and powertrain of a car against its fuel type. A car without a powertrain, such as
+// the engines recorded before powertrains were, only has its displacement, cylinders and range checked.
+func CheckCar(car models.Car) error {
+	fuel := NormalizeFuel(car.FuelType)
+	allowed, ok := fuels[fuel]
+
+	if car.Engine.Powertrain == nil {
+		if !ok {
+			return nil
+		}
+
+		return checkEngine(fuel+" car", specs[allowed[0]], car.Engine)
+	}
+
+	p := Normalize(*car.Engine.Powertrain)
+
+	if !ok {
+		return fmt.Errorf("%w: fuel type %q has no powertrain", ErrInvalidPowertrain, car.FuelType)
+	}
+
+	if !contains(allowed, p.Type) {
+		return fmt.Errorf("%w: a %s car is %s, not %s", ErrInvalidPowertrain, fuel, strings.Join(allowed, " or "),
+			p.Type)
+	}
+
+	if err := checkFigures(p); err != nil {
+		return err
+	}
+
+	return checkFields(p, specs[p.Type], car.Engine)
+}
+
+// checkFigures bounds each figure of a powertrain and checks its charging standards
+func checkFigures(p models.Powertrain) error {
+	switch {
+	case p.BatteryKWh < 0 || p.BatteryKWh > MaxBatteryKWh:
+		return fmt.Errorf("%w: battery must be between 0 and %d kWh", ErrInvalidPowertrain, MaxBatteryKWh)
+	case p.MaxChargeKW < 0 || p.MaxChargeKW > MaxChargeKW:
+		return fmt.Errorf("%w: charge rate must be between 0 and %d kW", ErrInvalidPowertrain, MaxChargeKW)
+	case p.MotorPowerKW < 0 || p.MotorPowerKW > MaxMotorPowerKW:
+		return fmt.Errorf("%w: motor power must be between 0 and %d kW", ErrInvalidPowertrain, MaxMotorPowerKW)
+	case p.FuelConsumption < 0 || p.FuelConsumption > MaxFuelConsumption:
+		return fmt.Errorf("%w: fuel consumption must be between 0 and %d l/100 km", ErrInvalidPowertrain,
+			MaxFuelConsumption)
+	case p.Efficiency < 0 || p.Efficiency > MaxEfficiency:
+		return fmt.Errorf("%w: efficiency must be between 0 and %d Wh/km", ErrInvalidPowertrain, MaxEfficiency)
+	}
+
+	for i, s := range p.ChargingStandards {
+		if !models.ValidChargingStandard(s) {
+			return fmt.Errorf("%w: unknown charging standard %q", ErrInvalidPowertrain, s)
+		}
+
+		if contains(p.ChargingStandards[:i], s) {
+			return fmt.Errorf("%w: charging standard %s is listed twice", ErrInvalidPowertrain, s)
+		}
+	}
+
+	return nil
+}
+
+// checkEngine checks an engine has a displacement and cylinders only when it burns fuel, and a range otherwise. The
+// powertrains a fuel type allows all burn fuel or none do, so any of them tells which.
+func checkEngine(t string, s spec, engine models.Engine) error {
+	switch {
+	case s.combustion && (engine.Displacement <= 0 || engine.NoOfCylinder <= 0):
+		return fmt.Errorf("%w: a %s engine needs a displacement and cylinders", ErrInvalidPowertrain, t)
+	case !s.combustion && (engine.Displacement != 0 || engine.NoOfCylinder != 0):
+		return fmt.Errorf("%w: a %s has no displacement or cylinders", ErrInvalidPowertrain, t)
+	case !s.combustion && engine.CarRange <= 0:
+		return fmt.Errorf("%w: a %s needs a range", ErrInvalidPowertrain, t)
+	}
+
+	return nil
+}
+
+// checkFields checks a powertrain and its engine only have the fields of its type
+func checkFields(p models.Powertrain, s spec, engine models.Engine) error {
+	t := p.Type
+
+	if err := checkEngine(t, s, engine); err != nil {
+		return err
+	}
+
+	switch {
+	case !s.combustion && p.FuelConsumption != 0:
+		return fmt.Errorf("%w: a %s burns no fuel", ErrInvalidPowertrain, t)
+	case s.battery && p.BatteryKWh == 0:
+		return fmt.Errorf("%w: a %s needs a battery capacity", ErrInvalidPowertrain, t)
+	case !s.battery && p.BatteryKWh != 0:
+		return fmt.Errorf("%w: a %s has no traction battery", ErrInvalidPowertrain, t)
+	case !s.motor && p.MotorPowerKW != 0:
+		return fmt.Errorf("%w: a %s has no electric motor", ErrInvalidPowertrain, t)
+	case !s.plugIn && (len(p.ChargingStandards) > 0 || p.MaxChargeKW != 0 || p.Efficiency != 0):
+		return fmt.Errorf("%w: a %s does not plug in to charge", ErrInvalidPowertrain, t)
+	case p.MaxChargeKW != 0 && len(p.ChargingStandards) == 0:
+		return fmt.Errorf("%w: a charge rate needs the charging standards it is reached on", ErrInvalidPowertrain)
+	}
+
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
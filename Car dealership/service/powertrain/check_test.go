@@ -0,0 +1,80 @@
+package powertrain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// TestCheckCar test function to test powertrains are checked against the fuel type and engine of the car
+func TestCheckCar(t *testing.T) {
+	petrol := models.Engine{Displacement: 1998, NoOfCylinder: 4}
+	electric := models.Engine{CarRange: 500}
+
+	car := func(fuel string, engine models.Engine, p models.Powertrain) models.Car {
+		engine.Powertrain = &p
+		return models.Car{Name: "Car", Brand: "Brand", FuelType: fuel, Engine: engine}
+	}
+
+	bev := models.Powertrain{Type: "BEV", BatteryKWh: 75, ChargingStandards: []string{"Type2", " CCS2"},
+		MaxChargeKW: 250, MotorPowerKW: 300, Efficiency: 160}
+	phev := models.Powertrain{Type: "plug_in_hybrid", BatteryKWh: 18, ChargingStandards: []string{"type2"},
+		MaxChargeKW: 7.4, MotorPowerKW: 80, FuelConsumption: 1.6, Efficiency: 200}
+
+	testCases := []struct {
+		desc string
+		car  models.Car
+		err  error
+	}{
+		{desc: "no powertrain", car: models.Car{FuelType: "Diesel", Engine: petrol}},
+		{desc: "no powertrain, electric", car: models.Car{FuelType: "Electric", Engine: electric}},
+		{desc: "no powertrain, unknown fuel", car: models.Car{FuelType: "Steam", Engine: electric}},
+		{desc: "no powertrain, electric with cylinders", car: models.Car{FuelType: "Electric", Engine: petrol},
+			err: ErrInvalidPowertrain},
+		{desc: "no powertrain, petrol without cylinders", car: models.Car{FuelType: "Petrol", Engine: electric},
+			err: ErrInvalidPowertrain},
+		{desc: "petrol", car: car("Petrol", petrol, models.Powertrain{Type: "ice", FuelConsumption: 6.8})},
+		{desc: "petrol hybrid", car: car("Petrol", petrol, models.Powertrain{Type: "hybrid", BatteryKWh: 1.3,
+			MotorPowerKW: 53, FuelConsumption: 4.5})},
+		{desc: "plug-in hybrid", car: car("Plug-in Hybrid", petrol, phev)},
+		{desc: "electric", car: car("Electric", electric, bev)},
+		{desc: "unknown fuel", car: car("Steam", petrol, models.Powertrain{Type: "ice"}), err: ErrInvalidPowertrain},
+		{desc: "electric ice", car: car("Electric", electric, models.Powertrain{Type: "ice"}),
+			err: ErrInvalidPowertrain},
+		{desc: "petrol bev", car: car("Petrol", electric, bev), err: ErrInvalidPowertrain},
+		{desc: "unknown type", car: car("Petrol", petrol, models.Powertrain{Type: "steam"}), err: ErrInvalidPowertrain},
+		{desc: "battery too large", car: car("Electric", electric, models.Powertrain{Type: "bev", BatteryKWh: 75000}),
+			err: ErrInvalidPowertrain},
+		{desc: "negative motor power", car: car("Electric", electric, models.Powertrain{Type: "bev", BatteryKWh: 75,
+			MotorPowerKW: -1}), err: ErrInvalidPowertrain},
+		{desc: "unknown charging standard", car: car("Electric", electric, models.Powertrain{Type: "bev",
+			BatteryKWh: 75, ChargingStandards: []string{"usb"}}), err: ErrInvalidPowertrain},
+		{desc: "charging standard twice", car: car("Electric", electric, models.Powertrain{Type: "bev",
+			BatteryKWh: 75, ChargingStandards: []string{"ccs2", "CCS2"}}), err: ErrInvalidPowertrain},
+		{desc: "electric with cylinders", car: car("Electric", models.Engine{NoOfCylinder: 4, CarRange: 500}, bev),
+			err: ErrInvalidPowertrain},
+		{desc: "electric without range", car: car("Electric", models.Engine{}, bev), err: ErrInvalidPowertrain},
+		{desc: "electric burning fuel", car: car("Electric", electric, models.Powertrain{Type: "bev", BatteryKWh: 75,
+			FuelConsumption: 5}), err: ErrInvalidPowertrain},
+		{desc: "electric without battery", car: car("Electric", electric, models.Powertrain{Type: "bev"}),
+			err: ErrInvalidPowertrain},
+		{desc: "hybrid without engine", car: car("Hybrid", electric, models.Powertrain{Type: "hybrid",
+			BatteryKWh: 1}), err: ErrInvalidPowertrain},
+		{desc: "ice with battery", car: car("Diesel", petrol, models.Powertrain{Type: "ice", BatteryKWh: 1}),
+			err: ErrInvalidPowertrain},
+		{desc: "ice with motor", car: car("Diesel", petrol, models.Powertrain{Type: "ice", MotorPowerKW: 10}),
+			err: ErrInvalidPowertrain},
+		{desc: "hybrid plugged in", car: car("Hybrid", petrol, models.Powertrain{Type: "hybrid", BatteryKWh: 1,
+			ChargingStandards: []string{"type2"}}), err: ErrInvalidPowertrain},
+		{desc: "charge rate without standard", car: car("Electric", electric, models.Powertrain{Type: "bev",
+			BatteryKWh: 75, MaxChargeKW: 150}), err: ErrInvalidPowertrain},
+	}
+
+	for i, tc := range testCases {
+		err := CheckCar(tc.car)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package price
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCarNotFound  = errors.New("car not found")
+	ErrInvalidPrice = errors.New("invalid price")
+)
+
+type service struct {
+	car   datastore.Car
+	price datastore.Price
+	now   func() time.Time
+}
+
+func New(car datastore.Car, price datastore.Price) service { //nolint
+	return service{car: car, price: price, now: time.Now}
+}
+
+// GetPrice service layer function to get the current price of a car
+func (s service) GetPrice(ctx context.Context, id string) (models.Price, error) {
+	car, err := s.getCar(ctx, id)
+	if err != nil {
+		return models.Price{}, err
+	}
+
+	return car.Price(), nil
+}
+
+// SetPrice service layer function to validate and set the price of a car, recording who changed it and why
+func (s service) SetPrice(ctx context.Context, id string, change models.PriceChange) (models.PriceChange, error) {
+	carID, err := uuid.Parse(id)
+	if err != nil {
+		return models.PriceChange{}, ErrCarNotFound
+	}
+
+	change.Price.Currency = strings.ToUpper(strings.TrimSpace(change.Price.Currency))
+	change.Actor = strings.TrimSpace(change.Actor)
+	change.Reason = strings.TrimSpace(change.Reason)
+
+	if err = validate(change); err != nil {
+		return models.PriceChange{}, err
+	}
+
+	change.ID = uuid.New()
+	change.CarID = carID
+	change.ChangedAt = s.now().UTC().Truncate(time.Second)
+
+	change, err = s.price.SetPrice(ctx, change)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.PriceChange{}, ErrCarNotFound
+	}
+
+	return change, err
+}
+
+// GetPriceHistory service layer function to get every price change of a car, oldest first
+func (s service) GetPriceHistory(ctx context.Context, id string) ([]models.PriceChange, error) {
+	if _, err := s.getCar(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.price.GetPriceHistory(ctx, id)
+}
+
+func (s service) getCar(ctx context.Context, id string) (models.Car, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Car{}, ErrCarNotFound
+	}
+
+	car, err := s.car.GetCarByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Car{}, ErrCarNotFound
+	}
+
+	return car, err
+}
+
+func validate(change models.PriceChange) error {
+	p := change.Price
+
+	switch {
+	case !models.ValidCurrency(p.Currency):
+		return fmt.Errorf("%w: currency must be a supported ISO 4217 code", ErrInvalidPrice)
+	case p.MSRP < 0 || p.ListPrice < 0 || p.Cost < 0:
+		return fmt.Errorf("%w: amounts must not be negative", ErrInvalidPrice)
+	case !p.MSRP.Fits(p.Currency) || !p.ListPrice.Fits(p.Currency) || !p.Cost.Fits(p.Currency):
+		return fmt.Errorf("%w: amounts have more fraction digits than %s", ErrInvalidPrice, p.Currency)
+	case change.Actor == "":
+		return fmt.Errorf("%w: actor is required", ErrInvalidPrice)
+	case change.Reason == "":
+		return fmt.Errorf("%w: reason is required", ErrInvalidPrice)
+	}
+
+	return nil
+}
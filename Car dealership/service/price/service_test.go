@@ -0,0 +1,121 @@
+package price
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSetPrice service layer test function to test prices are validated and stamped before they are stored
+func TestSetPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	priceStore := datastore.NewMockPrice(ctrl)
+	s := New(carStore, priceStore)
+
+	now := time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	id := uuid.New()
+	price := models.Price{MSRP: 4999900, ListPrice: 4750000, Cost: 4100000, Currency: "usd"}
+	change := models.PriceChange{Price: price, Actor: " sahil ", Reason: "spring offer"}
+
+	stored := func(_ context.Context, c models.PriceChange) (models.PriceChange, error) {
+		return c, nil
+	}
+
+	testCases := []struct {
+		desc   string
+		id     string
+		change models.PriceChange
+		mock   func()
+		err    error
+	}{
+		{desc: "success", id: id.String(), change: change,
+			mock: func() { priceStore.EXPECT().SetPrice(gomock.Any(), gomock.Any()).DoAndReturn(stored) }},
+		{desc: "invalid id", id: "abc", change: change, err: ErrCarNotFound},
+		{desc: "unknown currency", id: id.String(),
+			change: models.PriceChange{Price: models.Price{Currency: "dollar"}, Actor: "a", Reason: "r"},
+			err:    ErrInvalidPrice},
+		{desc: "currency of three fraction digits", id: id.String(),
+			change: models.PriceChange{Price: models.Price{Currency: "BHD"}, Actor: "a", Reason: "r"},
+			err:    ErrInvalidPrice},
+		{desc: "fraction of a yen", id: id.String(),
+			change: models.PriceChange{Price: models.Price{ListPrice: 150050, Currency: "JPY"}, Actor: "a",
+				Reason: "r"},
+			err: ErrInvalidPrice},
+		{desc: "negative amount", id: id.String(),
+			change: models.PriceChange{Price: models.Price{Cost: -1, Currency: "EUR"}, Actor: "a", Reason: "r"},
+			err:    ErrInvalidPrice},
+		{desc: "missing reason", id: id.String(),
+			change: models.PriceChange{Price: price, Actor: "sahil"}, err: ErrInvalidPrice},
+		{desc: "car not found", id: id.String(), change: change,
+			mock: func() {
+				priceStore.EXPECT().SetPrice(gomock.Any(), gomock.Any()).Return(models.PriceChange{}, sql.ErrNoRows)
+			},
+			err: ErrCarNotFound},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.SetPrice(context.TODO(), tc.id, tc.change)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, id, res.CarID, tc.desc)
+		assert.Equal(t, "USD", res.Price.Currency, tc.desc)
+		assert.Equal(t, "sahil", res.Actor, tc.desc)
+		assert.Equal(t, now, res.ChangedAt, tc.desc)
+		assert.NotEqual(t, uuid.Nil, res.ID, tc.desc)
+	}
+}
+
+// TestGetPrice service layer test function to test reading the current price and the price history
+func TestGetPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	priceStore := datastore.NewMockPrice(ctrl)
+	s := New(carStore, priceStore)
+
+	id := uuid.New().String()
+	car := models.Car{ListPrice: 2599900, MSRP: 2699900, Currency: "EUR"}
+	history := []models.PriceChange{{Price: car.Price(), Actor: "sahil", Reason: "launch"}}
+
+	carStore.EXPECT().GetCarByID(gomock.Any(), id).Return(car, nil).Times(2)
+	priceStore.EXPECT().GetPriceHistory(gomock.Any(), id).Return(history, nil)
+
+	price, err := s.GetPrice(context.TODO(), id)
+	assert.Nil(t, err)
+	assert.Equal(t, models.Price{MSRP: 2699900, ListPrice: 2599900, Currency: "EUR"}, price)
+
+	changes, err := s.GetPriceHistory(context.TODO(), id)
+	assert.Nil(t, err)
+	assert.Equal(t, history, changes)
+
+	missing := uuid.New().String()
+	carStore.EXPECT().GetCarByID(gomock.Any(), missing).Return(models.Car{}, sql.ErrNoRows)
+
+	_, err = s.GetPriceHistory(context.TODO(), missing)
+	assert.Equal(t, ErrCarNotFound, err)
+}
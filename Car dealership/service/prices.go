@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Prices interface {
+	GetPrice(ctx context.Context, id string) (models.Price, error)
+	SetPrice(ctx context.Context, id string, change models.PriceChange) (models.PriceChange, error)
+	GetPriceHistory(ctx context.Context, id string) ([]models.PriceChange, error)
+}
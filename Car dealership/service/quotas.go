@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Quotas interface {
+	Usage(ctx context.Context, day time.Time) ([]models.QuotaUsage, error)
+}
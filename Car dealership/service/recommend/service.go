@@ -0,0 +1,119 @@
+package recommend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultLimit is how many cars are suggested when no limit is asked for
+	DefaultLimit = 5
+	// MaxLimit is the most cars suggested at once
+	MaxLimit = 20
+)
+
+var (
+	ErrCarNotFound    = errors.New("car not found")
+	ErrInvalidWeights = errors.New("invalid weights")
+	ErrInvalidLimit   = errors.New("invalid limit")
+)
+
+type service struct {
+	car    datastore.Car
+	engine datastore.Engine
+}
+
+func New(car datastore.Car, engine datastore.Engine) service { //nolint
+	return service{car: car, engine: engine}
+}
+
+// Similar service layer function to suggest the in stock cars most like the car with the id, ranked by the weights
+// over the defaults, a limit of 0 suggests DefaultLimit cars
+func (s service) Similar(ctx context.Context, carID, weights string, limit int) (models.Recommendations, error) {
+	switch {
+	case limit == 0:
+		limit = DefaultLimit
+	case limit < 0 || limit > MaxLimit:
+		return models.Recommendations{}, fmt.Errorf("%w: suggest between 1 and %d cars", ErrInvalidLimit, MaxLimit)
+	}
+
+	w, err := parseWeights(weights, DefaultWeights)
+	if err != nil {
+		return models.Recommendations{}, err
+	}
+
+	target, err := s.load(ctx, carID)
+	if err != nil {
+		return models.Recommendations{}, err
+	}
+
+	suggested := []models.Recommendation{}
+
+	err = s.car.StreamCars(ctx, models.CarFilter{Status: models.StatusInStock}, true, func(c models.Car) error {
+		if c.ID == target.ID {
+			return nil
+		}
+
+		score, reasons := similarity(target, c, w)
+		suggested = append(suggested, models.Recommendation{Car: c, Score: score, Reasons: reasons})
+
+		return nil
+	})
+	if err != nil {
+		return models.Recommendations{}, err
+	}
+
+	sort.SliceStable(suggested, func(i, j int) bool {
+		if suggested[i].Score != suggested[j].Score {
+			return suggested[i].Score > suggested[j].Score
+		}
+
+		return suggested[i].Car.ID.String() < suggested[j].Car.ID.String()
+	})
+
+	if len(suggested) > limit {
+		suggested = suggested[:limit]
+	}
+
+	return models.Recommendations{CarID: target.ID.String(), Weights: w, Cars: suggested}, nil
+}
+
+// load reads a car with its engine, a car whose engine is missing is compared without one
+func (s service) load(ctx context.Context, id string) (models.Car, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Car{}, fmt.Errorf("%w: %s", ErrCarNotFound, id)
+	}
+
+	car, err := s.car.GetCarByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Car{}, fmt.Errorf("%w: %s", ErrCarNotFound, id)
+	}
+
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	if car.Engine.EngineID == uuid.Nil {
+		return car, nil
+	}
+
+	engine, err := s.engine.EngineGetByID(ctx, car.Engine.EngineID.String())
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return models.Car{}, err
+	default:
+		car.Engine = engine
+	}
+
+	return car, nil
+}
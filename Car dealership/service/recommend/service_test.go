@@ -0,0 +1,110 @@
+package recommend
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSimilar service layer test function to test in stock cars are ranked against the car, best first
+func TestSimilar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCar := datastore.NewMockCar(ctrl)
+	mockEngine := datastore.NewMockEngine(ctrl)
+	s := New(mockCar, mockEngine)
+
+	id, engineID := uuid.New(), uuid.New()
+	engine := models.Engine{EngineID: engineID, Displacement: 2000, NoOfCylinder: 4}
+	target := models.Car{ID: id, Brand: "BMW", FuelType: "Petrol", Year: 2020,
+		Engine: models.Engine{EngineID: engineID}}
+
+	near := models.Car{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Brand: "BMW", FuelType: "Petrol",
+		Year: 2020, Engine: engine}
+	tied := models.Car{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Brand: "BMW", FuelType: "Petrol",
+		Year: 2020, Engine: engine}
+	far := models.Car{ID: uuid.New(), Brand: "Tesla", FuelType: "Electric", Year: 2022,
+		Engine: models.Engine{CarRange: 500}}
+
+	stream := func(cars ...models.Car) func(context.Context, models.CarFilter, bool, func(models.Car) error) error {
+		return func(_ context.Context, _ models.CarFilter, _ bool, fn func(models.Car) error) error {
+			for _, c := range cars {
+				if err := fn(c); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+	}
+
+	loaded := func() {
+		mockCar.EXPECT().GetCarByID(gomock.Any(), id.String()).Return(target, nil)
+		mockEngine.EXPECT().EngineGetByID(gomock.Any(), engineID.String()).Return(engine, nil)
+	}
+
+	testCases := []struct {
+		desc    string
+		id      string
+		weights string
+		limit   int
+		mock    func()
+		ids     []uuid.UUID
+		err     error
+	}{
+		{desc: "success", id: id.String(), ids: []uuid.UUID{tied.ID, near.ID, far.ID},
+			mock: func() {
+				loaded()
+				mockCar.EXPECT().StreamCars(gomock.Any(), models.CarFilter{Status: models.StatusInStock}, true,
+					gomock.Any()).DoAndReturn(stream(far, target, near, tied))
+			}},
+		{desc: "limited", id: id.String(), limit: 1, weights: "brand:0", ids: []uuid.UUID{tied.ID},
+			mock: func() {
+				loaded()
+				mockCar.EXPECT().StreamCars(gomock.Any(), gomock.Any(), true, gomock.Any()).
+					DoAndReturn(stream(far, near, tied))
+			}},
+		{desc: "limit too high", id: id.String(), limit: MaxLimit + 1, err: ErrInvalidLimit},
+		{desc: "negative limit", id: id.String(), limit: -1, err: ErrInvalidLimit},
+		{desc: "invalid weights", id: id.String(), weights: "colour:1", err: ErrInvalidWeights},
+		{desc: "bad id", id: "abc", err: ErrCarNotFound},
+		{desc: "car not found", id: id.String(), err: ErrCarNotFound,
+			mock: func() {
+				mockCar.EXPECT().GetCarByID(gomock.Any(), id.String()).Return(models.Car{}, sql.ErrNoRows)
+			}},
+		{desc: "stream error", id: id.String(), err: sql.ErrConnDone,
+			mock: func() {
+				loaded()
+				mockCar.EXPECT().StreamCars(gomock.Any(), gomock.Any(), true, gomock.Any()).Return(sql.ErrConnDone)
+			}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Similar(context.TODO(), tc.id, tc.weights, tc.limit)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		ids := []uuid.UUID{}
+		for _, r := range res.Cars {
+			ids = append(ids, r.Car.ID)
+		}
+
+		if tc.err == nil {
+			assert.Equal(t, tc.ids, ids, tc.desc)
+		}
+	}
+}
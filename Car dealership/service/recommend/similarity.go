@@ -0,0 +1,166 @@
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+const (
+	// YearSpan is how many years apart two cars may be before their age counts nothing towards their similarity
+	YearSpan = 10
+	// MaxWeight is the largest weight an attribute may be given
+	MaxWeight = 100
+
+	// reasonAt is the similarity from which an attribute is given as a reason for a suggestion
+	reasonAt = 0.8
+)
+
+// DefaultWeights rank cars mostly by what they run on, then by brand, age and engine
+var DefaultWeights = models.SimilarityWeights{Brand: 20, FuelType: 25, Year: 15, Displacement: 15, Cylinders: 10,
+	Range: 15}
+
+// factor is how alike two cars are in one attribute, from 0 to 1. An attribute applies unless neither car has it,
+// such as the displacement of two electric cars.
+type factor struct {
+	weight     int
+	similarity float64
+	applies    bool
+	reason     string
+}
+
+// similarity scores how alike c is to target from 0 to 100 as the weighted mean of the attributes that apply,
+// with the reasons they are alike
+func similarity(target, c models.Car, w models.SimilarityWeights) (int, []string) {
+	factors := []factor{
+		same(w.Brand, target.Brand, c.Brand, "Same brand, "+c.Brand),
+		same(w.FuelType, target.FuelType, c.FuelType, "Same fuel type, "+c.FuelType),
+		year(w.Year, target.Year, c.Year),
+		relative(w.Displacement, target.Engine.Displacement, c.Engine.Displacement, "engine size", " cc"),
+		relative(w.Cylinders, target.Engine.NoOfCylinder, c.Engine.NoOfCylinder, "number of cylinders", ""),
+		relative(w.Range, target.Engine.CarRange, c.Engine.CarRange, "range", " km"),
+	}
+
+	var total, score float64
+
+	reasons := []string{}
+
+	for _, f := range factors {
+		if !f.applies || f.weight == 0 {
+			continue
+		}
+
+		total += float64(f.weight)
+		score += float64(f.weight) * f.similarity
+
+		if f.similarity >= reasonAt {
+			reasons = append(reasons, f.reason)
+		}
+	}
+
+	if total == 0 {
+		return 0, reasons
+	}
+
+	return int(math.Round(100 * score / total)), reasons
+}
+
+// same compares two names, which match whatever their case
+func same(weight int, a, b, reason string) factor {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+
+	f := factor{weight: weight, applies: a != "" || b != "", reason: reason}
+	if a != "" && strings.EqualFold(a, b) {
+		f.similarity = 1
+	}
+
+	return f
+}
+
+// year falls off evenly until the cars are YearSpan years apart
+func year(weight, a, b int) factor {
+	apart := a - b
+	if apart < 0 {
+		apart = -apart
+	}
+
+	f := factor{weight: weight, applies: true, similarity: math.Max(0, 1-float64(apart)/YearSpan)}
+
+	switch {
+	case apart == 0:
+		f.reason = fmt.Sprintf("Same year, %d", b)
+	default:
+		f.reason = fmt.Sprintf("Close in age, %d against %d", b, a)
+	}
+
+	return f
+}
+
+// relative compares two measures by how far apart they are relative to the larger, a measure of 0 is one the
+// car does not have
+func relative(weight int, a, b int64, name, unit string) factor {
+	f := factor{weight: weight, applies: a != 0 || b != 0}
+
+	if a <= 0 || b <= 0 {
+		return f
+	}
+
+	larger, apart := a, a-b
+	if b > a {
+		larger, apart = b, b-a
+	}
+
+	f.similarity = 1 - float64(apart)/float64(larger)
+
+	if apart == 0 {
+		f.reason = "Same " + name + ", " + strconv.FormatInt(b, 10) + unit
+	} else {
+		f.reason = "Similar " + name + ", " + strconv.FormatInt(b, 10) + unit + " against " +
+			strconv.FormatInt(a, 10) + unit
+	}
+
+	return f
+}
+
+// parseWeights reads weights such as "brand:30,range:0" over the defaults, attributes left out keep their default
+func parseWeights(s string, defaults models.SimilarityWeights) (models.SimilarityWeights, error) {
+	w := defaults
+
+	fields := map[string]*int{"brand": &w.Brand, "fueltype": &w.FuelType, "year": &w.Year,
+		"displacement": &w.Displacement, "cylinders": &w.Cylinders, "range": &w.Range}
+
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		i := strings.IndexByte(pair, ':')
+		if i < 0 {
+			return models.SimilarityWeights{}, fmt.Errorf("%w: %q is not attribute:weight", ErrInvalidWeights, pair)
+		}
+
+		name := strings.ToLower(strings.TrimSpace(pair[:i]))
+
+		field, ok := fields[name]
+		if !ok {
+			return models.SimilarityWeights{}, fmt.Errorf("%w: unknown attribute %q", ErrInvalidWeights, name)
+		}
+
+		weight, err := strconv.Atoi(strings.TrimSpace(pair[i+1:]))
+		if err != nil || weight < 0 || weight > MaxWeight {
+			return models.SimilarityWeights{}, fmt.Errorf("%w: the weight of %s must be between 0 and %d",
+				ErrInvalidWeights, name, MaxWeight)
+		}
+
+		*field = weight
+	}
+
+	if w == (models.SimilarityWeights{}) {
+		return models.SimilarityWeights{}, fmt.Errorf("%w: at least one weight must be above 0", ErrInvalidWeights)
+	}
+
+	return w, nil
+}
@@ -0,0 +1,82 @@
+package recommend
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSimilarity test function to test the weighted score and the reasons given for it
+func TestSimilarity(t *testing.T) {
+	petrol := models.Car{Brand: "BMW", FuelType: "Petrol", Year: 2020,
+		Engine: models.Engine{Displacement: 2000, NoOfCylinder: 4}}
+	electric := models.Car{Brand: "Tesla", FuelType: "Electric", Year: 2021, Engine: models.Engine{CarRange: 500}}
+
+	testCases := []struct {
+		desc    string
+		target  models.Car
+		car     models.Car
+		weights models.SimilarityWeights
+		score   int
+		reasons []string
+	}{
+		{desc: "petrol cars", target: petrol, weights: DefaultWeights, score: 96,
+			car: models.Car{Brand: "bmw", FuelType: "Petrol", Year: 2019,
+				Engine: models.Engine{Displacement: 1800, NoOfCylinder: 4}},
+			reasons: []string{"Same brand, bmw", "Same fuel type, Petrol", "Close in age, 2019 against 2020",
+				"Similar engine size, 1800 cc against 2000 cc", "Same number of cylinders, 4"}},
+		{desc: "electric cars skip the engine", target: electric, weights: DefaultWeights, score: 71,
+			car: models.Car{Brand: "Kia", FuelType: "Electric", Year: 2021, Engine: models.Engine{CarRange: 450}},
+			reasons: []string{"Same fuel type, Electric", "Same year, 2021",
+				"Similar range, 450 km against 500 km"}},
+		{desc: "electric against petrol", target: electric, weights: DefaultWeights, score: 15,
+			car:     models.Car{Brand: "BMW", FuelType: "Petrol", Year: 2021, Engine: petrol.Engine},
+			reasons: []string{"Same year, 2021"}},
+		{desc: "zero weights are left out", target: petrol, weights: models.SimilarityWeights{Brand: 50, Year: 50},
+			car: models.Car{Brand: "Audi", FuelType: "Petrol", Year: 2030}, score: 0, reasons: []string{}},
+		{desc: "nothing applies", target: models.Car{}, weights: models.SimilarityWeights{Brand: 10}, car: models.Car{},
+			score: 0, reasons: []string{}},
+	}
+
+	for i, tc := range testCases {
+		score, reasons := similarity(tc.target, tc.car, tc.weights)
+		if score != tc.score {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, score, tc.score)
+		}
+
+		assert.Equal(t, tc.reasons, reasons, tc.desc)
+	}
+}
+
+// TestParseWeights test function to test weights are read over the defaults
+func TestParseWeights(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		weights models.SimilarityWeights
+		err     error
+	}{
+		{desc: "defaults", input: "", weights: DefaultWeights},
+		{desc: "overrides", input: " brand:40, fuelType:0 ,", weights: models.SimilarityWeights{Brand: 40, Year: 15,
+			Displacement: 15, Cylinders: 10, Range: 15}},
+		{desc: "missing weight", input: "brand", err: ErrInvalidWeights},
+		{desc: "unknown attribute", input: "colour:10", err: ErrInvalidWeights},
+		{desc: "not a number", input: "year:ten", err: ErrInvalidWeights},
+		{desc: "above the max", input: "year:101", err: ErrInvalidWeights},
+		{desc: "negative", input: "year:-1", err: ErrInvalidWeights},
+		{desc: "all zero", input: "brand:0,fueltype:0,year:0,displacement:0,cylinders:0,range:0",
+			err: ErrInvalidWeights},
+	}
+
+	for i, tc := range testCases {
+		w, err := parseWeights(tc.input, DefaultWeights)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		assert.Equal(t, tc.weights, w, tc.desc)
+	}
+}
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Recommender interface {
+	Similar(ctx context.Context, carID, weights string, limit int) (models.Recommendations, error)
+}
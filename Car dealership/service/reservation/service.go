@@ -0,0 +1,234 @@
+package reservation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service/status"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultHold is how long a car is held when no expiry is given
+	DefaultHold = 48 * time.Hour
+	// MaxHold is the furthest ahead a hold may expire, also when it is extended
+	MaxHold = 7 * 24 * time.Hour
+)
+
+var (
+	ErrCarNotFound         = errors.New("car not found")
+	ErrReservationNotFound = errors.New("reservation not found")
+	ErrCarNotAvailable     = errors.New("car can not be held")
+	ErrHoldNotActive       = errors.New("hold is no longer active")
+	ErrInvalidHold         = errors.New("invalid hold")
+)
+
+type service struct {
+	car         datastore.Car
+	reservation datastore.Reservation
+	now         func() time.Time
+}
+
+func New(car datastore.Car, reservation datastore.Reservation) service { //nolint
+	return service{car: car, reservation: reservation, now: time.Now}
+}
+
+// Hold service layer function to hold a car in stock for r.Holder until r.ExpiresAt, DefaultHold from now if
+// no expiry is given
+func (s service) Hold(ctx context.Context, r models.Reservation) (models.Reservation, error) {
+	now := s.clock()
+
+	r.Holder = strings.TrimSpace(r.Holder)
+	if r.Holder == "" {
+		return models.Reservation{}, fmt.Errorf("%w: holder is required", ErrInvalidHold)
+	}
+
+	if r.ExpiresAt.IsZero() {
+		r.ExpiresAt = now.Add(DefaultHold)
+	}
+
+	if err := checkExpiry(r.ExpiresAt, now); err != nil {
+		return models.Reservation{}, err
+	}
+
+	car, err := s.car.GetCarByID(ctx, r.CarID.String())
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Reservation{}, ErrCarNotFound
+	}
+
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	if err = status.CheckHold(car); err != nil {
+		return models.Reservation{}, fmt.Errorf("%w: %v", ErrCarNotAvailable, err)
+	}
+
+	r.ID = uuid.New()
+	r.Status = models.ReservationActive
+	r.CreatedAt = now
+	r.ExpiresAt = r.ExpiresAt.UTC().Truncate(time.Second)
+	r.EndedAt = nil
+
+	r, err = s.reservation.CreateHold(ctx, r)
+	if errors.Is(err, sql.ErrNoRows) {
+		// another request changed the car since it was read
+		return models.Reservation{}, fmt.Errorf("%w: the car is no longer in stock", ErrCarNotAvailable)
+	}
+
+	return r, err
+}
+
+// GetReservation service layer function to get a reservation by its id
+func (s service) GetReservation(ctx context.Context, id string) (models.Reservation, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Reservation{}, ErrReservationNotFound
+	}
+
+	r, err := s.reservation.GetReservation(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Reservation{}, ErrReservationNotFound
+	}
+
+	return r, err
+}
+
+// Extend service layer function to move the expiry of an active hold further ahead
+func (s service) Extend(ctx context.Context, id string, expiresAt time.Time) (models.Reservation, error) {
+	now := s.clock()
+
+	r, err := s.active(ctx, id, now)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	expiresAt = expiresAt.UTC().Truncate(time.Second)
+	if !expiresAt.After(r.ExpiresAt) {
+		return models.Reservation{}, fmt.Errorf("%w: the new expiry must be after the current one", ErrInvalidHold)
+	}
+
+	if err = checkExpiry(expiresAt, now); err != nil {
+		return models.Reservation{}, err
+	}
+
+	err = s.reservation.ExtendHold(ctx, id, expiresAt, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Reservation{}, ErrHoldNotActive
+	}
+
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	r.ExpiresAt = expiresAt
+
+	return r, nil
+}
+
+// Release service layer function to end an active hold and put the car back in stock
+func (s service) Release(ctx context.Context, id string) (models.Reservation, error) {
+	now := s.clock()
+
+	r, err := s.active(ctx, id, now)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	r.Status = models.ReservationReleased
+	r.EndedAt = &now
+
+	err = s.reservation.EndHold(ctx, r)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Reservation{}, ErrHoldNotActive
+	}
+
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	return r, nil
+}
+
+// Sweep expires every active hold whose expiry has passed and returns how many were expired
+func (s service) Sweep(ctx context.Context) (int, error) {
+	now := s.clock()
+
+	holds, err := s.reservation.GetExpiredHolds(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+
+	for i := range holds {
+		holds[i].Status = models.ReservationExpired
+		holds[i].EndedAt = &now
+
+		err = s.reservation.EndHold(ctx, holds[i])
+
+		switch {
+		case err == nil:
+			expired++
+		case errors.Is(err, sql.ErrNoRows):
+			// released or extended since it was read
+		default:
+			log.Println("Cant expire hold", holds[i].ID, err)
+		}
+	}
+
+	return expired, nil
+}
+
+// Run sweeps stale holds every interval until ctx is done
+func (s service) Run(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Sweep(ctx); err != nil {
+				log.Println("Cant sweep holds", err)
+			}
+		}
+	}
+}
+
+// active returns the hold with the id if it is active and has not expired by now
+func (s service) active(ctx context.Context, id string, now time.Time) (models.Reservation, error) {
+	r, err := s.GetReservation(ctx, id)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	if r.Status != models.ReservationActive || !r.ExpiresAt.After(now) {
+		return models.Reservation{}, ErrHoldNotActive
+	}
+
+	return r, nil
+}
+
+func (s service) clock() time.Time {
+	return s.now().UTC().Truncate(time.Second)
+}
+
+func checkExpiry(expiresAt, now time.Time) error {
+	switch {
+	case !expiresAt.After(now):
+		return fmt.Errorf("%w: the expiry must be in the future", ErrInvalidHold)
+	case expiresAt.After(now.Add(MaxHold)):
+		return fmt.Errorf("%w: a car can be held for at most 7 days", ErrInvalidHold)
+	}
+
+	return nil
+}
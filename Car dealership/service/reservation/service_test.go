@@ -0,0 +1,175 @@
+package reservation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// TestHold service layer test function to test holds are only placed on available cars
+func TestHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	reservationStore := datastore.NewMockReservation(ctrl)
+	s := New(carStore, reservationStore)
+	s.now = func() time.Time { return now }
+
+	carID := uuid.New()
+	inStock := models.Car{ID: carID, Status: models.StatusInStock, ListPrice: 2499900}
+	hold := models.Reservation{CarID: carID, Holder: "sahil"}
+
+	stored := func(_ context.Context, r models.Reservation) (models.Reservation, error) {
+		return r, nil
+	}
+
+	testCases := []struct {
+		desc    string
+		input   models.Reservation
+		mock    func()
+		expires time.Time
+		err     error
+	}{
+		{desc: "default expiry", input: hold, expires: now.Add(48 * time.Hour),
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(inStock, nil)
+				reservationStore.EXPECT().CreateHold(gomock.Any(), gomock.Any()).DoAndReturn(stored)
+			}},
+		{desc: "given expiry", input: models.Reservation{CarID: carID, Holder: "sahil", ExpiresAt: now.Add(time.Hour)},
+			expires: now.Add(time.Hour),
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(inStock, nil)
+				reservationStore.EXPECT().CreateHold(gomock.Any(), gomock.Any()).DoAndReturn(stored)
+			}},
+		{desc: "missing holder", input: models.Reservation{CarID: carID}, err: ErrInvalidHold},
+		{desc: "expiry too far", input: models.Reservation{CarID: carID, Holder: "sahil", ExpiresAt: now.AddDate(0, 1, 0)},
+			err: ErrInvalidHold},
+		{desc: "car not found", input: hold, err: ErrCarNotFound,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{}, sql.ErrNoRows)
+			}},
+		{desc: "car already held", input: hold, err: ErrCarNotAvailable,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).
+					Return(models.Car{ID: carID, Status: models.StatusReserved, ListPrice: 2499900}, nil)
+			}},
+		{desc: "held concurrently", input: hold, err: ErrCarNotAvailable,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(inStock, nil)
+				reservationStore.EXPECT().CreateHold(gomock.Any(), gomock.Any()).
+					Return(models.Reservation{}, sql.ErrNoRows)
+			}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Hold(context.TODO(), tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, models.ReservationActive, res.Status, tc.desc)
+		assert.Equal(t, tc.expires, res.ExpiresAt, tc.desc)
+		assert.Equal(t, now, res.CreatedAt, tc.desc)
+	}
+}
+
+// TestExtendRelease service layer test function to test only active holds are extended or released
+func TestExtendRelease(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reservationStore := datastore.NewMockReservation(ctrl)
+	s := New(datastore.NewMockCar(ctrl), reservationStore)
+	s.now = func() time.Time { return now }
+
+	active := models.Reservation{ID: uuid.New(), CarID: uuid.New(), Holder: "sahil",
+		Status: models.ReservationActive, CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(47 * time.Hour)}
+	lapsed := active
+	lapsed.ExpiresAt = now.Add(-time.Minute)
+	id := active.ID.String()
+	until := now.Add(72 * time.Hour)
+
+	reservationStore.EXPECT().GetReservation(gomock.Any(), id).Return(active, nil).Times(4)
+	reservationStore.EXPECT().ExtendHold(gomock.Any(), id, until, now).Return(nil)
+	reservationStore.EXPECT().EndHold(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r models.Reservation) error {
+			assert.Equal(t, models.ReservationReleased, r.Status)
+			assert.Equal(t, now, *r.EndedAt)
+
+			return nil
+		})
+	reservationStore.EXPECT().EndHold(gomock.Any(), gomock.Any()).Return(sql.ErrNoRows)
+
+	res, err := s.Extend(context.TODO(), id, until)
+	assert.Nil(t, err)
+	assert.Equal(t, until, res.ExpiresAt)
+
+	_, err = s.Extend(context.TODO(), id, now.Add(time.Hour))
+	assert.True(t, errors.Is(err, ErrInvalidHold))
+
+	res, err = s.Release(context.TODO(), id)
+	assert.Nil(t, err)
+	assert.Equal(t, models.ReservationReleased, res.Status)
+
+	_, err = s.Release(context.TODO(), id)
+	assert.Equal(t, ErrHoldNotActive, err)
+
+	reservationStore.EXPECT().GetReservation(gomock.Any(), id).Return(lapsed, nil)
+
+	_, err = s.Release(context.TODO(), id)
+	assert.Equal(t, ErrHoldNotActive, err)
+
+	_, err = s.Extend(context.TODO(), "abc", until)
+	assert.Equal(t, ErrReservationNotFound, err)
+}
+
+// TestSweep service layer test function to test stale holds are expired
+func TestSweep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reservationStore := datastore.NewMockReservation(ctrl)
+	s := New(datastore.NewMockCar(ctrl), reservationStore)
+	s.now = func() time.Time { return now }
+
+	stale := []models.Reservation{
+		{ID: uuid.New(), Status: models.ReservationActive, ExpiresAt: now.Add(-time.Hour)},
+		{ID: uuid.New(), Status: models.ReservationActive, ExpiresAt: now.Add(-time.Minute)},
+		{ID: uuid.New(), Status: models.ReservationActive, ExpiresAt: now},
+	}
+
+	reservationStore.EXPECT().GetExpiredHolds(gomock.Any(), now).Return(stale, nil)
+	gomock.InOrder(
+		reservationStore.EXPECT().EndHold(gomock.Any(), gomock.Any()).Return(nil),
+		reservationStore.EXPECT().EndHold(gomock.Any(), gomock.Any()).Return(sql.ErrNoRows),
+		reservationStore.EXPECT().EndHold(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, r models.Reservation) error {
+				assert.Equal(t, models.ReservationExpired, r.Status)
+				return nil
+			}),
+	)
+
+	n, err := s.Sweep(context.TODO())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+}
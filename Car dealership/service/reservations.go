@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Reservations interface {
+	Hold(ctx context.Context, r models.Reservation) (models.Reservation, error)
+	GetReservation(ctx context.Context, id string) (models.Reservation, error)
+	Extend(ctx context.Context, id string, expiresAt time.Time) (models.Reservation, error)
+	Release(ctx context.Context, id string) (models.Reservation, error)
+}
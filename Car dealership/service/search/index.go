@@ -0,0 +1,303 @@
+package search
+
+import (
+	"context"
+	"html"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+// defaultLimit is the number of results returned when the caller does not ask for a limit
+const defaultLimit = 20
+
+// field is a bit set of the car fields a term was found in
+type field uint8
+
+const (
+	fieldName field = 1 << iota
+	fieldBrand
+	fieldFuel
+	fieldYear
+)
+
+// fields lists the searchable fields with their weight in the relevance score
+var fields = []struct {
+	field  field
+	name   string
+	weight float64
+	value  func(c models.Car) string
+}{
+	{fieldName, "Name", 3, func(c models.Car) string { return c.Name }},
+	{fieldBrand, "Brand", 2, func(c models.Car) string { return c.Brand }},
+	{fieldFuel, "FuelType", 1, func(c models.Car) string { return c.FuelType }},
+	{fieldYear, "Year", 1, func(c models.Car) string { return strconv.Itoa(c.Year) }},
+}
+
+// match quality of a query word against an indexed term
+const (
+	exactMatch  = 1.0
+	prefixMatch = 0.8
+	fuzzyMatch  = 0.6
+)
+
+// Index is an in-process inverted index over the searchable fields of every car
+type Index struct {
+	mu       sync.RWMutex
+	cars     map[uuid.UUID]models.Car
+	postings map[string]map[uuid.UUID]field
+}
+
+func NewIndex() *Index {
+	return &Index{cars: make(map[uuid.UUID]models.Car), postings: make(map[string]map[uuid.UUID]field)}
+}
+
+// Load indexes every car currently in the store
+func (x *Index) Load(ctx context.Context, car datastore.Car) error {
+	return car.StreamCars(ctx, models.CarFilter{}, false, func(c models.Car) error {
+		x.Put(c)
+		return nil
+	})
+}
+
+// Put adds or replaces a car in the index
+func (x *Index) Put(c models.Car) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.put(c)
+}
+
+// renameBrands re-indexes the cars of each rename under their new brand, in the order the store applies them
+func (x *Index) renameBrands(renames []models.BrandRename) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, r := range renames {
+		var renamed []models.Car
+
+		for _, c := range x.cars {
+			if c.Brand == r.From {
+				c.Brand = r.To
+				renamed = append(renamed, c)
+			}
+		}
+
+		for i := range renamed {
+			x.put(renamed[i])
+		}
+	}
+}
+
+// put adds or replaces a car and its postings, callers must hold x.mu
+func (x *Index) put(c models.Car) {
+	x.remove(c.ID)
+	x.cars[c.ID] = c
+
+	for _, f := range fields {
+		for _, t := range tokenize(f.value(c)) {
+			docs, ok := x.postings[t.term]
+			if !ok {
+				docs = make(map[uuid.UUID]field)
+				x.postings[t.term] = docs
+			}
+
+			docs[c.ID] |= f.field
+		}
+	}
+}
+
+// update changes attributes of an indexed car which are not searched, such as its price or status,
+// so the postings stay as they are
+func (x *Index) update(id uuid.UUID, fn func(c *models.Car)) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	c, ok := x.cars[id]
+	if !ok {
+		return
+	}
+
+	fn(&c)
+	x.cars[id] = c
+}
+
+// Delete removes a car from the index
+func (x *Index) Delete(id uuid.UUID) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(id)
+}
+
+// remove drops a car and its postings, callers must hold x.mu
+func (x *Index) remove(id uuid.UUID) {
+	c, ok := x.cars[id]
+	if !ok {
+		return
+	}
+
+	delete(x.cars, id)
+
+	for _, f := range fields {
+		for _, t := range tokenize(f.value(c)) {
+			delete(x.postings[t.term], id)
+
+			if len(x.postings[t.term]) == 0 {
+				delete(x.postings, t.term)
+			}
+		}
+	}
+}
+
+// hit collects how well a single car matched a query
+type hit struct {
+	score   float64
+	words   int
+	matched map[field]map[string]bool
+}
+
+// Search service layer function to find the cars matching every word of query, best matches first
+func (x *Index) Search(ctx context.Context, query string, limit int) ([]models.SearchResult, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	words := tokenize(query)
+	if len(words) == 0 {
+		return []models.SearchResult{}, nil
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	hits := make(map[uuid.UUID]*hit)
+
+	for _, w := range words {
+		best := make(map[uuid.UUID]float64)
+
+		for term, docs := range x.postings {
+			quality := matchQuality(w.term, term)
+			if quality == 0 {
+				continue
+			}
+
+			idf := 1 + math.Log(float64(len(x.cars))/float64(len(docs)))
+
+			for id, in := range docs {
+				h, ok := hits[id]
+				if !ok {
+					h = &hit{matched: make(map[field]map[string]bool)}
+					hits[id] = h
+				}
+
+				for _, f := range fields {
+					if in&f.field == 0 {
+						continue
+					}
+
+					if h.matched[f.field] == nil {
+						h.matched[f.field] = make(map[string]bool)
+					}
+
+					h.matched[f.field][term] = true
+
+					if s := f.weight * quality * idf; s > best[id] {
+						best[id] = s
+					}
+				}
+			}
+		}
+
+		for id, s := range best {
+			hits[id].score += s
+			hits[id].words++
+		}
+	}
+
+	results := make([]models.SearchResult, 0, len(hits))
+
+	for id, h := range hits {
+		if h.words < len(words) {
+			continue
+		}
+
+		results = append(results, models.SearchResult{
+			Car:        x.cars[id],
+			Score:      math.Round(h.score*1000) / 1000,
+			Highlights: highlights(x.cars[id], h.matched),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+
+		return results[i].Car.Name < results[j].Car.Name
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// matchQuality scores an indexed term against a query word, 0 means no match
+func matchQuality(word, term string) float64 {
+	switch {
+	case word == term:
+		return exactMatch
+	case len(word) >= 2 && strings.HasPrefix(term, word):
+		return prefixMatch
+	}
+
+	if edits := maxEdits(word); edits > 0 && distance(word, term, edits) <= edits {
+		return fuzzyMatch
+	}
+
+	return 0
+}
+
+// highlights wraps the matched words of every matched field in <mark> tags, the rest of the value is
+// HTML escaped so the highlight can be rendered as is
+func highlights(c models.Car, matched map[field]map[string]bool) map[string]string {
+	out := make(map[string]string)
+
+	for _, f := range fields {
+		terms := matched[f.field]
+		if len(terms) == 0 {
+			continue
+		}
+
+		value := f.value(c)
+
+		var (
+			b    strings.Builder
+			last int
+		)
+
+		for _, t := range tokenize(value) {
+			if !terms[t.term] {
+				continue
+			}
+
+			b.WriteString(html.EscapeString(value[last:t.start]))
+			b.WriteString("<mark>" + html.EscapeString(value[t.start:t.end]) + "</mark>")
+			last = t.end
+		}
+
+		b.WriteString(html.EscapeString(value[last:]))
+		out[f.name] = b.String()
+	}
+
+	return out
+}
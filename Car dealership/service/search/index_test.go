@@ -0,0 +1,110 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testIndex() (*Index, []models.Car) {
+	cars := []models.Car{
+		{ID: uuid.New(), Name: "Model 3", Year: 2018, Brand: "Tesla", FuelType: "electric"},
+		{ID: uuid.New(), Name: "Model X", Year: 2016, Brand: "Tesla", FuelType: "electric"},
+		{ID: uuid.New(), Name: "Cayenne E-Hybrid", Year: 2020, Brand: "Porsche", FuelType: "petrol"},
+		{ID: uuid.New(), Name: "GLA <AMG>", Year: 2019, Brand: "Mercedes", FuelType: "diesel"},
+	}
+
+	x := NewIndex()
+	for _, c := range cars {
+		x.Put(c)
+	}
+
+	return x, cars
+}
+
+// TestSearch test function to test matching and ranking of the index
+func TestSearch(t *testing.T) {
+	x, cars := testIndex()
+
+	testCases := []struct {
+		desc  string
+		query string
+		limit int
+		ids   []uuid.UUID
+	}{
+		{desc: "case insensitive brand", query: "TESLA", ids: []uuid.UUID{cars[0].ID, cars[1].ID}},
+		{desc: "every word must match", query: "tesla 2016", ids: []uuid.UUID{cars[1].ID}},
+		{desc: "typo in brand", query: "porshe", ids: []uuid.UUID{cars[2].ID}},
+		{desc: "transposed letters", query: "mreCedes", ids: []uuid.UUID{cars[3].ID}},
+		{desc: "prefix", query: "cay", ids: []uuid.UUID{cars[2].ID}},
+		{desc: "name ranks above fuel type", query: "hybrid", ids: []uuid.UUID{cars[2].ID}},
+		{desc: "limit", query: "model", limit: 1, ids: []uuid.UUID{cars[0].ID}},
+		{desc: "no match", query: "ferrari", ids: []uuid.UUID{}},
+		{desc: "short words are not fuzzy", query: "x3", ids: []uuid.UUID{}},
+	}
+
+	for i, tc := range testCases {
+		res, err := x.Search(context.TODO(), tc.query, tc.limit)
+		assert.Nil(t, err)
+
+		ids := []uuid.UUID{}
+		for _, r := range res {
+			ids = append(ids, r.Car.ID)
+		}
+
+		assert.Equal(t, tc.ids, ids, "[TEST %v] %v", i, tc.desc)
+	}
+}
+
+// TestHighlights test function to test matched words are marked and the rest escaped
+func TestHighlights(t *testing.T) {
+	x, _ := testIndex()
+
+	res, _ := x.Search(context.TODO(), "amg mercedez", 0)
+	if assert.Equal(t, 1, len(res)) {
+		assert.Equal(t, map[string]string{"Name": "GLA &lt;<mark>AMG</mark>&gt;", "Brand": "<mark>Mercedes</mark>"},
+			res[0].Highlights)
+	}
+}
+
+// TestIndexUpdates test function to test replaced and deleted cars leave the index
+func TestIndexUpdates(t *testing.T) {
+	x, cars := testIndex()
+
+	renamed := cars[0]
+	renamed.Name = "Roadster"
+	x.Put(renamed)
+	x.Delete(cars[1].ID)
+
+	res, _ := x.Search(context.TODO(), "model", 0)
+	assert.Equal(t, 0, len(res))
+
+	res, _ = x.Search(context.TODO(), "roadster", 0)
+	assert.Equal(t, 1, len(res))
+
+	_, ok := x.postings["x"]
+	assert.False(t, ok)
+}
+
+// TestDistance test function to test the edit distance used for typos
+func TestDistance(t *testing.T) {
+	testCases := []struct {
+		a, b  string
+		limit int
+		dist  int
+	}{
+		{"tesla", "tesla", 1, 0},
+		{"tesla", "telsa", 1, 1},
+		{"porshe", "porsche", 1, 1},
+		{"bmw", "audi", 1, 2},
+		{"ferrari", "fiat", 2, 3},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.dist, distance(tc.a, tc.b, tc.limit), tc.a+" "+tc.b)
+	}
+}
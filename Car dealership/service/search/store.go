@@ -0,0 +1,217 @@
+package search
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+// IndexedStore is a datastore.Car which keeps an Index in sync with every successful write
+type IndexedStore struct {
+	datastore.Car
+	index *Index
+}
+
+func NewIndexedStore(car datastore.Car, index *Index) IndexedStore {
+	return IndexedStore{Car: car, index: index}
+}
+
+// CreateCar creates the car and adds it to the index
+func (s IndexedStore) CreateCar(ctx context.Context, car *models.Car) (models.Car, error) {
+	c, err := s.Car.CreateCar(ctx, car)
+	if err != nil {
+		return c, err
+	}
+
+	s.index.Put(c)
+
+	return c, nil
+}
+
+// CreateCars creates the cars and adds them to the index once the transaction is committed
+func (s IndexedStore) CreateCars(ctx context.Context, cars []models.Car) error {
+	if err := s.Car.CreateCars(ctx, cars); err != nil {
+		return err
+	}
+
+	for i := range cars {
+		s.index.Put(cars[i])
+	}
+
+	return nil
+}
+
+// UpdateCar updates the car and re-indexes it
+func (s IndexedStore) UpdateCar(ctx context.Context, id string, car models.Car) (models.Car, error) {
+	c, err := s.Car.UpdateCar(ctx, id, car)
+	if err != nil {
+		return c, err
+	}
+
+	s.index.Put(c)
+
+	return c, nil
+}
+
+// DeleteCar deletes the car and removes it from the index
+func (s IndexedStore) DeleteCar(ctx context.Context, id string) (models.Car, error) {
+	c, err := s.Car.DeleteCar(ctx, id)
+	if err != nil {
+		return c, err
+	}
+
+	if carID, err := uuid.Parse(id); err == nil {
+		s.index.Delete(carID)
+	}
+
+	return c, nil
+}
+
+// IndexedPrices is a datastore.Price which updates the price of indexed cars, so that search results
+// carry the current price
+type IndexedPrices struct {
+	datastore.Price
+	index *Index
+}
+
+func NewIndexedPrices(price datastore.Price, index *Index) IndexedPrices {
+	return IndexedPrices{Price: price, index: index}
+}
+
+// SetPrice sets the price and updates the indexed car
+func (s IndexedPrices) SetPrice(ctx context.Context, change models.PriceChange) (models.PriceChange, error) {
+	c, err := s.Price.SetPrice(ctx, change)
+	if err != nil {
+		return c, err
+	}
+
+	s.index.update(c.CarID, func(car *models.Car) {
+		car.MSRP, car.ListPrice, car.Cost, car.Currency = c.Price.MSRP, c.Price.ListPrice, c.Price.Cost, c.Price.Currency
+	})
+
+	return c, nil
+}
+
+// IndexedStatus is a datastore.Status which updates the status of indexed cars
+type IndexedStatus struct {
+	datastore.Status
+	index *Index
+}
+
+func NewIndexedStatus(status datastore.Status, index *Index) IndexedStatus {
+	return IndexedStatus{Status: status, index: index}
+}
+
+// Transition moves the car to its new status and updates the indexed car
+func (s IndexedStatus) Transition(ctx context.Context, change models.StatusChange) (models.StatusChange, error) {
+	c, err := s.Status.Transition(ctx, change)
+	if err != nil {
+		return c, err
+	}
+
+	s.index.update(c.CarID, func(car *models.Car) {
+		car.Status = c.To
+	})
+
+	return c, nil
+}
+
+// IndexedReservations is a datastore.Reservation which keeps the status of indexed cars in step with their holds
+type IndexedReservations struct {
+	datastore.Reservation
+	index *Index
+}
+
+func NewIndexedReservations(reservation datastore.Reservation, index *Index) IndexedReservations {
+	return IndexedReservations{Reservation: reservation, index: index}
+}
+
+// CreateHold places the hold and marks the indexed car reserved
+func (s IndexedReservations) CreateHold(ctx context.Context, r models.Reservation) (models.Reservation, error) {
+	res, err := s.Reservation.CreateHold(ctx, r)
+	if err != nil {
+		return res, err
+	}
+
+	s.index.update(res.CarID, func(car *models.Car) {
+		car.Status = models.StatusReserved
+	})
+
+	return res, nil
+}
+
+// EndHold ends the hold and puts the indexed car back in stock
+func (s IndexedReservations) EndHold(ctx context.Context, r models.Reservation) error {
+	if err := s.Reservation.EndHold(ctx, r); err != nil {
+		return err
+	}
+
+	s.index.update(r.CarID, func(car *models.Car) {
+		car.Status = models.StatusInStock
+	})
+
+	return nil
+}
+
+// IndexedOrders is a datastore.Order which marks indexed cars sold with their order and back in stock when
+// the order is cancelled
+type IndexedOrders struct {
+	datastore.Order
+	index *Index
+}
+
+func NewIndexedOrders(order datastore.Order, index *Index) IndexedOrders {
+	return IndexedOrders{Order: order, index: index}
+}
+
+// CreateOrder sells the car and marks the indexed car sold
+func (s IndexedOrders) CreateOrder(ctx context.Context, o models.SalesOrder) (models.SalesOrder, error) {
+	res, err := s.Order.CreateOrder(ctx, o)
+	if err != nil {
+		return res, err
+	}
+
+	s.index.update(res.CarID, func(car *models.Car) {
+		car.Status = models.StatusSold
+	})
+
+	return res, nil
+}
+
+// CancelOrder cancels the order and puts the indexed car back in stock
+func (s IndexedOrders) CancelOrder(ctx context.Context, o models.SalesOrder) error {
+	if err := s.Order.CancelOrder(ctx, o); err != nil {
+		return err
+	}
+
+	s.index.update(o.CarID, func(car *models.Car) {
+		car.Status = models.StatusInStock
+	})
+
+	return nil
+}
+
+// IndexedCatalogue is a datastore.Catalogue which re-indexes the cars whose brand a rename or migration rewrites
+type IndexedCatalogue struct {
+	datastore.Catalogue
+	index *Index
+}
+
+func NewIndexedCatalogue(catalogue datastore.Catalogue, index *Index) IndexedCatalogue {
+	return IndexedCatalogue{Catalogue: catalogue, index: index}
+}
+
+// RenameBrands renames the brands of cars and re-indexes the renamed cars under their new brand
+func (s IndexedCatalogue) RenameBrands(ctx context.Context, renames []models.BrandRename) (int, error) {
+	n, err := s.Catalogue.RenameBrands(ctx, renames)
+	if err != nil {
+		return n, err
+	}
+
+	s.index.renameBrands(renames)
+
+	return n, nil
+}
@@ -0,0 +1,211 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIndexedStore test function to test the index follows successful store writes only
+func TestIndexedStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockCar(ctrl)
+	index := NewIndex()
+	s := NewIndexedStore(mockStore, index)
+
+	car := models.Car{ID: uuid.New(), Name: "Model 3", Year: 2018, Brand: "Tesla", FuelType: "electric"}
+	updated := car
+	updated.Name = "Roadster"
+	failed := models.Car{ID: uuid.New(), Name: "GenX", Brand: "Ferrari"}
+
+	count := func(q string) int {
+		res, _ := index.Search(context.TODO(), q, 0)
+		return len(res)
+	}
+
+	mockStore.EXPECT().CreateCar(gomock.Any(), &car).Return(car, nil)
+	mockStore.EXPECT().CreateCar(gomock.Any(), &failed).Return(models.Car{}, errors.New("db error"))
+	mockStore.EXPECT().CreateCars(gomock.Any(), []models.Car{failed}).Return(errors.New("db error"))
+	mockStore.EXPECT().UpdateCar(gomock.Any(), car.ID.String(), updated).Return(updated, nil)
+	mockStore.EXPECT().DeleteCar(gomock.Any(), car.ID.String()).Return(models.Car{}, nil)
+
+	_, _ = s.CreateCar(context.TODO(), &car)
+	assert.Equal(t, 1, count("tesla"))
+
+	_, _ = s.CreateCar(context.TODO(), &failed)
+	_ = s.CreateCars(context.TODO(), []models.Car{failed})
+	assert.Equal(t, 0, count("ferrari"))
+
+	_, _ = s.UpdateCar(context.TODO(), car.ID.String(), updated)
+	assert.Equal(t, 0, count("model"))
+	assert.Equal(t, 1, count("roadster"))
+
+	_, _ = s.DeleteCar(context.TODO(), car.ID.String())
+	assert.Equal(t, 0, count("tesla"))
+}
+
+// TestLoad test function to test the index is built from the store
+func TestLoad(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockCar(ctrl)
+	index := NewIndex()
+
+	mockStore.EXPECT().StreamCars(gomock.Any(), models.CarFilter{}, false, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ models.CarFilter, _ bool, fn func(models.Car) error) error {
+			return fn(models.Car{ID: uuid.New(), Name: "X4", Brand: "BMW"})
+		})
+
+	assert.Nil(t, index.Load(context.TODO(), mockStore))
+
+	res, _ := index.Search(context.TODO(), "bmw", 0)
+	assert.Equal(t, 1, len(res))
+}
+
+// TestIndexedPrices test function to test search results carry the price once it is set
+func TestIndexedPrices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockPrice(ctrl)
+	index := NewIndex()
+	s := NewIndexedPrices(mockStore, index)
+
+	car := models.Car{ID: uuid.New(), Name: "Model 3", Year: 2018, Brand: "Tesla", FuelType: "electric"}
+	index.Put(car)
+
+	change := models.PriceChange{CarID: car.ID, Price: models.Price{ListPrice: 4500000, Currency: "USD"}}
+
+	mockStore.EXPECT().SetPrice(gomock.Any(), change).Return(change, nil)
+
+	_, err := s.SetPrice(context.TODO(), change)
+	assert.Nil(t, err)
+
+	res, _ := index.Search(context.TODO(), "tesla", 0)
+	assert.Equal(t, 1, len(res))
+	assert.Equal(t, change.Price, res[0].Car.Price())
+}
+
+// TestIndexedStatus test function to test search results carry the status once it changes
+func TestIndexedStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockStatus(ctrl)
+	index := NewIndex()
+	s := NewIndexedStatus(mockStore, index)
+
+	car := models.Car{ID: uuid.New(), Name: "Model 3", Brand: "Tesla", Status: models.StatusInStock}
+	index.Put(car)
+
+	change := models.StatusChange{CarID: car.ID, From: models.StatusInStock, To: models.StatusSold}
+
+	mockStore.EXPECT().Transition(gomock.Any(), change).Return(change, nil)
+
+	_, err := s.Transition(context.TODO(), change)
+	assert.Nil(t, err)
+
+	res, _ := index.Search(context.TODO(), "tesla", 0)
+	assert.Equal(t, models.StatusSold, res[0].Car.Status)
+}
+
+// TestIndexedReservations test function to test search results follow a car in and out of a hold
+func TestIndexedReservations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockReservation(ctrl)
+	index := NewIndex()
+	s := NewIndexedReservations(mockStore, index)
+
+	car := models.Car{ID: uuid.New(), Name: "Model 3", Brand: "Tesla", Status: models.StatusInStock}
+	index.Put(car)
+
+	hold := models.Reservation{ID: uuid.New(), CarID: car.ID, Holder: "sahil", Status: models.ReservationActive}
+
+	mockStore.EXPECT().CreateHold(gomock.Any(), hold).Return(hold, nil)
+	mockStore.EXPECT().EndHold(gomock.Any(), hold).Return(nil)
+
+	_, err := s.CreateHold(context.TODO(), hold)
+	assert.Nil(t, err)
+
+	res, _ := index.Search(context.TODO(), "tesla", 0)
+	assert.Equal(t, models.StatusReserved, res[0].Car.Status)
+
+	err = s.EndHold(context.TODO(), hold)
+	assert.Nil(t, err)
+
+	res, _ = index.Search(context.TODO(), "tesla", 0)
+	assert.Equal(t, models.StatusInStock, res[0].Car.Status)
+}
+
+// TestIndexedOrders test function to test search results show a car sold with its order until it is cancelled
+func TestIndexedOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockOrder(ctrl)
+	index := NewIndex()
+	s := NewIndexedOrders(mockStore, index)
+
+	car := models.Car{ID: uuid.New(), Name: "Model 3", Brand: "Tesla", Status: models.StatusInStock}
+	index.Put(car)
+
+	o := models.SalesOrder{ID: uuid.New(), CarID: car.ID, Status: models.OrderOpen}
+
+	mockStore.EXPECT().CreateOrder(gomock.Any(), o).Return(o, nil)
+	mockStore.EXPECT().CancelOrder(gomock.Any(), o).Return(nil)
+
+	_, err := s.CreateOrder(context.TODO(), o)
+	assert.Nil(t, err)
+
+	res, _ := index.Search(context.TODO(), "tesla", 0)
+	assert.Equal(t, models.StatusSold, res[0].Car.Status)
+
+	err = s.CancelOrder(context.TODO(), o)
+	assert.Nil(t, err)
+
+	res, _ = index.Search(context.TODO(), "tesla", 0)
+	assert.Equal(t, models.StatusInStock, res[0].Car.Status)
+}
+
+// TestIndexedCatalogue test function to test renamed brands are re-indexed after a successful rename only
+func TestIndexedCatalogue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockCatalogue(ctrl)
+	index := NewIndex()
+	s := NewIndexedCatalogue(mockStore, index)
+
+	car := models.Car{ID: uuid.New(), Name: "Golf", Brand: "VW"}
+	index.Put(car)
+
+	count := func(q string) int {
+		res, _ := index.Search(context.TODO(), q, 0)
+		return len(res)
+	}
+
+	failed := []models.BrandRename{{From: "VW", To: "Volks Wagen"}}
+	renames := []models.BrandRename{{From: "VW", To: "Volkswagen"}}
+
+	mockStore.EXPECT().RenameBrands(gomock.Any(), failed).Return(0, errors.New("db error"))
+	mockStore.EXPECT().RenameBrands(gomock.Any(), renames).Return(1, nil)
+
+	_, _ = s.RenameBrands(context.TODO(), failed)
+	assert.Equal(t, 0, count("wagen"))
+
+	_, _ = s.RenameBrands(context.TODO(), renames)
+	assert.Equal(t, 0, count("vw"))
+	assert.Equal(t, 1, count("volkswagen"))
+}
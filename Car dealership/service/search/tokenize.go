@@ -0,0 +1,107 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// token is a lower cased word of a field value with its byte offsets in the original value
+type token struct {
+	term       string
+	start, end int
+}
+
+func tokenize(s string) []token {
+	var (
+		tokens []token
+		start  = -1
+	)
+
+	for i, r := range s {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+
+		switch {
+		case word && start < 0:
+			start = i
+		case !word && start >= 0:
+			tokens = append(tokens, token{term: strings.ToLower(s[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		tokens = append(tokens, token{term: strings.ToLower(s[start:]), start: start, end: len(s)})
+	}
+
+	return tokens
+}
+
+// maxEdits is the number of typos tolerated in a query word, short words and numbers such as
+// years must match exactly
+func maxEdits(term string) int {
+	switch n := utf8.RuneCountInString(term); {
+	case n < 4 || strings.IndexFunc(term, func(r rune) bool { return !unicode.IsDigit(r) }) < 0:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// distance returns the optimal string alignment distance between a and b, giving up with limit+1
+// as soon as the distance is known to exceed limit
+func distance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+
+	if d := len(ra) - len(rb); d > limit || -d > limit {
+		return limit + 1
+	}
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = minInt(cur[j], prev2[j-2]+1)
+			}
+
+			rowMin = minInt(rowMin, cur[j])
+		}
+
+		if rowMin > limit {
+			return limit + 1
+		}
+
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(v int, vs ...int) int {
+	for _, x := range vs {
+		if x < v {
+			v = x
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Searcher interface {
+	Search(ctx context.Context, query string, limit int) ([]models.SearchResult, error)
+}
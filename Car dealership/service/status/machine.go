@@ -0,0 +1,114 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+var (
+	ErrIllegalTransition = errors.New("illegal status transition")
+	ErrGuardFailed       = errors.New("status transition not allowed")
+)
+
+// guard decides whether a car may take an otherwise allowed transition
+type guard func(car models.Car, change models.StatusChange) error
+
+// transitions lists the allowed moves from each status with the guard checked before the move, a status
+// missing from the map, such as delivered, is final
+var transitions = map[string]map[string]guard{
+	models.StatusInTransit: {
+		models.StatusInStock: nil,
+	},
+	models.StatusInStock: {
+		models.StatusInTransit: nil,
+		models.StatusReserved:  byReservation,
+		models.StatusSold:      hasListPrice,
+	},
+	models.StatusReserved: {
+		models.StatusInStock: byReservation,
+		models.StatusSold:    byReservation,
+	},
+	models.StatusSold: {
+		models.StatusInStock:   hasReason,
+		models.StatusDelivered: nil,
+	},
+}
+
+// initial are the statuses a car may be created with
+var initial = map[string]bool{models.StatusInTransit: true, models.StatusInStock: true}
+
+func hasListPrice(car models.Car, _ models.StatusChange) error {
+	if car.ListPrice <= 0 {
+		return fmt.Errorf("%w: the car has no list price", ErrGuardFailed)
+	}
+
+	return nil
+}
+
+// byReservation rejects moves in and out of reserved, a car is reserved by placing a hold on it and
+// stays reserved until the hold is released or expires, or the car is sold to the holder with an order
+func byReservation(car models.Car, _ models.StatusChange) error {
+	if car.Status == models.StatusReserved {
+		return fmt.Errorf("%w: the car is held, release the hold or order it for the holder", ErrGuardFailed)
+	}
+
+	return fmt.Errorf("%w: cars are reserved by placing a hold", ErrGuardFailed)
+}
+
+func hasReason(_ models.Car, change models.StatusChange) error {
+	if change.Reason == "" {
+		return fmt.Errorf("%w: a reason is required to cancel a sale", ErrGuardFailed)
+	}
+
+	return nil
+}
+
+// check returns an error unless car may move to change.To
+func check(car models.Car, change models.StatusChange) error {
+	g, ok := transitions[car.Status][change.To]
+	if !ok {
+		return fmt.Errorf("%w: %s to %s", ErrIllegalTransition, car.Status, change.To)
+	}
+
+	if g == nil {
+		return nil
+	}
+
+	return g(car, change)
+}
+
+// CheckHold returns an error unless a hold may be placed on the car, the reservation subsystem makes this move
+// instead of the status transitions
+func CheckHold(car models.Car) error {
+	if car.Status != models.StatusInStock {
+		return fmt.Errorf("%w: %s to %s", ErrIllegalTransition, car.Status, models.StatusReserved)
+	}
+
+	return hasListPrice(car, models.StatusChange{})
+}
+
+// CheckSale returns an error unless the car may be sold, the order subsystem makes this move instead of the
+// status transitions. A held car passes as it may be sold to its holder, which the order store checks as it
+// closes the hold.
+func CheckSale(car models.Car) error {
+	if car.Status == models.StatusReserved {
+		return hasListPrice(car, models.StatusChange{})
+	}
+
+	return check(car, models.StatusChange{To: models.StatusSold})
+}
+
+// CheckInitial returns an error unless a car may be created with the status, an empty status means in stock
+func CheckInitial(status string) error {
+	if status == "" || initial[status] {
+		return nil
+	}
+
+	if !models.ValidStatus(status) {
+		return fmt.Errorf("%w: %s", models.ErrUnknownStatus, status)
+	}
+
+	return fmt.Errorf("%w: a car cannot be created %s", ErrIllegalTransition, status)
+}
@@ -0,0 +1,113 @@
+package status
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// TestCheck test function to test the allowed transitions and their guards
+func TestCheck(t *testing.T) {
+	priced := func(status string) models.Car {
+		return models.Car{Status: status, ListPrice: 2499900, Currency: "EUR"}
+	}
+
+	testCases := []struct {
+		desc   string
+		car    models.Car
+		change models.StatusChange
+		err    error
+	}{
+		{"arrives in stock", priced(models.StatusInTransit), models.StatusChange{To: models.StatusInStock}, nil},
+		{"sold", priced(models.StatusInStock), models.StatusChange{To: models.StatusSold}, nil},
+		{"reserved without a hold", priced(models.StatusInStock), models.StatusChange{To: models.StatusReserved},
+			ErrGuardFailed},
+		{"held car sold", priced(models.StatusReserved), models.StatusChange{To: models.StatusSold}, ErrGuardFailed},
+		{"held car back in stock", priced(models.StatusReserved), models.StatusChange{To: models.StatusInStock},
+			ErrGuardFailed},
+		{"delivered", priced(models.StatusSold), models.StatusChange{To: models.StatusDelivered}, nil},
+		{"sale cancelled", priced(models.StatusSold),
+			models.StatusChange{To: models.StatusInStock, Reason: "finance declined"}, nil},
+		{"sale cancelled without reason", priced(models.StatusSold), models.StatusChange{To: models.StatusInStock},
+			ErrGuardFailed},
+		{"sold without a price", models.Car{Status: models.StatusInStock}, models.StatusChange{To: models.StatusSold},
+			ErrGuardFailed},
+		{"sold in transit", priced(models.StatusInTransit), models.StatusChange{To: models.StatusSold},
+			ErrIllegalTransition},
+		{"delivered is final", priced(models.StatusDelivered), models.StatusChange{To: models.StatusInStock},
+			ErrIllegalTransition},
+		{"same status", priced(models.StatusInStock), models.StatusChange{To: models.StatusInStock},
+			ErrIllegalTransition},
+	}
+
+	for i, tc := range testCases {
+		err := check(tc.car, tc.change)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
+
+// TestCheckHold test function to test only priced cars in stock can be held
+func TestCheckHold(t *testing.T) {
+	testCases := []struct {
+		desc string
+		car  models.Car
+		err  error
+	}{
+		{"in stock", models.Car{Status: models.StatusInStock, ListPrice: 100}, nil},
+		{"already held", models.Car{Status: models.StatusReserved, ListPrice: 100}, ErrIllegalTransition},
+		{"no list price", models.Car{Status: models.StatusInStock}, ErrGuardFailed},
+	}
+
+	for i, tc := range testCases {
+		err := CheckHold(tc.car)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
+
+// TestCheckSale test function to test only priced cars in stock or held can be sold through an order
+func TestCheckSale(t *testing.T) {
+	testCases := []struct {
+		desc string
+		car  models.Car
+		err  error
+	}{
+		{"in stock", models.Car{Status: models.StatusInStock, ListPrice: 100}, nil},
+		{"held", models.Car{Status: models.StatusReserved, ListPrice: 100}, nil},
+		{"held without list price", models.Car{Status: models.StatusReserved}, ErrGuardFailed},
+		{"already sold", models.Car{Status: models.StatusSold, ListPrice: 100}, ErrIllegalTransition},
+		{"no list price", models.Car{Status: models.StatusInStock}, ErrGuardFailed},
+	}
+
+	for i, tc := range testCases {
+		err := CheckSale(tc.car)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
+
+// TestCheckInitial test function to test the statuses a car can be created with
+func TestCheckInitial(t *testing.T) {
+	testCases := []struct {
+		status string
+		err    error
+	}{
+		{"", nil},
+		{models.StatusInTransit, nil},
+		{models.StatusInStock, nil},
+		{models.StatusSold, ErrIllegalTransition},
+		{"scrapped", models.ErrUnknownStatus},
+	}
+
+	for i, tc := range testCases {
+		err := CheckInitial(tc.status)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.status, err, tc.err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package status
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCarNotFound    = errors.New("car not found")
+	ErrMissingActor   = errors.New("actor is required")
+	ErrStatusConflict = errors.New("the car status was changed by another request")
+)
+
+type statusService struct {
+	car    datastore.Car
+	status datastore.Status
+	now    func() time.Time
+}
+
+func New(car datastore.Car, status datastore.Status) statusService { //nolint
+	return statusService{car: car, status: status, now: time.Now}
+}
+
+// Transition service layer function to move a car to change.To if the state machine allows it from its current status
+func (s statusService) Transition(ctx context.Context, id string,
+	change models.StatusChange) (models.StatusChange, error) {
+	change.To = strings.ToLower(strings.TrimSpace(change.To))
+	change.Actor = strings.TrimSpace(change.Actor)
+	change.Reason = strings.TrimSpace(change.Reason)
+
+	if !models.ValidStatus(change.To) {
+		return models.StatusChange{}, fmt.Errorf("%w: %s", models.ErrUnknownStatus, change.To)
+	}
+
+	if change.Actor == "" {
+		return models.StatusChange{}, ErrMissingActor
+	}
+
+	carID, err := uuid.Parse(id)
+	if err != nil {
+		return models.StatusChange{}, ErrCarNotFound
+	}
+
+	car, err := s.car.GetCarByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.StatusChange{}, ErrCarNotFound
+	}
+
+	if err != nil {
+		return models.StatusChange{}, err
+	}
+
+	if err = check(car, change); err != nil {
+		return models.StatusChange{}, err
+	}
+
+	change.ID = uuid.New()
+	change.CarID = carID
+	change.From = car.Status
+	change.ChangedAt = s.now().UTC().Truncate(time.Second)
+
+	change, err = s.status.Transition(ctx, change)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.StatusChange{}, ErrStatusConflict
+	}
+
+	return change, err
+}
+
+// GetStatusHistory service layer function to get every status transition of a car, oldest first
+func (s statusService) GetStatusHistory(ctx context.Context, id string) ([]models.StatusChange, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrCarNotFound
+	}
+
+	if _, err := s.car.GetCarByID(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCarNotFound
+		}
+
+		return nil, err
+	}
+
+	return s.status.GetStatusHistory(ctx, id)
+}
+
+// carValidator is a service.Cars which only creates cars in one of the initial statuses
+type carValidator struct {
+	service.Cars
+}
+
+// NewCarValidator wraps a car service so that new cars start in transit or in stock
+func NewCarValidator(next service.Cars) service.Cars {
+	return carValidator{Cars: next}
+}
+
+// CreateCar checks the status before creating the car
+func (v carValidator) CreateCar(ctx context.Context, car *models.Car) (models.Car, error) {
+	if car != nil {
+		car.Status = strings.ToLower(strings.TrimSpace(car.Status))
+
+		if err := CheckInitial(car.Status); err != nil {
+			return models.Car{}, err
+		}
+	}
+
+	return v.Cars.CreateCar(ctx, car)
+}
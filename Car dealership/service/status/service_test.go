@@ -0,0 +1,137 @@
+package status
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTransition service layer test function to test transitions are checked against the current status
+func TestTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	statusStore := datastore.NewMockStatus(ctrl)
+	s := New(carStore, statusStore)
+
+	now := time.Date(2022, 3, 1, 10, 30, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	id := uuid.New()
+	inStock := models.Car{ID: id, Status: models.StatusInStock, ListPrice: 2499900}
+	sell := models.StatusChange{To: " SOLD ", Actor: "sahil", Reason: "cash sale"}
+
+	stored := func(_ context.Context, c models.StatusChange) (models.StatusChange, error) {
+		return c, nil
+	}
+
+	testCases := []struct {
+		desc   string
+		id     string
+		change models.StatusChange
+		mock   func()
+		err    error
+	}{
+		{desc: "success", id: id.String(), change: sell,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), id.String()).Return(inStock, nil)
+				statusStore.EXPECT().Transition(gomock.Any(), gomock.Any()).DoAndReturn(stored)
+			}},
+		{desc: "unknown status", id: id.String(), change: models.StatusChange{To: "lost", Actor: "sahil"},
+			err: models.ErrUnknownStatus},
+		{desc: "missing actor", id: id.String(), change: models.StatusChange{To: models.StatusSold},
+			err: ErrMissingActor},
+		{desc: "car not found", id: id.String(), change: sell,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), id.String()).Return(models.Car{}, sql.ErrNoRows)
+			},
+			err: ErrCarNotFound},
+		{desc: "illegal move", id: id.String(), change: sell,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), id.String()).
+					Return(models.Car{ID: id, Status: models.StatusDelivered}, nil)
+			},
+			err: ErrIllegalTransition},
+		{desc: "changed concurrently", id: id.String(), change: sell,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), id.String()).Return(inStock, nil)
+				statusStore.EXPECT().Transition(gomock.Any(), gomock.Any()).
+					Return(models.StatusChange{}, sql.ErrNoRows)
+			},
+			err: ErrStatusConflict},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Transition(context.TODO(), tc.id, tc.change)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, models.StatusInStock, res.From, tc.desc)
+		assert.Equal(t, models.StatusSold, res.To, tc.desc)
+		assert.Equal(t, id, res.CarID, tc.desc)
+		assert.Equal(t, now, res.ChangedAt, tc.desc)
+	}
+}
+
+// TestGetStatusHistory service layer test function to test GetStatusHistory function
+func TestGetStatusHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	statusStore := datastore.NewMockStatus(ctrl)
+	s := New(carStore, statusStore)
+
+	id := uuid.New().String()
+	history := []models.StatusChange{{From: models.StatusInTransit, To: models.StatusInStock}}
+
+	carStore.EXPECT().GetCarByID(gomock.Any(), id).Return(models.Car{}, nil)
+	statusStore.EXPECT().GetStatusHistory(gomock.Any(), id).Return(history, nil)
+
+	res, err := s.GetStatusHistory(context.TODO(), id)
+	assert.Nil(t, err)
+	assert.Equal(t, history, res)
+
+	_, err = s.GetStatusHistory(context.TODO(), "abc")
+	assert.Equal(t, ErrCarNotFound, err)
+}
+
+// TestCarValidator service layer test function to test cars are only created in an initial status
+func TestCarValidator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCars(ctrl)
+	s := NewCarValidator(mockService)
+
+	inTransit := models.Car{Name: "Model 3", Status: models.StatusInTransit}
+	sold := models.Car{Name: "Model 3", Status: models.StatusSold}
+
+	mockService.EXPECT().CreateCar(gomock.Any(), &inTransit).Return(inTransit, nil)
+
+	_, err := s.CreateCar(context.TODO(), &models.Car{Name: "Model 3", Status: "In_Transit"})
+	assert.Nil(t, err)
+
+	_, err = s.CreateCar(context.TODO(), &sold)
+	assert.True(t, errors.Is(err, ErrIllegalTransition))
+}
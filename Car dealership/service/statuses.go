@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Statuses interface {
+	Transition(ctx context.Context, id string, change models.StatusChange) (models.StatusChange, error)
+	GetStatusHistory(ctx context.Context, id string) ([]models.StatusChange, error)
+}
@@ -0,0 +1,97 @@
+package testdrive
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// event is a VEVENT of an iCalendar feed
+type event struct {
+	uid         string
+	summary     string
+	description string
+	status      string
+	sequence    int
+	start       time.Time
+	end         time.Time
+	modified    time.Time
+}
+
+const icalTime = "20060102T150405Z"
+
+// calendar writes the events as an iCalendar (RFC 5545) feed named name, stamped at stamp
+func calendar(name string, events []event, stamp time.Time) []byte {
+	var b bytes.Buffer
+
+	line := func(s string) {
+		b.WriteString(fold(s))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Car Dealership//Test drives//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escape(name))
+
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.uid)
+		line("DTSTAMP:" + stamp.UTC().Format(icalTime))
+		line("DTSTART:" + e.start.UTC().Format(icalTime))
+		line("DTEND:" + e.end.UTC().Format(icalTime))
+		line("LAST-MODIFIED:" + e.modified.UTC().Format(icalTime))
+		line("SEQUENCE:" + strconv.Itoa(e.sequence))
+		line("STATUS:" + e.status)
+		line("SUMMARY:" + escape(e.summary))
+
+		if e.description != "" {
+			line("DESCRIPTION:" + escape(e.description))
+		}
+
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+
+	return b.Bytes()
+}
+
+// escape escapes a TEXT value
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// fold splits a content line into lines of at most 75 octets, continuation lines start with a space. Lines are
+// only split between runes so that multi byte characters stay intact.
+func fold(s string) string {
+	const limit = 75
+
+	if len(s) <= limit {
+		return s
+	}
+
+	var b strings.Builder
+
+	n := 0
+
+	for _, r := range s {
+		size := utf8.RuneLen(r)
+
+		if n+size > limit {
+			b.WriteString("\r\n ")
+
+			n = 1
+		}
+
+		b.WriteRune(r)
+
+		n += size
+	}
+
+	return b.String()
+}
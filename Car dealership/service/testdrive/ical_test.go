@@ -0,0 +1,51 @@
+package testdrive
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEscape test function to test text values are escaped as iCalendar requires
+func TestEscape(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		input  string
+		output string
+	}{
+		{desc: "plain", input: "Test drive", output: "Test drive"},
+		{desc: "separators", input: "a,b;c", output: `a\,b\;c`},
+		{desc: "backslash", input: `a\b`, output: `a\\b`},
+		{desc: "newlines", input: "a\r\nb\nc", output: "a\\nb\\nc"},
+	}
+
+	for i, tc := range testCases {
+		if got := escape(tc.input); got != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, got, tc.output)
+		}
+	}
+}
+
+// TestFold test function to test long lines are folded at 75 octets without splitting a character
+func TestFold(t *testing.T) {
+	assert.Equal(t, "SUMMARY:short", fold("SUMMARY:short"))
+
+	long := "DESCRIPTION:" + strings.Repeat("é", 100)
+	folded := fold(long)
+
+	lines := strings.Split(folded, "\r\n")
+	assert.Greater(t, len(lines), 1)
+
+	for i, l := range lines {
+		assert.LessOrEqual(t, len(l), 75)
+		assert.True(t, utf8.ValidString(l))
+
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(l, " "))
+		}
+	}
+
+	assert.Equal(t, long, strings.ReplaceAll(folded, "\r\n ", ""))
+}
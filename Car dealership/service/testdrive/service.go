@@ -0,0 +1,412 @@
+package testdrive
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// OpenHour and CloseHour bound the test drives of a day in the dealership's time zone
+	OpenHour  = 9
+	CloseHour = 19
+
+	// DefaultLength is the length of a test drive booked without an end
+	DefaultLength = time.Hour
+	MinLength     = 15 * time.Minute
+	MaxLength     = 3 * time.Hour
+
+	// SlotStep is the distance between the starts of the free slots offered for a day
+	SlotStep = 30 * time.Minute
+
+	// calendarHistory is how far back the calendar feed of a salesperson goes
+	calendarHistory = 30 * 24 * time.Hour
+)
+
+var (
+	ErrTestDriveNotFound = errors.New("test drive not found")
+	ErrCarNotFound       = errors.New("car not found")
+	ErrCustomerNotFound  = errors.New("customer not found")
+	ErrCarNotAvailable   = errors.New("car can not be test driven")
+	ErrInvalidTestDrive  = errors.New("invalid test drive")
+	ErrSlotTaken         = errors.New("the time is already booked")
+	ErrTestDriveClosed   = errors.New("test drive is cancelled")
+)
+
+type service struct {
+	car      datastore.Car
+	customer datastore.Customer
+	drive    datastore.TestDrive
+	loc      *time.Location
+	now      func() time.Time
+}
+
+// New returns the test drive service, opening hours and dates are in loc, the dealership's time zone
+func New(car datastore.Car, customer datastore.Customer, drive datastore.TestDrive, loc *time.Location) service { //nolint
+	return service{car: car, customer: customer, drive: drive, loc: loc, now: time.Now}
+}
+
+// Book service layer function to book a test drive of a car in stock for a customer with a salesperson. It is
+// rejected with ErrSlotTaken when the car or the salesperson is busy at some time between d.Start and d.End.
+func (s service) Book(ctx context.Context, d models.TestDrive) (models.TestDrive, error) {
+	d.Salesperson = strings.TrimSpace(d.Salesperson)
+	d.Notes = strings.TrimSpace(d.Notes)
+
+	if d.Salesperson == "" {
+		return models.TestDrive{}, fmt.Errorf("%w: salesperson is required", ErrInvalidTestDrive)
+	}
+
+	now := s.clock()
+
+	if err := s.checkTime(&d, DefaultLength, now); err != nil {
+		return models.TestDrive{}, err
+	}
+
+	if err := s.checkCar(ctx, d.CarID.String()); err != nil {
+		return models.TestDrive{}, err
+	}
+
+	_, err := s.customer.GetCustomerByID(ctx, d.CustomerID.String())
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.TestDrive{}, ErrCustomerNotFound
+	}
+
+	if err != nil {
+		return models.TestDrive{}, err
+	}
+
+	d.ID = uuid.New()
+	d.Status = models.TestDriveScheduled
+	d.Sequence = 0
+	d.CreatedAt = now
+	d.UpdatedAt = now
+
+	conflicts, err := s.drive.ScheduleTestDrive(ctx, d)
+	if err != nil {
+		return models.TestDrive{}, err
+	}
+
+	if len(conflicts) > 0 {
+		return models.TestDrive{}, s.taken(d, conflicts)
+	}
+
+	return d, nil
+}
+
+// GetTestDrive service layer function to get a test drive by its id
+func (s service) GetTestDrive(ctx context.Context, id string) (models.TestDrive, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.TestDrive{}, ErrTestDriveNotFound
+	}
+
+	d, err := s.drive.GetTestDrive(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.TestDrive{}, ErrTestDriveNotFound
+	}
+
+	return d, err
+}
+
+// Reschedule service layer function to move a scheduled test drive to d.Start and d.End, and to d.Salesperson
+// when one is given. Without an end the test drive keeps its length.
+func (s service) Reschedule(ctx context.Context, id string, d models.TestDrive) (models.TestDrive, error) {
+	current, err := s.scheduled(ctx, id)
+	if err != nil {
+		return models.TestDrive{}, err
+	}
+
+	if salesperson := strings.TrimSpace(d.Salesperson); salesperson != "" {
+		current.Salesperson = salesperson
+	}
+
+	now := s.clock()
+	length := current.End.Sub(current.Start)
+
+	current.Start, current.End = d.Start, d.End
+	if err = s.checkTime(&current, length, now); err != nil {
+		return models.TestDrive{}, err
+	}
+
+	current.Sequence++
+	current.UpdatedAt = now
+
+	conflicts, err := s.drive.RescheduleTestDrive(ctx, current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.TestDrive{}, ErrTestDriveClosed
+	}
+
+	if err != nil {
+		return models.TestDrive{}, err
+	}
+
+	if len(conflicts) > 0 {
+		return models.TestDrive{}, s.taken(current, conflicts)
+	}
+
+	return current, nil
+}
+
+// Cancel service layer function to cancel a scheduled test drive
+func (s service) Cancel(ctx context.Context, id string) (models.TestDrive, error) {
+	d, err := s.scheduled(ctx, id)
+	if err != nil {
+		return models.TestDrive{}, err
+	}
+
+	d.Status = models.TestDriveCancelled
+	d.Sequence++
+	d.UpdatedAt = s.clock()
+
+	err = s.drive.CancelTestDrive(ctx, d)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.TestDrive{}, ErrTestDriveClosed
+	}
+
+	if err != nil {
+		return models.TestDrive{}, err
+	}
+
+	return d, nil
+}
+
+// GetSlots service layer function to list the free slots of length on date, a YYYY-MM-DD day, in which the car
+// can be test driven. With a salesperson only the slots in which the salesperson is free as well are listed.
+func (s service) GetSlots(ctx context.Context, carID, date string, length time.Duration,
+	salesperson string) ([]models.Slot, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, s.loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrInvalidTestDrive)
+	}
+
+	if length == 0 {
+		length = DefaultLength
+	}
+
+	if length < MinLength || length > MaxLength {
+		return nil, fmt.Errorf("%w: a test drive takes between %v and %v", ErrInvalidTestDrive, MinLength, MaxLength)
+	}
+
+	if err = s.checkCar(ctx, carID); err != nil {
+		return nil, err
+	}
+
+	open, closing := hours(day)
+	filter := models.TestDriveFilter{CarID: carID, Status: models.TestDriveScheduled, From: open, To: closing}
+
+	busy, err := s.drive.GetTestDrives(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if salesperson = strings.TrimSpace(salesperson); salesperson != "" {
+		filter.CarID, filter.Salesperson = "", salesperson
+
+		drives, err := s.drive.GetTestDrives(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		busy = append(busy, drives...)
+	}
+
+	now := s.now()
+	slots := []models.Slot{}
+
+	for start := open; !start.Add(length).After(closing); start = start.Add(SlotStep) {
+		if start.Before(now) || overlapsAny(busy, start, start.Add(length)) {
+			continue
+		}
+
+		slots = append(slots, models.Slot{Start: start, End: start.Add(length)})
+	}
+
+	return slots, nil
+}
+
+// Calendar service layer function to get the iCalendar feed of the test drives of a salesperson from the last
+// 30 days on. Cancelled test drives stay in the feed so that calendar clients remove them.
+func (s service) Calendar(ctx context.Context, salesperson string) ([]byte, error) {
+	salesperson = strings.TrimSpace(salesperson)
+	if salesperson == "" {
+		return nil, fmt.Errorf("%w: salesperson is required", ErrInvalidTestDrive)
+	}
+
+	now := s.clock()
+
+	drives, err := s.drive.GetTestDrives(ctx, models.TestDriveFilter{Salesperson: salesperson,
+		From: now.Add(-calendarHistory)})
+	if err != nil {
+		return nil, err
+	}
+
+	cars := map[uuid.UUID]models.Car{}
+	customers := map[uuid.UUID]models.Customer{}
+	events := make([]event, 0, len(drives))
+
+	for _, d := range drives {
+		car, ok := cars[d.CarID]
+		if !ok {
+			if car, err = s.car.GetCarByID(ctx, d.CarID.String()); err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+
+			cars[d.CarID] = car
+		}
+
+		c, ok := customers[d.CustomerID]
+		if !ok {
+			if c, err = s.customer.GetCustomerByID(ctx, d.CustomerID.String()); err != nil &&
+				!errors.Is(err, sql.ErrNoRows) {
+				return nil, err
+			}
+
+			customers[d.CustomerID] = c
+		}
+
+		events = append(events, toEvent(d, car, c))
+	}
+
+	return calendar("Test drives of "+salesperson, events, now), nil
+}
+
+func toEvent(d models.TestDrive, car models.Car, c models.Customer) event {
+	summary := "Test drive"
+	if car.Name != "" {
+		summary += ": " + strings.TrimSpace(fmt.Sprintf("%d %s %s", car.Year, car.Brand, car.Name))
+	}
+
+	var details []string
+
+	if c.Name != "" {
+		details = append(details, "Customer: "+c.Name)
+	}
+
+	if c.Phone != "" {
+		details = append(details, "Phone: "+c.Phone)
+	}
+
+	if car.VIN != "" {
+		details = append(details, "VIN: "+car.VIN)
+	}
+
+	if d.Notes != "" {
+		details = append(details, d.Notes)
+	}
+
+	status := "CONFIRMED"
+	if d.Status == models.TestDriveCancelled {
+		status = "CANCELLED"
+	}
+
+	return event{uid: d.ID.String() + "@car-dealership", summary: summary, description: strings.Join(details, "\n"),
+		status: status, sequence: d.Sequence, start: d.Start, end: d.End, modified: d.UpdatedAt}
+}
+
+// scheduled returns the test drive with the id if it is still scheduled
+func (s service) scheduled(ctx context.Context, id string) (models.TestDrive, error) {
+	d, err := s.GetTestDrive(ctx, id)
+	if err != nil {
+		return models.TestDrive{}, err
+	}
+
+	if d.Status != models.TestDriveScheduled {
+		return models.TestDrive{}, ErrTestDriveClosed
+	}
+
+	return d, nil
+}
+
+// checkCar returns an error unless the car exists and is on the lot, in stock or held
+func (s service) checkCar(ctx context.Context, id string) error {
+	car, err := s.car.GetCarByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCarNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if car.Status != models.StatusInStock && car.Status != models.StatusReserved {
+		return fmt.Errorf("%w: the car is %s", ErrCarNotAvailable, car.Status)
+	}
+
+	return nil
+}
+
+// checkTime defaults the end of d to length after its start and checks that it takes place in the future
+// within the opening hours of a single day
+func (s service) checkTime(d *models.TestDrive, length time.Duration, now time.Time) error {
+	if d.Start.IsZero() {
+		return fmt.Errorf("%w: start is required", ErrInvalidTestDrive)
+	}
+
+	if d.End.IsZero() {
+		d.End = d.Start.Add(length)
+	}
+
+	d.Start = d.Start.UTC().Truncate(time.Second)
+	d.End = d.End.UTC().Truncate(time.Second)
+
+	if n := d.End.Sub(d.Start); n < MinLength || n > MaxLength {
+		return fmt.Errorf("%w: a test drive takes between %v and %v", ErrInvalidTestDrive, MinLength, MaxLength)
+	}
+
+	if d.Start.Before(now) {
+		return fmt.Errorf("%w: the start has passed", ErrInvalidTestDrive)
+	}
+
+	open, closing := hours(d.Start.In(s.loc))
+	if d.Start.Before(open) || d.End.After(closing) {
+		return fmt.Errorf("%w: test drives take place between %02d:00 and %02d:00", ErrInvalidTestDrive, OpenHour,
+			CloseHour)
+	}
+
+	return nil
+}
+
+// taken describes the test drives d overlaps
+func (s service) taken(d models.TestDrive, conflicts []models.TestDrive) error {
+	reasons := make([]string, 0, len(conflicts))
+
+	for _, c := range conflicts {
+		who := "the car"
+		if c.CarID != d.CarID {
+			who = c.Salesperson
+		}
+
+		reasons = append(reasons, fmt.Sprintf("%s is booked from %s to %s", who,
+			c.Start.In(s.loc).Format("2006-01-02 15:04"), c.End.In(s.loc).Format("15:04")))
+	}
+
+	return fmt.Errorf("%w: %s", ErrSlotTaken, strings.Join(reasons, ", "))
+}
+
+func (s service) clock() time.Time {
+	return s.now().UTC().Truncate(time.Second)
+}
+
+// hours returns the opening and closing time of the day of t, in the location of t
+func hours(t time.Time) (open, closing time.Time) {
+	y, m, d := t.Date()
+
+	return time.Date(y, m, d, OpenHour, 0, 0, 0, t.Location()), time.Date(y, m, d, CloseHour, 0, 0, 0, t.Location())
+}
+
+func overlapsAny(drives []models.TestDrive, start, end time.Time) bool {
+	for _, d := range drives {
+		if d.Overlaps(start, end) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,263 @@
+package testdrive
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ist = time.FixedZone("IST", 5*3600+1800)
+	now = time.Date(2022, 3, 1, 10, 0, 0, 0, ist)
+)
+
+// at returns the time on the day of now in the dealership's time zone
+func at(hour, minute int) time.Time {
+	return time.Date(2022, 3, 1, hour, minute, 0, 0, ist).UTC()
+}
+
+// TestBook service layer test function to test test drives are only booked in free slots within opening hours
+func TestBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	customerStore := datastore.NewMockCustomer(ctrl)
+	driveStore := datastore.NewMockTestDrive(ctrl)
+	s := New(carStore, customerStore, driveStore, ist)
+	s.now = func() time.Time { return now }
+
+	carID, customerID := uuid.New(), uuid.New()
+	inStock := models.Car{ID: carID, Status: models.StatusInStock}
+	drive := models.TestDrive{CarID: carID, CustomerID: customerID, Salesperson: " sahil ", Start: at(11, 0)}
+	clash := models.TestDrive{CarID: uuid.New(), Salesperson: "sahil", Start: at(11, 30), End: at(12, 0)}
+
+	found := func() {
+		carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(inStock, nil)
+		customerStore.EXPECT().GetCustomerByID(gomock.Any(), customerID.String()).
+			Return(models.Customer{ID: customerID}, nil)
+	}
+
+	testCases := []struct {
+		desc  string
+		input models.TestDrive
+		mock  func()
+		end   time.Time
+		err   error
+	}{
+		{desc: "default length", input: drive, end: at(12, 0),
+			mock: func() {
+				found()
+				driveStore.EXPECT().ScheduleTestDrive(gomock.Any(), gomock.Any()).Return(nil, nil)
+			}},
+		{desc: "given end", input: models.TestDrive{CarID: carID, CustomerID: customerID, Salesperson: "sahil",
+			Start: at(18, 30), End: at(19, 0)}, end: at(19, 0),
+			mock: func() {
+				found()
+				driveStore.EXPECT().ScheduleTestDrive(gomock.Any(), gomock.Any()).Return(nil, nil)
+			}},
+		{desc: "missing salesperson", input: models.TestDrive{CarID: carID, Start: at(11, 0)}, err: ErrInvalidTestDrive},
+		{desc: "missing start", input: models.TestDrive{CarID: carID, Salesperson: "sahil"}, err: ErrInvalidTestDrive},
+		{desc: "passed", input: models.TestDrive{CarID: carID, Salesperson: "sahil", Start: at(9, 30)},
+			err: ErrInvalidTestDrive},
+		{desc: "too short", input: models.TestDrive{CarID: carID, Salesperson: "sahil", Start: at(11, 0),
+			End: at(11, 10)}, err: ErrInvalidTestDrive},
+		{desc: "after closing", input: models.TestDrive{CarID: carID, Salesperson: "sahil", Start: at(18, 30)},
+			err: ErrInvalidTestDrive},
+		{desc: "car not found", input: drive, err: ErrCarNotFound,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{}, sql.ErrNoRows)
+			}},
+		{desc: "car sold", input: drive, err: ErrCarNotAvailable,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).
+					Return(models.Car{ID: carID, Status: models.StatusSold}, nil)
+			}},
+		{desc: "customer not found", input: drive, err: ErrCustomerNotFound,
+			mock: func() {
+				carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(inStock, nil)
+				customerStore.EXPECT().GetCustomerByID(gomock.Any(), customerID.String()).
+					Return(models.Customer{}, sql.ErrNoRows)
+			}},
+		{desc: "salesperson busy", input: drive, err: ErrSlotTaken,
+			mock: func() {
+				found()
+				driveStore.EXPECT().ScheduleTestDrive(gomock.Any(), gomock.Any()).Return([]models.TestDrive{clash}, nil)
+			}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Book(context.TODO(), tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, "sahil", res.Salesperson, tc.desc)
+		assert.Equal(t, models.TestDriveScheduled, res.Status, tc.desc)
+		assert.Equal(t, tc.end, res.End, tc.desc)
+		assert.Equal(t, now.UTC(), res.CreatedAt, tc.desc)
+	}
+
+	carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(inStock, nil)
+	customerStore.EXPECT().GetCustomerByID(gomock.Any(), customerID.String()).
+		Return(models.Customer{ID: customerID}, nil)
+	driveStore.EXPECT().ScheduleTestDrive(gomock.Any(), gomock.Any()).Return([]models.TestDrive{clash}, nil)
+
+	_, err := s.Book(context.TODO(), drive)
+	assert.Contains(t, err.Error(), "sahil is booked from 2022-03-01 11:30 to 12:00")
+}
+
+// TestRescheduleCancel service layer test function to test only scheduled test drives are moved or cancelled
+func TestRescheduleCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	driveStore := datastore.NewMockTestDrive(ctrl)
+	s := New(datastore.NewMockCar(ctrl), datastore.NewMockCustomer(ctrl), driveStore, ist)
+	s.now = func() time.Time { return now }
+
+	scheduled := models.TestDrive{ID: uuid.New(), CarID: uuid.New(), CustomerID: uuid.New(), Salesperson: "sahil",
+		Start: at(11, 0), End: at(11, 30), Status: models.TestDriveScheduled, Sequence: 1}
+	cancelled := scheduled
+	cancelled.Status = models.TestDriveCancelled
+	id := scheduled.ID.String()
+
+	driveStore.EXPECT().GetTestDrive(gomock.Any(), id).Return(scheduled, nil).Times(4)
+	driveStore.EXPECT().RescheduleTestDrive(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d models.TestDrive) ([]models.TestDrive, error) {
+			assert.Equal(t, at(15, 30), d.End)
+			assert.Equal(t, "rahul", d.Salesperson)
+			assert.Equal(t, 2, d.Sequence)
+
+			return nil, nil
+		})
+	driveStore.EXPECT().RescheduleTestDrive(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+	driveStore.EXPECT().CancelTestDrive(gomock.Any(), gomock.Any()).Return(nil)
+
+	res, err := s.Reschedule(context.TODO(), id, models.TestDrive{Start: at(15, 0), Salesperson: "rahul"})
+	assert.Nil(t, err)
+	assert.Equal(t, at(15, 0), res.Start)
+
+	_, err = s.Reschedule(context.TODO(), id, models.TestDrive{Start: at(15, 0)})
+	assert.Equal(t, ErrTestDriveClosed, err)
+
+	_, err = s.Reschedule(context.TODO(), id, models.TestDrive{Start: at(20, 0)})
+	assert.True(t, errors.Is(err, ErrInvalidTestDrive))
+
+	res, err = s.Cancel(context.TODO(), id)
+	assert.Nil(t, err)
+	assert.Equal(t, models.TestDriveCancelled, res.Status)
+	assert.Equal(t, 2, res.Sequence)
+
+	driveStore.EXPECT().GetTestDrive(gomock.Any(), id).Return(cancelled, nil)
+
+	_, err = s.Cancel(context.TODO(), id)
+	assert.Equal(t, ErrTestDriveClosed, err)
+
+	_, err = s.Cancel(context.TODO(), "abc")
+	assert.Equal(t, ErrTestDriveNotFound, err)
+}
+
+// TestGetSlots service layer test function to test busy and passed slots are left out
+func TestGetSlots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	driveStore := datastore.NewMockTestDrive(ctrl)
+	s := New(carStore, datastore.NewMockCustomer(ctrl), driveStore, ist)
+	s.now = func() time.Time { return now }
+
+	carID := uuid.New()
+	open := time.Date(2022, 3, 1, OpenHour, 0, 0, 0, ist)
+	closing := time.Date(2022, 3, 1, CloseHour, 0, 0, 0, ist)
+
+	carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID,
+		Status: models.StatusReserved}, nil)
+	driveStore.EXPECT().GetTestDrives(gomock.Any(), models.TestDriveFilter{CarID: carID.String(),
+		Status: models.TestDriveScheduled, From: open, To: closing}).
+		Return([]models.TestDrive{{Start: at(11, 0), End: at(12, 0)}}, nil)
+	driveStore.EXPECT().GetTestDrives(gomock.Any(), models.TestDriveFilter{Salesperson: "sahil",
+		Status: models.TestDriveScheduled, From: open, To: closing}).
+		Return([]models.TestDrive{{Start: at(17, 0), End: at(19, 0)}}, nil)
+
+	slots, err := s.GetSlots(context.TODO(), carID.String(), "2022-03-01", time.Hour, "sahil")
+	assert.Nil(t, err)
+
+	starts := make([]string, len(slots))
+	for i := range slots {
+		starts[i] = slots[i].Start.In(ist).Format("15:04")
+	}
+
+	assert.Equal(t, "10:00 12:00 12:30 13:00 13:30 14:00 14:30 15:00 15:30 16:00", strings.Join(starts, " "))
+
+	_, err = s.GetSlots(context.TODO(), carID.String(), "01-03-2022", 0, "")
+	assert.True(t, errors.Is(err, ErrInvalidTestDrive))
+
+	_, err = s.GetSlots(context.TODO(), carID.String(), "2022-03-01", 5*time.Hour, "")
+	assert.True(t, errors.Is(err, ErrInvalidTestDrive))
+
+	carStore.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID,
+		Status: models.StatusSold}, nil)
+
+	_, err = s.GetSlots(context.TODO(), carID.String(), "2022-03-01", 0, "")
+	assert.True(t, errors.Is(err, ErrCarNotAvailable))
+}
+
+// TestCalendar service layer test function to test the feed of a salesperson lists their test drives
+func TestCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carStore := datastore.NewMockCar(ctrl)
+	customerStore := datastore.NewMockCustomer(ctrl)
+	driveStore := datastore.NewMockTestDrive(ctrl)
+	s := New(carStore, customerStore, driveStore, ist)
+	s.now = func() time.Time { return now }
+
+	car := models.Car{ID: uuid.New(), Name: "Civic", Brand: "Honda", Year: 2020}
+	customer := models.Customer{ID: uuid.New(), Name: "Asha", Phone: "+919876543210"}
+	drives := []models.TestDrive{
+		{ID: uuid.New(), CarID: car.ID, CustomerID: customer.ID, Salesperson: "sahil", Start: at(11, 0),
+			End: at(12, 0), Status: models.TestDriveScheduled, UpdatedAt: now},
+		{ID: uuid.New(), CarID: car.ID, CustomerID: customer.ID, Salesperson: "sahil", Start: at(14, 0),
+			End: at(15, 0), Status: models.TestDriveCancelled, Sequence: 1, UpdatedAt: now},
+	}
+
+	driveStore.EXPECT().GetTestDrives(gomock.Any(), models.TestDriveFilter{Salesperson: "sahil",
+		From: now.UTC().Add(-calendarHistory)}).Return(drives, nil)
+	carStore.EXPECT().GetCarByID(gomock.Any(), car.ID.String()).Return(car, nil)
+	customerStore.EXPECT().GetCustomerByID(gomock.Any(), customer.ID.String()).Return(customer, nil)
+
+	feed, err := s.Calendar(context.TODO(), "sahil")
+	assert.Nil(t, err)
+
+	ics := string(feed)
+	assert.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT\r\n"))
+	assert.Contains(t, ics, "UID:"+drives[0].ID.String()+"@car-dealership\r\n")
+	assert.Contains(t, ics, "SUMMARY:Test drive: 2020 Honda Civic\r\n")
+	assert.Contains(t, ics, "DTSTART:20220301T053000Z\r\n")
+	assert.Contains(t, ics, "STATUS:CANCELLED\r\nSUMMARY")
+	assert.Contains(t, ics, "DESCRIPTION:Customer: Asha\\nPhone: +919876543210\r\n")
+
+	_, err = s.Calendar(context.TODO(), " ")
+	assert.True(t, errors.Is(err, ErrInvalidTestDrive))
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type TestDrives interface {
+	Book(ctx context.Context, d models.TestDrive) (models.TestDrive, error)
+	GetTestDrive(ctx context.Context, id string) (models.TestDrive, error)
+	Reschedule(ctx context.Context, id string, d models.TestDrive) (models.TestDrive, error)
+	Cancel(ctx context.Context, id string) (models.TestDrive, error)
+	GetSlots(ctx context.Context, carID, date string, length time.Duration, salesperson string) ([]models.Slot, error)
+	Calendar(ctx context.Context, salesperson string) ([]byte, error)
+}
@@ -0,0 +1,198 @@
+package tradein
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+// MaxRetentionYears is the longest retention table of a depreciation curve
+const MaxRetentionYears = 30
+
+var (
+	ErrAppraisalNotFound = errors.New("appraisal not found")
+	ErrCustomerNotFound  = errors.New("customer not found")
+	ErrInvalidVehicle    = errors.New("invalid trade-in")
+	ErrInvalidAppraisal  = errors.New("invalid appraisal")
+	ErrInvalidCurve      = errors.New("invalid depreciation curve")
+	ErrNoCurve           = errors.New("no depreciation curve for the trade-in")
+)
+
+type service struct {
+	customer datastore.Customer
+	tradeIn  datastore.TradeIn
+	now      func() time.Time
+}
+
+func New(customer datastore.Customer, tradeIn datastore.TradeIn) service { //nolint
+	return service{customer: customer, tradeIn: tradeIn, now: time.Now}
+}
+
+// Estimate service layer function to estimate the value of a trade-in on the depreciation curve of its brand and
+// fuel type
+func (s service) Estimate(ctx context.Context, v models.TradeInVehicle) (models.Valuation, error) {
+	year := s.now().UTC().Year()
+
+	if err := checkVehicle(&v, year); err != nil {
+		return models.Valuation{}, err
+	}
+
+	return s.estimate(ctx, v, year)
+}
+
+// Appraise service layer function to value the trade-in of a customer and record the appraisal
+func (s service) Appraise(ctx context.Context, a models.Appraisal) (models.Appraisal, error) {
+	now := s.now().UTC().Truncate(time.Second)
+
+	a.Appraiser = strings.TrimSpace(a.Appraiser)
+	a.Notes = strings.TrimSpace(a.Notes)
+
+	if a.Appraiser == "" {
+		return models.Appraisal{}, fmt.Errorf("%w: appraiser is required", ErrInvalidAppraisal)
+	}
+
+	if err := checkVehicle(&a.Vehicle, now.Year()); err != nil {
+		return models.Appraisal{}, err
+	}
+
+	if err := s.checkCustomer(ctx, a.CustomerID.String()); err != nil {
+		return models.Appraisal{}, err
+	}
+
+	valuation, err := s.estimate(ctx, a.Vehicle, now.Year())
+	if err != nil {
+		return models.Appraisal{}, err
+	}
+
+	a.ID = uuid.New()
+	a.Valuation = valuation
+	a.CreatedAt = now
+
+	return s.tradeIn.CreateAppraisal(ctx, a)
+}
+
+// GetAppraisal service layer function to get an appraisal by its id
+func (s service) GetAppraisal(ctx context.Context, id string) (models.Appraisal, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Appraisal{}, ErrAppraisalNotFound
+	}
+
+	a, err := s.tradeIn.GetAppraisal(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Appraisal{}, ErrAppraisalNotFound
+	}
+
+	return a, err
+}
+
+// GetAppraisals service layer function to get the appraisals of a customer, newest first
+func (s service) GetAppraisals(ctx context.Context, customerID string) ([]models.Appraisal, error) {
+	if err := s.checkCustomer(ctx, customerID); err != nil {
+		return nil, err
+	}
+
+	return s.tradeIn.GetAppraisals(ctx, customerID)
+}
+
+// GetCurves service layer function to get every depreciation curve
+func (s service) GetCurves(ctx context.Context) ([]models.DepreciationCurve, error) {
+	return s.tradeIn.GetCurves(ctx)
+}
+
+// SetCurve service layer function to set the depreciation curve of a brand and fuel type, either may be "*" to
+// cover every brand or fuel type. Retention may not go up with age.
+func (s service) SetCurve(ctx context.Context, c models.DepreciationCurve) (models.DepreciationCurve, error) {
+	c.Brand = strings.ToLower(strings.TrimSpace(c.Brand))
+	c.FuelType = strings.ToLower(strings.TrimSpace(c.FuelType))
+	c.Currency = strings.ToUpper(strings.TrimSpace(c.Currency))
+
+	switch {
+	case c.Brand == "" || c.FuelType == "":
+		return models.DepreciationCurve{}, fmt.Errorf("%w: brand and fuel type are required, * matches any",
+			ErrInvalidCurve)
+	case !models.ValidCurrency(c.Currency):
+		return models.DepreciationCurve{}, fmt.Errorf("%w: currency must be a supported ISO 4217 code",
+			ErrInvalidCurve)
+	case c.BaseValue <= 0 || c.ValuePerLitre < 0:
+		return models.DepreciationCurve{}, fmt.Errorf("%w: base value must be positive and value per litre must "+
+			"not be negative", ErrInvalidCurve)
+	case len(c.Retention) == 0 || len(c.Retention) > MaxRetentionYears:
+		return models.DepreciationCurve{}, fmt.Errorf("%w: retention needs between 1 and %d years", ErrInvalidCurve,
+			MaxRetentionYears)
+	case c.MileagePerYear <= 0 || c.MileageRate < 0 || c.MileageRate > 10000:
+		return models.DepreciationCurve{}, fmt.Errorf("%w: mileage per year must be positive and mileage rate "+
+			"between 0 and 10000 basis points", ErrInvalidCurve)
+	}
+
+	prev := 10000
+
+	for i, r := range c.Retention {
+		if r <= 0 || r > prev {
+			return models.DepreciationCurve{}, fmt.Errorf("%w: retention of year %d must be between 1 and %d basis "+
+				"points", ErrInvalidCurve, i+1, prev)
+		}
+
+		prev = r
+	}
+
+	if err := s.tradeIn.SaveCurve(ctx, c); err != nil {
+		return models.DepreciationCurve{}, err
+	}
+
+	return c, nil
+}
+
+func (s service) estimate(ctx context.Context, v models.TradeInVehicle, year int) (models.Valuation, error) {
+	curve, err := s.tradeIn.FindCurve(ctx, strings.ToLower(v.Brand), v.FuelType)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Valuation{}, fmt.Errorf("%w: %s %s", ErrNoCurve, v.Brand, v.FuelType)
+	}
+
+	if err != nil {
+		return models.Valuation{}, err
+	}
+
+	return value(v, curve, year), nil
+}
+
+func (s service) checkCustomer(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return ErrCustomerNotFound
+	}
+
+	_, err := s.customer.GetCustomerByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCustomerNotFound
+	}
+
+	return err
+}
+
+// checkVehicle normalises the trade-in and checks it can be valued in year
+func checkVehicle(v *models.TradeInVehicle, year int) error {
+	v.Brand = strings.TrimSpace(v.Brand)
+	v.Name = strings.TrimSpace(v.Name)
+	v.FuelType = strings.ToLower(strings.TrimSpace(v.FuelType))
+	v.Condition = strings.ToLower(strings.TrimSpace(v.Condition))
+
+	switch {
+	case v.Brand == "" || v.Name == "" || v.FuelType == "":
+		return fmt.Errorf("%w: brand, name and fuel type are required", ErrInvalidVehicle)
+	case v.Year < 1900 || v.Year > year+1:
+		return fmt.Errorf("%w: year must be between 1900 and %d", ErrInvalidVehicle, year+1)
+	case v.Displacement < 0 || v.Mileage < 0:
+		return fmt.Errorf("%w: displacement and mileage must not be negative", ErrInvalidVehicle)
+	case !models.ValidCondition(v.Condition):
+		return fmt.Errorf("%w: %v %q", ErrInvalidVehicle, models.ErrUnknownCondition, v.Condition)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package tradein
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// TestEstimate service layer test function to test trade-ins are checked and valued on the curve of their brand
+func TestEstimate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tradeInStore := datastore.NewMockTradeIn(ctrl)
+	s := New(datastore.NewMockCustomer(ctrl), tradeInStore)
+	s.now = func() time.Time { return now }
+
+	vehicle := models.TradeInVehicle{Brand: " BMW ", Name: "320d", Year: 2018, FuelType: "Diesel",
+		Displacement: 2000, Mileage: 75000, Condition: "Good"}
+
+	testCases := []struct {
+		desc  string
+		input models.TradeInVehicle
+		mock  func()
+		value models.Money
+		err   error
+	}{
+		{desc: "success", input: vehicle, value: 1444000,
+			mock: func() { tradeInStore.EXPECT().FindCurve(gomock.Any(), "bmw", "diesel").Return(curve, nil) }},
+		{desc: "no curve", input: vehicle, err: ErrNoCurve,
+			mock: func() {
+				tradeInStore.EXPECT().FindCurve(gomock.Any(), "bmw", "diesel").
+					Return(models.DepreciationCurve{}, sql.ErrNoRows)
+			}},
+		{desc: "missing name", input: models.TradeInVehicle{Brand: "BMW", Year: 2018, FuelType: "diesel",
+			Condition: "good"}, err: ErrInvalidVehicle},
+		{desc: "future year", input: models.TradeInVehicle{Brand: "BMW", Name: "320d", Year: 2030,
+			FuelType: "diesel", Condition: "good"}, err: ErrInvalidVehicle},
+		{desc: "unknown condition", input: models.TradeInVehicle{Brand: "BMW", Name: "320d", Year: 2018,
+			FuelType: "diesel", Condition: "mint"}, err: ErrInvalidVehicle},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Estimate(context.TODO(), tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if res.Value != tc.value {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res.Value, tc.value)
+		}
+	}
+}
+
+// TestAppraise service layer test function to test appraisals are only recorded for known customers
+func TestAppraise(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	customerStore := datastore.NewMockCustomer(ctrl)
+	tradeInStore := datastore.NewMockTradeIn(ctrl)
+	s := New(customerStore, tradeInStore)
+	s.now = func() time.Time { return now }
+
+	customerID := uuid.New()
+	appraisal := models.Appraisal{CustomerID: customerID, Appraiser: " sahil ", Vehicle: models.TradeInVehicle{
+		Brand: "BMW", Name: "320d", Year: 2018, FuelType: "diesel", Displacement: 2000, Mileage: 75000,
+		Condition: "good"}}
+	stored := func(_ context.Context, a models.Appraisal) (models.Appraisal, error) { return a, nil }
+
+	customerStore.EXPECT().GetCustomerByID(gomock.Any(), customerID.String()).
+		Return(models.Customer{ID: customerID}, nil)
+	tradeInStore.EXPECT().FindCurve(gomock.Any(), "bmw", "diesel").Return(curve, nil)
+	tradeInStore.EXPECT().CreateAppraisal(gomock.Any(), gomock.Any()).DoAndReturn(stored)
+
+	res, err := s.Appraise(context.TODO(), appraisal)
+	assert.Nil(t, err)
+	assert.Equal(t, "sahil", res.Appraiser)
+	assert.Equal(t, models.Money(1444000), res.Valuation.Value)
+	assert.Equal(t, now, res.CreatedAt)
+	assert.NotEqual(t, uuid.Nil, res.ID)
+
+	customerStore.EXPECT().GetCustomerByID(gomock.Any(), customerID.String()).
+		Return(models.Customer{}, sql.ErrNoRows)
+
+	_, err = s.Appraise(context.TODO(), appraisal)
+	assert.Equal(t, ErrCustomerNotFound, err)
+
+	_, err = s.Appraise(context.TODO(), models.Appraisal{CustomerID: customerID, Vehicle: appraisal.Vehicle})
+	assert.True(t, errors.Is(err, ErrInvalidAppraisal))
+
+	tradeInStore.EXPECT().GetAppraisal(gomock.Any(), customerID.String()).Return(models.Appraisal{}, sql.ErrNoRows)
+
+	_, err = s.GetAppraisal(context.TODO(), customerID.String())
+	assert.Equal(t, ErrAppraisalNotFound, err)
+
+	_, err = s.GetAppraisals(context.TODO(), "abc")
+	assert.Equal(t, ErrCustomerNotFound, err)
+}
+
+// TestSetCurve service layer test function to test depreciation curves are validated before they are saved
+func TestSetCurve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tradeInStore := datastore.NewMockTradeIn(ctrl)
+	s := New(datastore.NewMockCustomer(ctrl), tradeInStore)
+	s.now = func() time.Time { return now }
+
+	valid := models.DepreciationCurve{Brand: " BMW", FuelType: "Diesel", Currency: "usd", BaseValue: 3000000,
+		Retention: []int{8000, 7000}, MileagePerYear: 15000, MileageRate: 300}
+	saved := models.DepreciationCurve{Brand: "bmw", FuelType: "diesel", Currency: "USD", BaseValue: 3000000,
+		Retention: []int{8000, 7000}, MileagePerYear: 15000, MileageRate: 300}
+
+	rising := saved
+	rising.Retention = []int{8000, 8500}
+	noBase := saved
+	noBase.BaseValue = 0
+	noBrand := saved
+	noBrand.Brand = ""
+
+	testCases := []struct {
+		desc  string
+		input models.DepreciationCurve
+		mock  func()
+		err   error
+	}{
+		{desc: "success", input: valid,
+			mock: func() { tradeInStore.EXPECT().SaveCurve(gomock.Any(), saved).Return(nil) }},
+		{desc: "rising retention", input: rising, err: ErrInvalidCurve},
+		{desc: "no base value", input: noBase, err: ErrInvalidCurve},
+		{desc: "no brand", input: noBrand, err: ErrInvalidCurve},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.SetCurve(context.TODO(), tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err == nil {
+			assert.Equal(t, saved, res, tc.desc)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package tradein
+
+import "github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+const (
+	// Spread is how far, in basis points of the value, the low and high estimates lie from it
+	Spread = 750
+	// MinRetention is the share of its new value, in basis points, no car falls below however old it is
+	MinRetention = 500
+
+	// mileage takes at most maxMileagePenalty basis points off the value and adds at most maxMileageCredit
+	maxMileagePenalty = 3000
+	maxMileageCredit  = 1000
+)
+
+// conditions holds the adjustment of the value for each condition in basis points
+var conditions = map[string]int{
+	models.ConditionExcellent: 10500,
+	models.ConditionGood:      10000,
+	models.ConditionFair:      8500,
+	models.ConditionPoor:      7000,
+}
+
+// value estimates what v is worth in year on curve c: the new value is depreciated for the age of the car and
+// adjusted for its mileage and condition, and the estimates are rounded to whole currency units
+func value(v models.TradeInVehicle, c models.DepreciationCurve, year int) models.Valuation {
+	val := models.Valuation{Currency: c.Currency, Age: year - v.Year, ConditionAdjustment: conditions[v.Condition]}
+	if val.Age < 0 {
+		val.Age = 0
+	}
+
+	// displacement is in cc, so the value per litre is taken displacement / 1000 times
+	val.NewValue = c.BaseValue + scale(c.ValuePerLitre, int(v.Displacement*10))
+	val.Retention = retention(c.Retention, val.Age)
+	val.MileageAdjustment = mileage(v.Mileage, val.Age, c)
+
+	worth := scale(scale(scale(val.NewValue, val.Retention), val.MileageAdjustment), val.ConditionAdjustment)
+
+	val.Value = whole(worth)
+	val.Low = whole(scale(worth, 10000-Spread))
+	val.High = whole(scale(worth, 10000+Spread))
+
+	return val
+}
+
+// retention returns the share of its new value, in basis points, a car keeps at age years. Past the end of the
+// table a car keeps losing value at the rate of the last year of the table.
+func retention(table []int, age int) int {
+	if age == 0 || len(table) == 0 {
+		return 10000
+	}
+
+	if age <= len(table) {
+		return floor(table[age-1])
+	}
+
+	last, prev := table[len(table)-1], 10000
+	if len(table) > 1 {
+		prev = table[len(table)-2]
+	}
+
+	r := last
+	for i := len(table); i < age && r > MinRetention; i++ {
+		r = (r*last*2 + prev) / (prev * 2)
+	}
+
+	return floor(r)
+}
+
+// mileage returns the adjustment, in basis points, for mileage km driven over age years. A car of less than a
+// year is expected to have done a year's mileage.
+func mileage(km, age int, c models.DepreciationCurve) int {
+	years := age
+	if years < 1 {
+		years = 1
+	}
+
+	adjustment := 10000 - (km-c.MileagePerYear*years)*c.MileageRate/10000
+
+	switch {
+	case adjustment < 10000-maxMileagePenalty:
+		return 10000 - maxMileagePenalty
+	case adjustment > 10000+maxMileageCredit:
+		return 10000 + maxMileageCredit
+	}
+
+	return adjustment
+}
+
+func floor(r int) int {
+	if r < MinRetention {
+		return MinRetention
+	}
+
+	return r
+}
+
+// scale returns bps basis points of m, rounded half up to the cent
+func scale(m models.Money, bps int) models.Money {
+	rate := models.Money(bps)
+
+	return m/10000*rate + (m%10000*rate+5000)/10000
+}
+
+// whole rounds m half up to a whole currency unit
+func whole(m models.Money) models.Money {
+	return (m + 50) / 100 * 100
+}
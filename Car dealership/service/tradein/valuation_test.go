@@ -0,0 +1,67 @@
+package tradein
+
+import (
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var curve = models.DepreciationCurve{Brand: "*", FuelType: "*", Currency: "USD", BaseValue: 2000000,
+	ValuePerLitre: 400000, Retention: []int{8000, 7000, 6100, 5400, 4800}, MileagePerYear: 15000, MileageRate: 300}
+
+// TestValue test function to test trade-ins are depreciated for age and adjusted for mileage and condition
+func TestValue(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		vehicle models.TradeInVehicle
+		output  models.Valuation
+	}{
+		{desc: "high mileage", vehicle: models.TradeInVehicle{Year: 2018, Displacement: 2000, Mileage: 75000,
+			Condition: models.ConditionGood},
+			output: models.Valuation{Currency: "USD", NewValue: 2800000, Age: 4, Retention: 5400,
+				MileageAdjustment: 9550, ConditionAdjustment: 10000, Value: 1444000, Low: 1335700, High: 1552300}},
+		{desc: "past the table, low mileage", vehicle: models.TradeInVehicle{Year: 2015, Mileage: 90000,
+			Condition: models.ConditionPoor},
+			output: models.Valuation{Currency: "USD", NewValue: 2000000, Age: 7, Retention: 3793,
+				MileageAdjustment: 10450, ConditionAdjustment: 7000, Value: 554900, Low: 513300, High: 596500}},
+	}
+
+	for i, tc := range testCases {
+		if got := value(tc.vehicle, curve, 2022); got != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, got, tc.output)
+		}
+	}
+}
+
+// TestRetention test function to test retention follows the table and keeps falling past it down to the floor
+func TestRetention(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		table  []int
+		age    int
+		output int
+	}{
+		{"new", curve.Retention, 0, 10000},
+		{"first year", curve.Retention, 1, 8000},
+		{"last year of the table", curve.Retention, 5, 4800},
+		{"one year past the table", curve.Retention, 6, 4267},
+		{"floor", []int{2000}, 5, MinRetention},
+		{"empty table", nil, 3, 10000},
+	}
+
+	for i, tc := range testCases {
+		if got := retention(tc.table, tc.age); got != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, got, tc.output)
+		}
+	}
+}
+
+// TestMileage test function to test the mileage adjustment is capped
+func TestMileage(t *testing.T) {
+	assert.Equal(t, 10000, mileage(60000, 4, curve))
+	assert.Equal(t, 10000-maxMileagePenalty, mileage(300000, 4, curve))
+	assert.Equal(t, 10000+maxMileageCredit, mileage(20000, 10, curve))
+	assert.Equal(t, 10450, mileage(0, 0, curve))
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type TradeIns interface {
+	Estimate(ctx context.Context, v models.TradeInVehicle) (models.Valuation, error)
+	Appraise(ctx context.Context, a models.Appraisal) (models.Appraisal, error)
+	GetAppraisal(ctx context.Context, id string) (models.Appraisal, error)
+	GetAppraisals(ctx context.Context, customerID string) ([]models.Appraisal, error)
+	GetCurves(ctx context.Context) ([]models.DepreciationCurve, error)
+	SetCurve(ctx context.Context, c models.DepreciationCurve) (models.DepreciationCurve, error)
+}
@@ -0,0 +1,181 @@
+package vin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+var (
+	ErrInvalidVIN  = errors.New("invalid vin")
+	ErrVINMismatch = errors.New("vin does not match car")
+)
+
+// alphabet is the ISO 3779 character set in ISO 3780 order, I, O and Q are never used
+const alphabet = "ABCDEFGHJKLMNPRSTUVWXYZ1234567890"
+
+// yearCodes are the position 10 model year codes, starting with 1980 and repeating every 30 years
+const yearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+// weights of each position in the check digit sum, position 9 is the check digit itself
+var weights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// transliteration of letters to their check digit values
+var values = map[rune]int{
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+// Normalize upper cases a vin and strips surrounding space
+func Normalize(vin string) string {
+	return strings.ToUpper(strings.TrimSpace(vin))
+}
+
+// checkFormat reports whether vin has 17 characters of the ISO 3779 character set
+func checkFormat(vin string) error {
+	if len(vin) != 17 {
+		return fmt.Errorf("%w: %q must have 17 characters", ErrInvalidVIN, vin)
+	}
+
+	for _, r := range vin {
+		if !strings.ContainsRune(alphabet, r) {
+			return fmt.Errorf("%w: %q contains %q", ErrInvalidVIN, vin, r)
+		}
+	}
+
+	return nil
+}
+
+// CheckDigit computes the position 9 check digit of a well formed vin
+func CheckDigit(vin string) byte {
+	sum := 0
+
+	for i, r := range vin {
+		v, ok := values[r]
+		if !ok {
+			v = int(r - '0')
+		}
+
+		sum += v * weights[i]
+	}
+
+	if sum%11 == 10 {
+		return 'X'
+	}
+
+	return byte('0' + sum%11)
+}
+
+// Validate checks the length, characters and check digit of a vin
+func Validate(vin string) error {
+	if err := checkFormat(vin); err != nil {
+		return err
+	}
+
+	if want := CheckDigit(vin); vin[8] != want {
+		return fmt.Errorf("%w: check digit is %c, expected %c", ErrInvalidVIN, vin[8], want)
+	}
+
+	return nil
+}
+
+// Decode reads the manufacturer, country and model year of a vin. Only malformed vins are an error,
+// a wrong check digit is reported in CheckDigitValid.
+func Decode(vin string) (models.VINInfo, error) {
+	vin = Normalize(vin)
+
+	if err := checkFormat(vin); err != nil {
+		return models.VINInfo{}, err
+	}
+
+	info := models.VINInfo{
+		VIN:             vin,
+		WMI:             vin[:3],
+		VDS:             vin[3:9],
+		VIS:             vin[9:],
+		Region:          region(vin[0]),
+		Country:         country(vin[:2]),
+		ModelYears:      modelYears(vin[9]),
+		CheckDigitValid: vin[8] == CheckDigit(vin),
+	}
+
+	if m, ok := manufacturer(vin[:3]); ok {
+		info.Manufacturer = m.name
+	}
+
+	info.ModelYear = likelyYear(vin, info.ModelYears)
+
+	return info, nil
+}
+
+// modelYears lists every year up to next year that position 10 code can stand for, latest first
+func modelYears(code byte) []int {
+	i := strings.IndexByte(yearCodes, code)
+	if i < 0 {
+		return []int{}
+	}
+
+	var years []int
+
+	for y := 1980 + i; y <= time.Now().Year()+1; y += len(yearCodes) {
+		years = append([]int{y}, years...)
+	}
+
+	return years
+}
+
+// likelyYear picks a model year using the North American rule that position 7 is a digit for
+// 1980 to 2009 and a letter from 2010 to 2039, falling back to the latest candidate
+func likelyYear(vin string, years []int) int {
+	if len(years) == 0 {
+		return 0
+	}
+
+	letter := vin[6] < '0' || vin[6] > '9'
+
+	for _, y := range years {
+		if (y >= 2010 && y < 2040 && letter) || (y < 2010 && !letter) {
+			return y
+		}
+	}
+
+	return years[0]
+}
+
+// CheckCar validates the vin of a car and cross checks the decoded model year and manufacturer with
+// the car's year and brand. Cars without a vin are accepted.
+func CheckCar(car models.Car) error {
+	if car.VIN == "" {
+		return nil
+	}
+
+	vin := Normalize(car.VIN)
+
+	if err := Validate(vin); err != nil {
+		return err
+	}
+
+	if !containsYear(modelYears(vin[9]), car.Year) {
+		return fmt.Errorf("%w: model year code %c does not stand for %d", ErrVINMismatch, vin[9], car.Year)
+	}
+
+	if m, ok := manufacturer(vin[:3]); ok && car.Brand != "" && !m.makes(car.Brand) {
+		return fmt.Errorf("%w: %s is made by %s, not %s", ErrVINMismatch, vin[:3], m.name, car.Brand)
+	}
+
+	return nil
+}
+
+func containsYear(years []int, year int) bool {
+	for _, y := range years {
+		if y == year {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,89 @@
+package vin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidate test function to test the ISO 3779 format and check digit
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc string
+		vin  string
+		err  error
+	}{
+		{"valid", "5YJ3E1EA2JF000316", nil},
+		{"check digit X", "1M8GDM9AXKP042788", nil},
+		{"wrong check digit", "5YJ3E1EA0JF000316", ErrInvalidVIN},
+		{"too short", "5YJ3E1EA2JF00031", ErrInvalidVIN},
+		{"letter O is not allowed", "5YJ3E1EA2JF0O0316", ErrInvalidVIN},
+	}
+
+	for i, tc := range testCases {
+		err := Validate(tc.vin)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
+
+// TestDecode test function to test decoding manufacturer, country and model year
+func TestDecode(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		vin    string
+		output models.VINInfo
+		err    error
+	}{
+		{desc: "tesla built in the US", vin: " 5yj3e1ea2jf000316",
+			output: models.VINInfo{VIN: "5YJ3E1EA2JF000316", WMI: "5YJ", VDS: "3E1EA2", VIS: "JF000316",
+				Manufacturer: "Tesla", Country: "United States", Region: "North America", ModelYear: 2018,
+				ModelYears: []int{2018, 1988}, CheckDigitValid: true}},
+		{desc: "digit in position 7 means the earlier cycle", vin: "1M8GDM9AXKP042788",
+			output: models.VINInfo{VIN: "1M8GDM9AXKP042788", WMI: "1M8", VDS: "GDM9AX", VIS: "KP042788",
+				Country: "United States", Region: "North America", ModelYear: 1989,
+				ModelYears: []int{2019, 1989}, CheckDigitValid: true}},
+		{desc: "bad check digit still decodes", vin: "WDDGF4HB1CA123456",
+			output: models.VINInfo{VIN: "WDDGF4HB1CA123456", WMI: "WDD", VDS: "GF4HB1", VIS: "CA123456",
+				Manufacturer: "Mercedes-Benz", Country: "Germany", Region: "Europe", ModelYear: 2012,
+				ModelYears: []int{2012, 1982}}},
+		{desc: "malformed", vin: "ABC", err: ErrInvalidVIN},
+	}
+
+	for i, tc := range testCases {
+		info, err := Decode(tc.vin)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		assert.Equal(t, tc.output, info, tc.desc)
+	}
+}
+
+// TestCheckCar test function to test the vin is cross checked with the car's year and brand
+func TestCheckCar(t *testing.T) {
+	testCases := []struct {
+		desc string
+		car  models.Car
+		err  error
+	}{
+		{"no vin", models.Car{Brand: "Tesla", Year: 2018}, nil},
+		{"matches", models.Car{VIN: "WDDGF4HB0CA123456", Brand: "Mercedes", Year: 2012}, nil},
+		{"brand punctuation ignored", models.Car{VIN: "WBA3A5C53CF256551", Brand: "B.M.W.", Year: 2012}, nil},
+		{"unknown manufacturer", models.Car{VIN: "1M8GDM9AXKP042788", Brand: "MCI", Year: 2019}, nil},
+		{"wrong year", models.Car{VIN: "5YJ3E1EA2JF000316", Brand: "Tesla", Year: 2017}, ErrVINMismatch},
+		{"wrong brand", models.Car{VIN: "ZFF67NFA1A0123456", Brand: "Porsche", Year: 2010}, ErrVINMismatch},
+		{"invalid", models.Car{VIN: "ZFF67NFA2A0123456", Brand: "Ferrari", Year: 2010}, ErrInvalidVIN},
+	}
+
+	for i, tc := range testCases {
+		err := CheckCar(tc.car)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
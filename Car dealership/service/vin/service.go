@@ -0,0 +1,78 @@
+package vin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+)
+
+var ErrCarNotFound = errors.New("no car with this vin")
+
+type vinService struct {
+	car datastore.Car
+}
+
+func New(car datastore.Car) vinService { //nolint
+	return vinService{car: car}
+}
+
+// Decode service layer function to decode a vin without looking up the car
+func (s vinService) Decode(ctx context.Context, vin string) (models.VINInfo, error) {
+	return Decode(vin)
+}
+
+// GetCarByVIN service layer function to get the car with the given vin
+func (s vinService) GetCarByVIN(ctx context.Context, vin string) (models.Car, error) {
+	vin = Normalize(vin)
+
+	if err := Validate(vin); err != nil {
+		return models.Car{}, err
+	}
+
+	car, err := s.car.GetCarByVIN(ctx, vin)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Car{}, ErrCarNotFound
+	}
+
+	return car, err
+}
+
+// carValidator is a service.Cars which rejects cars whose vin is invalid or does not match the car
+type carValidator struct {
+	service.Cars
+}
+
+// NewCarValidator wraps a car service so that cars are only created or updated with a valid vin
+func NewCarValidator(next service.Cars) service.Cars {
+	return carValidator{Cars: next}
+}
+
+// CreateCar validates and normalises the vin before creating the car
+func (v carValidator) CreateCar(ctx context.Context, car *models.Car) (models.Car, error) {
+	if car == nil {
+		return v.Cars.CreateCar(ctx, car)
+	}
+
+	if err := CheckCar(*car); err != nil {
+		return models.Car{}, err
+	}
+
+	car.VIN = Normalize(car.VIN)
+
+	return v.Cars.CreateCar(ctx, car)
+}
+
+// UpdateCar validates and normalises the vin before updating the car
+func (v carValidator) UpdateCar(ctx context.Context, id string, car models.Car) (models.Car, error) {
+	if err := CheckCar(car); err != nil {
+		return models.Car{}, err
+	}
+
+	car.VIN = Normalize(car.VIN)
+
+	return v.Cars.UpdateCar(ctx, id, car)
+}
@@ -0,0 +1,90 @@
+package vin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/service"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+// TestGetCarByVIN service layer test function to test GetCarByVIN function
+func TestGetCarByVIN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := datastore.NewMockCar(ctrl)
+	s := New(mockStore)
+
+	car := models.Car{ID: uuid.New(), VIN: "5YJ3E1EA2JF000316", Name: "Model 3", Year: 2018, Brand: "Tesla"}
+	dbErr := errors.New("db error")
+
+	testCases := []struct {
+		desc   string
+		vin    string
+		output models.Car
+		err    error
+	}{
+		{desc: "success", vin: "5yj3e1ea2jf000316", output: car},
+		{desc: "invalid vin", vin: "5YJ3E1EA0JF000316", err: ErrInvalidVIN},
+		{desc: "not found", vin: "1M8GDM9AXKP042788", err: ErrCarNotFound},
+		{desc: "db error", vin: "WBA3A5C53CF256551", err: dbErr},
+	}
+
+	gomock.InOrder(
+		mockStore.EXPECT().GetCarByVIN(gomock.Any(), car.VIN).Return(car, nil),
+		mockStore.EXPECT().GetCarByVIN(gomock.Any(), "1M8GDM9AXKP042788").Return(models.Car{}, sql.ErrNoRows),
+		mockStore.EXPECT().GetCarByVIN(gomock.Any(), "WBA3A5C53CF256551").Return(models.Car{}, dbErr),
+	)
+
+	for i, tc := range testCases {
+		res, err := s.GetCarByVIN(context.TODO(), tc.vin)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if res != tc.output {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, res, tc.output)
+		}
+	}
+}
+
+// TestCarValidator service layer test function to test cars with a bad vin never reach the car service
+func TestCarValidator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := service.NewMockCars(ctrl)
+	s := NewCarValidator(mockService)
+
+	id := uuid.New().String()
+	valid := models.Car{VIN: "5YJ3E1EA2JF000316", Name: "Model 3", Year: 2018, Brand: "Tesla"}
+	lower := valid
+	lower.VIN = "5yj3e1ea2jf000316"
+	mismatch := models.Car{VIN: "5YJ3E1EA2JF000316", Name: "Model 3", Year: 2018, Brand: "BMW"}
+
+	mockService.EXPECT().CreateCar(gomock.Any(), &valid).Return(valid, nil)
+	mockService.EXPECT().UpdateCar(gomock.Any(), id, valid).Return(valid, nil)
+
+	if _, err := s.CreateCar(context.TODO(), &lower); err != nil {
+		t.Errorf("create with valid vin: %v", err)
+	}
+
+	if _, err := s.UpdateCar(context.TODO(), id, lower); err != nil {
+		t.Errorf("update with valid vin: %v", err)
+	}
+
+	if _, err := s.CreateCar(context.TODO(), &mismatch); !errors.Is(err, ErrVINMismatch) {
+		t.Errorf("create with mismatched vin: got %v", err)
+	}
+
+	if _, err := s.UpdateCar(context.TODO(), id, mismatch); !errors.Is(err, ErrVINMismatch) {
+		t.Errorf("update with mismatched vin: got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package vin
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maker is the manufacturer behind a world manufacturer identifier with the brand names it sells under
+type maker struct {
+	name   string
+	brands []string
+}
+
+// makes reports whether brand is one of the maker's brands, ignoring case and punctuation
+// so that "Mercedes" matches "Mercedes-Benz" and "B.M.W." matches "BMW"
+func (m maker) makes(brand string) bool {
+	b := simplify(brand)
+
+	for _, name := range append([]string{m.name}, m.brands...) {
+		n := simplify(name)
+		if b != "" && (strings.HasPrefix(n, b) || strings.HasPrefix(b, n)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func simplify(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return -1
+	}, s)
+}
+
+// wmis maps the world manufacturer identifier, the first three vin characters, of the brands we sell
+var wmis = map[string]maker{
+	"5YJ": {name: "Tesla"}, "7SA": {name: "Tesla"}, "LRW": {name: "Tesla"}, "XP7": {name: "Tesla"},
+	"WBA": {name: "BMW"}, "WBS": {name: "BMW", brands: []string{"BMW M"}}, "WBY": {name: "BMW"},
+	"5UX": {name: "BMW"}, "5YM": {name: "BMW"}, "WMW": {name: "MINI"},
+	"WDB": {name: "Mercedes-Benz"}, "WDD": {name: "Mercedes-Benz"}, "WDC": {name: "Mercedes-Benz"},
+	"W1K": {name: "Mercedes-Benz"}, "W1N": {name: "Mercedes-Benz"}, "4JG": {name: "Mercedes-Benz"},
+	"55S": {name: "Mercedes-Benz"},
+	"WP0": {name: "Porsche"}, "WP1": {name: "Porsche"},
+	"ZFF": {name: "Ferrari"}, "ZHW": {name: "Lamborghini"}, "ZAM": {name: "Maserati"},
+	"ZFA": {name: "Fiat"}, "ZAR": {name: "Alfa Romeo"},
+	"WAU": {name: "Audi"}, "WA1": {name: "Audi"}, "WUA": {name: "Audi"},
+	"WVW": {name: "Volkswagen", brands: []string{"VW"}}, "WVG": {name: "Volkswagen", brands: []string{"VW"}},
+	"3VW": {name: "Volkswagen", brands: []string{"VW"}}, "1VW": {name: "Volkswagen", brands: []string{"VW"}},
+	"TMB": {name: "Skoda"}, "VSS": {name: "SEAT"},
+	"JTD": {name: "Toyota"}, "JTE": {name: "Toyota"}, "JT2": {name: "Toyota"}, "4T1": {name: "Toyota"},
+	"5TD": {name: "Toyota"}, "JTH": {name: "Lexus"},
+	"JHM": {name: "Honda"}, "1HG": {name: "Honda"}, "2HG": {name: "Honda"}, "JH4": {name: "Acura"},
+	"1FA": {name: "Ford"}, "1FT": {name: "Ford"}, "1FM": {name: "Ford"}, "3FA": {name: "Ford"},
+	"WF0": {name: "Ford"},
+	"1G1": {name: "Chevrolet"}, "1GC": {name: "Chevrolet"}, "2G1": {name: "Chevrolet"}, "3G1": {name: "Chevrolet"},
+	"JN1": {name: "Nissan"}, "JN8": {name: "Nissan"}, "1N4": {name: "Nissan"}, "5N1": {name: "Nissan"},
+	"KMH": {name: "Hyundai"}, "5NP": {name: "Hyundai"}, "KNA": {name: "Kia"}, "KND": {name: "Kia"},
+	"5XY": {name: "Kia"},
+	"YV1": {name: "Volvo"}, "YV4": {name: "Volvo"},
+	"SAJ": {name: "Jaguar"}, "SAL": {name: "Land Rover"}, "SCA": {name: "Rolls-Royce"}, "SCB": {name: "Bentley"},
+	"SCF": {name: "Aston Martin"}, "SBM": {name: "McLaren"},
+	"JF1": {name: "Subaru"}, "JF2": {name: "Subaru"}, "JM1": {name: "Mazda"}, "JM3": {name: "Mazda"},
+	"JA3": {name: "Mitsubishi"}, "JA4": {name: "Mitsubishi"},
+	"VF1": {name: "Renault"}, "VF3": {name: "Peugeot"}, "VF7": {name: "Citroen"}, "VF9": {name: "Bugatti"},
+	"MA3": {name: "Maruti Suzuki", brands: []string{"Maruti", "Suzuki"}}, "MAT": {name: "Tata"},
+	"MA1": {name: "Mahindra"},
+}
+
+func manufacturer(wmi string) (maker, bool) {
+	m, ok := wmis[wmi]
+	return m, ok
+}
+
+// regions by the first vin character
+var regions = []struct {
+	from, to byte
+	name     string
+}{
+	{'A', 'H', "Africa"},
+	{'J', 'R', "Asia"},
+	{'S', 'Z', "Europe"},
+	{'1', '5', "North America"},
+	{'6', '7', "Oceania"},
+	{'8', '9', "South America"},
+}
+
+func region(c byte) string {
+	for _, r := range regions {
+		if order(c) >= order(r.from) && order(c) <= order(r.to) {
+			return r.name
+		}
+	}
+
+	return ""
+}
+
+// countries by the first two vin characters as assigned in ISO 3780, ranges are inclusive
+var countries = []struct {
+	from, to string
+	name     string
+}{
+	{"AA", "AH", "South Africa"},
+	{"JA", "JT", "Japan"},
+	{"KL", "KR", "South Korea"},
+	{"LA", "L0", "China"},
+	{"MA", "ME", "India"},
+	{"MF", "MK", "Indonesia"},
+	{"ML", "MR", "Thailand"},
+	{"PL", "PR", "Malaysia"},
+	{"SA", "SM", "United Kingdom"},
+	{"SN", "ST", "Germany"},
+	{"SU", "SZ", "Poland"},
+	{"TA", "TH", "Switzerland"},
+	{"TJ", "TP", "Czech Republic"},
+	{"TR", "TV", "Hungary"},
+	{"VA", "VE", "Austria"},
+	{"VF", "VR", "France"},
+	{"VS", "VW", "Spain"},
+	{"WA", "W0", "Germany"},
+	{"XL", "XR", "Netherlands"},
+	{"XS", "XW", "Russia"},
+	{"YA", "YE", "Belgium"},
+	{"YF", "YK", "Finland"},
+	{"YS", "YW", "Sweden"},
+	{"ZA", "ZR", "Italy"},
+	{"1A", "10", "United States"},
+	{"2A", "20", "Canada"},
+	{"3A", "3W", "Mexico"},
+	{"4A", "40", "United States"},
+	{"5A", "50", "United States"},
+	{"6A", "6W", "Australia"},
+	{"8A", "8E", "Argentina"},
+	{"9A", "9E", "Brazil"},
+}
+
+func country(prefix string) string {
+	for _, c := range countries {
+		if prefix[0] == c.from[0] && order(prefix[1]) >= order(c.from[1]) && order(prefix[1]) <= order(c.to[1]) {
+			return c.name
+		}
+	}
+
+	return ""
+}
+
+// order is the position of c in the ISO 3780 sequence A to Z followed by 1 to 9 and 0
+func order(c byte) int {
+	return strings.IndexByte(alphabet, c)
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type VINs interface {
+	Decode(ctx context.Context, vin string) (models.VINInfo, error)
+	GetCarByVIN(ctx context.Context, vin string) (models.Car, error)
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+type Warranties interface {
+	Attach(ctx context.Context, carID string, w models.Warranty) (models.Warranty, error)
+	GetWarranty(ctx context.Context, id string) (models.Warranty, error)
+	GetWarranties(ctx context.Context, carID string) ([]models.Warranty, error)
+	Coverage(ctx context.Context, carID, date string, odometer int) (models.Coverage, error)
+	Expiring(ctx context.Context, days int) ([]models.Warranty, error)
+}
@@ -0,0 +1,54 @@
+package warranty
+
+import (
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// status works out whether a warranty is in force on a date at an odometer reading. A warranty covers from its
+// start date up to but not including the date it expires, and up to and including its mileage limit.
+func status(w models.Warranty, date time.Time, odometer int) string {
+	switch {
+	case date.Before(w.StartDate):
+		return models.WarrantyNotStarted
+	case !date.Before(w.ExpiresAt):
+		return models.WarrantyExpired
+	case w.MileageLimit > 0 && odometer > w.MileageLimit:
+		return models.WarrantyMileageExceeded
+	}
+
+	return models.WarrantyActive
+}
+
+// coverage works out the cover a car has on a date at an odometer reading from its warranties
+func coverage(w []models.Warranty, date time.Time, odometer int) models.Coverage {
+	c := models.Coverage{Date: date, Odometer: odometer, Covered: []string{}, Warranties: []models.WarrantyStatus{}}
+	covered := map[string]bool{}
+
+	for _, warranty := range w {
+		st := status(warranty, date, odometer)
+		if st == models.WarrantyActive {
+			for _, component := range warranty.Components {
+				covered[component] = true
+			}
+		}
+
+		c.Warranties = append(c.Warranties, models.WarrantyStatus{Warranty: warranty, Status: st})
+	}
+
+	for _, component := range models.Components {
+		if covered[component] {
+			c.Covered = append(c.Covered, component)
+		}
+	}
+
+	return c
+}
+
+// date returns the calendar date of t as midnight UTC, so that a date keeps its day whatever offset it came with
+func date(t time.Time) time.Time {
+	y, m, d := t.Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,82 @@
+package warranty
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+)
+
+// TestStatus test function to test a warranty covers from its start date, up to its expiry and mileage limit
+func TestStatus(t *testing.T) {
+	start := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	w := models.Warranty{StartDate: start, ExpiresAt: start.AddDate(3, 0, 0), MileageLimit: 100000}
+
+	testCases := []struct {
+		desc     string
+		date     time.Time
+		odometer int
+		limit    int
+		status   string
+	}{
+		{desc: "before start", date: start.AddDate(0, 0, -1), status: models.WarrantyNotStarted},
+		{desc: "start date", date: start, status: models.WarrantyActive},
+		{desc: "day before expiry", date: start.AddDate(3, 0, -1), status: models.WarrantyActive},
+		{desc: "expiry date", date: start.AddDate(3, 0, 0), status: models.WarrantyExpired},
+		{desc: "at mileage limit", date: start, odometer: 100000, status: models.WarrantyActive},
+		{desc: "past mileage limit", date: start, odometer: 100001, status: models.WarrantyMileageExceeded},
+		{desc: "unlimited mileage", date: start, odometer: 500000, limit: -1, status: models.WarrantyActive},
+		{desc: "expired and past limit", date: start.AddDate(4, 0, 0), odometer: 200000,
+			status: models.WarrantyExpired},
+	}
+
+	for i, tc := range testCases {
+		warranty := w
+		if tc.limit < 0 {
+			warranty.MileageLimit = 0
+		}
+
+		if got := status(warranty, tc.date, tc.odometer); got != tc.status {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, got, tc.status)
+		}
+	}
+}
+
+// TestCoverage test function to test only active warranties count towards the components covered
+func TestCoverage(t *testing.T) {
+	start := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	factory := models.Warranty{Kind: models.WarrantyManufacturer, StartDate: start, ExpiresAt: start.AddDate(2, 0, 0),
+		MileageLimit: 60000, Components: []string{"engine", "powertrain", "battery"}}
+	extended := models.Warranty{Kind: models.WarrantyExtended, StartDate: start.AddDate(2, 0, 0),
+		ExpiresAt: start.AddDate(5, 0, 0), Components: []string{"powertrain"}}
+
+	testCases := []struct {
+		desc     string
+		date     time.Time
+		odometer int
+		covered  []string
+		statuses []string
+	}{
+		{desc: "factory cover", date: start.AddDate(1, 0, 0), odometer: 20000,
+			covered: []string{"engine", "powertrain", "battery"}, statuses: []string{"active", "not_started"}},
+		{desc: "factory mileage exceeded", date: start.AddDate(1, 0, 0), odometer: 70000, covered: []string{},
+			statuses: []string{"mileage_exceeded", "not_started"}},
+		{desc: "extended cover", date: start.AddDate(3, 0, 0), odometer: 70000, covered: []string{"powertrain"},
+			statuses: []string{"expired", "active"}},
+	}
+
+	for i, tc := range testCases {
+		c := coverage([]models.Warranty{factory, extended}, tc.date, tc.odometer)
+
+		statuses := []string{}
+		for _, w := range c.Warranties {
+			statuses = append(statuses, w.Status)
+		}
+
+		if !reflect.DeepEqual(c.Covered, tc.covered) || !reflect.DeepEqual(statuses, tc.statuses) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v %v\n Expected %v %v", i, tc.desc, c.Covered, statuses,
+				tc.covered, tc.statuses)
+		}
+	}
+}
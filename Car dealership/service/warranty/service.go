@@ -0,0 +1,211 @@
+package warranty
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxDurationMonths is the longest a single warranty may run
+	MaxDurationMonths = 120
+	// DefaultExpiringDays is how far ahead the expiring report looks when no window is asked for
+	DefaultExpiringDays = 30
+	// MaxExpiringDays is the furthest ahead the expiring report may look
+	MaxExpiringDays = 365
+)
+
+var (
+	ErrWarrantyNotFound = errors.New("warranty not found")
+	ErrCarNotFound      = errors.New("car not found")
+	ErrInvalidWarranty  = errors.New("invalid warranty")
+	ErrWarrantyExists   = errors.New("car already has a manufacturer warranty")
+)
+
+type service struct {
+	car      datastore.Car
+	warranty datastore.Warranty
+	now      func() time.Time
+}
+
+func New(car datastore.Car, warranty datastore.Warranty) service { //nolint
+	return service{car: car, warranty: warranty, now: time.Now}
+}
+
+// Attach service layer function to attach a warranty plan to a car. A car has at most one manufacturer warranty
+// and only an electric car may have its battery covered.
+func (s service) Attach(ctx context.Context, carID string, w models.Warranty) (models.Warranty, error) {
+	car, err := s.getCar(ctx, carID)
+	if err != nil {
+		return models.Warranty{}, err
+	}
+
+	if err = check(&w, car); err != nil {
+		return models.Warranty{}, err
+	}
+
+	existing, err := s.warranty.GetWarranties(ctx, carID)
+	if err != nil {
+		return models.Warranty{}, err
+	}
+
+	if w.Kind == models.WarrantyManufacturer {
+		for _, e := range existing {
+			if e.Kind == models.WarrantyManufacturer {
+				return models.Warranty{}, ErrWarrantyExists
+			}
+		}
+	}
+
+	w.ID = uuid.New()
+	w.CarID = car.ID
+	w.ExpiresAt = w.StartDate.AddDate(0, w.DurationMonths, 0)
+	w.CreatedAt = s.now().UTC().Truncate(time.Second)
+
+	return s.warranty.CreateWarranty(ctx, w)
+}
+
+// GetWarranty service layer function to get a warranty by its id
+func (s service) GetWarranty(ctx context.Context, id string) (models.Warranty, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Warranty{}, ErrWarrantyNotFound
+	}
+
+	w, err := s.warranty.GetWarranty(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Warranty{}, ErrWarrantyNotFound
+	}
+
+	return w, err
+}
+
+// GetWarranties service layer function to get the warranties of a car, earliest start first
+func (s service) GetWarranties(ctx context.Context, carID string) ([]models.Warranty, error) {
+	if _, err := s.getCar(ctx, carID); err != nil {
+		return nil, err
+	}
+
+	return s.warranty.GetWarranties(ctx, carID)
+}
+
+// Coverage service layer function to work out the warranty cover of a car on a date, YYYY-MM-DD and today when
+// empty, at an odometer reading in km
+func (s service) Coverage(ctx context.Context, carID, day string, odometer int) (models.Coverage, error) {
+	on := date(s.now().UTC())
+
+	if day != "" {
+		var err error
+
+		if on, err = time.Parse("2006-01-02", day); err != nil {
+			return models.Coverage{}, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrInvalidWarranty)
+		}
+	}
+
+	if odometer < 0 {
+		return models.Coverage{}, fmt.Errorf("%w: odometer can not be negative", ErrInvalidWarranty)
+	}
+
+	car, err := s.getCar(ctx, carID)
+	if err != nil {
+		return models.Coverage{}, err
+	}
+
+	warranties, err := s.warranty.GetWarranties(ctx, carID)
+	if err != nil {
+		return models.Coverage{}, err
+	}
+
+	c := coverage(warranties, on, odometer)
+	c.CarID = car.ID
+
+	return c, nil
+}
+
+// Expiring service layer function to report the warranties in force today that expire within the next days,
+// soonest first. Only the dates are considered, a warranty past its mileage limit is still reported.
+func (s service) Expiring(ctx context.Context, days int) ([]models.Warranty, error) {
+	if days == 0 {
+		days = DefaultExpiringDays
+	}
+
+	if days < 0 || days > MaxExpiringDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidWarranty, MaxExpiringDays)
+	}
+
+	today := date(s.now().UTC())
+
+	return s.warranty.GetExpiring(ctx, today.AddDate(0, 0, 1), today.AddDate(0, 0, days+1))
+}
+
+func (s service) getCar(ctx context.Context, id string) (models.Car, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Car{}, ErrCarNotFound
+	}
+
+	car, err := s.car.GetCarByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Car{}, ErrCarNotFound
+	}
+
+	return car, err
+}
+
+// check validates and normalises a warranty for a car, its start date is kept to the day and the components it
+// covers are listed once each in report order
+func check(w *models.Warranty, car models.Car) error {
+	w.Kind = strings.ToLower(strings.TrimSpace(w.Kind))
+	w.Provider = strings.TrimSpace(w.Provider)
+
+	switch {
+	case w.Kind != models.WarrantyManufacturer && w.Kind != models.WarrantyExtended:
+		return fmt.Errorf("%w: kind must be %s or %s", ErrInvalidWarranty, models.WarrantyManufacturer,
+			models.WarrantyExtended)
+	case w.Provider == "":
+		return fmt.Errorf("%w: provider is required", ErrInvalidWarranty)
+	case w.StartDate.IsZero():
+		return fmt.Errorf("%w: start date is required", ErrInvalidWarranty)
+	case w.DurationMonths < 1 || w.DurationMonths > MaxDurationMonths:
+		return fmt.Errorf("%w: duration must be between 1 and %d months", ErrInvalidWarranty, MaxDurationMonths)
+	case w.MileageLimit < 0:
+		return fmt.Errorf("%w: mileage limit can not be negative", ErrInvalidWarranty)
+	}
+
+	w.StartDate = date(w.StartDate)
+
+	asked := map[string]bool{}
+
+	for _, c := range w.Components {
+		c = strings.ToLower(strings.TrimSpace(c))
+
+		switch {
+		case !models.ValidComponent(c):
+			return fmt.Errorf("%w: unknown component %q", ErrInvalidWarranty, c)
+		case c == models.ComponentBattery && !strings.EqualFold(car.FuelType, "electric"):
+			return fmt.Errorf("%w: only an electric car has its battery covered", ErrInvalidWarranty)
+		}
+
+		asked[c] = true
+	}
+
+	if len(asked) == 0 {
+		return fmt.Errorf("%w: at least one component must be covered", ErrInvalidWarranty)
+	}
+
+	w.Components = []string{}
+
+	for _, c := range models.Components {
+		if asked[c] {
+			w.Components = append(w.Components, c)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,262 @@
+package warranty
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/datastore"
+	"github.com/zopsmart/GoLang-Interns-2022/tree/sahil-zs/models"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// TestAttach service layer test function to test warranties are validated against the car they are attached to
+func TestAttach(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	car := datastore.NewMockCar(ctrl)
+	store := datastore.NewMockWarranty(ctrl)
+	s := New(car, store)
+	s.now = func() time.Time { return now }
+
+	carID := uuid.New()
+	petrol := models.Car{ID: carID, FuelType: "Petrol"}
+	electric := models.Car{ID: carID, FuelType: "Electric"}
+	start := time.Date(2022, 3, 1, 23, 30, 0, 0, time.FixedZone("IST", 19800))
+	w := models.Warranty{Kind: " Manufacturer ", Provider: "BMW", StartDate: start, DurationMonths: 36,
+		MileageLimit: 100000, Components: []string{"powertrain", "Engine", "engine"}}
+	battery := models.Warranty{Kind: "manufacturer", Provider: "Tesla", StartDate: start, DurationMonths: 96,
+		Components: []string{"battery"}}
+	stored := func(_ context.Context, w models.Warranty) (models.Warranty, error) {
+		return w, nil
+	}
+	with := func(changes func(w *models.Warranty)) models.Warranty {
+		c := w
+		changes(&c)
+
+		return c
+	}
+
+	testCases := []struct {
+		desc  string
+		input models.Warranty
+		mock  func()
+		err   error
+	}{
+		{desc: "success", input: w,
+			mock: func() {
+				car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(petrol, nil)
+				store.EXPECT().GetWarranties(gomock.Any(), carID.String()).Return([]models.Warranty{}, nil)
+				store.EXPECT().CreateWarranty(gomock.Any(), gomock.Any()).DoAndReturn(stored)
+			}},
+		{desc: "battery of an electric car", input: battery,
+			mock: func() {
+				car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(electric, nil)
+				store.EXPECT().GetWarranties(gomock.Any(), carID.String()).Return([]models.Warranty{}, nil)
+				store.EXPECT().CreateWarranty(gomock.Any(), gomock.Any()).DoAndReturn(stored)
+			}},
+		{desc: "battery of a petrol car", input: battery, err: ErrInvalidWarranty,
+			mock: func() {
+				car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(petrol, nil)
+			}},
+		{desc: "unknown kind", input: with(func(w *models.Warranty) { w.Kind = "lifetime" }), err: ErrInvalidWarranty,
+			mock: func() {
+				car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(petrol, nil)
+			}},
+		{desc: "too long", input: with(func(w *models.Warranty) { w.DurationMonths = 121 }), err: ErrInvalidWarranty,
+			mock: func() {
+				car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(petrol, nil)
+			}},
+		{desc: "unknown component", input: with(func(w *models.Warranty) { w.Components = []string{"paint"} }),
+			err: ErrInvalidWarranty,
+			mock: func() {
+				car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(petrol, nil)
+			}},
+		{desc: "no components", input: with(func(w *models.Warranty) { w.Components = nil }), err: ErrInvalidWarranty,
+			mock: func() {
+				car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(petrol, nil)
+			}},
+		{desc: "second manufacturer warranty", input: w, err: ErrWarrantyExists,
+			mock: func() {
+				car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(petrol, nil)
+				store.EXPECT().GetWarranties(gomock.Any(), carID.String()).
+					Return([]models.Warranty{{Kind: models.WarrantyManufacturer}}, nil)
+			}},
+		{desc: "car not found", input: w, err: ErrCarNotFound,
+			mock: func() {
+				car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{}, sql.ErrNoRows)
+			}},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Attach(context.TODO(), carID.String(), tc.input)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		assert.Equal(t, carID, res.CarID, tc.desc)
+		assert.Equal(t, time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC), res.StartDate, tc.desc)
+		assert.Equal(t, res.StartDate.AddDate(0, res.DurationMonths, 0), res.ExpiresAt, tc.desc)
+		assert.Equal(t, now, res.CreatedAt, tc.desc)
+	}
+}
+
+// TestAttachNormalises service layer test function to test a warranty is stored normalised
+func TestAttachNormalises(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	car := datastore.NewMockCar(ctrl)
+	store := datastore.NewMockWarranty(ctrl)
+	s := New(car, store)
+	s.now = func() time.Time { return now }
+
+	carID := uuid.New()
+
+	car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+	store.EXPECT().GetWarranties(gomock.Any(), carID.String()).Return([]models.Warranty{}, nil)
+	store.EXPECT().CreateWarranty(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, w models.Warranty) (models.Warranty, error) { return w, nil })
+
+	res, err := s.Attach(context.TODO(), carID.String(), models.Warranty{Kind: " Extended", Provider: " CarCare ",
+		StartDate: now, DurationMonths: 24, Components: []string{"powertrain", "Engine", "engine"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "extended", res.Kind)
+	assert.Equal(t, "CarCare", res.Provider)
+	assert.Equal(t, []string{"engine", "powertrain"}, res.Components)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), res.ExpiresAt)
+}
+
+// TestCoverageOfCar service layer test function to test the coverage of a car on a date and odometer reading
+func TestCoverageOfCar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	car := datastore.NewMockCar(ctrl)
+	store := datastore.NewMockWarranty(ctrl)
+	s := New(car, store)
+	s.now = func() time.Time { return now }
+
+	carID := uuid.New()
+	start := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	warranties := []models.Warranty{{Kind: models.WarrantyManufacturer, StartDate: start,
+		ExpiresAt: start.AddDate(1, 0, 0), Components: []string{"engine"}}}
+
+	testCases := []struct {
+		desc     string
+		date     string
+		odometer int
+		mock     func()
+		covered  []string
+		err      error
+	}{
+		{desc: "today", covered: []string{},
+			mock: func() {
+				car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+				store.EXPECT().GetWarranties(gomock.Any(), carID.String()).Return(warranties, nil)
+			}},
+		{desc: "on a date", date: "2022-02-28", odometer: 12000, covered: []string{"engine"},
+			mock: func() {
+				car.EXPECT().GetCarByID(gomock.Any(), carID.String()).Return(models.Car{ID: carID}, nil)
+				store.EXPECT().GetWarranties(gomock.Any(), carID.String()).Return(warranties, nil)
+			}},
+		{desc: "bad date", date: "28/02/2022", err: ErrInvalidWarranty},
+		{desc: "negative odometer", odometer: -1, err: ErrInvalidWarranty},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		res, err := s.Coverage(context.TODO(), carID.String(), tc.date, tc.odometer)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+
+		if err == nil {
+			assert.Equal(t, carID, res.CarID, tc.desc)
+			assert.Equal(t, tc.covered, res.Covered, tc.desc)
+		}
+	}
+}
+
+// TestExpiring service layer test function to test the window of the expiring report
+func TestExpiring(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := datastore.NewMockWarranty(ctrl)
+	s := New(datastore.NewMockCar(ctrl), store)
+	s.now = func() time.Time { return now }
+
+	today := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc string
+		days int
+		mock func()
+		err  error
+	}{
+		{desc: "default window",
+			mock: func() {
+				store.EXPECT().GetExpiring(gomock.Any(), today.AddDate(0, 0, 1), today.AddDate(0, 0, 31)).
+					Return([]models.Warranty{}, nil)
+			}},
+		{desc: "a week",
+			days: 7,
+			mock: func() {
+				store.EXPECT().GetExpiring(gomock.Any(), today.AddDate(0, 0, 1), today.AddDate(0, 0, 8)).
+					Return([]models.Warranty{}, nil)
+			}},
+		{desc: "too far", days: 366, err: ErrInvalidWarranty},
+		{desc: "negative", days: -1, err: ErrInvalidWarranty},
+	}
+
+	for i, tc := range testCases {
+		if tc.mock != nil {
+			tc.mock()
+		}
+
+		if _, err := s.Expiring(context.TODO(), tc.days); !errors.Is(err, tc.err) {
+			t.Errorf("\n[TEST %v] Failed \nDesc %v\nGot %v\n Expected %v", i, tc.desc, err, tc.err)
+		}
+	}
+}
+
+// TestGetWarranty service layer test function to test unknown warranties are not found
+func TestGetWarranty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := datastore.NewMockWarranty(ctrl)
+	s := New(datastore.NewMockCar(ctrl), store)
+	s.now = func() time.Time { return now }
+
+	id := uuid.New().String()
+
+	store.EXPECT().GetWarranty(gomock.Any(), id).Return(models.Warranty{}, sql.ErrNoRows)
+
+	_, err := s.GetWarranty(context.TODO(), id)
+	assert.ErrorIs(t, err, ErrWarrantyNotFound)
+
+	_, err = s.GetWarranty(context.TODO(), "abc")
+	assert.ErrorIs(t, err, ErrWarrantyNotFound)
+}